@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
+	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
+	"github.com/keep-network/keep-core/pkg/bitcoin/headerchain"
+	"github.com/keep-network/keep-core/pkg/bitcoin/quorum"
+)
+
+// connectBitcoinChain connects to the Bitcoin chain using the backend
+// selected in the given configuration. If additional Electrum servers are
+// configured for the quorum, the returned chain fans each call out to the
+// selected backend and those servers. The headers persistence handle is
+// used to store the local header chain of the Electrum backend; if it is
+// nil, the local header chain is kept only in memory.
+func connectBitcoinChain(
+	ctx context.Context,
+	bitcoinConfig config.BitcoinConfig,
+	headersPersistence persistence.BasicHandle,
+) (bitcoin.Chain, error) {
+	primaryChain, err := connectBitcoinBackend(
+		ctx,
+		bitcoinConfig,
+		headersPersistence,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bitcoinConfig.Quorum.ElectrumURLs) == 0 {
+		return primaryChain, nil
+	}
+
+	backends := []bitcoin.Chain{primaryChain}
+	for _, url := range bitcoinConfig.Quorum.ElectrumURLs {
+		// Use the same timeouts as configured for the primary
+		// Electrum backend. The local header chain is maintained only
+		// for the primary backend.
+		electrumConfig := bitcoinConfig.Electrum
+		electrumConfig.URL = url
+		electrumConfig.HeaderChain = headerchain.Config{}
+
+		backend, err := electrum.Connect(ctx, electrumConfig)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not connect to quorum Electrum server [%s]: [%v]",
+				url,
+				err,
+			)
+		}
+
+		backends = append(backends, backend)
+	}
+
+	quorumChain, err := quorum.NewChain(
+		backends,
+		bitcoinConfig.Quorum.Threshold,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not set up Bitcoin quorum: [%v]", err)
+	}
+
+	logger.Infof(
+		"using quorum of [%v] Bitcoin chain backends",
+		quorumChain.BackendsCount(),
+	)
+
+	return quorumChain, nil
+}
+
+// connectBitcoinBackend connects to the Bitcoin chain backend selected in
+// the given configuration.
+func connectBitcoinBackend(
+	ctx context.Context,
+	bitcoinConfig config.BitcoinConfig,
+	headersPersistence persistence.BasicHandle,
+) (bitcoin.Chain, error) {
+	switch bitcoinConfig.Backend {
+	case "", config.ElectrumBitcoinBackend:
+		return electrum.ConnectWithHeaderChain(
+			ctx,
+			bitcoinConfig.Electrum,
+			bitcoinConfig.Network,
+			headersPersistence,
+		)
+	case config.BitcoindBitcoinBackend:
+		return bitcoind.Connect(ctx, bitcoinConfig.Bitcoind)
+	case config.EsploraBitcoinBackend:
+		return esplora.Connect(ctx, bitcoinConfig.Esplora)
+	default:
+		return nil, fmt.Errorf(
+			"unsupported Bitcoin backend [%s]",
+			bitcoinConfig.Backend,
+		)
+	}
+}
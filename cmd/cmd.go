@@ -37,6 +37,8 @@ func init() {
 		EthereumCommand,
 		MaintainerCommand,
 		MaintainerCliCommand,
+		KeystoreCommand,
+		StorageCommand,
 	)
 }
 
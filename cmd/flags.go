@@ -12,12 +12,19 @@ import (
 	"github.com/keep-network/keep-common/pkg/rate"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/config/network"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
 	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/maintainer/fraud"
+	"github.com/keep-network/keep-core/pkg/maintainer/redemption"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/storage"
 	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
 func initGlobalFlags(
@@ -39,7 +46,11 @@ func initFlags(
 		case config.Ethereum:
 			initEthereumFlags(cmd, cfg)
 		case config.BitcoinElectrum:
+			initBitcoinBackendFlags(cmd, cfg)
 			initBitcoinElectrumFlags(cmd, cfg)
+			initBitcoindFlags(cmd, cfg)
+			initBitcoinEsploraFlags(cmd, cfg)
+			initBitcoinQuorumFlags(cmd, cfg)
 		case config.Network:
 			initNetworkFlags(cmd, cfg)
 		case config.Storage:
@@ -155,6 +166,16 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 	)
 }
 
+// Initialize flags for Bitcoin backend configuration.
+func initBitcoinBackendFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Backend,
+		"bitcoin.backend",
+		config.ElectrumBitcoinBackend,
+		"Backend of the Bitcoin chain client: `electrum`, `bitcoind` or `esplora`.",
+	)
+}
+
 // Initialize flags for Bitcoin electrum configuration.
 func initBitcoinElectrumFlags(cmd *cobra.Command, cfg *config.Config) {
 	cmd.Flags().StringVar(
@@ -198,6 +219,113 @@ func initBitcoinElectrumFlags(cmd *cobra.Command, cfg *config.Config) {
 		electrum.DefaultKeepAliveInterval,
 		"Interval for connection keep alive requests.",
 	)
+
+	cmd.Flags().UintVar(
+		&cfg.Bitcoin.Electrum.HeaderChain.CheckpointHeight,
+		"bitcoin.electrum.headerChain.checkpointHeight",
+		0,
+		"Height of the trusted block the local header chain verifying Electrum responses starts from. Must be a multiple of 2016.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Electrum.HeaderChain.CheckpointHash,
+		"bitcoin.electrum.headerChain.checkpointHash",
+		"",
+		"Hash of the trusted block at the checkpoint height. Enables verification of Electrum responses against the local header chain.",
+	)
+}
+
+// Initialize flags for Bitcoin bitcoind configuration.
+func initBitcoindFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Bitcoind.URL,
+		"bitcoin.bitcoind.url",
+		"",
+		"URL to the Bitcoin Core node JSON-RPC endpoint in format: `scheme://hostname:port`.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Bitcoind.Username,
+		"bitcoin.bitcoind.username",
+		"",
+		"Username used to authenticate Bitcoin Core node JSON-RPC requests.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Bitcoind.Wallet,
+		"bitcoin.bitcoind.wallet",
+		"",
+		"Name of a watch-only descriptor wallet loaded on the Bitcoin Core node.",
+	)
+
+	cmd.Flags().Int64Var(
+		&cfg.Bitcoin.Bitcoind.ImportTimestamp,
+		"bitcoin.bitcoind.importTimestamp",
+		0,
+		"Unix timestamp from which the Bitcoin Core node rescans the chain when a new wallet is tracked.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Bitcoind.RequestTimeout,
+		"bitcoin.bitcoind.requestTimeout",
+		bitcoind.DefaultRequestTimeout,
+		"Timeout for a single attempt of Bitcoin Core node JSON-RPC request.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Bitcoind.RequestRetryTimeout,
+		"bitcoin.bitcoind.requestRetryTimeout",
+		bitcoind.DefaultRequestRetryTimeout,
+		"Timeout for Bitcoin Core node JSON-RPC request retries.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Bitcoind.ImportTimeout,
+		"bitcoin.bitcoind.importTimeout",
+		bitcoind.DefaultImportTimeout,
+		"Timeout for a single attempt of the Bitcoin Core node descriptors import request.",
+	)
+}
+
+// Initialize flags for Bitcoin esplora configuration.
+func initBitcoinEsploraFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Esplora.URL,
+		"bitcoin.esplora.url",
+		"",
+		"URL to the Esplora API in format: `scheme://hostname:port/path`.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Esplora.RequestTimeout,
+		"bitcoin.esplora.requestTimeout",
+		esplora.DefaultRequestTimeout,
+		"Timeout for a single attempt of Esplora API request.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Esplora.RequestRetryTimeout,
+		"bitcoin.esplora.requestRetryTimeout",
+		esplora.DefaultRequestRetryTimeout,
+		"Timeout for Esplora API request retries.",
+	)
+}
+
+// Initialize flags for Bitcoin quorum configuration.
+func initBitcoinQuorumFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringSliceVar(
+		&cfg.Bitcoin.Quorum.ElectrumURLs,
+		"bitcoin.quorum.electrumURLs",
+		[]string{},
+		"URLs of additional Electrum servers that must agree with the primary Bitcoin backend.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Bitcoin.Quorum.Threshold,
+		"bitcoin.quorum.threshold",
+		0,
+		"Minimum number of Bitcoin backends that must agree on a result. Simple majority if zero.",
+	)
 }
 
 // Initialize flags for Network configuration.
@@ -247,6 +375,13 @@ func initStorageFlags(cmd *cobra.Command, cfg *config.Config) {
 		"",
 		"Location to store the Keep client key shares and other sensitive data.",
 	)
+
+	cmd.Flags().StringVar(
+		&cfg.Storage.Backend,
+		"storage.backend",
+		storage.FileBackend,
+		"Backend of the storage: `file` or `bbolt`.",
+	)
 }
 
 // Initialize flags for ClientInfo configuration.
@@ -308,6 +443,36 @@ func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 		tbtc.DefaultKeyGenerationConcurrency,
 		"tECDSA key generation concurrency.",
 	)
+
+	cmd.Flags().StringVar(
+		&cfg.Tbtc.DepositSelectionPolicy,
+		"tbtc.depositSelectionPolicy",
+		tbtcpg.DefaultDepositSelectionPolicy,
+		fmt.Sprintf(
+			"Policy used to select deposits for sweeping. One of: %s, %s, %s, %s. "+
+				"Must not be changed if the remote proposal generator is used.",
+			tbtcpg.OldestFirstPolicy,
+			tbtcpg.LargestValueFirstPolicy,
+			tbtcpg.ClosestRefundLocktimeFirstPolicy,
+			tbtcpg.GroupedByVaultPolicy,
+		),
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.ProposalGenerator.RemoteURL,
+		"proposalGenerator.remoteURL",
+		"",
+		"URL of the remote coordination proposal generator service. "+
+			"Proposals are generated in-process if not set.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.ProposalGenerator.RemoteTimeout,
+		"proposalGenerator.remoteTimeout",
+		tbtcpg.DefaultRemoteTimeout,
+		"Timeout of a request sent to the remote coordination proposal "+
+			"generator service.",
+	)
 }
 
 // Initialize flags for Maintainer configuration.
@@ -363,6 +528,133 @@ func initMaintainerFlags(command *cobra.Command, cfg *config.Config) {
 		"The wait time which should be applied when there are no more "+
 			"transaction proofs to submit.",
 	)
+
+	command.Flags().BoolVar(
+		&cfg.Maintainer.Spv.ResetCheckpoint,
+		"spv.resetCheckpoint",
+		false,
+		"Discard the persisted SPV maintainer checkpoint and scan the whole "+
+			"history depth again.",
+	)
+
+	command.Flags().BoolVar(
+		&cfg.Maintainer.Fraud.Enabled,
+		"fraud",
+		false,
+		"Start fraud maintainer.",
+	)
+
+	command.Flags().IntVar(
+		&cfg.Maintainer.Fraud.TransactionLimit,
+		"fraud.transactionLimit",
+		fraud.DefaultTransactionLimit,
+		"The maximum number of confirmed transactions inspected for "+
+			"fraudulent signatures of a single wallet.",
+	)
+
+	command.Flags().UintVar(
+		&cfg.Maintainer.Fraud.MinConfirmations,
+		"fraud.minConfirmations",
+		fraud.DefaultMinConfirmations,
+		"The number of confirmations a wallet transaction must have before "+
+			"its signatures can be challenged.",
+	)
+
+	command.Flags().UintVar(
+		&cfg.Maintainer.Fraud.PendingActionMaxConfirmations,
+		"fraud.pendingActionMaxConfirmations",
+		fraud.DefaultPendingActionMaxConfirmations,
+		"The number of confirmations up to which an unproven wallet "+
+			"transaction matching a pending wallet action is not challenged.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Fraud.RestartBackoffTime,
+		"fraud.restartBackoffTime",
+		fraud.DefaultRestartBackoffTime,
+		"The restart backoff which should be applied when the fraud "+
+			"maintainer is restarted.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Fraud.IdleBackoffTime,
+		"fraud.idleBackoffTime",
+		fraud.DefaultIdleBackOffTime,
+		"The wait time which should be applied between subsequent scans of "+
+			"wallet transactions.",
+	)
+
+	command.Flags().BoolVar(
+		&cfg.Maintainer.Redemption.Enabled,
+		"redemption",
+		false,
+		"Start redemption timeout maintainer.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Redemption.LookBackPeriod,
+		"redemption.lookBackPeriod",
+		redemption.DefaultLookBackPeriod,
+		"The period after the redemption timeout during which timed-out "+
+			"redemption requests are still looked for.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Redemption.RestartBackoffTime,
+		"redemption.restartBackoffTime",
+		redemption.DefaultRestartBackoffTime,
+		"The restart backoff which should be applied when the redemption "+
+			"timeout maintainer is restarted.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Redemption.IdleBackoffTime,
+		"redemption.idleBackoffTime",
+		redemption.DefaultIdleBackOffTime,
+		"The wait time which should be applied between subsequent scans of "+
+			"pending redemption requests.",
+	)
+
+	command.Flags().BoolVar(
+		&cfg.Maintainer.Submission.Disabled,
+		"submission.disabled",
+		false,
+		"Disable the submission policy and send all reimbursed submissions "+
+			"regardless of their cost.",
+	)
+
+	flag.WeiVarFlag(
+		command.Flags(),
+		&cfg.Maintainer.Submission.MaxUncoveredCost,
+		"submission.maxUncoveredCost",
+		*submission.DefaultMaxUncoveredCost,
+		"The maximum part of the submission cost not covered by the refund "+
+			"for which the submission is still sent.",
+	)
+
+	flag.WeiVarFlag(
+		command.Flags(),
+		&cfg.Maintainer.Submission.SkipUncoveredCost,
+		"submission.skipUncoveredCost",
+		*submission.DefaultSkipUncoveredCost,
+		"The part of the submission cost not covered by the refund above "+
+			"which the submission is skipped instead of being delayed.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Submission.DelayBackoffTime,
+		"submission.delayBackoffTime",
+		submission.DefaultDelayBackoffTime,
+		"The wait time which should be applied before a delayed submission "+
+			"is evaluated again.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.Submission.MaxDelayTime,
+		"submission.maxDelayTime",
+		submission.DefaultMaxDelayTime,
+		"The maximum time a submission can be delayed before it is skipped.",
+	)
 }
 
 // Initialize flags for Developer configuration.
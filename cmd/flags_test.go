@@ -122,6 +122,111 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 660 * time.Second,
 		defaultValue:          300 * time.Second,
 	},
+	"bitcoin.electrum.headerChain.checkpointHeight": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Electrum.HeaderChain.CheckpointHeight },
+		flagName:              "--bitcoin.electrum.headerChain.checkpointHeight",
+		flagValue:             "2016",
+		expectedValueFromFlag: uint(2016),
+		defaultValue:          uint(0),
+	},
+	"bitcoin.electrum.headerChain.checkpointHash": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Electrum.HeaderChain.CheckpointHash },
+		flagName:              "--bitcoin.electrum.headerChain.checkpointHash",
+		flagValue:             "000000000000000000000000000000000000000000000000000000000000abcd",
+		expectedValueFromFlag: "000000000000000000000000000000000000000000000000000000000000abcd",
+		defaultValue:          "",
+	},
+	"bitcoin.backend": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Backend },
+		flagName:              "--bitcoin.backend",
+		flagValue:             "bitcoind",
+		expectedValueFromFlag: "bitcoind",
+		defaultValue:          "electrum",
+	},
+	"bitcoin.bitcoind.url": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.URL },
+		flagName:              "--bitcoin.bitcoind.url",
+		flagValue:             "http://url.to.bitcoind:18332",
+		expectedValueFromFlag: "http://url.to.bitcoind:18332",
+		defaultValue:          "",
+	},
+	"bitcoin.bitcoind.username": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.Username },
+		flagName:              "--bitcoin.bitcoind.username",
+		flagValue:             "keep",
+		expectedValueFromFlag: "keep",
+		defaultValue:          "",
+	},
+	"bitcoin.bitcoind.wallet": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.Wallet },
+		flagName:              "--bitcoin.bitcoind.wallet",
+		flagValue:             "keep-watch-only",
+		expectedValueFromFlag: "keep-watch-only",
+		defaultValue:          "",
+	},
+	"bitcoin.bitcoind.importTimestamp": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.ImportTimestamp },
+		flagName:              "--bitcoin.bitcoind.importTimestamp",
+		flagValue:             "1641000000",
+		expectedValueFromFlag: int64(1641000000),
+		defaultValue:          int64(0),
+	},
+	"bitcoin.bitcoind.requestTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.RequestTimeout },
+		flagName:              "--bitcoin.bitcoind.requestTimeout",
+		flagValue:             "47s",
+		expectedValueFromFlag: 47 * time.Second,
+		defaultValue:          30 * time.Second,
+	},
+	"bitcoin.bitcoind.requestRetryTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.RequestRetryTimeout },
+		flagName:              "--bitcoin.bitcoind.requestRetryTimeout",
+		flagValue:             "5m",
+		expectedValueFromFlag: 300 * time.Second,
+		defaultValue:          120 * time.Second,
+	},
+	"bitcoin.bitcoind.importTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.ImportTimeout },
+		flagName:              "--bitcoin.bitcoind.importTimeout",
+		flagValue:             "2h",
+		expectedValueFromFlag: 2 * time.Hour,
+		defaultValue:          time.Hour,
+	},
+	"bitcoin.esplora.url": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.URL },
+		flagName:              "--bitcoin.esplora.url",
+		flagValue:             "https://url.to.esplora/api",
+		expectedValueFromFlag: "https://url.to.esplora/api",
+		defaultValue:          "",
+	},
+	"bitcoin.esplora.requestTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.RequestTimeout },
+		flagName:              "--bitcoin.esplora.requestTimeout",
+		flagValue:             "51s",
+		expectedValueFromFlag: 51 * time.Second,
+		defaultValue:          30 * time.Second,
+	},
+	"bitcoin.esplora.requestRetryTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.RequestRetryTimeout },
+		flagName:              "--bitcoin.esplora.requestRetryTimeout",
+		flagValue:             "3m",
+		expectedValueFromFlag: 180 * time.Second,
+		defaultValue:          120 * time.Second,
+	},
+	"bitcoin.quorum.electrumURLs": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Quorum.ElectrumURLs },
+		flagName:              "--bitcoin.quorum.electrumURLs",
+		flagValue:             "tcp://url.to.electrum.1:18332,ssl://url.to.electrum.2:18332",
+		expectedValueFromFlag: []string{"tcp://url.to.electrum.1:18332", "ssl://url.to.electrum.2:18332"},
+		defaultValue:          []string{},
+	},
+	"bitcoin.quorum.threshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Quorum.Threshold },
+		flagName:              "--bitcoin.quorum.threshold",
+		flagValue:             "3",
+		expectedValueFromFlag: 3,
+		defaultValue:          0,
+	},
 	"network.bootstrap": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.Bootstrap },
 		flagName:              "--network.bootstrap",
@@ -225,6 +330,27 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 101,
 		defaultValue:          runtime.GOMAXPROCS(0),
 	},
+	"tbtc.depositSelectionPolicy": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.DepositSelectionPolicy },
+		flagName:              "--tbtc.depositSelectionPolicy",
+		flagValue:             "largest-value-first",
+		expectedValueFromFlag: "largest-value-first",
+		defaultValue:          "oldest-first",
+	},
+	"proposalGenerator.remoteURL": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.ProposalGenerator.RemoteURL },
+		flagName:              "--proposalGenerator.remoteURL",
+		flagValue:             "http://proposals.example.com:8080",
+		expectedValueFromFlag: "http://proposals.example.com:8080",
+		defaultValue:          "",
+	},
+	"proposalGenerator.remoteTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.ProposalGenerator.RemoteTimeout },
+		flagName:              "--proposalGenerator.remoteTimeout",
+		flagValue:             "45s",
+		expectedValueFromFlag: 45 * time.Second,
+		defaultValue:          1 * time.Minute,
+	},
 	"maintainer.bitcoinDifficulty": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.BitcoinDifficulty.Enabled },
 		flagName:              "--bitcoinDifficulty",
@@ -274,6 +400,118 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 20 * time.Minute,
 		defaultValue:          10 * time.Minute,
 	},
+	"maintainer.spv.resetCheckpoint": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Spv.ResetCheckpoint },
+		flagName:              "--spv.resetCheckpoint",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"maintainer.fraud": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.Enabled },
+		flagName:              "--fraud",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"maintainer.fraud.transactionLimit": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.TransactionLimit },
+		flagName:              "--fraud.transactionLimit",
+		flagValue:             "5",
+		expectedValueFromFlag: 5,
+		defaultValue:          20,
+	},
+	"maintainer.fraud.minConfirmations": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.MinConfirmations },
+		flagName:              "--fraud.minConfirmations",
+		flagValue:             "144",
+		expectedValueFromFlag: uint(144),
+		defaultValue:          uint(432),
+	},
+	"maintainer.fraud.pendingActionMaxConfirmations": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.PendingActionMaxConfirmations },
+		flagName:              "--fraud.pendingActionMaxConfirmations",
+		flagValue:             "1008",
+		expectedValueFromFlag: uint(1008),
+		defaultValue:          uint(2016),
+	},
+	"maintainer.fraud.restartBackoffTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.RestartBackoffTime },
+		flagName:              "--fraud.restartBackoffTime",
+		flagValue:             "1h",
+		expectedValueFromFlag: time.Hour,
+		defaultValue:          30 * time.Minute,
+	},
+	"maintainer.fraud.idleBackoffTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Fraud.IdleBackoffTime },
+		flagName:              "--fraud.idleBackoffTime",
+		flagValue:             "20m",
+		expectedValueFromFlag: 20 * time.Minute,
+		defaultValue:          30 * time.Minute,
+	},
+	"maintainer.redemption": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Redemption.Enabled },
+		flagName:              "--redemption",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"maintainer.redemption.lookBackPeriod": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Redemption.LookBackPeriod },
+		flagName:              "--redemption.lookBackPeriod",
+		flagValue:             "72h",
+		expectedValueFromFlag: 72 * time.Hour,
+		defaultValue:          168 * time.Hour,
+	},
+	"maintainer.redemption.restartBackoffTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Redemption.RestartBackoffTime },
+		flagName:              "--redemption.restartBackoffTime",
+		flagValue:             "1h",
+		expectedValueFromFlag: time.Hour,
+		defaultValue:          30 * time.Minute,
+	},
+	"maintainer.redemption.idleBackoffTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Redemption.IdleBackoffTime },
+		flagName:              "--redemption.idleBackoffTime",
+		flagValue:             "20m",
+		expectedValueFromFlag: 20 * time.Minute,
+		defaultValue:          30 * time.Minute,
+	},
+	"maintainer.submission.disabled": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Submission.Disabled },
+		flagName:              "--submission.disabled",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"maintainer.submission.maxUncoveredCost": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Submission.MaxUncoveredCost.Int },
+		flagName:              "--submission.maxUncoveredCost",
+		flagValue:             "1000000 Gwei",
+		expectedValueFromFlag: big.NewInt(1000000000000000),
+		defaultValue:          big.NewInt(0),
+	},
+	"maintainer.submission.skipUncoveredCost": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Submission.SkipUncoveredCost.Int },
+		flagName:              "--submission.skipUncoveredCost",
+		flagValue:             "0.1 ether",
+		expectedValueFromFlag: big.NewInt(100000000000000000),
+		defaultValue:          big.NewInt(50000000000000000),
+	},
+	"maintainer.submission.delayBackoffTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Submission.DelayBackoffTime },
+		flagName:              "--submission.delayBackoffTime",
+		flagValue:             "10m",
+		expectedValueFromFlag: 10 * time.Minute,
+		defaultValue:          5 * time.Minute,
+	},
+	"maintainer.submission.maxDelayTime": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.Submission.MaxDelayTime },
+		flagName:              "--submission.maxDelayTime",
+		flagValue:             "2h",
+		expectedValueFromFlag: 2 * time.Hour,
+		defaultValue:          1 * time.Hour,
+	},
 	"developer.randomBeaconAddress": {
 		readValueFunc: func(c *config.Config) interface{} {
 			address, _ := c.Ethereum.ContractAddress(chainEthereum.RandomBeaconContractName)
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// #nosec G101 (look for hardcoded credentials)
+	// This line doesn't contain any credentials.
+	// It's just the name of the environment variable.
+	keystoreArchivePasswordEnvVariable = "KEEP_KEYSTORE_ARCHIVE_PASSWORD"
+)
+
+var (
+	// keystoreExportCommand:
+	// keystoreImportCommand:
+	archiveFlagName = "archive"
+
+	// keystoreExportCommand:
+	walletsFlagName = "wallets"
+)
+
+// KeystoreCommand contains the definition of tools allowing to back up and
+// restore the client's keystore.
+var KeystoreCommand = &cobra.Command{
+	Use:   "keystore",
+	Short: "Keystore backup tools",
+	Long: "The tool exposes commands allowing to back up and restore the " +
+		"signers stored in the client's keystore. Losing the keystore data " +
+		"is a serious protocol violation so keep the archives safe.",
+	TraverseChildren: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+}
+
+var keystoreExportCommand = cobra.Command{
+	Use:   "export",
+	Short: "export signers to an archive",
+	Long: "Exports signers of the given wallets from the tBTC keystore to " +
+		"a single encrypted and integrity-checked archive. Signers of all " +
+		"wallets are exported if no wallets are given. The archive password " +
+		"is read from the " + keystoreArchivePasswordEnvVariable +
+		" environment variable or prompted for.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archivePath, err := cmd.Flags().GetString(archiveFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find archive flag: [%v]", err)
+		}
+
+		wallets, err := cmd.Flags().GetStringSlice(walletsFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallets flag: [%v]", err)
+		}
+
+		walletPublicKeyHashes := make([][20]byte, len(wallets))
+		for i, wallet := range wallets {
+			walletPublicKeyHashes[i], err = newWalletPublicKeyHash(wallet)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to extract wallet public key hash: [%v]",
+					err,
+				)
+			}
+		}
+
+		password, err := readKeystoreArchivePassword(true)
+		if err != nil {
+			return err
+		}
+
+		keyStorePersistence, err := initializeTbtcKeyStorePersistence()
+		if err != nil {
+			return err
+		}
+
+		archive, signers, err := tbtc.ExportKeystoreArchive(
+			keyStorePersistence,
+			walletPublicKeyHashes,
+			password,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to export keystore: [%v]", err)
+		}
+
+		// Never overwrite an existing file; it may be a previous backup.
+		// #nosec G304 (file path provided as taint input)
+		// The path is provided by the operator running the command.
+		archiveFile, err := os.OpenFile(
+			archivePath,
+			os.O_WRONLY|os.O_CREATE|os.O_EXCL,
+			0600,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create archive file: [%v]", err)
+		}
+		defer archiveFile.Close()
+
+		if _, err := archiveFile.Write(archive); err != nil {
+			return fmt.Errorf("failed to write archive file: [%v]", err)
+		}
+
+		if err := archiveFile.Sync(); err != nil {
+			return fmt.Errorf("failed to write archive file: [%v]", err)
+		}
+
+		for _, signer := range signers {
+			fmt.Printf(
+				"exported signer [%v] of wallet [0x%x]\n",
+				signer.SigningGroupMemberIndex,
+				signer.WalletPublicKeyHash,
+			)
+		}
+
+		fmt.Printf(
+			"exported [%v] signers to archive [%s]\n",
+			len(signers),
+			archivePath,
+		)
+
+		return nil
+	},
+}
+
+var keystoreImportCommand = cobra.Command{
+	Use:   "import",
+	Short: "import signers from an archive",
+	Long: "Imports signers from an archive produced by the export command " +
+		"into the tBTC keystore. Each signer is verified to reconstruct " +
+		"its wallet's public key before anything is written. Signers " +
+		"already present in the keystore are left untouched. The client " +
+		"must not be running during the import. The archive password " +
+		"is read from the " + keystoreArchivePasswordEnvVariable +
+		" environment variable or prompted for.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archivePath, err := cmd.Flags().GetString(archiveFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find archive flag: [%v]", err)
+		}
+
+		// #nosec G304 (file path provided as taint input)
+		// The path is provided by the operator running the command.
+		archive, err := os.ReadFile(archivePath)
+		if err != nil {
+			return fmt.Errorf("failed to read archive file: [%v]", err)
+		}
+
+		password, err := readKeystoreArchivePassword(false)
+		if err != nil {
+			return err
+		}
+
+		keyStorePersistence, err := initializeTbtcKeyStorePersistence()
+		if err != nil {
+			return err
+		}
+
+		signers, err := tbtc.ImportKeystoreArchive(
+			keyStorePersistence,
+			archive,
+			password,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to import keystore: [%v]", err)
+		}
+
+		imported := 0
+		for _, signer := range signers {
+			if signer.AlreadyPresent {
+				fmt.Printf(
+					"skipped signer [%v] of wallet [0x%x]; already present\n",
+					signer.SigningGroupMemberIndex,
+					signer.WalletPublicKeyHash,
+				)
+				continue
+			}
+
+			imported++
+
+			fmt.Printf(
+				"imported signer [%v] of wallet [0x%x]\n",
+				signer.SigningGroupMemberIndex,
+				signer.WalletPublicKeyHash,
+			)
+		}
+
+		fmt.Printf(
+			"imported [%v] signers from archive [%s]\n",
+			imported,
+			archivePath,
+		)
+
+		return nil
+	},
+}
+
+func init() {
+	// Export Subcommand.
+
+	initFlags(
+		&keystoreExportCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	keystoreExportCommand.Flags().String(
+		archiveFlagName,
+		"",
+		"path to the archive file to create",
+	)
+
+	if err := keystoreExportCommand.MarkFlagRequired(
+		archiveFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	keystoreExportCommand.Flags().StringSlice(
+		walletsFlagName,
+		[]string{},
+		"comma-separated public key hashes of wallets to export; "+
+			"all wallets are exported if not set",
+	)
+
+	KeystoreCommand.AddCommand(&keystoreExportCommand)
+
+	// Import Subcommand.
+
+	initFlags(
+		&keystoreImportCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	keystoreImportCommand.Flags().String(
+		archiveFlagName,
+		"",
+		"path to the archive file to import",
+	)
+
+	if err := keystoreImportCommand.MarkFlagRequired(
+		archiveFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	KeystoreCommand.AddCommand(&keystoreImportCommand)
+}
+
+// initializeTbtcKeyStorePersistence initializes the tBTC keystore persistence
+// the same way the client does.
+func initializeTbtcKeyStorePersistence() (
+	persistence.ProtectedHandle,
+	error,
+) {
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	tbtcKeyStorePersistence, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot initialize tbtc keystore persistence: [%w]",
+			err,
+		)
+	}
+
+	return tbtcKeyStorePersistence, nil
+}
+
+// readKeystoreArchivePassword reads the keystore archive password from the
+// environment variable or prompts for it. If confirm is set, the prompted
+// password has to be entered twice.
+func readKeystoreArchivePassword(confirm bool) (string, error) {
+	password := os.Getenv(keystoreArchivePasswordEnvVariable)
+	if strings.TrimSpace(password) != "" {
+		return password, nil
+	}
+
+	prompt := func(text string) (string, error) {
+		fmt.Print(text)
+		bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Print("\n")
+		if err != nil {
+			return "", fmt.Errorf("unable to read password: [%v]", err)
+		}
+
+		return strings.TrimSpace(string(bytePassword)), nil
+	}
+
+	password, err := prompt("Enter keystore archive password: ")
+	if err != nil {
+		return "", err
+	}
+
+	if len(password) == 0 {
+		return "", fmt.Errorf("keystore archive password must not be empty")
+	}
+
+	if confirm {
+		confirmation, err := prompt("Confirm keystore archive password: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirmation != password {
+			return "", fmt.Errorf("keystore archive passwords do not match")
+		}
+	}
+
+	return password, nil
+}
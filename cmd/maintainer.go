@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/spf13/cobra"
 
 	"github.com/keep-network/keep-core/config"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/maintainer"
+	"github.com/keep-network/keep-core/pkg/storage"
 )
 
 // MaintainerCommand contains the definition of the maintainer command-line
@@ -37,6 +39,11 @@ func init() {
 		clientConfig,
 		config.MaintainerCategories...,
 	)
+
+	// The storage and client info are optional for maintainers so they are
+	// not a part of the maintainer config categories.
+	initStorageFlags(MaintainerCommand, clientConfig)
+	initClientInfoFlags(MaintainerCommand, clientConfig)
 }
 
 // maintainers initializes maintainer tasks specified by flags passed to the
@@ -44,9 +51,9 @@ func init() {
 func maintainers(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+	btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
 	if err != nil {
-		return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+		return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 	}
 
 	btcDiffChain, err := ethereum.ConnectBitcoinDifficulty(
@@ -72,14 +79,58 @@ func maintainers(cmd *cobra.Command, args []string) error {
 		)
 	}
 
+	spvCheckpointPersistence, err := initializeMaintainerPersistence()
+	if err != nil {
+		return fmt.Errorf(
+			"could not initialize maintainer persistence: [%v]",
+			err,
+		)
+	}
+
+	clientInfoRegistry, isConfigured := clientinfo.Initialize(
+		ctx,
+		clientConfig.ClientInfo.Port,
+	)
+	if !isConfigured {
+		logger.Infof("client info endpoint not configured")
+	}
+
 	maintainer.Initialize(
 		ctx,
 		clientConfig.Maintainer,
 		btcChain,
 		btcDiffChain,
 		tbtcChain,
+		tbtcChain,
+		tbtcChain,
+		spvCheckpointPersistence,
+		clientInfoRegistry,
 	)
 
 	<-ctx.Done()
 	return fmt.Errorf("unexpected context cancellation")
 }
+
+// initializeMaintainerPersistence initializes the work persistence used by
+// maintainers to keep their state between restarts. If the storage directory
+// is not configured, nil is returned and maintainers keep their state only
+// in memory.
+func initializeMaintainerPersistence() (persistence.BasicHandle, error) {
+	if len(clientConfig.Storage.Dir) == 0 {
+		logger.Warn(
+			"storage directory is not configured; maintainers state " +
+				"will not be persisted between restarts",
+		)
+		return nil, nil
+	}
+
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	return storage.InitializeWorkPersistence("maintainer")
+}
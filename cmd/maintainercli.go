@@ -1,24 +1,28 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
-	"os"
 	"sort"
-	"text/tabwriter"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/internal/hexutils"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
 	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
 var (
 	// listDepositsCommand:
+	// walletInfoCommand:
+	// coordinationScheduleCommand:
+	// actionHistoryCommand:
 	walletFlagName = "wallet"
 
 	// listDepositsCommand:
@@ -30,8 +34,19 @@ var (
 
 	// submitDepositSweepProofCommand:
 	// submitRedemptionProofCommand:
+	// submitMovingFundsProofCommand:
+	// submitMovedFundsSweepProofCommand:
 	transactionHashFlagName = "transaction-hash"
 	confirmationsFlagName   = "confirmations"
+
+	// buildDepositRefundCommand:
+	fundingTransactionHashFlagName = "funding-transaction-hash"
+	fundingOutputIndexFlagName     = "funding-output-index"
+	recipientOutputScriptFlagName  = "recipient-output-script"
+	feeFlagName                    = "fee"
+
+	// coordinationScheduleCommand:
+	windowsFlagName = "windows"
 )
 
 // MaintainerCliCommand contains the definition of tools associated with maintainers
@@ -42,6 +57,10 @@ var MaintainerCliCommand = &cobra.Command{
 	Long:             "The tool exposes commands for tools associated with maintainers.",
 	TraverseChildren: true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if _, err := outputFormatFromFlags(cmd); err != nil {
+			logger.Fatalf("error reading output format: %v", err)
+		}
+
 		if err := clientConfig.ReadConfig(
 			configFilePath,
 			cmd.Flags(),
@@ -86,9 +105,9 @@ var listDepositsCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		var walletPublicKeyHash [20]byte
@@ -122,43 +141,31 @@ var listDepositsCommand = cobra.Command{
 			return fmt.Errorf("no deposits found")
 		}
 
-		if err := printDepositsTable(deposits); err != nil {
-			return fmt.Errorf("failed to print deposits table: %v", err)
+		result := &depositsResult{
+			Deposits: make([]depositResult, len(deposits)),
+		}
+		for i, deposit := range deposits {
+			result.Deposits[i] = depositResult{
+				WalletPublicKeyHash: hexutils.Encode(deposit.WalletPublicKeyHash[:]),
+				ScriptType:          deposit.ScriptType.String(),
+				AmountBtc:           deposit.AmountBtc,
+				DepositKey:          deposit.DepositKey,
+				FundingTxHash:       deposit.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+				FundingOutputIndex:  deposit.FundingOutputIndex,
+				RevealBlock:         deposit.RevealBlock,
+				Confirmations:       deposit.Confirmations,
+				Swept:               deposit.IsSwept,
+			}
+		}
+
+		if err := printResult(cmd, result); err != nil {
+			return fmt.Errorf("failed to print deposits: %v", err)
 		}
 
 		return nil
 	},
 }
 
-func printDepositsTable(deposits []*tbtcpg.Deposit) error {
-	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', tabwriter.AlignRight)
-	fmt.Fprintf(w, "index\twallet\ttype\tvalue (BTC)\tdeposit key\trevealed deposit data\tconfirmations\tswept\t\n")
-
-	for i, deposit := range deposits {
-		fmt.Fprintf(w, "%d\t%s\t%s\t%.5f\t%s\t%s\t%d\t%t\t\n",
-			i,
-			hexutils.Encode(deposit.WalletPublicKeyHash[:]),
-			deposit.ScriptType,
-			deposit.AmountBtc,
-			deposit.DepositKey,
-			fmt.Sprintf(
-				"%s:%d:%d",
-				deposit.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
-				deposit.FundingOutputIndex,
-				deposit.RevealBlock,
-			),
-			deposit.Confirmations,
-			deposit.IsSwept,
-		)
-	}
-
-	if err := w.Flush(); err != nil {
-		return fmt.Errorf("failed to flush the writer: %v", err)
-	}
-
-	return nil
-}
-
 var estimateDepositsSweepFeeCommand = cobra.Command{
 	Use:              "estimate-deposits-sweep-fee",
 	Short:            "estimates deposits sweep fee",
@@ -180,9 +187,9 @@ var estimateDepositsSweepFeeCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		fees, err := tbtcpg.EstimateDepositsSweepFee(
@@ -194,71 +201,27 @@ var estimateDepositsSweepFeeCommand = cobra.Command{
 			return fmt.Errorf("cannot estimate deposits sweep fee: [%v]", err)
 		}
 
-		err = printDepositsSweepFeeTable(fees)
-		if err != nil {
-			return fmt.Errorf("cannot print fees table: [%v]", err)
+		result := &depositsSweepFeeResult{
+			Fees: make([]depositsSweepFee, 0, len(fees)),
+		}
+		for depositsCount, fee := range fees {
+			result.Fees = append(result.Fees, depositsSweepFee{
+				DepositsCount:  depositsCount,
+				TotalFee:       fee.TotalFee,
+				SatPerVByteFee: fee.SatPerVByteFee,
+			})
 		}
 
-		return nil
-	},
-}
-
-// printDepositsSweepFeeTable prints estimated fees for specific deposits counts
-// to the standard output. For example:
-//
-// ---------------------------------------------
-// deposits count total fee (satoshis) sat/vbyte
-//	            1                  201         1
-//	            2                  292         1
-//	            3                  384         1
-// ---------------------------------------------
-func printDepositsSweepFeeTable(
-	fees map[int]struct {
-		TotalFee       int64
-		SatPerVByteFee int64
-	},
-) error {
-	writer := tabwriter.NewWriter(
-		os.Stdout,
-		2,
-		4,
-		1,
-		' ',
-		tabwriter.AlignRight,
-	)
-
-	_, err := fmt.Fprintf(writer, "deposits count\ttotal fee (satoshis)\tsat/vbyte\t\n")
-	if err != nil {
-		return err
-	}
-
-	var depositsCountKeys []int
-	for depositsCountKey := range fees {
-		depositsCountKeys = append(depositsCountKeys, depositsCountKey)
-	}
-
-	sort.Slice(depositsCountKeys, func(i, j int) bool {
-		return depositsCountKeys[i] < depositsCountKeys[j]
-	})
+		sort.Slice(result.Fees, func(i, j int) bool {
+			return result.Fees[i].DepositsCount < result.Fees[j].DepositsCount
+		})
 
-	for _, depositsCountKey := range depositsCountKeys {
-		_, err := fmt.Fprintf(
-			writer,
-			"%v\t%v\t%v\t\n",
-			depositsCountKey,
-			fees[depositsCountKey].TotalFee,
-			fees[depositsCountKey].SatPerVByteFee,
-		)
-		if err != nil {
-			return err
+		if err := printResult(cmd, result); err != nil {
+			return fmt.Errorf("cannot print fees: [%v]", err)
 		}
-	}
-
-	if err := writer.Flush(); err != nil {
-		return fmt.Errorf("failed to flush the writer: %v", err)
-	}
 
-	return nil
+		return nil
+	},
 }
 
 var estimateDepositsSweepFeeCommandDescription = "Estimates the satoshi " +
@@ -295,9 +258,9 @@ var submitDepositSweepProofCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
@@ -362,7 +325,11 @@ var submitDepositSweepProofCommand = cobra.Command{
 			transactionHashFlag,
 		)
 
-		return nil
+		return printResult(cmd, &proofSubmissionResult{
+			ProofType:       "DepositSweep",
+			TransactionHash: transactionHashFlag,
+			Confirmations:   requiredConfirmations,
+		})
 	},
 }
 
@@ -385,9 +352,9 @@ var submitRedemptionProofCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
@@ -452,101 +419,920 @@ var submitRedemptionProofCommand = cobra.Command{
 			transactionHashFlag,
 		)
 
-		return nil
+		return printResult(cmd, &proofSubmissionResult{
+			ProofType:       "Redemption",
+			TransactionHash: transactionHashFlag,
+			Confirmations:   requiredConfirmations,
+		})
 	},
 }
 
-func init() {
-	initFlags(
-		MaintainerCliCommand,
-		&configFilePath,
-		clientConfig,
-		config.General, config.Ethereum, config.BitcoinElectrum,
-	)
+var submitMovingFundsProofCommand = cobra.Command{
+	Use:              "submit-moving-funds-proof",
+	Short:            "submit moving funds proof",
+	Long:             "Submits moving funds proof to the Bridge contract",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 
-	// Deposits Subcommand
-	listDepositsCommand.Flags().String(
-		walletFlagName,
-		"",
-		"wallet public key hash",
-	)
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
 
-	listDepositsCommand.Flags().Bool(
-		hideSweptFlagName,
-		false,
-		"hide swept deposits",
-	)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
+		if err != nil {
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
 
-	listDepositsCommand.Flags().Int(
-		headFlagName,
-		0,
-		"get head of deposits",
-	)
+		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction hash flag: [%v]", err)
+		}
 
-	MaintainerCliCommand.AddCommand(&listDepositsCommand)
+		transactionHash, err := bitcoin.NewHashFromString(
+			transactionHashFlag,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to parse transaction hash flag: [%v]",
+				err,
+			)
+		}
 
-	// Estimate Deposits Sweep Fee Subcommand.
-	estimateDepositsSweepFeeCommand.Flags().Int(
-		depositsCountFlagName,
-		0,
-		"get estimation for a specific count of input deposits",
-	)
+		// Allow the caller to request a specific number of confirmations.
+		// The Bridge calculates the required difficulty of a chain of block
+		// headers by multiplying the difficulty of the first block header by
+		// the difficulty factor. If the block headers happen to span the
+		// Bitcoin epoch difficulty change and there is a drop of difficulty
+		// between the epochs, the sum of difficulties from the headers chain
+		// may be too low. Allowing the caller to specify a greater number of
+		// confirmations will ensure the transaction can be proven.
+		requiredConfirmations, err := cmd.Flags().GetUint(confirmationsFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to get confirmations flag: [%v]", err)
+		}
 
-	MaintainerCliCommand.AddCommand(&estimateDepositsSweepFeeCommand)
+		// If the caller did not provide the number of required confirmations,
+		// use the default value enforced by the chain.
+		if requiredConfirmations == 0 {
+			txProofDifficulty, err := tbtcChain.TxProofDifficultyFactor()
+			if err != nil {
+				return fmt.Errorf(
+					"failed to get transaction proof difficulty factor: [%v]",
+					err,
+				)
+			}
 
-	// Submit Deposit Sweep Proof Subcommand.
+			requiredConfirmations = uint(txProofDifficulty.Int64())
+		}
 
-	submitDepositSweepProofCommand.Flags().String(
-		transactionHashFlagName,
-		"",
-		"transaction hash the proof will be prepared for (the format should "+
-			"be the same as in Bitcoin explorers).",
-	)
+		logger.Infof(
+			"Submitting moving funds proof for transaction [%s]",
+			transactionHashFlag,
+		)
 
-	if err := submitDepositSweepProofCommand.MarkFlagRequired(
-		transactionHashFlagName,
-	); err != nil {
-		logger.Fatalf("failed to mark flag required: [%v]", err)
-	}
+		if err = spv.SubmitMovingFundsProof(
+			transactionHash,
+			requiredConfirmations,
+			btcChain,
+			tbtcChain,
+		); err != nil {
+			return fmt.Errorf("failed to submit moving funds proof [%v]", err)
+		}
 
-	submitDepositSweepProofCommand.Flags().Uint(
-		confirmationsFlagName,
-		0,
-		"(optional) number of confirmations that will be provided in the proof. "+
-			"This is an optional parameter that can be used in a rare event when "+
-			"more confirmations are required to perform a successful proof "+
-			"validation. If this parameter is not provided, the default value, "+
-			"retrieved from the Bridge will be used.",
-	)
+		logger.Infof(
+			"successfully submitted moving funds proof for transaction: [%s]",
+			transactionHashFlag,
+		)
 
-	MaintainerCliCommand.AddCommand(&submitDepositSweepProofCommand)
+		return printResult(cmd, &proofSubmissionResult{
+			ProofType:       "MovingFunds",
+			TransactionHash: transactionHashFlag,
+			Confirmations:   requiredConfirmations,
+		})
+	},
+}
 
-	// Submit Redemption Proof Subcommand.
+var submitMovedFundsSweepProofCommand = cobra.Command{
+	Use:              "submit-moved-funds-sweep-proof",
+	Short:            "submit moved funds sweep proof",
+	Long:             "Submits moved funds sweep proof to the Bridge contract",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 
-	submitRedemptionProofCommand.Flags().String(
-		transactionHashFlagName,
-		"",
-		"transaction hash the proof will be prepared for (the format should "+
-			"be the same as in Bitcoin explorers).",
-	)
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
 
-	if err := submitRedemptionProofCommand.MarkFlagRequired(
-		transactionHashFlagName,
-	); err != nil {
-		logger.Fatalf("failed to mark flag required: [%v]", err)
-	}
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
+		if err != nil {
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
 
-	submitRedemptionProofCommand.Flags().Uint(
-		confirmationsFlagName,
-		0,
-		"(optional) number of confirmations that will be provided in the proof. "+
-			"This is an optional parameter that can be used in a rare event when "+
-			"more confirmations are required to perform a successful proof "+
-			"validation. If this parameter is not provided, the default value, "+
-			"retrieved from the Bridge will be used.",
-	)
+		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction hash flag: [%v]", err)
+		}
 
-	MaintainerCliCommand.AddCommand(&submitRedemptionProofCommand)
+		transactionHash, err := bitcoin.NewHashFromString(
+			transactionHashFlag,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to parse transaction hash flag: [%v]",
+				err,
+			)
+		}
+
+		// Allow the caller to request a specific number of confirmations.
+		// The Bridge calculates the required difficulty of a chain of block
+		// headers by multiplying the difficulty of the first block header by
+		// the difficulty factor. If the block headers happen to span the
+		// Bitcoin epoch difficulty change and there is a drop of difficulty
+		// between the epochs, the sum of difficulties from the headers chain
+		// may be too low. Allowing the caller to specify a greater number of
+		// confirmations will ensure the transaction can be proven.
+		requiredConfirmations, err := cmd.Flags().GetUint(confirmationsFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to get confirmations flag: [%v]", err)
+		}
+
+		// If the caller did not provide the number of required confirmations,
+		// use the default value enforced by the chain.
+		if requiredConfirmations == 0 {
+			txProofDifficulty, err := tbtcChain.TxProofDifficultyFactor()
+			if err != nil {
+				return fmt.Errorf(
+					"failed to get transaction proof difficulty factor: [%v]",
+					err,
+				)
+			}
+
+			requiredConfirmations = uint(txProofDifficulty.Int64())
+		}
+
+		logger.Infof(
+			"Submitting moved funds sweep proof for transaction [%s]",
+			transactionHashFlag,
+		)
+
+		if err = spv.SubmitMovedFundsSweepProof(
+			transactionHash,
+			requiredConfirmations,
+			btcChain,
+			tbtcChain,
+		); err != nil {
+			return fmt.Errorf("failed to submit moved funds sweep proof [%v]", err)
+		}
+
+		logger.Infof(
+			"successfully submitted moved funds sweep proof for transaction: [%s]",
+			transactionHashFlag,
+		)
+
+		return printResult(cmd, &proofSubmissionResult{
+			ProofType:       "MovedFundsSweep",
+			TransactionHash: transactionHashFlag,
+			Confirmations:   requiredConfirmations,
+		})
+	},
+}
+
+var buildDepositRefundCommand = cobra.Command{
+	Use:   "build-deposit-refund",
+	Short: "build deposit refund transaction",
+	Long: "Builds an unsigned Bitcoin transaction refunding the given " +
+		"revealed deposit to the depositor and prints it along with the " +
+		"signature hash that must be signed by the depositor using the " +
+		"private key corresponding to the deposit refund public key hash. " +
+		"The transaction can be mined only once the deposit refund " +
+		"locktime passes.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		fundingTransactionHashFlag, err := cmd.Flags().GetString(
+			fundingTransactionHashFlagName,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to find funding transaction hash flag: [%v]",
+				err,
+			)
+		}
+
+		fundingTransactionHash, err := bitcoin.NewHashFromString(
+			fundingTransactionHashFlag,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to parse funding transaction hash flag: [%v]",
+				err,
+			)
+		}
+
+		fundingOutputIndex, err := cmd.Flags().GetUint32(
+			fundingOutputIndexFlagName,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to find funding output index flag: [%v]",
+				err,
+			)
+		}
+
+		recipientOutputScriptFlag, err := cmd.Flags().GetString(
+			recipientOutputScriptFlagName,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to find recipient output script flag: [%v]",
+				err,
+			)
+		}
+
+		recipientOutputScript, err := hexutils.Decode(recipientOutputScriptFlag)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to parse recipient output script flag: [%v]",
+				err,
+			)
+		}
+
+		fee, err := cmd.Flags().GetInt64(feeFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find fee flag: [%v]", err)
+		}
+
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
+		if err != nil {
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
+
+		deposit, err := tbtc.FindRevealedDeposit(
+			tbtcChain,
+			fundingTransactionHash,
+			fundingOutputIndex,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to find deposit: [%v]", err)
+		}
+
+		builder, err := tbtc.NewDepositRefundTransactionBuilder(
+			btcChain,
+			deposit,
+			recipientOutputScript,
+			fee,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to build deposit refund transaction: [%v]",
+				err,
+			)
+		}
+
+		depositScript, err := deposit.Script()
+		if err != nil {
+			return fmt.Errorf("failed to compute deposit script: [%v]", err)
+		}
+
+		unsignedTransaction := builder.UnsignedTransaction()
+
+		return printResult(cmd, &depositRefundResult{
+			RefundPublicKeyHash: hex.EncodeToString(deposit.RefundPublicKeyHash[:]),
+			RefundLocktime:      unsignedTransaction.Locktime,
+			DepositScript:       hex.EncodeToString(depositScript),
+			UnsignedTransaction: hex.EncodeToString(
+				unsignedTransaction.Serialize(bitcoin.Standard),
+			),
+			SignatureHash: hex.EncodeToString(
+				builder.SignatureHash().FillBytes(make([]byte, 32)),
+			),
+		})
+	},
+}
+
+var walletInfoCommand = cobra.Command{
+	Use:   "wallet-info",
+	Short: "get wallet details",
+	Long: "Gets the current state of the given wallet from the host chain " +
+		"and the Bitcoin chain, including its main UTXO, pending " +
+		"redemptions, unswept deposits and mempool transactions, and " +
+		"prints whether the wallet is able to run each action type.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: [%v]", err)
+		}
+
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to extract wallet public key hash: [%v]",
+				err,
+			)
+		}
+
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin, nil)
+		if err != nil {
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
+
+		walletInfo, err := tbtcpg.GetWalletInfo(
+			tbtcChain,
+			btcChain,
+			walletPublicKeyHash,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get wallet info: [%v]", err)
+		}
+
+		return printResult(cmd, newWalletInfoResult(walletInfo))
+	},
+}
+
+var coordinationScheduleCommand = cobra.Command{
+	Use:   "coordination-schedule",
+	Short: "get wallet coordination schedule",
+	Long: "Computes upcoming coordination windows of the given wallet and " +
+		"prints their blocks, the coordination leader and the actions " +
+		"checklist, the same way the client does during the coordination. " +
+		"The leader and the heartbeat inclusion can be determined only " +
+		"once the safe block of the given window is mined.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: [%v]", err)
+		}
+
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to extract wallet public key hash: [%v]",
+				err,
+			)
+		}
+
+		windows, err := cmd.Flags().GetUint64(windowsFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find windows flag: [%v]", err)
+		}
+
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		schedule, err := tbtc.CoordinationSchedule(
+			tbtcChain,
+			walletPublicKeyHash,
+			windows,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to compute coordination schedule: [%v]",
+				err,
+			)
+		}
+
+		result := &coordinationScheduleResult{
+			WalletPublicKeyHash: hexutils.Encode(walletPublicKeyHash[:]),
+			Windows:             make([]coordinationWindowResult, len(schedule)),
+		}
+		for i, entry := range schedule {
+			actionsChecklist := make([]string, len(entry.ActionsChecklist))
+			for j, action := range entry.ActionsChecklist {
+				actionsChecklist[j] = action.String()
+			}
+
+			result.Windows[i] = coordinationWindowResult{
+				Index:               entry.Index,
+				CoordinationBlock:   entry.CoordinationBlock,
+				ActivePhaseEndBlock: entry.ActivePhaseEndBlock,
+				EndBlock:            entry.EndBlock,
+				SafeBlock:           entry.SafeBlock,
+				SeedAvailable:       entry.SeedAvailable,
+				Leader:              entry.Leader.String(),
+				ActionsChecklist:    actionsChecklist,
+			}
+		}
+
+		return printResult(cmd, result)
+	},
+}
+
+var actionHistoryCommand = cobra.Command{
+	Use:   "action-history",
+	Short: "get node's action history",
+	Long: "Reads the action history persisted by the client in the work " +
+		"storage directory and prints the coordination results, including " +
+		"detected coordination faults, and wallet actions executed by " +
+		"the client. The storage directory must be the one used by the " +
+		"client and the Ethereum key file password must be the one used " +
+		"to encrypt the storage.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: [%v]", err)
+		}
+
+		var walletFilter string
+		if len(wallet) > 0 {
+			walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to extract wallet public key hash: [%v]",
+					err,
+				)
+			}
+
+			walletFilter = hexutils.Encode(walletPublicKeyHash[:])
+		}
+
+		if len(clientConfig.Storage.Dir) == 0 {
+			return fmt.Errorf(
+				"missing value for storage.dir; see storage section " +
+					"in configuration",
+			)
+		}
+
+		storage, err := storage.Initialize(
+			clientConfig.Storage,
+			clientConfig.Ethereum.KeyFilePassword,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot initialize storage: [%w]", err)
+		}
+
+		tbtcDataPersistence, err := storage.InitializeWorkPersistence("tbtc")
+		if err != nil {
+			return fmt.Errorf(
+				"cannot initialize tbtc data persistence: [%w]",
+				err,
+			)
+		}
+
+		entries, err := tbtc.ReadActionHistory(tbtcDataPersistence)
+		if err != nil {
+			return fmt.Errorf("failed to read action history: [%v]", err)
+		}
+
+		return printResult(cmd, newActionHistoryResult(entries, walletFilter))
+	},
+}
+
+// newActionHistoryResult converts the given action history entries to
+// the action-history command result. If walletFilter is not empty, only
+// entries of the wallet with the given 0x-prefixed public key hash are
+// included.
+func newActionHistoryResult(
+	entries []*tbtc.ActionHistoryEntry,
+	walletFilter string,
+) *actionHistoryResult {
+	result := &actionHistoryResult{
+		Entries: make([]actionHistoryEntry, 0),
+	}
+
+	for _, entry := range entries {
+		if len(walletFilter) > 0 &&
+			!strings.EqualFold(entry.WalletPublicKeyHash, walletFilter) {
+			continue
+		}
+
+		faults := make([]actionHistoryFault, len(entry.Faults))
+		for i, fault := range entry.Faults {
+			faults[i] = actionHistoryFault{
+				Culprit:   fault.Culprit,
+				FaultType: fault.FaultType,
+			}
+		}
+
+		result.Entries = append(result.Entries, actionHistoryEntry{
+			Sequence:               entry.Sequence,
+			Timestamp:              entry.Timestamp,
+			Event:                  string(entry.Event),
+			WalletPublicKeyHash:    entry.WalletPublicKeyHash,
+			Action:                 entry.Action,
+			CoordinationBlock:      entry.CoordinationBlock,
+			Leader:                 entry.Leader,
+			Leading:                entry.Leading,
+			Faults:                 faults,
+			Error:                  entry.Error,
+			BitcoinTransactionHash: entry.BitcoinTransactionHash,
+		})
+	}
+
+	return result
+}
+
+// newWalletInfoResult converts the given wallet info to the wallet-info
+// command result.
+func newWalletInfoResult(walletInfo *tbtcpg.WalletInfo) *walletInfoResult {
+	chainData := walletInfo.ChainData
+
+	result := &walletInfoResult{
+		WalletPublicKeyHash:                 hexutils.Encode(walletInfo.WalletPublicKeyHash[:]),
+		State:                               chainData.State.String(),
+		CreatedAt:                           chainData.CreatedAt,
+		PendingRedemptionsValue:             chainData.PendingRedemptionsValue,
+		PendingMovedFundsSweepRequestsCount: chainData.PendingMovedFundsSweepRequestsCount,
+		Synced:                              walletInfo.SyncErr == nil,
+		PendingRedemptions:                  make([]walletInfoRedemption, 0),
+		UnsweptDeposits:                     make([]walletInfoDeposit, 0),
+		MempoolTransactions:                 make([]string, 0),
+		Actions:                             make([]walletInfoAction, 0),
+	}
+
+	if walletInfo.MainUtxo != nil {
+		result.MainUtxo = fmt.Sprintf(
+			"%s:%d",
+			walletInfo.MainUtxo.Outpoint.TransactionHash.Hex(
+				bitcoin.ReversedByteOrder,
+			),
+			walletInfo.MainUtxo.Outpoint.OutputIndex,
+		)
+		result.MainUtxoValue = walletInfo.MainUtxo.Value
+	}
+
+	if walletInfo.MainUtxoErr != nil {
+		result.MainUtxoError = walletInfo.MainUtxoErr.Error()
+	}
+
+	if walletInfo.SyncErr != nil {
+		result.SyncError = walletInfo.SyncErr.Error()
+	}
+
+	for _, redemption := range walletInfo.PendingRedemptions {
+		result.PendingRedemptions = append(
+			result.PendingRedemptions,
+			walletInfoRedemption{
+				RedemptionKey: redemption.RedemptionKey,
+				RedeemerOutputScript: hexutils.Encode(
+					redemption.RedeemerOutputScript,
+				),
+				RequestedAt:     redemption.RequestedAt,
+				RequestedAmount: redemption.RequestedAmount,
+			},
+		)
+	}
+
+	for _, deposit := range walletInfo.UnsweptDeposits {
+		result.UnsweptDeposits = append(
+			result.UnsweptDeposits,
+			walletInfoDeposit{
+				DepositKey: deposit.DepositKey,
+				FundingTxHash: deposit.FundingTxHash.Hex(
+					bitcoin.ReversedByteOrder,
+				),
+				OutputIndex:   deposit.FundingOutputIndex,
+				RevealBlock:   deposit.RevealBlock,
+				AmountBtc:     deposit.AmountBtc,
+				Confirmations: deposit.Confirmations,
+			},
+		)
+	}
+
+	for _, transaction := range walletInfo.MempoolTransactions {
+		result.MempoolTransactions = append(
+			result.MempoolTransactions,
+			transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	for _, action := range walletInfo.Actions {
+		result.Actions = append(
+			result.Actions,
+			walletInfoAction{
+				Action:    action.Action.String(),
+				Available: action.Available,
+				Reason:    action.Reason,
+			},
+		)
+	}
+
+	return result
+}
+
+func init() {
+	initFlags(
+		MaintainerCliCommand,
+		&configFilePath,
+		clientConfig,
+		config.General, config.Ethereum, config.BitcoinElectrum,
+	)
+
+	MaintainerCliCommand.PersistentFlags().String(
+		outputFlagName,
+		string(tableOutputFormat),
+		fmt.Sprintf(
+			"output format of the commands result; one of: %s, %s, %s",
+			tableOutputFormat,
+			jsonOutputFormat,
+			csvOutputFormat,
+		),
+	)
+
+	// Deposits Subcommand
+	listDepositsCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	listDepositsCommand.Flags().Bool(
+		hideSweptFlagName,
+		false,
+		"hide swept deposits",
+	)
+
+	listDepositsCommand.Flags().Int(
+		headFlagName,
+		0,
+		"get head of deposits",
+	)
+
+	MaintainerCliCommand.AddCommand(&listDepositsCommand)
+
+	// Estimate Deposits Sweep Fee Subcommand.
+	estimateDepositsSweepFeeCommand.Flags().Int(
+		depositsCountFlagName,
+		0,
+		"get estimation for a specific count of input deposits",
+	)
+
+	MaintainerCliCommand.AddCommand(&estimateDepositsSweepFeeCommand)
+
+	// Submit Deposit Sweep Proof Subcommand.
+
+	submitDepositSweepProofCommand.Flags().String(
+		transactionHashFlagName,
+		"",
+		"transaction hash the proof will be prepared for (the format should "+
+			"be the same as in Bitcoin explorers).",
+	)
+
+	if err := submitDepositSweepProofCommand.MarkFlagRequired(
+		transactionHashFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	submitDepositSweepProofCommand.Flags().Uint(
+		confirmationsFlagName,
+		0,
+		"(optional) number of confirmations that will be provided in the proof. "+
+			"This is an optional parameter that can be used in a rare event when "+
+			"more confirmations are required to perform a successful proof "+
+			"validation. If this parameter is not provided, the default value, "+
+			"retrieved from the Bridge will be used.",
+	)
+
+	MaintainerCliCommand.AddCommand(&submitDepositSweepProofCommand)
+
+	// Submit Redemption Proof Subcommand.
+
+	submitRedemptionProofCommand.Flags().String(
+		transactionHashFlagName,
+		"",
+		"transaction hash the proof will be prepared for (the format should "+
+			"be the same as in Bitcoin explorers).",
+	)
+
+	if err := submitRedemptionProofCommand.MarkFlagRequired(
+		transactionHashFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	submitRedemptionProofCommand.Flags().Uint(
+		confirmationsFlagName,
+		0,
+		"(optional) number of confirmations that will be provided in the proof. "+
+			"This is an optional parameter that can be used in a rare event when "+
+			"more confirmations are required to perform a successful proof "+
+			"validation. If this parameter is not provided, the default value, "+
+			"retrieved from the Bridge will be used.",
+	)
+
+	MaintainerCliCommand.AddCommand(&submitRedemptionProofCommand)
+
+	// Submit Moving Funds Proof Subcommand.
+
+	submitMovingFundsProofCommand.Flags().String(
+		transactionHashFlagName,
+		"",
+		"transaction hash the proof will be prepared for (the format should "+
+			"be the same as in Bitcoin explorers).",
+	)
+
+	if err := submitMovingFundsProofCommand.MarkFlagRequired(
+		transactionHashFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	submitMovingFundsProofCommand.Flags().Uint(
+		confirmationsFlagName,
+		0,
+		"(optional) number of confirmations that will be provided in the proof. "+
+			"This is an optional parameter that can be used in a rare event when "+
+			"more confirmations are required to perform a successful proof "+
+			"validation. If this parameter is not provided, the default value, "+
+			"retrieved from the Bridge will be used.",
+	)
+
+	MaintainerCliCommand.AddCommand(&submitMovingFundsProofCommand)
+
+	// Submit Moved Funds Sweep Proof Subcommand.
+
+	submitMovedFundsSweepProofCommand.Flags().String(
+		transactionHashFlagName,
+		"",
+		"transaction hash the proof will be prepared for (the format should "+
+			"be the same as in Bitcoin explorers).",
+	)
+
+	if err := submitMovedFundsSweepProofCommand.MarkFlagRequired(
+		transactionHashFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	submitMovedFundsSweepProofCommand.Flags().Uint(
+		confirmationsFlagName,
+		0,
+		"(optional) number of confirmations that will be provided in the proof. "+
+			"This is an optional parameter that can be used in a rare event when "+
+			"more confirmations are required to perform a successful proof "+
+			"validation. If this parameter is not provided, the default value, "+
+			"retrieved from the Bridge will be used.",
+	)
+
+	MaintainerCliCommand.AddCommand(&submitMovedFundsSweepProofCommand)
+
+	// Build Deposit Refund Subcommand.
+
+	buildDepositRefundCommand.Flags().String(
+		fundingTransactionHashFlagName,
+		"",
+		"deposit funding transaction hash (the format should be the same "+
+			"as in Bitcoin explorers).",
+	)
+
+	buildDepositRefundCommand.Flags().Uint32(
+		fundingOutputIndexFlagName,
+		0,
+		"deposit funding transaction output index.",
+	)
+
+	buildDepositRefundCommand.Flags().String(
+		recipientOutputScriptFlagName,
+		"",
+		"output script the refunded funds will be locked with.",
+	)
+
+	buildDepositRefundCommand.Flags().Int64(
+		feeFlagName,
+		0,
+		"refund transaction fee in satoshi.",
+	)
+
+	for _, flagName := range []string{
+		fundingTransactionHashFlagName,
+		recipientOutputScriptFlagName,
+		feeFlagName,
+	} {
+		if err := buildDepositRefundCommand.MarkFlagRequired(
+			flagName,
+		); err != nil {
+			logger.Fatalf("failed to mark flag required: [%v]", err)
+		}
+	}
+
+	MaintainerCliCommand.AddCommand(&buildDepositRefundCommand)
+
+	// Wallet Info Subcommand.
+
+	walletInfoCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	if err := walletInfoCommand.MarkFlagRequired(
+		walletFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	// Kept for backward compatibility with scripts relying on the flag.
+	walletInfoCommand.Flags().Bool(
+		jsonFlagName,
+		false,
+		"print the wallet info in the JSON format",
+	)
+
+	if err := walletInfoCommand.Flags().MarkDeprecated(
+		jsonFlagName,
+		fmt.Sprintf("use --%s=%s instead", outputFlagName, jsonOutputFormat),
+	); err != nil {
+		logger.Fatalf("failed to mark flag deprecated: [%v]", err)
+	}
+
+	MaintainerCliCommand.AddCommand(&walletInfoCommand)
+
+	// Coordination Schedule Subcommand.
+
+	coordinationScheduleCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	if err := coordinationScheduleCommand.MarkFlagRequired(
+		walletFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	coordinationScheduleCommand.Flags().Uint64(
+		windowsFlagName,
+		10,
+		"number of coordination windows to compute",
+	)
+
+	MaintainerCliCommand.AddCommand(&coordinationScheduleCommand)
+
+	// Action History Subcommand.
+
+	initFlags(
+		&actionHistoryCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	actionHistoryCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash; if set, only entries of the given wallet "+
+			"are printed",
+	)
+
+	MaintainerCliCommand.AddCommand(&actionHistoryCommand)
 }
 
 func newWalletPublicKeyHash(str string) ([20]byte, error) {
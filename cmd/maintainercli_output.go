@@ -0,0 +1,643 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// outputFlagName is the name of the global maintainer CLI flag determining
+// the format of the commands output.
+const outputFlagName = "output"
+
+// jsonFlagName is the name of the deprecated flag of some maintainer CLI
+// commands that used to print their results in the JSON format before the
+// global output flag was introduced. It is an alias of the JSON output format.
+const jsonFlagName = "json"
+
+// resultSchemaVersion is the version of the schema of results printed by
+// maintainer CLI commands in the JSON format. It must be incremented on each
+// backward incompatible change of the result structs, e.g. when a field is
+// renamed or removed. Adding a new field is a backward compatible change.
+const resultSchemaVersion = 1
+
+// outputFormat is the format of the maintainer CLI commands output.
+type outputFormat string
+
+const (
+	tableOutputFormat outputFormat = "table"
+	jsonOutputFormat  outputFormat = "json"
+	csvOutputFormat   outputFormat = "csv"
+)
+
+// parseOutputFormat parses the given output format name.
+func parseOutputFormat(name string) (outputFormat, error) {
+	switch format := outputFormat(name); format {
+	case tableOutputFormat, jsonOutputFormat, csvOutputFormat:
+		return format, nil
+	default:
+		return "", fmt.Errorf(
+			"unsupported output format [%s]; expected one of: %s, %s, %s",
+			name,
+			tableOutputFormat,
+			jsonOutputFormat,
+			csvOutputFormat,
+		)
+	}
+}
+
+// outputFormatFromFlags returns the output format set using the global
+// output flag of the given command. If the command has the deprecated json
+// flag set, the JSON output format is returned.
+func outputFormatFromFlags(cmd *cobra.Command) (outputFormat, error) {
+	name, err := cmd.Flags().GetString(outputFlagName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find output flag: [%v]", err)
+	}
+
+	format, err := parseOutputFormat(name)
+	if err != nil {
+		return "", err
+	}
+
+	if cmd.Flags().Lookup(jsonFlagName) == nil {
+		return format, nil
+	}
+
+	printJSON, err := cmd.Flags().GetBool(jsonFlagName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find json flag: [%v]", err)
+	}
+
+	if !printJSON {
+		return format, nil
+	}
+
+	if cmd.Flags().Changed(outputFlagName) && format != jsonOutputFormat {
+		return "", fmt.Errorf(
+			"json flag cannot be used along with output format [%s]",
+			format,
+		)
+	}
+
+	return jsonOutputFormat, nil
+}
+
+// resultTable is a tabular representation of a command result used by the
+// table and CSV output formats.
+type resultTable struct {
+	// title is printed above the table in the table output format. It is
+	// optional and ignored by the CSV output format.
+	title  string
+	header []string
+	rows   [][]string
+}
+
+// commandResult is a result of a maintainer CLI command.
+type commandResult interface {
+	// resultKind returns the name of the result kind, included in the JSON
+	// output to let consumers distinguish between results.
+	resultKind() string
+	// tables returns the tabular representation of the result.
+	tables() []resultTable
+}
+
+// resultEnvelope wraps command results printed in the JSON format.
+type resultEnvelope struct {
+	Version int           `json:"version"`
+	Kind    string        `json:"kind"`
+	Result  commandResult `json:"result"`
+}
+
+// printResult prints the given result to the standard output in the format
+// set using the global output flag of the given command.
+func printResult(cmd *cobra.Command, result commandResult) error {
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	return writeResult(os.Stdout, format, result)
+}
+
+// writeResult writes the given result to the given writer using the given
+// output format.
+func writeResult(
+	writer io.Writer,
+	format outputFormat,
+	result commandResult,
+) error {
+	switch format {
+	case jsonOutputFormat:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(&resultEnvelope{
+			Version: resultSchemaVersion,
+			Kind:    result.resultKind(),
+			Result:  result,
+		})
+	case csvOutputFormat:
+		return writeResultCsv(writer, result.tables())
+	case tableOutputFormat:
+		return writeResultTable(writer, result.tables())
+	default:
+		return fmt.Errorf("unsupported output format [%s]", format)
+	}
+}
+
+// writeResultTable writes the given tables in a human-readable form.
+func writeResultTable(writer io.Writer, tables []resultTable) error {
+	tabWriter := tabwriter.NewWriter(
+		writer,
+		2,
+		4,
+		1,
+		' ',
+		tabwriter.AlignRight,
+	)
+
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(tabWriter); err != nil {
+				return err
+			}
+		}
+
+		if len(table.title) > 0 {
+			if _, err := fmt.Fprintf(tabWriter, "%s:\n", table.title); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range append([][]string{table.header}, table.rows...) {
+			for _, cell := range row {
+				if _, err := fmt.Fprintf(tabWriter, "%s\t", cell); err != nil {
+					return err
+				}
+			}
+
+			if _, err := fmt.Fprintln(tabWriter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tabWriter.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the writer: %v", err)
+	}
+
+	return nil
+}
+
+// writeResultCsv writes the given tables in the CSV format. Subsequent tables
+// are separated with an empty line.
+func writeResultCsv(writer io.Writer, tables []resultTable) error {
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(writer); err != nil {
+				return err
+			}
+		}
+
+		csvWriter := csv.NewWriter(writer)
+
+		if err := csvWriter.Write(table.header); err != nil {
+			return err
+		}
+
+		if err := csvWriter.WriteAll(table.rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// depositsResult is the result of the list-deposits command.
+type depositsResult struct {
+	Deposits []depositResult `json:"deposits"`
+}
+
+type depositResult struct {
+	WalletPublicKeyHash string  `json:"walletPublicKeyHash"`
+	ScriptType          string  `json:"scriptType"`
+	AmountBtc           float64 `json:"amountBtc"`
+	DepositKey          string  `json:"depositKey"`
+	FundingTxHash       string  `json:"fundingTxHash"`
+	FundingOutputIndex  uint32  `json:"fundingOutputIndex"`
+	RevealBlock         uint64  `json:"revealBlock"`
+	Confirmations       uint    `json:"confirmations"`
+	Swept               bool    `json:"swept"`
+}
+
+func (dr *depositsResult) resultKind() string {
+	return "deposits"
+}
+
+func (dr *depositsResult) tables() []resultTable {
+	rows := make([][]string, len(dr.Deposits))
+	for i, deposit := range dr.Deposits {
+		rows[i] = []string{
+			strconv.Itoa(i),
+			deposit.WalletPublicKeyHash,
+			deposit.ScriptType,
+			fmt.Sprintf("%.5f", deposit.AmountBtc),
+			deposit.DepositKey,
+			fmt.Sprintf(
+				"%s:%d:%d",
+				deposit.FundingTxHash,
+				deposit.FundingOutputIndex,
+				deposit.RevealBlock,
+			),
+			strconv.FormatUint(uint64(deposit.Confirmations), 10),
+			strconv.FormatBool(deposit.Swept),
+		}
+	}
+
+	return []resultTable{{
+		header: []string{
+			"index",
+			"wallet",
+			"type",
+			"value (BTC)",
+			"deposit key",
+			"revealed deposit data",
+			"confirmations",
+			"swept",
+		},
+		rows: rows,
+	}}
+}
+
+// depositsSweepFeeResult is the result of the estimate-deposits-sweep-fee
+// command.
+type depositsSweepFeeResult struct {
+	Fees []depositsSweepFee `json:"fees"`
+}
+
+type depositsSweepFee struct {
+	DepositsCount  int   `json:"depositsCount"`
+	TotalFee       int64 `json:"totalFee"`
+	SatPerVByteFee int64 `json:"satPerVByteFee"`
+}
+
+func (dsfr *depositsSweepFeeResult) resultKind() string {
+	return "depositsSweepFee"
+}
+
+func (dsfr *depositsSweepFeeResult) tables() []resultTable {
+	rows := make([][]string, len(dsfr.Fees))
+	for i, fee := range dsfr.Fees {
+		rows[i] = []string{
+			strconv.Itoa(fee.DepositsCount),
+			strconv.FormatInt(fee.TotalFee, 10),
+			strconv.FormatInt(fee.SatPerVByteFee, 10),
+		}
+	}
+
+	return []resultTable{{
+		header: []string{"deposits count", "total fee (satoshis)", "sat/vbyte"},
+		rows:   rows,
+	}}
+}
+
+// proofSubmissionResult is the result of the commands submitting SPV proofs.
+type proofSubmissionResult struct {
+	ProofType       string `json:"proofType"`
+	TransactionHash string `json:"transactionHash"`
+	Confirmations   uint   `json:"confirmations"`
+}
+
+func (psr *proofSubmissionResult) resultKind() string {
+	return "proofSubmission"
+}
+
+func (psr *proofSubmissionResult) tables() []resultTable {
+	return []resultTable{{
+		header: []string{"proof type", "transaction hash", "confirmations"},
+		rows: [][]string{{
+			psr.ProofType,
+			psr.TransactionHash,
+			strconv.FormatUint(uint64(psr.Confirmations), 10),
+		}},
+	}}
+}
+
+// depositRefundResult is the result of the build-deposit-refund command.
+type depositRefundResult struct {
+	RefundPublicKeyHash string `json:"refundPublicKeyHash"`
+	RefundLocktime      uint32 `json:"refundLocktime"`
+	DepositScript       string `json:"depositScript"`
+	UnsignedTransaction string `json:"unsignedTransaction"`
+	SignatureHash       string `json:"signatureHash"`
+}
+
+func (drr *depositRefundResult) resultKind() string {
+	return "depositRefund"
+}
+
+func (drr *depositRefundResult) tables() []resultTable {
+	return []resultTable{{
+		header: []string{"field", "value"},
+		rows: [][]string{
+			{"refund public key hash", drr.RefundPublicKeyHash},
+			{"refund locktime", strconv.FormatUint(uint64(drr.RefundLocktime), 10)},
+			{"deposit script", drr.DepositScript},
+			{"unsigned transaction", drr.UnsignedTransaction},
+			{"signature hash", drr.SignatureHash},
+		},
+	}}
+}
+
+// walletInfoResult is the result of the wallet-info command.
+type walletInfoResult struct {
+	WalletPublicKeyHash                 string                 `json:"walletPublicKeyHash"`
+	State                               string                 `json:"state"`
+	CreatedAt                           time.Time              `json:"createdAt"`
+	PendingRedemptionsValue             uint64                 `json:"pendingRedemptionsValue"`
+	PendingMovedFundsSweepRequestsCount uint32                 `json:"pendingMovedFundsSweepRequestsCount"`
+	MainUtxo                            string                 `json:"mainUtxo,omitempty"`
+	MainUtxoValue                       int64                  `json:"mainUtxoValue,omitempty"`
+	MainUtxoError                       string                 `json:"mainUtxoError,omitempty"`
+	Synced                              bool                   `json:"synced"`
+	SyncError                           string                 `json:"syncError,omitempty"`
+	PendingRedemptions                  []walletInfoRedemption `json:"pendingRedemptions"`
+	UnsweptDeposits                     []walletInfoDeposit    `json:"unsweptDeposits"`
+	MempoolTransactions                 []string               `json:"mempoolTransactions"`
+	Actions                             []walletInfoAction     `json:"actions"`
+}
+
+type walletInfoRedemption struct {
+	RedemptionKey        string    `json:"redemptionKey"`
+	RedeemerOutputScript string    `json:"redeemerOutputScript"`
+	RequestedAt          time.Time `json:"requestedAt"`
+	RequestedAmount      uint64    `json:"requestedAmount"`
+}
+
+type walletInfoDeposit struct {
+	DepositKey    string  `json:"depositKey"`
+	FundingTxHash string  `json:"fundingTxHash"`
+	OutputIndex   uint32  `json:"outputIndex"`
+	RevealBlock   uint64  `json:"revealBlock"`
+	AmountBtc     float64 `json:"amountBtc"`
+	Confirmations uint    `json:"confirmations"`
+}
+
+type walletInfoAction struct {
+	Action    string `json:"action"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (wir *walletInfoResult) resultKind() string {
+	return "walletInfo"
+}
+
+func (wir *walletInfoResult) tables() []resultTable {
+	valueOrNone := func(value string) string {
+		if len(value) == 0 {
+			return "none"
+		}
+		return value
+	}
+
+	summary := resultTable{
+		title:  "wallet",
+		header: []string{"field", "value"},
+		rows: [][]string{
+			{"wallet", wir.WalletPublicKeyHash},
+			{"state", wir.State},
+			{"created at", wir.CreatedAt.String()},
+			{
+				"pending redemptions value (sat)",
+				strconv.FormatUint(wir.PendingRedemptionsValue, 10),
+			},
+			{
+				"pending moved funds sweep requests",
+				strconv.FormatUint(uint64(wir.PendingMovedFundsSweepRequestsCount), 10),
+			},
+			{"main UTXO", valueOrNone(wir.MainUtxo)},
+			{"main UTXO value (sat)", strconv.FormatInt(wir.MainUtxoValue, 10)},
+			{"main UTXO error", valueOrNone(wir.MainUtxoError)},
+			{"synced between chains", strconv.FormatBool(wir.Synced)},
+			{"sync error", valueOrNone(wir.SyncError)},
+		},
+	}
+
+	actions := resultTable{
+		title:  "actions",
+		header: []string{"action", "available", "reason"},
+	}
+	for _, action := range wir.Actions {
+		actions.rows = append(actions.rows, []string{
+			action.Action,
+			strconv.FormatBool(action.Available),
+			action.Reason,
+		})
+	}
+
+	redemptions := resultTable{
+		title: "pending redemptions",
+		header: []string{
+			"index",
+			"redemption key",
+			"redeemer output script",
+			"requested at",
+			"requested amount (sat)",
+		},
+	}
+	for i, redemption := range wir.PendingRedemptions {
+		redemptions.rows = append(redemptions.rows, []string{
+			strconv.Itoa(i),
+			redemption.RedemptionKey,
+			redemption.RedeemerOutputScript,
+			redemption.RequestedAt.String(),
+			strconv.FormatUint(redemption.RequestedAmount, 10),
+		})
+	}
+
+	deposits := resultTable{
+		title: "unswept deposits",
+		header: []string{
+			"index",
+			"deposit key",
+			"funding transaction",
+			"reveal block",
+			"value (BTC)",
+			"confirmations",
+		},
+	}
+	for i, deposit := range wir.UnsweptDeposits {
+		deposits.rows = append(deposits.rows, []string{
+			strconv.Itoa(i),
+			deposit.DepositKey,
+			fmt.Sprintf("%s:%d", deposit.FundingTxHash, deposit.OutputIndex),
+			strconv.FormatUint(deposit.RevealBlock, 10),
+			fmt.Sprintf("%.5f", deposit.AmountBtc),
+			strconv.FormatUint(uint64(deposit.Confirmations), 10),
+		})
+	}
+
+	mempool := resultTable{
+		title:  "mempool transactions",
+		header: []string{"index", "transaction hash"},
+	}
+	for i, transactionHash := range wir.MempoolTransactions {
+		mempool.rows = append(mempool.rows, []string{
+			strconv.Itoa(i),
+			transactionHash,
+		})
+	}
+
+	return []resultTable{summary, actions, redemptions, deposits, mempool}
+}
+
+// coordinationScheduleResult is the result of the coordination-schedule
+// command.
+type coordinationScheduleResult struct {
+	WalletPublicKeyHash string                     `json:"walletPublicKeyHash"`
+	Windows             []coordinationWindowResult `json:"windows"`
+}
+
+type coordinationWindowResult struct {
+	Index               uint64   `json:"index"`
+	CoordinationBlock   uint64   `json:"coordinationBlock"`
+	ActivePhaseEndBlock uint64   `json:"activePhaseEndBlock"`
+	EndBlock            uint64   `json:"endBlock"`
+	SafeBlock           uint64   `json:"safeBlock"`
+	SeedAvailable       bool     `json:"seedAvailable"`
+	Leader              string   `json:"leader,omitempty"`
+	ActionsChecklist    []string `json:"actionsChecklist"`
+}
+
+func (csr *coordinationScheduleResult) resultKind() string {
+	return "coordinationSchedule"
+}
+
+func (csr *coordinationScheduleResult) tables() []resultTable {
+	rows := make([][]string, len(csr.Windows))
+	for i, window := range csr.Windows {
+		leader := window.Leader
+		actionsChecklist := strings.Join(window.ActionsChecklist, " ")
+
+		// The heartbeat inclusion is drawn using the coordination seed.
+		if !window.SeedAvailable {
+			leader = "unknown"
+			actionsChecklist += " (heartbeat unknown)"
+		}
+
+		rows[i] = []string{
+			strconv.FormatUint(window.Index, 10),
+			strconv.FormatUint(window.CoordinationBlock, 10),
+			strconv.FormatUint(window.ActivePhaseEndBlock, 10),
+			strconv.FormatUint(window.EndBlock, 10),
+			leader,
+			actionsChecklist,
+		}
+	}
+
+	return []resultTable{{
+		header: []string{
+			"window",
+			"coordination block",
+			"active phase end block",
+			"end block",
+			"leader",
+			"actions checklist",
+		},
+		rows: rows,
+	}}
+}
+
+// actionHistoryResult is the result of the action-history command.
+type actionHistoryResult struct {
+	Entries []actionHistoryEntry `json:"entries"`
+}
+
+type actionHistoryEntry struct {
+	Sequence               uint64               `json:"sequence"`
+	Timestamp              time.Time            `json:"timestamp"`
+	Event                  string               `json:"event"`
+	WalletPublicKeyHash    string               `json:"walletPublicKeyHash"`
+	Action                 string               `json:"action"`
+	CoordinationBlock      uint64               `json:"coordinationBlock,omitempty"`
+	Leader                 string               `json:"leader,omitempty"`
+	Leading                bool                 `json:"leading,omitempty"`
+	Faults                 []actionHistoryFault `json:"faults,omitempty"`
+	Error                  string               `json:"error,omitempty"`
+	BitcoinTransactionHash string               `json:"bitcoinTransactionHash,omitempty"`
+}
+
+type actionHistoryFault struct {
+	Culprit   string `json:"culprit"`
+	FaultType string `json:"faultType"`
+}
+
+func (ahr *actionHistoryResult) resultKind() string {
+	return "actionHistory"
+}
+
+func (ahr *actionHistoryResult) tables() []resultTable {
+	rows := make([][]string, len(ahr.Entries))
+	for i, entry := range ahr.Entries {
+		var details []string
+
+		if entry.CoordinationBlock != 0 {
+			details = append(
+				details,
+				fmt.Sprintf("window: %d", entry.CoordinationBlock),
+			)
+		}
+		if len(entry.Leader) > 0 {
+			leader := entry.Leader
+			if entry.Leading {
+				leader += " (self)"
+			}
+			details = append(details, fmt.Sprintf("leader: %s", leader))
+		}
+		for _, fault := range entry.Faults {
+			details = append(
+				details,
+				fmt.Sprintf("fault: %s %s", fault.Culprit, fault.FaultType),
+			)
+		}
+		if len(entry.BitcoinTransactionHash) > 0 {
+			details = append(
+				details,
+				fmt.Sprintf("tx: %s", entry.BitcoinTransactionHash),
+			)
+		}
+		if len(entry.Error) > 0 {
+			details = append(details, fmt.Sprintf("error: %s", entry.Error))
+		}
+
+		rows[i] = []string{
+			strconv.FormatUint(entry.Sequence, 10),
+			entry.Timestamp.Format(time.RFC3339),
+			entry.Event,
+			entry.WalletPublicKeyHash,
+			entry.Action,
+			strings.Join(details, "; "),
+		}
+	}
+
+	return []resultTable{{
+		header: []string{
+			"sequence",
+			"timestamp",
+			"event",
+			"wallet",
+			"action",
+			"details",
+		},
+		rows: rows,
+	}}
+}
@@ -0,0 +1,539 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// The expected JSON documents pin the schema of the maintainer CLI results.
+// A change breaking any of these tests is a change of the schema consumed
+// by external tools. Backward incompatible changes must be accompanied by
+// a bump of resultSchemaVersion.
+func TestWriteResult_JsonSchema(t *testing.T) {
+	requestedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var tests = map[string]struct {
+		result       commandResult
+		expectedJson string
+	}{
+		"deposits": {
+			result: &depositsResult{
+				Deposits: []depositResult{{
+					WalletPublicKeyHash: "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+					ScriptType:          "P2WSH",
+					AmountBtc:           0.012,
+					DepositKey:          "0x01",
+					FundingTxHash:       "a8c3b3c1",
+					FundingOutputIndex:  1,
+					RevealBlock:         21,
+					Confirmations:       6,
+					Swept:               false,
+				}},
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "deposits",
+  "result": {
+    "deposits": [
+      {
+        "walletPublicKeyHash": "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+        "scriptType": "P2WSH",
+        "amountBtc": 0.012,
+        "depositKey": "0x01",
+        "fundingTxHash": "a8c3b3c1",
+        "fundingOutputIndex": 1,
+        "revealBlock": 21,
+        "confirmations": 6,
+        "swept": false
+      }
+    ]
+  }
+}
+`,
+		},
+		"deposits sweep fee": {
+			result: &depositsSweepFeeResult{
+				Fees: []depositsSweepFee{{
+					DepositsCount:  1,
+					TotalFee:       201,
+					SatPerVByteFee: 1,
+				}},
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "depositsSweepFee",
+  "result": {
+    "fees": [
+      {
+        "depositsCount": 1,
+        "totalFee": 201,
+        "satPerVByteFee": 1
+      }
+    ]
+  }
+}
+`,
+		},
+		"proof submission": {
+			result: &proofSubmissionResult{
+				ProofType:       "Redemption",
+				TransactionHash: "a8c3b3c1",
+				Confirmations:   6,
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "proofSubmission",
+  "result": {
+    "proofType": "Redemption",
+    "transactionHash": "a8c3b3c1",
+    "confirmations": 6
+  }
+}
+`,
+		},
+		"deposit refund": {
+			result: &depositRefundResult{
+				RefundPublicKeyHash: "e257eccafbc07c381642ce6e7e55120fb077fbed",
+				RefundLocktime:      1700000000,
+				DepositScript:       "14",
+				UnsignedTransaction: "0100",
+				SignatureHash:       "ff",
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "depositRefund",
+  "result": {
+    "refundPublicKeyHash": "e257eccafbc07c381642ce6e7e55120fb077fbed",
+    "refundLocktime": 1700000000,
+    "depositScript": "14",
+    "unsignedTransaction": "0100",
+    "signatureHash": "ff"
+  }
+}
+`,
+		},
+		"wallet info": {
+			result: &walletInfoResult{
+				WalletPublicKeyHash:                 "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+				State:                               "Live",
+				CreatedAt:                           requestedAt,
+				PendingRedemptionsValue:             1000,
+				PendingMovedFundsSweepRequestsCount: 0,
+				MainUtxo:                            "a8c3b3c1:0",
+				MainUtxoValue:                       50000,
+				Synced:                              true,
+				PendingRedemptions: []walletInfoRedemption{{
+					RedemptionKey:        "0x02",
+					RedeemerOutputScript: "0x0014",
+					RequestedAt:          requestedAt,
+					RequestedAmount:      1000,
+				}},
+				UnsweptDeposits: []walletInfoDeposit{{
+					DepositKey:    "0x01",
+					FundingTxHash: "b822b302",
+					OutputIndex:   3,
+					RevealBlock:   32,
+					AmountBtc:     0.5,
+					Confirmations: 25,
+				}},
+				MempoolTransactions: []string{"c693aaa5"},
+				Actions: []walletInfoAction{
+					{Action: "Heartbeat", Available: true},
+					{
+						Action:    "MovingFunds",
+						Available: false,
+						Reason:    "wallet is in [Live] state; expected MovingFunds",
+					},
+				},
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "walletInfo",
+  "result": {
+    "walletPublicKeyHash": "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+    "state": "Live",
+    "createdAt": "2024-01-02T03:04:05Z",
+    "pendingRedemptionsValue": 1000,
+    "pendingMovedFundsSweepRequestsCount": 0,
+    "mainUtxo": "a8c3b3c1:0",
+    "mainUtxoValue": 50000,
+    "synced": true,
+    "pendingRedemptions": [
+      {
+        "redemptionKey": "0x02",
+        "redeemerOutputScript": "0x0014",
+        "requestedAt": "2024-01-02T03:04:05Z",
+        "requestedAmount": 1000
+      }
+    ],
+    "unsweptDeposits": [
+      {
+        "depositKey": "0x01",
+        "fundingTxHash": "b822b302",
+        "outputIndex": 3,
+        "revealBlock": 32,
+        "amountBtc": 0.5,
+        "confirmations": 25
+      }
+    ],
+    "mempoolTransactions": [
+      "c693aaa5"
+    ],
+    "actions": [
+      {
+        "action": "Heartbeat",
+        "available": true
+      },
+      {
+        "action": "MovingFunds",
+        "available": false,
+        "reason": "wallet is in [Live] state; expected MovingFunds"
+      }
+    ]
+  }
+}
+`,
+		},
+		"coordination schedule": {
+			result: &coordinationScheduleResult{
+				WalletPublicKeyHash: "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+				Windows: []coordinationWindowResult{
+					{
+						Index:               15,
+						CoordinationBlock:   13500,
+						ActivePhaseEndBlock: 13580,
+						EndBlock:            13600,
+						SafeBlock:           13468,
+						SeedAvailable:       true,
+						Leader:              "957ECF59507a6A74b8d98747f07a74De270D3CC3",
+						ActionsChecklist:    []string{"Redemption", "Heartbeat"},
+					},
+					{
+						Index:               16,
+						CoordinationBlock:   14400,
+						ActivePhaseEndBlock: 14480,
+						EndBlock:            14500,
+						SafeBlock:           14368,
+						SeedAvailable:       false,
+						ActionsChecklist:    []string{"Redemption"},
+					},
+				},
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "coordinationSchedule",
+  "result": {
+    "walletPublicKeyHash": "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+    "windows": [
+      {
+        "index": 15,
+        "coordinationBlock": 13500,
+        "activePhaseEndBlock": 13580,
+        "endBlock": 13600,
+        "safeBlock": 13468,
+        "seedAvailable": true,
+        "leader": "957ECF59507a6A74b8d98747f07a74De270D3CC3",
+        "actionsChecklist": [
+          "Redemption",
+          "Heartbeat"
+        ]
+      },
+      {
+        "index": 16,
+        "coordinationBlock": 14400,
+        "activePhaseEndBlock": 14480,
+        "endBlock": 14500,
+        "safeBlock": 14368,
+        "seedAvailable": false,
+        "actionsChecklist": [
+          "Redemption"
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+		"action history": {
+			result: &actionHistoryResult{
+				Entries: []actionHistoryEntry{
+					{
+						Sequence:            0,
+						Timestamp:           requestedAt,
+						Event:               "CoordinationResult",
+						WalletPublicKeyHash: "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+						Action:              "DepositSweep",
+						CoordinationBlock:   900,
+						Leader:              "957ECF59507a6A74b8d98747f07a74De270D3CC3",
+						Leading:             true,
+						Faults: []actionHistoryFault{{
+							Culprit:   "5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04",
+							FaultType: "LeaderImpersonation",
+						}},
+					},
+					{
+						Sequence:               1,
+						Timestamp:              requestedAt,
+						Event:                  "ActionFinished",
+						WalletPublicKeyHash:    "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+						Action:                 "DepositSweep",
+						Error:                  "broadcast timeout exceeded",
+						BitcoinTransactionHash: "a8c3b3c1",
+					},
+				},
+			},
+			expectedJson: `{
+  "version": 1,
+  "kind": "actionHistory",
+  "result": {
+    "entries": [
+      {
+        "sequence": 0,
+        "timestamp": "2024-01-02T03:04:05Z",
+        "event": "CoordinationResult",
+        "walletPublicKeyHash": "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+        "action": "DepositSweep",
+        "coordinationBlock": 900,
+        "leader": "957ECF59507a6A74b8d98747f07a74De270D3CC3",
+        "leading": true,
+        "faults": [
+          {
+            "culprit": "5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04",
+            "faultType": "LeaderImpersonation"
+          }
+        ]
+      },
+      {
+        "sequence": 1,
+        "timestamp": "2024-01-02T03:04:05Z",
+        "event": "ActionFinished",
+        "walletPublicKeyHash": "0x7670343fc00ccc2d0cd65360e6ad400697ea0fed",
+        "action": "DepositSweep",
+        "error": "broadcast timeout exceeded",
+        "bitcoinTransactionHash": "a8c3b3c1"
+      }
+    ]
+  }
+}
+`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			err := writeResult(buffer, jsonOutputFormat, test.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedJson != buffer.String() {
+				t.Errorf(
+					"unexpected JSON output\n"+
+						"expected: [%s]\n"+
+						"actual:   [%s]",
+					test.expectedJson,
+					buffer.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestWriteResult_Csv(t *testing.T) {
+	result := &depositsSweepFeeResult{
+		Fees: []depositsSweepFee{
+			{DepositsCount: 1, TotalFee: 201, SatPerVByteFee: 1},
+			{DepositsCount: 2, TotalFee: 292, SatPerVByteFee: 1},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+
+	err := writeResult(buffer, csvOutputFormat, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCsv := "deposits count,total fee (satoshis),sat/vbyte\n" +
+		"1,201,1\n" +
+		"2,292,1\n"
+
+	if expectedCsv != buffer.String() {
+		t.Errorf(
+			"unexpected CSV output\n"+
+				"expected: [%s]\n"+
+				"actual:   [%s]",
+			expectedCsv,
+			buffer.String(),
+		)
+	}
+}
+
+func TestWriteResult_Table(t *testing.T) {
+	result := &depositsSweepFeeResult{
+		Fees: []depositsSweepFee{
+			{DepositsCount: 1, TotalFee: 201, SatPerVByteFee: 1},
+			{DepositsCount: 10, TotalFee: 1028, SatPerVByteFee: 1},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+
+	err := writeResult(buffer, tableOutputFormat, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTable := " deposits count total fee (satoshis) sat/vbyte\n" +
+		"              1                  201         1\n" +
+		"             10                 1028         1\n"
+
+	if expectedTable != buffer.String() {
+		t.Errorf(
+			"unexpected table output\n"+
+				"expected: [%s]\n"+
+				"actual:   [%s]",
+			expectedTable,
+			buffer.String(),
+		)
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	var tests = map[string]struct {
+		name           string
+		expectedFormat outputFormat
+		expectedErr    error
+	}{
+		"table": {
+			name:           "table",
+			expectedFormat: tableOutputFormat,
+		},
+		"json": {
+			name:           "json",
+			expectedFormat: jsonOutputFormat,
+		},
+		"csv": {
+			name:           "csv",
+			expectedFormat: csvOutputFormat,
+		},
+		"unsupported": {
+			name: "yaml",
+			expectedErr: fmt.Errorf(
+				"unsupported output format [yaml]; expected one of: " +
+					"table, json, csv",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			format, err := parseOutputFormat(test.name)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			if test.expectedFormat != format {
+				t.Errorf(
+					"unexpected format\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedFormat,
+					format,
+				)
+			}
+		})
+	}
+}
+
+func TestOutputFormatFromFlags(t *testing.T) {
+	var tests = map[string]struct {
+		hasJSONFlag    bool
+		args           []string
+		expectedFormat outputFormat
+		expectedErr    error
+	}{
+		"default": {
+			hasJSONFlag:    true,
+			args:           []string{},
+			expectedFormat: tableOutputFormat,
+		},
+		"output flag": {
+			hasJSONFlag:    true,
+			args:           []string{"--output", "csv"},
+			expectedFormat: csvOutputFormat,
+		},
+		"json flag": {
+			hasJSONFlag:    true,
+			args:           []string{"--json"},
+			expectedFormat: jsonOutputFormat,
+		},
+		"json flag with json output format": {
+			hasJSONFlag:    true,
+			args:           []string{"--json", "--output", "json"},
+			expectedFormat: jsonOutputFormat,
+		},
+		"json flag with another output format": {
+			hasJSONFlag: true,
+			args:        []string{"--json", "--output", "csv"},
+			expectedErr: fmt.Errorf(
+				"json flag cannot be used along with output format [csv]",
+			),
+		},
+		"no json flag": {
+			hasJSONFlag:    false,
+			args:           []string{"--output", "json"},
+			expectedFormat: jsonOutputFormat,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			command := &cobra.Command{}
+			command.Flags().String(outputFlagName, string(tableOutputFormat), "")
+			if test.hasJSONFlag {
+				command.Flags().Bool(jsonFlagName, false, "")
+			}
+
+			if err := command.Flags().Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			format, err := outputFormatFromFlags(command)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			if test.expectedFormat != format {
+				t.Errorf(
+					"unexpected format\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedFormat,
+					format,
+				)
+			}
+		})
+	}
+}
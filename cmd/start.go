@@ -7,7 +7,6 @@ import (
 
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/build"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/storage"
 
@@ -15,6 +14,7 @@ import (
 
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/bitcoin/quorum"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
@@ -91,19 +91,24 @@ func start(cmd *cobra.Command) error {
 	// Skip initialization for bootstrap nodes as they are only used for network
 	// discovery.
 	if !isBootstrap() {
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
-		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
-		}
-
 		beaconKeyStorePersistence,
 			tbtcKeyStorePersistence,
 			tbtcDataPersistence,
+			bitcoinDataPersistence,
 			err := initializePersistence()
 		if err != nil {
 			return fmt.Errorf("cannot initialize persistence: [%w]", err)
 		}
 
+		btcChain, err := connectBitcoinChain(
+			ctx,
+			clientConfig.Bitcoin,
+			bitcoinDataPersistence,
+		)
+		if err != nil {
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
+
 		scheduler := generator.StartScheduler()
 
 		clientInfoRegistry.ObserveBtcConnectivity(
@@ -113,6 +118,13 @@ func start(cmd *cobra.Command) error {
 
 		clientInfoRegistry.RegisterBtcChainInfoSource(btcChain)
 
+		if btcQuorum, ok := btcChain.(*quorum.Chain); ok {
+			clientInfoRegistry.ObserveBtcQuorum(
+				btcQuorum,
+				clientConfig.ClientInfo.BitcoinMetricsTick,
+			)
+		}
+
 		err = beacon.Initialize(
 			ctx,
 			beaconChain,
@@ -124,10 +136,43 @@ func start(cmd *cobra.Command) error {
 			return fmt.Errorf("error initializing beacon: [%v]", err)
 		}
 
-		proposalGenerator := tbtcpg.NewProposalGenerator(
-			tbtcChain,
-			btcChain,
-		)
+		var proposalGenerator tbtc.CoordinationProposalGenerator
+		if remoteURL := clientConfig.ProposalGenerator.RemoteURL; len(remoteURL) > 0 {
+			// Deposits are selected by the remote service so a non-default
+			// deposit selection policy would be silently ignored.
+			policy := clientConfig.Tbtc.DepositSelectionPolicy
+			if len(policy) > 0 && policy != tbtcpg.DefaultDepositSelectionPolicy {
+				return fmt.Errorf(
+					"deposit selection policy [%s] cannot be used along "+
+						"with the remote proposal generator",
+					policy,
+				)
+			}
+
+			logger.Infof("using remote proposal generator [%s]", remoteURL)
+
+			proposalGenerator = tbtcpg.NewRemoteProposalGenerator(
+				clientConfig.ProposalGenerator,
+				tbtcChain,
+				btcChain,
+			)
+		} else {
+			depositSelectionPolicy, err := tbtcpg.NewDepositSelectionPolicy(
+				clientConfig.Tbtc.DepositSelectionPolicy,
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot create deposit selection policy: [%v]",
+					err,
+				)
+			}
+
+			proposalGenerator = tbtcpg.NewProposalGenerator(
+				tbtcChain,
+				btcChain,
+				depositSelectionPolicy,
+			)
+		}
 
 		err = tbtc.Initialize(
 			ctx,
@@ -229,6 +274,8 @@ func initializeClientInfo(
 
 	registry.RegisterConnectedPeersSource(netProvider, signing)
 
+	registry.RegisterPeerReputationSource(netProvider, signing)
+
 	registry.RegisterClientInfoSource(
 		netProvider,
 		signing,
@@ -250,6 +297,7 @@ func initializePersistence() (
 	beaconKeyStorePersistence persistence.ProtectedHandle,
 	tbtcKeyStorePersistence persistence.ProtectedHandle,
 	tbtcDataPersistence persistence.BasicHandle,
+	bitcoinDataPersistence persistence.BasicHandle,
 	err error,
 ) {
 	storage, err := storage.Initialize(
@@ -257,14 +305,14 @@ func initializePersistence() (
 		clientConfig.Ethereum.KeyFilePassword,
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+		return nil, nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
 	}
 
 	beaconKeyStorePersistence, err = storage.InitializeKeyStorePersistence(
 		"beacon",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize beacon keystore persistence: [%w]",
 			err,
 		)
@@ -274,7 +322,7 @@ func initializePersistence() (
 		"tbtc",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc keystore persistence: [%w]",
 			err,
 		)
@@ -282,11 +330,19 @@ func initializePersistence() (
 
 	tbtcDataPersistence, err = storage.InitializeWorkPersistence("tbtc")
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc data persistence: [%w]",
 			err,
 		)
 	}
 
+	bitcoinDataPersistence, err = storage.InitializeWorkPersistence("bitcoin")
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf(
+			"cannot initialize bitcoin data persistence: [%w]",
+			err,
+		)
+	}
+
 	return
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+var (
+	// storageMigrateCommand:
+	targetBackendFlagName = "target-backend"
+)
+
+// StorageCommand contains the definition of tools allowing to manage the
+// client's storage.
+var StorageCommand = &cobra.Command{
+	Use:   "storage",
+	Short: "Storage management tools",
+	Long: "The tool exposes commands allowing to manage the client's " +
+		"storage. The client must not be running when the storage is " +
+		"managed.",
+	TraverseChildren: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+}
+
+var storageMigrateCommand = cobra.Command{
+	Use:   "migrate",
+	Short: "migrate data between storage backends",
+	Long: "Copies all data from the configured storage backend to the " +
+		"target backend in the same storage directory. The data are copied " +
+		"as-is, without being decrypted, and verified once copied. The " +
+		"target backend must be empty. The source data are left untouched " +
+		"and should be kept as a backup. Once migrated, configure the " +
+		"client to use the target backend with the storage.backend property.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetBackend, err := cmd.Flags().GetString(targetBackendFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find target backend flag: [%v]", err)
+		}
+
+		migrated, err := storage.Migrate(clientConfig.Storage, targetBackend)
+		if err != nil {
+			return fmt.Errorf("failed to migrate storage: [%v]", err)
+		}
+
+		fmt.Printf(
+			"migrated [%v] entries to the [%s] backend; set storage.backend "+
+				"to [%s] to use it\n",
+			migrated,
+			targetBackend,
+			targetBackend,
+		)
+
+		return nil
+	},
+}
+
+func init() {
+	// Migrate Subcommand.
+
+	initFlags(
+		&storageMigrateCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	storageMigrateCommand.Flags().String(
+		targetBackendFlagName,
+		"",
+		"storage backend to migrate the data to: `file` or `bbolt`",
+	)
+
+	if err := storageMigrateCommand.MarkFlagRequired(
+		targetBackendFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	StorageCommand.AddCommand(&storageMigrateCommand)
+}
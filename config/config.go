@@ -19,12 +19,16 @@ import (
 	"golang.org/x/term"
 
 	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
+	"github.com/keep-network/keep-core/pkg/bitcoin/quorum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/storage"
 	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
 var logger = log.Logger("keep-config")
@@ -41,20 +45,43 @@ const (
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum   commonEthereum.Config
-	Bitcoin    BitcoinConfig
-	LibP2P     libp2p.Config `mapstructure:"network"`
-	Storage    storage.Config
-	ClientInfo clientinfo.Config
-	Maintainer maintainer.Config
-	Tbtc       tbtc.Config
+	Ethereum          commonEthereum.Config
+	Bitcoin           BitcoinConfig
+	LibP2P            libp2p.Config `mapstructure:"network"`
+	Storage           storage.Config
+	ClientInfo        clientinfo.Config
+	Maintainer        maintainer.Config
+	Tbtc              tbtc.Config
+	ProposalGenerator tbtcpg.Config
 }
 
+const (
+	// ElectrumBitcoinBackend denotes the Electrum server backend of the
+	// Bitcoin chain client.
+	ElectrumBitcoinBackend = "electrum"
+	// BitcoindBitcoinBackend denotes the Bitcoin Core node backend of the
+	// Bitcoin chain client.
+	BitcoindBitcoinBackend = "bitcoind"
+	// EsploraBitcoinBackend denotes the Esplora API backend of the Bitcoin
+	// chain client.
+	EsploraBitcoinBackend = "esplora"
+)
+
 // BitcoinConfig defines the configuration for Bitcoin.
 type BitcoinConfig struct {
 	bitcoin.Network
+	// Backend determines the backend of the Bitcoin chain client. Supported
+	// values are `electrum`, `bitcoind` and `esplora`. The Electrum backend
+	// is used if the value is empty.
+	Backend string
 	// Electrum defines the configuration for the Electrum client.
 	Electrum electrum.Config
+	// Bitcoind defines the configuration for the Bitcoin Core JSON-RPC client.
+	Bitcoind bitcoind.Config
+	// Esplora defines the configuration for the Esplora API client.
+	Esplora esplora.Config
+	// Quorum defines the configuration for the quorum of multiple backends.
+	Quorum quorum.Config
 }
 
 // Bind the flags to the viper configuration. Viper reads configuration from
@@ -206,9 +233,29 @@ func validateConfig(config *Config, categories ...Category) error {
 				))
 			}
 		case BitcoinElectrum:
-			if config.Bitcoin.Electrum.URL == "" {
+			switch config.Bitcoin.Backend {
+			case "", ElectrumBitcoinBackend:
+				if config.Bitcoin.Electrum.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.electrum.url; see bitcoin electrum section in configuration",
+					))
+				}
+			case BitcoindBitcoinBackend:
+				if config.Bitcoin.Bitcoind.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.bitcoind.url; see bitcoin bitcoind section in configuration",
+					))
+				}
+			case EsploraBitcoinBackend:
+				if config.Bitcoin.Esplora.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.esplora.url; see bitcoin esplora section in configuration",
+					))
+				}
+			default:
 				result = multierror.Append(result, fmt.Errorf(
-					"missing value for bitcoin.electrum.url; see bitcoin electrum section in configuration",
+					"unsupported value [%s] for bitcoin.backend; see bitcoin section in configuration",
+					config.Bitcoin.Backend,
 				))
 			}
 		case Network:
@@ -223,6 +270,15 @@ func validateConfig(config *Config, categories ...Category) error {
 					"missing value for storage.dir; see storage section in configuration",
 				))
 			}
+
+			switch config.Storage.Backend {
+			case "", storage.FileBackend, storage.BboltBackend:
+			default:
+				result = multierror.Append(result, fmt.Errorf(
+					"unsupported value [%s] for storage.backend; see storage section in configuration",
+					config.Storage.Backend,
+				))
+			}
 		}
 	}
 
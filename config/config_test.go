@@ -227,6 +227,46 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Spv.IdleBackoffTime },
 			expectedValue: 15 * time.Minute,
 		},
+		"Maintainer.Fraud.Enabled": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.Enabled },
+			expectedValue: true,
+		},
+		"Maintainer.Fraud.TransactionLimit": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.TransactionLimit },
+			expectedValue: 40,
+		},
+		"Maintainer.Fraud.MinConfirmations": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.MinConfirmations },
+			expectedValue: uint(288),
+		},
+		"Maintainer.Fraud.PendingActionMaxConfirmations": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.PendingActionMaxConfirmations },
+			expectedValue: uint(1008),
+		},
+		"Maintainer.Fraud.RestartBackoffTime": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.RestartBackoffTime },
+			expectedValue: 3 * time.Hour,
+		},
+		"Maintainer.Fraud.IdleBackoffTime": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Fraud.IdleBackoffTime },
+			expectedValue: 45 * time.Minute,
+		},
+		"Maintainer.Redemption.Enabled": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Redemption.Enabled },
+			expectedValue: true,
+		},
+		"Maintainer.Redemption.LookBackPeriod": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Redemption.LookBackPeriod },
+			expectedValue: 96 * time.Hour,
+		},
+		"Maintainer.Redemption.RestartBackoffTime": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Redemption.RestartBackoffTime },
+			expectedValue: 4 * time.Hour,
+		},
+		"Maintainer.Redemption.IdleBackoffTime": {
+			readValueFunc: func(c *Config) interface{} { return c.Maintainer.Redemption.IdleBackoffTime },
+			expectedValue: 50 * time.Minute,
+		},
 	}
 
 	for _, filePath := range filePaths {
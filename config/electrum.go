@@ -30,10 +30,16 @@ func readElectrumUrls(network bitcoin.Network) (
 
 // resolveElectrum checks if Electrum is already configured. If the Electrum URL
 // is empty it reads the Electrum configs from the embedded list for the given
-// network and picks up one randomly.
+// network and picks up one randomly. Electrum is not resolved if a backend
+// other than Electrum is used.
 func (c *Config) resolveElectrum(rng *rand.Rand) error {
 	network := c.Bitcoin.Network
 
+	// Return if a backend other than Electrum is used.
+	if len(c.Bitcoin.Backend) > 0 && c.Bitcoin.Backend != ElectrumBitcoinBackend {
+		return nil
+	}
+
 	// Return if Electrum is already set.
 	if len(c.Bitcoin.Electrum.URL) > 0 {
 		return nil
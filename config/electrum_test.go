@@ -83,6 +83,21 @@ func TestResolveElectrum(t *testing.T) {
 	}
 }
 
+func TestResolveElectrum_BitcoindBackend(t *testing.T) {
+	cfg := &Config{}
+	cfg.Bitcoin.Network = bitcoin.Mainnet
+	cfg.Bitcoin.Backend = BitcoindBitcoinBackend
+
+	err := cfg.resolveElectrum(rand.New(&fakeRandSource{0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(cfg.Bitcoin.Electrum, electrum.Config{}); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
 type fakeRandSource struct {
 	expectedValue int64
 }
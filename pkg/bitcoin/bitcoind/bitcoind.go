@@ -0,0 +1,1108 @@
+package bitcoind
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/wrappers"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+var logger = log.Logger("keep-bitcoind")
+
+// listTransactionsPageSize determines the number of wallet transactions
+// fetched by a single `listtransactions` request.
+const listTransactionsPageSize = 1000
+
+// Connection is a handle for interactions with a Bitcoin Core node over its
+// JSON-RPC interface.
+//
+// Queries by transaction hash require the node to maintain the full
+// transaction index (`txindex=1`). Queries by public key hash and queries
+// for spending transactions are served by a watch-only descriptor wallet
+// loaded on the node. Output scripts of a public key hash are imported to
+// that wallet on the first query concerning the given public key hash.
+// Output scripts of a spent outpoint are imported to that wallet on the
+// first query for the transaction spending the given outpoint.
+type Connection struct {
+	parentCtx context.Context
+	client    *rpcClient
+	config    Config
+
+	watchedMutex sync.Mutex
+	// watched holds labels of output scripts that were already imported to
+	// the descriptor wallet.
+	watched map[string]bool
+}
+
+// Connect initializes handle with provided Config.
+func Connect(parentCtx context.Context, config Config) (bitcoin.Chain, error) {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.RequestRetryTimeout == 0 {
+		config.RequestRetryTimeout = DefaultRequestRetryTimeout
+	}
+	if config.ImportTimeout == 0 {
+		config.ImportTimeout = DefaultImportTimeout
+	}
+
+	c := &Connection{
+		parentCtx: parentCtx,
+		client:    newRPCClient(config.URL, config.Username, config.Password),
+		config:    config,
+		watched:   make(map[string]bool),
+	}
+
+	if err := c.verifyNode(); err != nil {
+		return nil, fmt.Errorf("failed to verify bitcoind node: [%w]", err)
+	}
+
+	return c, nil
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error.
+func (c *Connection) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	rawTransaction, err := request[string](
+		c,
+		"",
+		"getrawtransaction",
+		txID,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get raw transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	result, err := convertRawTransaction(rawTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transaction: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+func (c *Connection) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	type verboseTransaction struct {
+		// Confirmations is absent for mempool transactions.
+		Confirmations uint `json:"confirmations"`
+	}
+
+	transaction, err := request[verboseTransaction](
+		c,
+		"",
+		"getrawtransaction",
+		txID,
+		true,
+	)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get verbose transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	return transaction.Confirmations, nil
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes. If the broadcast action could not be
+// done, this function returns an error. This function does not give any
+// guarantees regarding transaction mining. The transaction may be mined or
+// rejected eventually.
+func (c *Connection) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	rawTx := hex.EncodeToString(transaction.Serialize())
+
+	txID, err := request[string](c, "", "sendrawtransaction", rawTx)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the transaction: [%w]", err)
+	}
+
+	logger.Infof("transaction broadcast successful: [%s]", txID)
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error.
+func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	blockHeight, err := request[uint](c, "", "getblockcount")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block count: [%w]", err)
+	}
+
+	return blockHeight, nil
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error.
+func (c *Connection) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	rawBlockHeader, err := request[string](
+		c,
+		"",
+		"getblockheader",
+		blockHash,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: [%w]", err)
+	}
+
+	blockHeader, err := convertRawBlockHeader(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block header: [%w]", err)
+	}
+
+	return blockHeader, nil
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided.
+func (c *Connection) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	txIDs, err := c.getBlockTxIDs(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	position := -1
+	for i, blockTxID := range txIDs {
+		if blockTxID == txID {
+			position = i
+			break
+		}
+	}
+
+	if position < 0 {
+		return nil, fmt.Errorf(
+			"transaction [%s] not found in block [%v]",
+			txID,
+			blockHeight,
+		)
+	}
+
+	merkleNodes, err := computeMerkleProof(txIDs, position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute merkle proof: [%w]", err)
+	}
+
+	return &bitcoin.TransactionMerkleProof{
+		BlockHeight: blockHeight,
+		MerkleNodes: merkleNodes,
+		Position:    uint(position),
+	}, nil
+}
+
+// GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. Note that taking an unlimited
+// transaction history may be time-consuming as this function fetches
+// complete transactions with all necessary data.
+func (c *Connection) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	txHashes, err := c.GetTxHashesForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectedTxHashes []bitcoin.Hash
+	if len(txHashes) > limit {
+		selectedTxHashes = txHashes[len(txHashes)-limit:]
+	} else {
+		selectedTxHashes = txHashes
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(selectedTxHashes))
+	for i, txHash := range selectedTxHashes {
+		transaction, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request.
+func (c *Connection) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	items, err := c.getWalletTransactions(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmedItems := make([]*walletTransactionItem, 0)
+	for _, item := range items {
+		if item.Confirmations > 0 {
+			confirmedItems = append(confirmedItems, item)
+		}
+	}
+
+	sort.SliceStable(
+		confirmedItems,
+		func(i, j int) bool {
+			if confirmedItems[i].BlockHeight != confirmedItems[j].BlockHeight {
+				return confirmedItems[i].BlockHeight < confirmedItems[j].BlockHeight
+			}
+			return confirmedItems[i].BlockIndex < confirmedItems[j].BlockIndex
+		},
+	)
+
+	return convertWalletTransactionItems(confirmedItems)
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order.
+func (c *Connection) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	items, err := c.getWalletTransactions(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Negative confirmations denote transactions conflicting with
+	// the chain. Such transactions are not in the mempool.
+	mempoolItems := make([]*walletTransactionItem, 0)
+	for _, item := range items {
+		if item.Confirmations == 0 {
+			mempoolItems = append(mempoolItems, item)
+		}
+	}
+
+	txHashes, err := convertWalletTransactionItems(mempoolItems)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(txHashes))
+	for i, txHash := range txHashes {
+		transaction, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+func (c *Connection) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getUtxos(publicKeyHash, true)
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs.
+func (c *Connection) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getUtxos(publicKeyHash, false)
+}
+
+// GetSpendingTransaction gets the confirmed transaction spending the given
+// outpoint. The returned bool value is false if the outpoint has not been
+// spent by any confirmed transaction. Spending transactions living in the
+// mempool at the moment of request are not taken into account. If the
+// outpoint has been spent, its output script is imported to the descriptor
+// wallet in order to find the spending transaction.
+func (c *Connection) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	if len(c.config.Wallet) == 0 {
+		return nil, false, fmt.Errorf(
+			"wallet is not configured; queries for spending transactions " +
+				"are not supported",
+		)
+	}
+
+	txID := outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	type unspentOutput struct {
+		Confirmations uint `json:"confirmations"`
+	}
+
+	// The node returns null for outputs that are not in the UTXO set. Outputs
+	// spent by mempool transactions are still in the UTXO set as the mempool
+	// is not taken into account.
+	output, err := request[*unspentOutput](
+		c,
+		"",
+		"gettxout",
+		txID,
+		outpoint.OutputIndex,
+		false,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get output [%d] of transaction with ID [%s]: [%w]",
+			outpoint.OutputIndex,
+			txID,
+			err,
+		)
+	}
+
+	if output != nil {
+		return nil, false, nil
+	}
+
+	type verboseTransaction struct {
+		BlockHash string `json:"blockhash"`
+		Vout      []struct {
+			ScriptPubKey struct {
+				Hex string `json:"hex"`
+			} `json:"scriptPubKey"`
+		} `json:"vout"`
+	}
+
+	transaction, err := request[verboseTransaction](
+		c,
+		"",
+		"getrawtransaction",
+		txID,
+		true,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get verbose transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	if int(outpoint.OutputIndex) >= len(transaction.Vout) {
+		return nil, false, fmt.Errorf(
+			"transaction with ID [%s] has no output with index [%d]",
+			txID,
+			outpoint.OutputIndex,
+		)
+	}
+
+	script, err := hex.DecodeString(
+		transaction.Vout[outpoint.OutputIndex].ScriptPubKey.Hex,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot decode script [%s]: [%v]",
+			transaction.Vout[outpoint.OutputIndex].ScriptPubKey.Hex,
+			err,
+		)
+	}
+
+	if err := c.watchScripts(
+		hex.EncodeToString(script),
+		[]bitcoin.Script{script},
+	); err != nil {
+		return nil, false, err
+	}
+
+	type blockHeader struct {
+		PreviousBlockHash string `json:"previousblockhash"`
+	}
+
+	header, err := request[blockHeader](
+		c,
+		"",
+		"getblockheader",
+		transaction.BlockHash,
+		true,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get header of block [%s]: [%w]",
+			transaction.BlockHash,
+			err,
+		)
+	}
+
+	type walletTransactions struct {
+		Transactions []*walletTransactionItem `json:"transactions"`
+	}
+
+	// The spending transaction debits the imported script so it is listed
+	// among wallet transactions included in the block of the outpoint
+	// transaction or any later block.
+	sinceBlock, err := request[walletTransactions](
+		c,
+		c.config.Wallet,
+		"listsinceblock",
+		header.PreviousBlockHash,
+		1,
+		true,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to list wallet transactions since block [%s]: [%w]",
+			header.PreviousBlockHash,
+			err,
+		)
+	}
+
+	confirmedItems := make([]*walletTransactionItem, 0)
+	for _, item := range sinceBlock.Transactions {
+		if item.Confirmations > 0 && item.TxID != txID {
+			confirmedItems = append(confirmedItems, item)
+		}
+	}
+
+	txHashes, err := convertWalletTransactionItems(confirmedItems)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, txHash := range txHashes {
+		candidate, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		for _, input := range candidate.Inputs {
+			if *input.Outpoint == *outpoint {
+				return candidate, true, nil
+			}
+		}
+	}
+
+	return nil, false, fmt.Errorf(
+		"output [%d] of transaction with ID [%s] is spent but the spending "+
+			"transaction was not found in the wallet",
+		outpoint.OutputIndex,
+		txID,
+	)
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	type smartFeeEstimate struct {
+		// FeeRate is expressed in BTC/kvB and is absent if the node does
+		// not have enough information to make an estimate.
+		FeeRate float64  `json:"feerate"`
+		Errors  []string `json:"errors"`
+	}
+
+	estimate, err := request[smartFeeEstimate](
+		c,
+		"",
+		"estimatesmartfee",
+		blocks,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate fee: [%v]", err)
+	}
+
+	if estimate.FeeRate <= 0 {
+		return 0, fmt.Errorf(
+			"node does not have enough information to make an estimate: [%v]",
+			estimate.Errors,
+		)
+	}
+
+	return convertBtcKbToSatVByte(estimate.FeeRate), nil
+}
+
+// GetCoinbaseTxHash gets the hash of the coinbase transaction for the given
+// block height.
+func (c *Connection) GetCoinbaseTxHash(blockHeight uint) (bitcoin.Hash, error) {
+	txIDs, err := c.getBlockTxIDs(blockHeight)
+	if err != nil {
+		return bitcoin.Hash{}, err
+	}
+
+	if len(txIDs) == 0 {
+		return bitcoin.Hash{}, fmt.Errorf(
+			"block [%v] has no transactions",
+			blockHeight,
+		)
+	}
+
+	txHash, err := bitcoin.NewHashFromString(
+		txIDs[0],
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return bitcoin.Hash{}, fmt.Errorf(
+			"cannot parse hash [%s]: [%v]",
+			txIDs[0],
+			err,
+		)
+	}
+
+	return txHash, nil
+}
+
+// getBlockHash gets the hash of the block with the given height, as
+// a hexadecimal string in the reversed byte order.
+func (c *Connection) getBlockHash(blockHeight uint) (string, error) {
+	blockHash, err := request[string](c, "", "getblockhash", blockHeight)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to get hash of block [%v]: [%w]",
+			blockHeight,
+			err,
+		)
+	}
+
+	return blockHash, nil
+}
+
+// getBlockTxIDs gets IDs of all transactions of the block with the given
+// height, in the order they are included in the block.
+func (c *Connection) getBlockTxIDs(blockHeight uint) ([]string, error) {
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	type block struct {
+		Tx []string `json:"tx"`
+	}
+
+	// Verbosity 1 returns the block with transactions IDs only.
+	result, err := request[block](c, "", "getblock", blockHash, 1)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get block [%v]: [%w]",
+			blockHeight,
+			err,
+		)
+	}
+
+	return result.Tx, nil
+}
+
+// walletTransactionItem represents an entry returned by the
+// `listtransactions` wallet method.
+type walletTransactionItem struct {
+	TxID          string `json:"txid"`
+	Category      string `json:"category"`
+	Confirmations int64  `json:"confirmations"`
+	BlockHeight   uint   `json:"blockheight"`
+	BlockIndex    uint   `json:"blockindex"`
+}
+
+// getWalletTransactions returns wallet transactions paying the given public
+// key hash. The returned list may contain multiple entries for the same
+// transaction, one per each output paying the public key hash.
+func (c *Connection) getWalletTransactions(
+	publicKeyHash [20]byte,
+) ([]*walletTransactionItem, error) {
+	if err := c.watchPublicKeyHash(publicKeyHash); err != nil {
+		return nil, err
+	}
+
+	label := publicKeyHashLabel(publicKeyHash)
+
+	items := make([]*walletTransactionItem, 0)
+
+	for skip := 0; ; skip += listTransactionsPageSize {
+		page, err := request[[]*walletTransactionItem](
+			c,
+			c.config.Wallet,
+			"listtransactions",
+			label,
+			listTransactionsPageSize,
+			skip,
+			true,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to list wallet transactions for public key "+
+					"hash [0x%x]: [%w]",
+				publicKeyHash,
+				err,
+			)
+		}
+
+		for _, item := range page {
+			if item.Category == "receive" {
+				items = append(items, item)
+			}
+		}
+
+		if len(page) < listTransactionsPageSize {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// convertWalletTransactionItems converts the given wallet transaction items
+// to unique transaction hashes, preserving the order of items.
+func convertWalletTransactionItems(
+	items []*walletTransactionItem,
+) ([]bitcoin.Hash, error) {
+	txHashes := make([]bitcoin.Hash, 0)
+	seen := make(map[bitcoin.Hash]bool)
+
+	for _, item := range items {
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		if seen[txHash] {
+			continue
+		}
+
+		seen[txHash] = true
+		txHashes = append(txHashes, txHash)
+	}
+
+	return txHashes, nil
+}
+
+// getUtxos returns unspent outputs controlled by the given public key hash.
+//
+// If the `confirmed` flag is true, the returned list contains unspent outputs
+// of confirmed transactions, sorted by the block height in the ascending order,
+// i.e. the latest UTXO is at the end of the list.
+//
+// If the `confirmed` flag is false, the returned list contains unspent outputs
+// of unconfirmed transactions, in an indefinite order.
+//
+// In both cases, the resulted list DOES NOT CONTAIN outputs already used as
+// inputs of confirmed or mempool transactions because they are no longer UTXOs.
+func (c *Connection) getUtxos(
+	publicKeyHash [20]byte,
+	confirmed bool,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.watchPublicKeyHash(publicKeyHash); err != nil {
+		return nil, err
+	}
+
+	minConfirmations, maxConfirmations := 0, 0
+	if confirmed {
+		minConfirmations, maxConfirmations = 1, 9999999
+	}
+
+	type unspentItem struct {
+		TxID          string  `json:"txid"`
+		Vout          uint32  `json:"vout"`
+		ScriptPubKey  string  `json:"scriptPubKey"`
+		Amount        float64 `json:"amount"`
+		Confirmations uint    `json:"confirmations"`
+	}
+
+	items, err := request[[]*unspentItem](
+		c,
+		c.config.Wallet,
+		"listunspent",
+		minConfirmations,
+		maxConfirmations,
+		[]string{},
+		// Include outputs of unconfirmed transactions not created by the
+		// wallet itself.
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to list unspent outputs for public key hash [0x%x]: [%w]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	filteredItems := make([]*unspentItem, 0)
+	for _, item := range items {
+		script, err := hex.DecodeString(item.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decode script [%s]: [%v]",
+				item.ScriptPubKey,
+				err,
+			)
+		}
+
+		for _, s := range scripts {
+			if bytes.Equal(script, s) {
+				filteredItems = append(filteredItems, item)
+				break
+			}
+		}
+	}
+
+	if confirmed {
+		// More confirmations mean a lower block height.
+		sort.SliceStable(
+			filteredItems,
+			func(i, j int) bool {
+				return filteredItems[i].Confirmations > filteredItems[j].Confirmations
+			},
+		)
+	}
+
+	utxos := make([]*bitcoin.UnspentTransactionOutput, len(filteredItems))
+	for i, item := range filteredItems {
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		utxos[i] = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: txHash,
+				OutputIndex:     item.Vout,
+			},
+			Value: convertBtcToSatoshi(item.Amount),
+		}
+	}
+
+	return utxos, nil
+}
+
+// watchPublicKeyHash imports P2PKH and P2WPKH output scripts of the given
+// public key hash to the descriptor wallet unless they were already imported.
+// The import triggers a rescan of the chain starting from the configured
+// import timestamp so the wallet can track the history of the public key hash.
+func (c *Connection) watchPublicKeyHash(publicKeyHash [20]byte) error {
+	if len(c.config.Wallet) == 0 {
+		return fmt.Errorf(
+			"wallet is not configured; queries by public key hash " +
+				"are not supported",
+		)
+	}
+
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return err
+	}
+
+	return c.watchScripts(publicKeyHashLabel(publicKeyHash), scripts)
+}
+
+// watchScripts imports the given output scripts to the descriptor wallet
+// under the given label unless scripts with that label were already imported.
+// The import triggers a rescan of the chain starting from the configured
+// import timestamp so the wallet can track the history of the scripts.
+func (c *Connection) watchScripts(label string, scripts []bitcoin.Script) error {
+	c.watchedMutex.Lock()
+	defer c.watchedMutex.Unlock()
+
+	if c.watched[label] {
+		return nil
+	}
+
+	type importRequest struct {
+		Descriptor string `json:"desc"`
+		Timestamp  int64  `json:"timestamp"`
+		Label      string `json:"label"`
+	}
+
+	importRequests := make([]*importRequest, len(scripts))
+	for i, script := range scripts {
+		descriptor, err := c.getDescriptorWithChecksum(
+			fmt.Sprintf("raw(%s)", hex.EncodeToString(script)),
+		)
+		if err != nil {
+			return err
+		}
+
+		importRequests[i] = &importRequest{
+			Descriptor: descriptor,
+			Timestamp:  c.config.ImportTimestamp,
+			Label:      label,
+		}
+	}
+
+	logger.Infof(
+		"importing output scripts with label [%s] to wallet [%s]",
+		label,
+		c.config.Wallet,
+	)
+
+	type importResult struct {
+		Success bool      `json:"success"`
+		Error   *rpcError `json:"error"`
+	}
+
+	// The import rescans the chain and may take a long time. It is not
+	// retried as the next query concerning the scripts will attempt to
+	// import them again anyway.
+	ctx, cancelCtx := context.WithTimeout(c.parentCtx, c.config.ImportTimeout)
+	defer cancelCtx()
+
+	var results []*importResult
+	err := c.client.call(
+		ctx,
+		c.config.Wallet,
+		"importdescriptors",
+		[]interface{}{importRequests},
+		&results,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to import descriptors with label [%s]: [%w]",
+			label,
+			err,
+		)
+	}
+
+	for i, result := range results {
+		if !result.Success {
+			return fmt.Errorf(
+				"failed to import descriptor [%s]: [%v]",
+				importRequests[i].Descriptor,
+				result.Error,
+			)
+		}
+	}
+
+	c.watched[label] = true
+
+	return nil
+}
+
+// getDescriptorWithChecksum returns the given output descriptor along with
+// its checksum, as required by the `importdescriptors` wallet method.
+func (c *Connection) getDescriptorWithChecksum(
+	descriptor string,
+) (string, error) {
+	type descriptorInfo struct {
+		Checksum string `json:"checksum"`
+	}
+
+	info, err := request[descriptorInfo](
+		c,
+		"",
+		"getdescriptorinfo",
+		descriptor,
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to get info of descriptor [%s]: [%w]",
+			descriptor,
+			err,
+		)
+	}
+
+	return fmt.Sprintf("%s#%s", descriptor, info.Checksum), nil
+}
+
+// verifyNode checks whether the node is reachable and, if the wallet is
+// configured, whether the wallet is a descriptor wallet.
+func (c *Connection) verifyNode() error {
+	type blockchainInfo struct {
+		Chain  string `json:"chain"`
+		Blocks uint   `json:"blocks"`
+	}
+
+	info, err := request[blockchainInfo](c, "", "getblockchaininfo")
+	if err != nil {
+		return fmt.Errorf("failed to get blockchain info: [%w]", err)
+	}
+
+	logger.Infof(
+		"connected to bitcoind node; chain: [%s], blocks: [%v]",
+		info.Chain,
+		info.Blocks,
+	)
+
+	if len(c.config.Wallet) == 0 {
+		logger.Warn(
+			"wallet is not configured; queries by public key hash " +
+				"are not supported",
+		)
+		return nil
+	}
+
+	type walletInfo struct {
+		Descriptors bool `json:"descriptors"`
+	}
+
+	wallet, err := request[walletInfo](c, c.config.Wallet, "getwalletinfo")
+	if err != nil {
+		return fmt.Errorf(
+			"failed to get info of wallet [%s]: [%w]",
+			c.config.Wallet,
+			err,
+		)
+	}
+
+	if !wallet.Descriptors {
+		return fmt.Errorf(
+			"wallet [%s] is not a descriptor wallet",
+			c.config.Wallet,
+		)
+	}
+
+	return nil
+}
+
+// request executes the given JSON-RPC method, retrying it until the
+// configured retry timeout is hit. Errors returned by the JSON-RPC server
+// are not retried as they are deterministic responses to the request.
+func request[K interface{}](
+	c *Connection,
+	wallet string,
+	method string,
+	params ...interface{},
+) (K, error) {
+	startTime := time.Now()
+	logger.Debugf("starting [%s] request to bitcoind node", method)
+
+	var result K
+	var serverErr *rpcError
+
+	err := wrappers.DoWithDefaultRetry(
+		c.parentCtx,
+		c.config.RequestRetryTimeout,
+		func(ctx context.Context) error {
+			requestCtx, requestCancel := context.WithTimeout(
+				ctx,
+				c.config.RequestTimeout,
+			)
+			defer requestCancel()
+
+			var r K
+			err := c.client.call(requestCtx, wallet, method, params, &r)
+			if errors.As(err, &serverErr) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("request failed: [%w]", err)
+			}
+
+			result = r
+			return nil
+		},
+	)
+	if err == nil && serverErr != nil {
+		err = serverErr
+	}
+
+	solveRequestOutcome := func(err error) string {
+		if err != nil {
+			return fmt.Sprintf("error: [%v]", err)
+		}
+		return "success"
+	}
+
+	logger.Debugf("[%s] request to bitcoind node completed with [%s] after [%s]",
+		method,
+		solveRequestOutcome(err),
+		time.Since(startTime),
+	)
+
+	return result, err
+}
+
+// publicKeyHashScripts returns the P2PKH and P2WPKH output scripts of the
+// given public key hash.
+func publicKeyHashScripts(publicKeyHash [20]byte) ([]bitcoin.Script, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	return []bitcoin.Script{p2pkh, p2wpkh}, nil
+}
+
+// publicKeyHashLabel returns the wallet label assigned to output scripts of
+// the given public key hash.
+func publicKeyHashLabel(publicKeyHash [20]byte) string {
+	return hex.EncodeToString(publicKeyHash[:])
+}
@@ -0,0 +1,42 @@
+package bitcoind
+
+import "time"
+
+const (
+	// DefaultRequestTimeout is a default timeout used for a single attempt of
+	// JSON-RPC request.
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultRequestRetryTimeout is a default timeout used for JSON-RPC request
+	// retries.
+	DefaultRequestRetryTimeout = 2 * time.Minute
+	// DefaultImportTimeout is a default timeout used for a single attempt of
+	// the descriptors import request. The import triggers a rescan of the
+	// chain so it takes much longer than other requests.
+	DefaultImportTimeout = 1 * time.Hour
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// URL to the Bitcoin Core node JSON-RPC endpoint in format:
+	// `scheme://hostname:port`.
+	URL string
+	// Username used to authenticate JSON-RPC requests.
+	Username string
+	// Password used to authenticate JSON-RPC requests.
+	Password string
+	// Wallet is the name of a watch-only descriptor wallet loaded on the
+	// node. The wallet is used to track transactions of public key hashes
+	// and spent outputs requested by the client. If empty, address-based
+	// queries and queries for spending transactions are not supported.
+	Wallet string
+	// ImportTimestamp is the Unix timestamp from which the node rescans the
+	// chain when the client starts tracking a new public key hash. Zero
+	// means the rescan starts from the genesis block.
+	ImportTimestamp int64
+	// Timeout for a single attempt of JSON-RPC request.
+	RequestTimeout time.Duration
+	// Timeout for JSON-RPC request retries.
+	RequestRetryTimeout time.Duration
+	// Timeout for a single attempt of the descriptors import request.
+	ImportTimeout time.Duration
+}
@@ -0,0 +1,143 @@
+package bitcoind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// rpcError represents an error returned by the Bitcoin Core JSON-RPC server.
+// Such an error is a deterministic response of the server so there is no
+// point to retry the request that caused it.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (re *rpcError) Error() string {
+	return fmt.Sprintf("RPC error [%d]: [%s]", re.Code, re.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+	ID     uint64          `json:"id"`
+}
+
+// rpcClient is a minimal client of the Bitcoin Core JSON-RPC interface.
+type rpcClient struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+	nextID     uint64
+}
+
+func newRPCClient(url, username, password string) *rpcClient {
+	return &rpcClient{
+		url:        strings.TrimSuffix(url, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{},
+	}
+}
+
+// call executes the given JSON-RPC method with the given parameters and
+// decodes the result into the provided result value. If the wallet name is
+// not empty, the request is routed to the wallet-specific endpoint.
+func (rc *rpcClient) call(
+	ctx context.Context,
+	wallet string,
+	method string,
+	params []interface{},
+	result interface{},
+) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	requestBody, err := json.Marshal(
+		&rpcRequest{
+			JSONRPC: "1.0",
+			ID:      atomic.AddUint64(&rc.nextID, 1),
+			Method:  method,
+			Params:  params,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot marshal request: [%w]", err)
+	}
+
+	endpoint := rc.url
+	if len(wallet) > 0 {
+		endpoint = fmt.Sprintf("%s/wallet/%s", rc.url, url.PathEscape(wallet))
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		endpoint,
+		bytes.NewReader(requestBody),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create request: [%w]", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	if len(rc.username) > 0 || len(rc.password) > 0 {
+		request.SetBasicAuth(rc.username, rc.password)
+	}
+
+	response, err := rc.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("cannot execute request: [%w]", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read response: [%w]", err)
+	}
+
+	// Bitcoin Core returns errors along with non-2xx HTTP status codes
+	// so the body must be decoded before the status is checked.
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(responseBody, &rpcResp); err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal response with HTTP status [%s]: [%w]",
+			response.Status,
+			err,
+		)
+	}
+
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status [%s]", response.Status)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("cannot unmarshal result: [%w]", err)
+	}
+
+	return nil
+}
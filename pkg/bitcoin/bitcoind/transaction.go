@@ -0,0 +1,90 @@
+package bitcoind
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// convertRawTransaction transforms a transaction provided in the hexadecimal
+// serialized string to the format expected by the bitcoin.Chain interface.
+func convertRawTransaction(rawTx string) (*bitcoin.Transaction, error) {
+	txBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	transaction := new(bitcoin.Transaction)
+	if err := transaction.Deserialize(txBytes); err != nil {
+		return nil, fmt.Errorf("failed to deserialize a transaction: [%w]", err)
+	}
+
+	return transaction, nil
+}
+
+// computeMerkleProof computes the Merkle proof of the transaction placed at
+// the given position within the block whose transactions IDs are provided.
+// The transactions IDs as well as the returned Merkle nodes are hexadecimal
+// strings in the reversed byte order, consistently with the format used
+// by Electrum servers.
+func computeMerkleProof(txIDs []string, position int) ([]string, error) {
+	if position < 0 || position >= len(txIDs) {
+		return nil, fmt.Errorf(
+			"position [%v] is out of range of block transactions",
+			position,
+		)
+	}
+
+	level := make([]bitcoin.Hash, len(txIDs))
+	for i, txID := range txIDs {
+		hash, err := bitcoin.NewHashFromString(txID, bitcoin.ReversedByteOrder)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse hash [%s]: [%v]", txID, err)
+		}
+
+		level[i] = hash
+	}
+
+	merkleNodes := make([]string, 0)
+
+	for len(level) > 1 {
+		// The last hash of a level with an odd number of hashes is paired
+		// with itself.
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+
+		merkleNodes = append(
+			merkleNodes,
+			level[position^1].Hex(bitcoin.ReversedByteOrder),
+		)
+
+		nextLevel := make([]bitcoin.Hash, len(level)/2)
+		for i := range nextLevel {
+			left, right := level[2*i], level[2*i+1]
+			nextLevel[i] = bitcoin.ComputeHash(append(left[:], right[:]...))
+		}
+
+		level = nextLevel
+		position /= 2
+	}
+
+	return merkleNodes, nil
+}
+
+// convertBtcToSatoshi converts the given amount in BTC, as returned by the
+// Bitcoin Core JSON-RPC interface, to satoshi.
+func convertBtcToSatoshi(btc float64) int64 {
+	return int64(math.Round(btc * 1e8))
+}
+
+func convertBtcKbToSatVByte(btcPerKbFee float64) int64 {
+	// To convert from BTC/KB to sat/vbyte, we need to multiply by 1e8/1e3.
+	satPerVByte := (1e8 / 1e3) * btcPerKbFee
+	// Make sure the minimum returned sat/vbyte fee is always 1.
+	satPerVByte = math.Max(satPerVByte, 1)
+	// Round the returned fee to be an integer.
+	return int64(math.Round(satPerVByte))
+}
@@ -101,11 +101,8 @@ func (bh *BlockHeader) Deserialize(rawBlockHeader [BlockHeaderByteLength]byte) {
 // block header serialization format:
 // [Version][PreviousBlockHeaderHash][MerkleRootHash][Time][Bits][Nonce].
 func (bh *BlockHeader) Hash() Hash {
-	// TODO: Implementation of the Hash function that consists of the following:
-	//       1. Call bh.Serialize() to get the serialized block hash.
-	//       2. Compute the double SHA-256 over the serialized  block hash.
-	//       3. Construct the Hash instance appropriately.
-	return Hash{}
+	serializedHeader := bh.Serialize()
+	return ComputeHash(serializedHeader[:])
 }
 
 // Target calculates the difficulty target of a block header. A Bitcoin block
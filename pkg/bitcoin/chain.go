@@ -88,6 +88,14 @@ type Chain interface {
 		publicKeyHash [20]byte,
 	) ([]*UnspentTransactionOutput, error)
 
+	// GetSpendingTransaction gets the confirmed transaction spending the given
+	// outpoint. The returned bool value is false if the outpoint has not been
+	// spent by any confirmed transaction. Spending transactions living in the
+	// mempool at the moment of request are not taken into account.
+	GetSpendingTransaction(
+		outpoint *TransactionOutpoint,
+	) (*Transaction, bool, error)
+
 	// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
 	// transaction to be confirmed within the given number of blocks.
 	EstimateSatPerVByteFee(blocks uint32) (int64, error)
@@ -144,6 +144,12 @@ func (lc *localChain) GetMempoolUtxosForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lc *localChain) GetSpendingTransaction(
+	outpoint *TransactionOutpoint,
+) (*Transaction, bool, error) {
+	panic("unsupported")
+}
+
 func (lc *localChain) EstimateSatPerVByteFee(
 	blocks uint32,
 ) (int64, error) {
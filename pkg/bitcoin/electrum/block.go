@@ -3,6 +3,7 @@ package electrum
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/btcsuite/btcd/v2/wire"
 	"github.com/checksum0/go-electrum/electrum"
@@ -36,3 +37,35 @@ func convertBlockHeader(electrumResult *electrum.GetBlockHeaderResult) (*bitcoin
 
 	return result, nil
 }
+
+// convertBlockHeaders transforms a chunk of concatenated block headers
+// returned from Electrum protocol to the format expected by the bitcoin.Chain
+// interface.
+func convertBlockHeaders(
+	electrumResult *electrum.GetBlockHeadersResult,
+) ([]*bitcoin.BlockHeader, error) {
+	headersBytes, err := hex.DecodeString(electrumResult.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedLength := int(electrumResult.Count) * bitcoin.BlockHeaderByteLength
+	if len(headersBytes) != expectedLength {
+		return nil, fmt.Errorf(
+			"headers length [%v] does not match headers count [%v]",
+			len(headersBytes),
+			electrumResult.Count,
+		)
+	}
+
+	result := make([]*bitcoin.BlockHeader, electrumResult.Count)
+	for i := range result {
+		var rawBlockHeader [bitcoin.BlockHeaderByteLength]byte
+		copy(rawBlockHeader[:], headersBytes[i*bitcoin.BlockHeaderByteLength:])
+
+		result[i] = &bitcoin.BlockHeader{}
+		result[i].Deserialize(rawBlockHeader)
+	}
+
+	return result, nil
+}
@@ -1,6 +1,10 @@
 package electrum
 
-import "time"
+import (
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin/headerchain"
+)
 
 const (
 	// DefaultConnectTimeout is a default timeout used for a single attempt of
@@ -36,4 +40,7 @@ type Config struct {
 	// An Electrum server may disconnect clients that have not sent any requests
 	// for roughly 10 minutes.
 	KeepAliveInterval time.Duration
+	// Configuration of the local header chain used to verify block headers
+	// and Merkle proofs returned by the Electrum server.
+	HeaderChain headerchain.Config
 }
@@ -16,8 +16,10 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-common/pkg/wrappers"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/bitcoin/headerchain"
 	"github.com/keep-network/keep-core/pkg/internal/byteutils"
 )
 
@@ -32,10 +34,60 @@ type Connection struct {
 	client      *electrum.Client
 	clientMutex *sync.Mutex
 	config      Config
+	// headerChain is the local chain of validated block headers used to
+	// verify the block headers and Merkle proofs returned by the server.
+	// It is nil if the verification is disabled.
+	headerChain *headerchain.HeaderChain
 }
 
 // Connect initializes handle with provided Config.
 func Connect(parentCtx context.Context, config Config) (bitcoin.Chain, error) {
+	return connect(parentCtx, config)
+}
+
+// ConnectWithHeaderChain initializes handle with provided Config. If the
+// header chain verification is enabled in the Config, the handle keeps a
+// local chain of block headers validated against the proof-of-work and
+// difficulty rules of the given network. Block headers, Merkle proofs, and
+// the latest block height are then served from that local chain instead of
+// being trusted to the Electrum server. The local chain is stored using
+// the given persistence handle, if it is not nil.
+func ConnectWithHeaderChain(
+	parentCtx context.Context,
+	config Config,
+	network bitcoin.Network,
+	handle persistence.BasicHandle,
+) (bitcoin.Chain, error) {
+	c, err := connect(parentCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.HeaderChain.Enabled() {
+		return c, nil
+	}
+
+	headerChain, err := headerchain.New(network, config.HeaderChain, handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize header chain: [%w]", err)
+	}
+
+	c.headerChain = headerChain
+
+	if err := c.syncHeaderChain(); err != nil {
+		return nil, err
+	}
+
+	logger.Infof(
+		"verifying electrum server responses against local header chain "+
+			"with tip at block [%v]",
+		headerChain.TipHeight(),
+	)
+
+	return c, nil
+}
+
+func connect(parentCtx context.Context, config Config) (*Connection, error) {
 	if config.ConnectTimeout == 0 {
 		config.ConnectTimeout = DefaultConnectTimeout
 	}
@@ -260,6 +312,20 @@ func (c *Connection) BroadcastTransaction(
 // GetLatestBlockHeight gets the height of the latest block (tip). If the
 // latest block was not determined, this function returns an error.
 func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	if c.headerChain != nil {
+		if err := c.syncHeaderChain(); err != nil {
+			return 0, err
+		}
+
+		return c.headerChain.TipHeight(), nil
+	}
+
+	return c.getServerLatestBlockHeight()
+}
+
+// getServerLatestBlockHeight gets the height of the latest block (tip) as
+// reported by the Electrum server.
+func (c *Connection) getServerLatestBlockHeight() (uint, error) {
 	blockHeight, err := requestWithRetry(
 		c,
 		func(ctx context.Context, client *electrum.Client) (int32, error) {
@@ -289,6 +355,16 @@ func (c *Connection) GetLatestBlockHeight() (uint, error) {
 func (c *Connection) GetBlockHeader(
 	blockHeight uint,
 ) (*bitcoin.BlockHeader, error) {
+	if c.headerChain != nil {
+		if blockHeight > c.headerChain.TipHeight() {
+			if err := c.syncHeaderChain(); err != nil {
+				return nil, err
+			}
+		}
+
+		return c.headerChain.Header(blockHeight)
+	}
+
 	getBlockHeaderResult, err := requestWithRetry(
 		c,
 		func(
@@ -338,7 +414,27 @@ func (c *Connection) GetTransactionMerkleProof(
 		return nil, fmt.Errorf("failed to get merkle proof: [%w]", err)
 	}
 
-	return convertMerkleProof(getMerkleProofResult), nil
+	merkleProof := convertMerkleProof(getMerkleProofResult)
+
+	if c.headerChain != nil {
+		if merkleProof.BlockHeight > c.headerChain.TipHeight() {
+			if err := c.syncHeaderChain(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := c.headerChain.VerifyMerkleProof(
+			transactionHash,
+			merkleProof,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"failed to verify merkle proof against local header chain: [%w]",
+				err,
+			)
+		}
+	}
+
+	return merkleProof, nil
 }
 
 // GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
@@ -906,6 +1002,61 @@ func (c *Connection) getScriptUtxos(
 	return filteredItems, nil
 }
 
+// GetSpendingTransaction gets the confirmed transaction spending the given
+// outpoint. The returned bool value is false if the outpoint has not been
+// spent by any confirmed transaction. Spending transactions living in the
+// mempool at the moment of request are not taken into account.
+func (c *Connection) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	transaction, err := c.GetTransaction(outpoint.TransactionHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get outpoint transaction: [%v]",
+			err,
+		)
+	}
+
+	if int(outpoint.OutputIndex) >= len(transaction.Outputs) {
+		return nil, false, fmt.Errorf(
+			"outpoint transaction has no output with index [%v]",
+			outpoint.OutputIndex,
+		)
+	}
+
+	// The history of the output script contains the transaction spending
+	// the outpoint, if any, as the Electrum protocol tracks both the funding
+	// and spending transactions of the script.
+	items, err := c.getConfirmedScriptHistory(
+		transaction.Outputs[outpoint.OutputIndex].PublicKeyScript,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get outpoint script history: [%v]",
+			err,
+		)
+	}
+
+	for _, item := range items {
+		if item.txHash == outpoint.TransactionHash {
+			continue
+		}
+
+		candidate, err := c.GetTransaction(item.txHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		for _, input := range candidate.Inputs {
+			if *input.Outpoint == *outpoint {
+				return candidate, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
 // EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
 // transaction to be confirmed within the given number of blocks.
 func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
@@ -1,9 +1,13 @@
 package electrum
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/checksum0/go-electrum/electrum"
+
 	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
 )
 
 func TestConvertBtcKbToSatVByte(t *testing.T) {
@@ -62,3 +66,47 @@ func TestConvertBtcKbToSatVByte(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertBlockHeaders(t *testing.T) {
+	// The first header comes from the Bitcoin testnet block:
+	// https://live.blockcypher.com/btc-testnet/block/000000000000002af10911b8db32ed34dc6ea6515f84af5f7b82973c9a839e6d/
+	// The second header is an arbitrary one.
+	rawHeaders := "04000020a5a3501e6ba1f3e2a1ee5d29327a549524ed33f272dfef3000456600" +
+		"00000000e27d241ca36de831ab17e6729056c14a383e7a3f43d56254f846b496" +
+		"49775112939edd612ac0001abbaa602e" +
+		"0400002021ca9e7daaa33d5c6a1d2d5bea1e5e5a7f0e8d8bb34a5ab3d4102b00" +
+		"00000000b0bb0c6c0d2e3e5e21a4fd4c8d28b6b4ae1ea8e1b41a5c6d3b4f2e1a" +
+		"0c6a3a4f939fdd612ac0001a00000000"
+
+	blockHeaders, err := convertBlockHeaders(&electrum.GetBlockHeadersResult{
+		Count:   2,
+		Headers: rawHeaders,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "headers count", 2, len(blockHeaders))
+
+	testutils.AssertStringsEqual(
+		t,
+		"first header hash",
+		"000000000000002af10911b8db32ed34dc6ea6515f84af5f7b82973c9a839e6d",
+		blockHeaders[0].Hash().Hex(bitcoin.ReversedByteOrder),
+	)
+
+	testutils.AssertUintsEqual(
+		t,
+		"second header time",
+		1641914259,
+		uint64(blockHeaders[1].Time),
+	)
+
+	_, err = convertBlockHeaders(&electrum.GetBlockHeadersResult{
+		Count:   3,
+		Headers: rawHeaders,
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not match headers count") {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
@@ -0,0 +1,63 @@
+package electrum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/checksum0/go-electrum/electrum"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// headerSource is a source of block headers for the local header chain,
+// backed by the Electrum server.
+type headerSource struct {
+	connection *Connection
+}
+
+func (hs *headerSource) GetLatestBlockHeight() (uint, error) {
+	return hs.connection.getServerLatestBlockHeight()
+}
+
+func (hs *headerSource) GetBlockHeaders(
+	startHeight uint,
+	count uint,
+) ([]*bitcoin.BlockHeader, error) {
+	getBlockHeadersResult, err := requestWithRetry(
+		hs.connection,
+		func(
+			ctx context.Context,
+			client *electrum.Client,
+		) (*electrum.GetBlockHeadersResult, error) {
+			return client.GetBlockHeaders(
+				ctx,
+				uint32(startHeight),
+				uint32(count),
+			)
+		},
+		"GetBlockHeaders",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block headers: [%w]", err)
+	}
+
+	blockHeaders, err := convertBlockHeaders(getBlockHeadersResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block headers: [%w]", err)
+	}
+
+	return blockHeaders, nil
+}
+
+// syncHeaderChain extends the local header chain with the headers known to
+// the Electrum server.
+func (c *Connection) syncHeaderChain() error {
+	if err := c.headerChain.Sync(&headerSource{c}); err != nil {
+		return fmt.Errorf(
+			"failed to sync local header chain with electrum server: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package esplora
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// convertRawBlockHeader transforms a block header provided in the hexadecimal
+// serialized string to the format expected by the bitcoin.Chain interface.
+func convertRawBlockHeader(rawBlockHeader string) (*bitcoin.BlockHeader, error) {
+	headerBytes, err := hex.DecodeString(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	if len(headerBytes) != bitcoin.BlockHeaderByteLength {
+		return nil, fmt.Errorf(
+			"wrong block header length; expected [%v], actual [%v]",
+			bitcoin.BlockHeaderByteLength,
+			len(headerBytes),
+		)
+	}
+
+	var serializedHeader [bitcoin.BlockHeaderByteLength]byte
+	copy(serializedHeader[:], headerBytes)
+
+	blockHeader := new(bitcoin.BlockHeader)
+	blockHeader.Deserialize(serializedHeader)
+
+	return blockHeader, nil
+}
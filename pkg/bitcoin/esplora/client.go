@@ -0,0 +1,91 @@
+package esplora
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// httpError represents an error response returned by the Esplora API for
+// a request that cannot be fulfilled, e.g. because the requested object does
+// not exist or the request is malformed. Such an error is a deterministic
+// response of the server so there is no point to retry the request that
+// caused it.
+type httpError struct {
+	StatusCode int
+	Message    string
+}
+
+func (he *httpError) Error() string {
+	return fmt.Sprintf("HTTP error [%d]: [%s]", he.StatusCode, he.Message)
+}
+
+// restClient is a minimal client of the Esplora HTTP API.
+type restClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newRESTClient(url string) *restClient {
+	return &restClient{
+		url:        strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{},
+	}
+}
+
+// call executes a request with the given HTTP method against the given
+// API path and returns the response body. The request body is sent only
+// if it is not empty. Client error responses are returned as httpError.
+func (rc *restClient) call(
+	ctx context.Context,
+	method string,
+	path string,
+	body string,
+) ([]byte, error) {
+	var requestBody io.Reader
+	if len(body) > 0 {
+		requestBody = strings.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		method,
+		rc.url+path,
+		requestBody,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request: [%w]", err)
+	}
+
+	if len(body) > 0 {
+		request.Header.Set("Content-Type", "text/plain")
+	}
+
+	response, err := rc.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute request: [%w]", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response: [%w]", err)
+	}
+
+	// Server errors (5xx) may be transient so they are not reported as
+	// httpError in order to be retried.
+	if response.StatusCode >= 400 && response.StatusCode < 500 {
+		return nil, &httpError{
+			StatusCode: response.StatusCode,
+			Message:    strings.TrimSpace(string(responseBody)),
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status [%s]", response.Status)
+	}
+
+	return responseBody, nil
+}
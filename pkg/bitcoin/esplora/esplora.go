@@ -0,0 +1,835 @@
+package esplora
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/wrappers"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+var logger = log.Logger("keep-esplora")
+
+// Connection is a handle for interactions with an Esplora API, e.g.
+// a Blockstream Esplora or a mempool.space instance.
+//
+// In contrast to Electrum, the Esplora API is served over plain HTTP
+// requests so the connection does not maintain any session with the server.
+type Connection struct {
+	parentCtx context.Context
+	client    *restClient
+	config    Config
+}
+
+// Connect initializes handle with provided Config.
+func Connect(parentCtx context.Context, config Config) (bitcoin.Chain, error) {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.RequestRetryTimeout == 0 {
+		config.RequestRetryTimeout = DefaultRequestRetryTimeout
+	}
+
+	c := &Connection{
+		parentCtx: parentCtx,
+		client:    newRESTClient(config.URL),
+		config:    config,
+	}
+
+	if err := c.verifyServer(); err != nil {
+		return nil, fmt.Errorf("failed to verify esplora server: [%w]", err)
+	}
+
+	return c, nil
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error.
+func (c *Connection) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	rawTransaction, err := requestText(c, fmt.Sprintf("/tx/%s/hex", txID))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get raw transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	result, err := convertRawTransaction(rawTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transaction: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+func (c *Connection) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	status, err := requestJSON[transactionStatus](
+		c,
+		fmt.Sprintf("/tx/%s/status", txID),
+	)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get status of transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	// Transactions living in the mempool have no confirmations.
+	if !status.Confirmed {
+		return 0, nil
+	}
+
+	latestBlockHeight, err := c.GetLatestBlockHeight()
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get the latest block height: [%w]",
+			err,
+		)
+	}
+
+	if latestBlockHeight >= status.BlockHeight {
+		// Add `1` to the calculated difference as if the transaction block
+		// height equals the latest block height the transaction is already
+		// confirmed, so it has one confirmation.
+		return latestBlockHeight - status.BlockHeight + 1, nil
+	}
+
+	return 0, nil
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes. If the broadcast action could not be
+// done, this function returns an error. This function does not give any
+// guarantees regarding transaction mining. The transaction may be mined or
+// rejected eventually.
+func (c *Connection) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	rawTx := hex.EncodeToString(transaction.Serialize())
+
+	response, err := request(c, http.MethodPost, "/tx", rawTx)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the transaction: [%w]", err)
+	}
+
+	logger.Infof(
+		"transaction broadcast successful: [%s]",
+		strings.TrimSpace(string(response)),
+	)
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error.
+func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	blockHeight, err := requestJSON[uint](c, "/blocks/tip/height")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the blocks tip height: [%w]", err)
+	}
+
+	return blockHeight, nil
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error.
+func (c *Connection) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	rawBlockHeader, err := requestText(
+		c,
+		fmt.Sprintf("/block/%s/header", blockHash),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: [%w]", err)
+	}
+
+	blockHeader, err := convertRawBlockHeader(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block header: [%w]", err)
+	}
+
+	return blockHeader, nil
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided.
+func (c *Connection) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	type merkleProof struct {
+		BlockHeight uint     `json:"block_height"`
+		Merkle      []string `json:"merkle"`
+		Position    uint     `json:"pos"`
+	}
+
+	// The Esplora API returns the Merkle proof in the same format as
+	// the Electrum protocol does. The proof is always computed against
+	// the block the transaction is currently confirmed in.
+	proof, err := requestJSON[merkleProof](
+		c,
+		fmt.Sprintf("/tx/%s/merkle-proof", txID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle proof: [%w]", err)
+	}
+
+	if proof.BlockHeight != blockHeight {
+		return nil, fmt.Errorf(
+			"transaction [%s] is confirmed in block [%v] instead of "+
+				"block [%v]",
+			txID,
+			proof.BlockHeight,
+			blockHeight,
+		)
+	}
+
+	return &bitcoin.TransactionMerkleProof{
+		BlockHeight: proof.BlockHeight,
+		MerkleNodes: proof.Merkle,
+		Position:    proof.Position,
+	}, nil
+}
+
+// GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. Note that taking an unlimited
+// transaction history may be time-consuming as this function fetches
+// complete transactions with all necessary data.
+func (c *Connection) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	txHashes, err := c.GetTxHashesForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectedTxHashes []bitcoin.Hash
+	if len(txHashes) > limit {
+		selectedTxHashes = txHashes[len(txHashes)-limit:]
+	} else {
+		selectedTxHashes = txHashes
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(selectedTxHashes))
+	for i, txHash := range selectedTxHashes {
+		transaction, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request.
+func (c *Connection) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	p2pkh, p2wpkh, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pkhItems, err := c.getConfirmedScriptHistory(p2pkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2PKH history for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkhItems, err := c.getConfirmedScriptHistory(p2wpkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2WPKH history for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	items := append(p2pkhItems, p2wpkhItems...)
+
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].Status.BlockHeight < items[j].Status.BlockHeight
+		},
+	)
+
+	txHashes := make([]bitcoin.Hash, len(items))
+	for i, item := range items {
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		txHashes[i] = txHash
+	}
+
+	return txHashes, nil
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order.
+func (c *Connection) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	p2pkh, p2wpkh, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pkhItems, err := c.getScriptMempool(p2pkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2PKH mempool items for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkhItems, err := c.getScriptMempool(p2wpkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2WPKH mempool items for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	items := append(p2pkhItems, p2wpkhItems...)
+
+	transactions := make([]*bitcoin.Transaction, len(items))
+	for i, item := range items {
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		transaction, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+func (c *Connection) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getUtxos(publicKeyHash, true)
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs.
+func (c *Connection) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getUtxos(publicKeyHash, false)
+}
+
+// GetSpendingTransaction gets the confirmed transaction spending the given
+// outpoint. The returned bool value is false if the outpoint has not been
+// spent by any confirmed transaction. Spending transactions living in the
+// mempool at the moment of request are not taken into account.
+func (c *Connection) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	txID := outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	type outspend struct {
+		Spent  bool              `json:"spent"`
+		TxID   string            `json:"txid"`
+		Status transactionStatus `json:"status"`
+	}
+
+	spend, err := requestJSON[outspend](
+		c,
+		fmt.Sprintf("/tx/%s/outspend/%d", txID, outpoint.OutputIndex),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get spend of output [%d] of transaction with ID [%s]: [%w]",
+			outpoint.OutputIndex,
+			txID,
+			err,
+		)
+	}
+
+	if !spend.Spent || !spend.Status.Confirmed {
+		return nil, false, nil
+	}
+
+	spendingTxHash, err := bitcoin.NewHashFromString(
+		spend.TxID,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot parse hash [%s]: [%v]",
+			spend.TxID,
+			err,
+		)
+	}
+
+	transaction, err := c.GetTransaction(spendingTxHash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return transaction, true, nil
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	// According to Esplora API docs, the estimates are expressed in sat/vB
+	// and keyed by the confirmation target.
+	estimates, err := requestJSON[map[string]float64](c, "/fee-estimates")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee estimates: [%v]", err)
+	}
+
+	return selectFeeEstimate(estimates, blocks)
+}
+
+// GetCoinbaseTxHash gets the hash of the coinbase transaction for the given
+// block height.
+func (c *Connection) GetCoinbaseTxHash(blockHeight uint) (bitcoin.Hash, error) {
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return bitcoin.Hash{}, err
+	}
+
+	txHashString, err := requestText(
+		c,
+		fmt.Sprintf("/block/%s/txid/0", blockHash),
+	)
+	if err != nil {
+		return bitcoin.Hash{}, fmt.Errorf(
+			"failed to get coinbase tx hash for block height [%v]: [%v]",
+			blockHeight,
+			err,
+		)
+	}
+
+	txHash, err := bitcoin.NewHashFromString(
+		txHashString,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return bitcoin.Hash{}, fmt.Errorf(
+			"cannot parse hash [%s]: [%v]",
+			txHashString,
+			err,
+		)
+	}
+
+	return txHash, nil
+}
+
+// transactionStatus represents the confirmation status of a transaction
+// as returned by the Esplora API.
+type transactionStatus struct {
+	Confirmed   bool `json:"confirmed"`
+	BlockHeight uint `json:"block_height"`
+}
+
+// scriptHistoryItem represents a transaction entry of a script history
+// returned by the Esplora API. Only fields used by the client are decoded.
+type scriptHistoryItem struct {
+	TxID   string            `json:"txid"`
+	Status transactionStatus `json:"status"`
+}
+
+// getBlockHash gets the hash of the block with the given height, as
+// a hexadecimal string in the reversed byte order.
+func (c *Connection) getBlockHash(blockHeight uint) (string, error) {
+	blockHash, err := requestText(
+		c,
+		fmt.Sprintf("/block-height/%d", blockHeight),
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to get hash of block [%v]: [%w]",
+			blockHeight,
+			err,
+		)
+	}
+
+	return blockHash, nil
+}
+
+// getConfirmedScriptHistory returns a history of confirmed transactions for
+// the given script (P2PKH, P2WPKH, P2SH, P2WSH, etc.). The returned list
+// is sorted by the block height in the ascending order, i.e. the latest
+// transaction is at the end of the list. The resulting list does not contain
+// unconfirmed transactions living in the mempool at the moment of request.
+func (c *Connection) getConfirmedScriptHistory(
+	script []byte,
+) ([]*scriptHistoryItem, error) {
+	scriptHash := computeScriptHash(script)
+
+	items := make([]*scriptHistoryItem, 0)
+
+	// The Esplora API returns the confirmed history in pages, from the
+	// newest transaction to the oldest one. The next page is requested using
+	// the ID of the last transaction seen so far. An empty page means the
+	// whole history has been fetched.
+	lastSeenTxID := ""
+	for {
+		path := fmt.Sprintf("/scripthash/%s/txs/chain", scriptHash)
+		if len(lastSeenTxID) > 0 {
+			path = fmt.Sprintf("%s/%s", path, lastSeenTxID)
+		}
+
+		page, err := requestJSON[[]*scriptHistoryItem](c, path)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get history for script [0x%x]: [%v]",
+				script,
+				err,
+			)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		for _, item := range page {
+			if item.Status.Confirmed {
+				items = append(items, item)
+			}
+		}
+
+		lastSeenTxID = page[len(page)-1].TxID
+	}
+
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].Status.BlockHeight < items[j].Status.BlockHeight
+		},
+	)
+
+	return items, nil
+}
+
+// getScriptMempool returns unconfirmed mempool transactions for
+// the given script (P2PKH, P2WPKH, P2SH, P2WSH, etc.). The returned list
+// is in an indefinite order.
+func (c *Connection) getScriptMempool(
+	script []byte,
+) ([]*scriptHistoryItem, error) {
+	items, err := requestJSON[[]*scriptHistoryItem](
+		c,
+		fmt.Sprintf("/scripthash/%s/txs/mempool", computeScriptHash(script)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get mempool for script [0x%x]: [%v]",
+			script,
+			err,
+		)
+	}
+
+	return items, nil
+}
+
+// getUtxos returns unspent outputs controlled by the given public key hash.
+//
+// If the `confirmed` flag is true, the returned list contains unspent outputs
+// of confirmed transactions, sorted by the block height in the ascending order,
+// i.e. the latest UTXO is at the end of the list.
+//
+// If the `confirmed` flag is false, the returned list contains unspent outputs
+// of unconfirmed transactions, in an indefinite order.
+//
+// In both cases, the resulted list DOES NOT CONTAIN outputs already used as
+// inputs of confirmed or mempool transactions because they are no longer UTXOs.
+func (c *Connection) getUtxos(
+	publicKeyHash [20]byte,
+	confirmed bool,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	p2pkh, p2wpkh, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	type unspentItem struct {
+		TxID   string            `json:"txid"`
+		Vout   uint32            `json:"vout"`
+		Value  int64             `json:"value"`
+		Status transactionStatus `json:"status"`
+	}
+
+	items := make([]*unspentItem, 0)
+	for _, script := range [][]byte{p2pkh, p2wpkh} {
+		scriptItems, err := requestJSON[[]*unspentItem](
+			c,
+			fmt.Sprintf("/scripthash/%s/utxo", computeScriptHash(script)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get UTXOs for script [0x%x]: [%v]",
+				script,
+				err,
+			)
+		}
+
+		for _, item := range scriptItems {
+			if item.Status.Confirmed == confirmed {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if confirmed {
+		// Sorting makes sense only for confirmed items as unconfirmed ones
+		// have no block height.
+		sort.SliceStable(
+			items,
+			func(i, j int) bool {
+				return items[i].Status.BlockHeight < items[j].Status.BlockHeight
+			},
+		)
+	}
+
+	utxos := make([]*bitcoin.UnspentTransactionOutput, len(items))
+	for i, item := range items {
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		utxos[i] = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: txHash,
+				OutputIndex:     item.Vout,
+			},
+			Value: item.Value,
+		}
+	}
+
+	return utxos, nil
+}
+
+// verifyServer checks whether the Esplora API is reachable.
+func (c *Connection) verifyServer() error {
+	blockHeight, err := c.GetLatestBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(
+		"connected to esplora server; blocks: [%v]",
+		blockHeight,
+	)
+
+	return nil
+}
+
+// request executes the given HTTP request against the Esplora API, retrying
+// it until the configured retry timeout is hit. Client errors returned by
+// the API are not retried as they are deterministic responses to the request.
+func request(
+	c *Connection,
+	method string,
+	path string,
+	body string,
+) ([]byte, error) {
+	startTime := time.Now()
+	logger.Debugf("starting [%s %s] request to esplora server", method, path)
+
+	var result []byte
+	var clientErr *httpError
+
+	err := wrappers.DoWithDefaultRetry(
+		c.parentCtx,
+		c.config.RequestRetryTimeout,
+		func(ctx context.Context) error {
+			requestCtx, requestCancel := context.WithTimeout(
+				ctx,
+				c.config.RequestTimeout,
+			)
+			defer requestCancel()
+
+			r, err := c.client.call(requestCtx, method, path, body)
+			if errors.As(err, &clientErr) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("request failed: [%w]", err)
+			}
+
+			result = r
+			return nil
+		},
+	)
+	if err == nil && clientErr != nil {
+		err = clientErr
+	}
+
+	solveRequestOutcome := func(err error) string {
+		if err != nil {
+			return fmt.Sprintf("error: [%v]", err)
+		}
+		return "success"
+	}
+
+	logger.Debugf("[%s %s] request to esplora server completed with [%s] after [%s]",
+		method,
+		path,
+		solveRequestOutcome(err),
+		time.Since(startTime),
+	)
+
+	return result, err
+}
+
+// requestText executes a GET request against the given API path and returns
+// the response body as a trimmed string.
+func requestText(c *Connection, path string) (string, error) {
+	response, err := request(c, http.MethodGet, path, "")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(response)), nil
+}
+
+// requestJSON executes a GET request against the given API path and decodes
+// the JSON response body.
+func requestJSON[K interface{}](c *Connection, path string) (K, error) {
+	var result K
+
+	response, err := request(c, http.MethodGet, path, "")
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(response, &result); err != nil {
+		return result, fmt.Errorf("cannot unmarshal response: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// computeScriptHash computes the script hash used by the Esplora API to
+// identify the given output script. Unlike the Electrum protocol, the Esplora
+// API expects the SHA256 hash of the script in the natural byte order.
+func computeScriptHash(script []byte) string {
+	scriptHash := sha256.Sum256(script)
+	return hex.EncodeToString(scriptHash[:])
+}
+
+// publicKeyHashScripts returns the P2PKH and P2WPKH output scripts of the
+// given public key hash.
+func publicKeyHashScripts(
+	publicKeyHash [20]byte,
+) ([]byte, []byte, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	return p2pkh, p2wpkh, nil
+}
@@ -0,0 +1,656 @@
+package esplora
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+
+	testData "github.com/keep-network/keep-core/internal/testdata/bitcoin"
+)
+
+var testPublicKeyHash = [20]byte{
+	0x8d, 0xb5, 0x0e, 0xb5, 0x20, 0x63, 0xea, 0x9d, 0x98, 0xb3,
+	0xea, 0xc9, 0x14, 0x89, 0xa9, 0x0f, 0x73, 0x89, 0x86, 0xf6,
+}
+
+func TestConnect_ServerError(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusServiceUnavailable)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+
+	_, err := Connect(
+		context.Background(),
+		Config{
+			URL:                 server.URL,
+			RequestRetryTimeout: 100 * time.Millisecond,
+		},
+	)
+
+	expectedError := "failed to verify esplora server"
+	if err == nil || !strings.Contains(err.Error(), expectedError) {
+		t.Errorf(
+			"unexpected error\nexpected: containing [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestGetTransaction(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	expectedTransaction :=
+		testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"]
+
+	transaction, err := chain.GetTransaction(expectedTransaction.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(&expectedTransaction.BitcoinTx, transaction) {
+		t.Errorf(
+			"unexpected transaction\nexpected: [%+v]\nactual:   [%+v]",
+			&expectedTransaction.BitcoinTx,
+			transaction,
+		)
+	}
+}
+
+func TestGetTransaction_NotFound(t *testing.T) {
+	chain, server := newTestConnection(t)
+
+	txHash := bitcoin.Hash{0x01}
+
+	_, err := chain.GetTransaction(txHash)
+
+	expectedError := "HTTP error [404]: [Transaction not found]"
+	if err == nil || !strings.Contains(err.Error(), expectedError) {
+		t.Errorf(
+			"unexpected error\nexpected: containing [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	// Client errors returned by the server must not be retried.
+	testutils.AssertIntsEqual(
+		t,
+		"transaction requests",
+		1,
+		server.calls(
+			fmt.Sprintf("/tx/%s/hex", txHash.Hex(bitcoin.ReversedByteOrder)),
+		),
+	)
+}
+
+func TestGetTransactionConfirmations(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	var tests = map[string]struct {
+		txHash                bitcoin.Hash
+		expectedConfirmations uint
+	}{
+		"confirmed transaction": {
+			txHash:                hashFromString("9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0"),
+			expectedConfirmations: 221,
+		},
+		"mempool transaction": {
+			txHash:                hashFromString("4459881f4964ee08dd298a12dfc1f461bf35cca8a105974d8baf0955c830d836"),
+			expectedConfirmations: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			confirmations, err := chain.GetTransactionConfirmations(test.txHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"confirmations",
+				uint64(test.expectedConfirmations),
+				uint64(confirmations),
+			)
+		})
+	}
+}
+
+func TestBroadcastTransaction(t *testing.T) {
+	chain, server := newTestConnection(t)
+
+	transaction :=
+		testData.Transactions[bitcoin.Testnet]["input: P2PKH, output: P2SH, P2WPKH"]
+
+	err := chain.BroadcastTransaction(&transaction.BitcoinTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "broadcast requests", 1, server.calls("/tx"))
+}
+
+func TestGetLatestBlockHeight(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	blockHeight, err := chain.GetLatestBlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "block height", 2138000, uint64(blockHeight))
+}
+
+func TestGetBlockHeader(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	blockHeader, err := chain.GetBlockHeader(100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlockHeader := &bitcoin.BlockHeader{
+		Version:                 1,
+		PreviousBlockHeaderHash: hashFromString("000000000002d01c1fccc21636b607dfd930d31d01c3a62104612a1719011250"),
+		MerkleRootHash:          hashFromString("f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766"),
+		Time:                    1293623863,
+		Bits:                    453281356,
+		Nonce:                   274148111,
+	}
+
+	if !reflect.DeepEqual(expectedBlockHeader, blockHeader) {
+		t.Errorf(
+			"unexpected block header\nexpected: [%+v]\nactual:   [%+v]",
+			expectedBlockHeader,
+			blockHeader,
+		)
+	}
+}
+
+func TestGetBlockHeader_NotFound(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	_, err := chain.GetBlockHeader(3000000)
+
+	expectedError := "HTTP error [404]: [Block not found]"
+	if err == nil || !strings.Contains(err.Error(), expectedError) {
+		t.Errorf(
+			"unexpected error\nexpected: containing [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestGetTransactionMerkleProof(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	merkleProof, err := chain.GetTransactionMerkleProof(
+		hashFromString("6359f0868171b1d194cbee1af2f16ea598ae8fad666d9b012c8ed2b79a236ec4"),
+		100000,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMerkleProof := &bitcoin.TransactionMerkleProof{
+		BlockHeight: 100000,
+		MerkleNodes: []string{
+			"e9a66845e05d5abc0ad04ec80f774a7e585c6e8db975962d069a522137b80c1d",
+			"ccdafb73d8dcd0173d5d5c3c9a0770d0b3953db889dab99ef05b1907518cb815",
+		},
+		Position: 2,
+	}
+
+	if !reflect.DeepEqual(expectedMerkleProof, merkleProof) {
+		t.Errorf(
+			"unexpected merkle proof\nexpected: [%+v]\nactual:   [%+v]",
+			expectedMerkleProof,
+			merkleProof,
+		)
+	}
+}
+
+func TestGetTransactionMerkleProof_OtherBlock(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	txHash := hashFromString("9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0")
+
+	_, err := chain.GetTransactionMerkleProof(txHash, 100000)
+
+	expectedError := fmt.Sprintf(
+		"transaction [%s] is confirmed in block [2137780] instead of "+
+			"block [100000]",
+		txHash.Hex(bitcoin.ReversedByteOrder),
+	)
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestGetCoinbaseTxHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	txHash, err := chain.GetCoinbaseTxHash(100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"coinbase tx hash",
+		"8c14f0db3df150123e6f3dbbf30f8b955a8249b62ac1d1ff16284aefa3d06d87",
+		txHash.Hex(bitcoin.ReversedByteOrder),
+	)
+}
+
+func TestEstimateSatPerVByteFee(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	fee, err := chain.EstimateSatPerVByteFee(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "fee", 12, int(fee))
+}
+
+func TestGetTxHashesForPublicKeyHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	txHashes, err := chain.GetTxHashesForPublicKeyHash(testPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTxHashes := []bitcoin.Hash{
+		hashFromString("f5b9ad4e8cd5317925319ebc64dc923092bef3b56429c6b1bc2261bbdc73f351"),
+		hashFromString("9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0"),
+	}
+
+	if !reflect.DeepEqual(expectedTxHashes, txHashes) {
+		t.Errorf(
+			"unexpected transaction hashes\nexpected: [%v]\nactual:   [%v]",
+			expectedTxHashes,
+			txHashes,
+		)
+	}
+}
+
+func TestGetTransactionsForPublicKeyHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	transactions, err := chain.GetTransactionsForPublicKeyHash(
+		testPublicKeyHash,
+		1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "transactions count", 1, len(transactions))
+	testutils.AssertStringsEqual(
+		t,
+		"transaction hash",
+		"9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0",
+		transactions[0].Hash().Hex(bitcoin.ReversedByteOrder),
+	)
+}
+
+func TestGetMempoolForPublicKeyHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	transactions, err := chain.GetMempoolForPublicKeyHash(testPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "transactions count", 1, len(transactions))
+	testutils.AssertStringsEqual(
+		t,
+		"transaction hash",
+		"4459881f4964ee08dd298a12dfc1f461bf35cca8a105974d8baf0955c830d836",
+		transactions[0].Hash().Hex(bitcoin.ReversedByteOrder),
+	)
+}
+
+func TestGetUtxosForPublicKeyHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	utxos, err := chain.GetUtxosForPublicKeyHash(testPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []*bitcoin.UnspentTransactionOutput{
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hashFromString("f5b9ad4e8cd5317925319ebc64dc923092bef3b56429c6b1bc2261bbdc73f351"),
+				OutputIndex:     0,
+			},
+			Value: 18500,
+		},
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hashFromString("9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0"),
+				OutputIndex:     0,
+			},
+			Value: 77744,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedUtxos, utxos) {
+		t.Errorf(
+			"unexpected UTXOs\nexpected: [%+v]\nactual:   [%+v]",
+			expectedUtxos,
+			utxos,
+		)
+	}
+}
+
+func TestGetMempoolUtxosForPublicKeyHash(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	utxos, err := chain.GetMempoolUtxosForPublicKeyHash(testPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []*bitcoin.UnspentTransactionOutput{
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hashFromString("4459881f4964ee08dd298a12dfc1f461bf35cca8a105974d8baf0955c830d836"),
+				OutputIndex:     0,
+			},
+			Value: 4145001,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedUtxos, utxos) {
+		t.Errorf(
+			"unexpected UTXOs\nexpected: [%+v]\nactual:   [%+v]",
+			expectedUtxos,
+			utxos,
+		)
+	}
+}
+
+func TestGetSpendingTransaction(t *testing.T) {
+	chain, _ := newTestConnection(t)
+
+	var tests = map[string]struct {
+		outpoint             *bitcoin.TransactionOutpoint
+		expectedFound        bool
+		expectedSpendingTxID string
+	}{
+		"spent outpoint": {
+			outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hashFromString("c580e0e352570d90e303d912a506055ceeb0ee06f97dce6988c69941374f5479"),
+				OutputIndex:     0,
+			},
+			expectedFound:        true,
+			expectedSpendingTxID: "f5b9ad4e8cd5317925319ebc64dc923092bef3b56429c6b1bc2261bbdc73f351",
+		},
+		"unspent outpoint": {
+			outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hashFromString("9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0"),
+				OutputIndex:     0,
+			},
+			expectedFound: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			transaction, found, err := chain.GetSpendingTransaction(
+				test.outpoint,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(t, "found", test.expectedFound, found)
+
+			if !test.expectedFound {
+				return
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"spending transaction hash",
+				test.expectedSpendingTxID,
+				transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			)
+		})
+	}
+}
+
+func TestSelectFeeEstimate(t *testing.T) {
+	estimates := map[string]float64{
+		"1":    21.04,
+		"3":    15.1,
+		"6":    12.2,
+		"1008": 0.9,
+	}
+
+	var tests = map[string]struct {
+		estimates     map[string]float64
+		blocks        uint32
+		expectedFee   int64
+		expectedError string
+	}{
+		"exact target": {
+			estimates:   estimates,
+			blocks:      3,
+			expectedFee: 15,
+		},
+		"target between available targets": {
+			estimates:   estimates,
+			blocks:      5,
+			expectedFee: 15,
+		},
+		"target below lowest available target": {
+			estimates:   estimates,
+			blocks:      0,
+			expectedFee: 21,
+		},
+		"fee below minimum": {
+			estimates:   estimates,
+			blocks:      2000,
+			expectedFee: 1,
+		},
+		"no estimates": {
+			estimates: map[string]float64{},
+			blocks:    6,
+			expectedError: "server does not have enough information to " +
+				"make an estimate",
+		},
+		"malformed target": {
+			estimates:     map[string]float64{"one": 1},
+			blocks:        6,
+			expectedError: "cannot parse confirmation target [one]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fee, err := selectFeeEstimate(test.estimates, test.blocks)
+
+			if test.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+					t.Errorf(
+						"unexpected error\nexpected: containing [%v]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"fee",
+				int(test.expectedFee),
+				int(fee),
+			)
+		})
+	}
+}
+
+// recordedResponse is a response of the Esplora API recorded for the given
+// HTTP method, path and request body. The response body is either a plain
+// text or a JSON value.
+type recordedResponse struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   string          `json:"body"`
+	Status int             `json:"status"`
+	Text   string          `json:"text"`
+	JSON   json.RawMessage `json:"json"`
+}
+
+// recordedResponsesServer is an HTTP server mimicking the Esplora API that
+// replies with responses recorded in the testdata directory.
+type recordedResponsesServer struct {
+	t         *testing.T
+	server    *httptest.Server
+	responses []*recordedResponse
+
+	callsMutex sync.Mutex
+	callsCount map[string]int
+}
+
+func newRecordedResponsesServer(t *testing.T) *recordedResponsesServer {
+	data, err := os.ReadFile("testdata/recorded_responses.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var responses []*recordedResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		t.Fatal(err)
+	}
+
+	rrs := &recordedResponsesServer{
+		t:          t,
+		responses:  responses,
+		callsCount: make(map[string]int),
+	}
+
+	rrs.server = httptest.NewServer(http.HandlerFunc(rrs.handle))
+	t.Cleanup(rrs.server.Close)
+
+	return rrs
+}
+
+func (rrs *recordedResponsesServer) url() string {
+	return rrs.server.URL
+}
+
+func (rrs *recordedResponsesServer) calls(path string) int {
+	rrs.callsMutex.Lock()
+	defer rrs.callsMutex.Unlock()
+
+	return rrs.callsCount[path]
+}
+
+func (rrs *recordedResponsesServer) handle(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		rrs.t.Errorf("cannot read request: [%v]", err)
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rrs.callsMutex.Lock()
+	rrs.callsCount[request.URL.Path]++
+	rrs.callsMutex.Unlock()
+
+	response := rrs.find(request.Method, request.URL.Path, string(body))
+	if response == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("no recorded response"))
+		return
+	}
+
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if response.JSON != nil {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(status)
+		_, _ = writer.Write(response.JSON)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/plain")
+	writer.WriteHeader(status)
+	_, _ = writer.Write([]byte(response.Text))
+}
+
+func (rrs *recordedResponsesServer) find(
+	method string,
+	path string,
+	body string,
+) *recordedResponse {
+	for _, response := range rrs.responses {
+		if response.Method == method &&
+			response.Path == path &&
+			response.Body == body {
+			return response
+		}
+	}
+
+	return nil
+}
+
+func newTestConnection(t *testing.T) (bitcoin.Chain, *recordedResponsesServer) {
+	server := newRecordedResponsesServer(t)
+
+	chain, err := Connect(context.Background(), Config{URL: server.url()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chain, server
+}
+
+func hashFromString(s string) bitcoin.Hash {
+	hash, err := bitcoin.NewHashFromString(s, bitcoin.ReversedByteOrder)
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
@@ -0,0 +1,75 @@
+package esplora
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// convertRawTransaction transforms a transaction provided in the hexadecimal
+// serialized string to the format expected by the bitcoin.Chain interface.
+func convertRawTransaction(rawTx string) (*bitcoin.Transaction, error) {
+	txBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	transaction := new(bitcoin.Transaction)
+	if err := transaction.Deserialize(txBytes); err != nil {
+		return nil, fmt.Errorf("failed to deserialize a transaction: [%w]", err)
+	}
+
+	return transaction, nil
+}
+
+// selectFeeEstimate selects the sat/vbyte fee estimate for a transaction to
+// be confirmed within the given number of blocks from the estimates returned
+// by the Esplora API. The estimates are keyed by the confirmation target
+// expressed in blocks and the set of available targets is not contiguous.
+// The estimate of the greatest target not exceeding the given number of blocks
+// is selected. If there is no such target, the estimate of the lowest target
+// is selected as it is the most conservative one.
+func selectFeeEstimate(
+	estimates map[string]float64,
+	blocks uint32,
+) (int64, error) {
+	targets := make([]uint64, 0, len(estimates))
+	for key := range estimates {
+		target, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"cannot parse confirmation target [%s]: [%v]",
+				key,
+				err,
+			)
+		}
+
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return 0, fmt.Errorf(
+			"server does not have enough information to make an estimate",
+		)
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	selectedTarget := targets[0]
+	for _, target := range targets {
+		if target > uint64(blocks) {
+			break
+		}
+		selectedTarget = target
+	}
+
+	satPerVByte := estimates[strconv.FormatUint(selectedTarget, 10)]
+	// Make sure the minimum returned sat/vbyte fee is always 1.
+	satPerVByte = math.Max(satPerVByte, 1)
+	// Round the returned fee to be an integer.
+	return int64(math.Round(satPerVByte)), nil
+}
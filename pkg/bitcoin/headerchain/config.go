@@ -0,0 +1,19 @@
+package headerchain
+
+// Config holds configurable properties.
+type Config struct {
+	// Height of the trusted block the local header chain starts from. It
+	// must be a difficulty adjustment height, i.e. a multiple of 2016, so
+	// the difficulty of all headers above it can be validated.
+	CheckpointHeight uint
+	// Hash of the trusted block at the checkpoint height, as an unprefixed
+	// hex string in the reversed byte order, i.e. the way block explorers
+	// display it. Header chain verification is disabled if this value is
+	// empty.
+	CheckpointHash string
+}
+
+// Enabled returns true if the header chain verification is configured.
+func (c Config) Enabled() bool {
+	return c.CheckpointHash != ""
+}
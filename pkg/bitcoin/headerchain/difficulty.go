@@ -0,0 +1,219 @@
+package headerchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/blockchain"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// retargetInterval is the number of blocks after which the difficulty
+	// target is recalculated.
+	retargetInterval = 2016
+	// targetSpacing is the expected time between two blocks, in seconds.
+	targetSpacing = 10 * 60
+	// targetTimespan is the expected time it takes to mine retargetInterval
+	// blocks, in seconds.
+	targetTimespan = retargetInterval * targetSpacing
+)
+
+// headerLookup returns the header at the given height or nil if the header
+// is not known.
+type headerLookup func(height uint) *bitcoin.BlockHeader
+
+// networkParams holds the consensus parameters relevant for the block
+// header validation.
+type networkParams struct {
+	// powLimitBits is the compact representation of the highest allowed
+	// proof-of-work target.
+	powLimitBits uint32
+	// powLimit is the highest allowed proof-of-work target.
+	powLimit *big.Int
+	// minDifficultyBlocks determines whether a block can be mined with the
+	// lowest difficulty if its time is more than twice the target spacing
+	// after the previous block.
+	minDifficultyBlocks bool
+	// noRetargeting determines whether the difficulty target never changes.
+	noRetargeting bool
+}
+
+func paramsForNetwork(network bitcoin.Network) (*networkParams, error) {
+	var params *networkParams
+
+	switch network {
+	case bitcoin.Mainnet:
+		params = &networkParams{
+			powLimitBits: 0x1d00ffff,
+		}
+	case bitcoin.Testnet:
+		params = &networkParams{
+			powLimitBits:        0x1d00ffff,
+			minDifficultyBlocks: true,
+		}
+	case bitcoin.Regtest:
+		params = &networkParams{
+			powLimitBits:        0x207fffff,
+			minDifficultyBlocks: true,
+			noRetargeting:       true,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Bitcoin network [%v]", network)
+	}
+
+	params.powLimit = blockchain.CompactToBig(params.powLimitBits)
+
+	return params, nil
+}
+
+// validate checks whether the given header can be placed at the given height
+// on top of the headers returned by the lookup function. The header must
+// link to the previous header, its hash must meet the target declared in the
+// header, and the declared target must be the one required by the difficulty
+// adjustment rules. An error is returned if the headers needed to compute
+// the required target are not known.
+func (np *networkParams) validate(
+	header *bitcoin.BlockHeader,
+	height uint,
+	lookup headerLookup,
+) error {
+	previous := lookup(height - 1)
+	if previous == nil {
+		return fmt.Errorf("previous header is not known")
+	}
+
+	if header.PreviousBlockHeaderHash != previous.Hash() {
+		return fmt.Errorf("header does not link to the previous header")
+	}
+
+	target := header.Target()
+	if target.Sign() <= 0 || target.Cmp(np.powLimit) > 0 {
+		return fmt.Errorf("target of bits [0x%08x] is out of range", header.Bits)
+	}
+
+	hash := header.Hash()
+	if hashToBig(hash).Cmp(target) > 0 {
+		return fmt.Errorf(
+			"hash [%s] does not meet the target of bits [0x%08x]",
+			hash.Hex(bitcoin.ReversedByteOrder),
+			header.Bits,
+		)
+	}
+
+	expectedBits, err := np.requiredBits(header, height, lookup)
+	if err != nil {
+		return fmt.Errorf("cannot compute required bits: [%w]", err)
+	}
+
+	if header.Bits != expectedBits {
+		return fmt.Errorf(
+			"unexpected bits [0x%08x]; required bits are [0x%08x]",
+			header.Bits,
+			expectedBits,
+		)
+	}
+
+	return nil
+}
+
+// requiredBits computes the bits the given header at the given height must
+// declare according to the difficulty adjustment rules. An error is returned
+// if the headers needed for the computation are not known. Such headers are
+// always known if the local chain starts at a difficulty adjustment height.
+func (np *networkParams) requiredBits(
+	header *bitcoin.BlockHeader,
+	height uint,
+	lookup headerLookup,
+) (uint32, error) {
+	previous := lookup(height - 1)
+	if previous == nil {
+		return 0, fmt.Errorf(
+			"header at height [%v] is not known",
+			height-1,
+		)
+	}
+
+	if height%retargetInterval != 0 {
+		if !np.minDifficultyBlocks {
+			return previous.Bits, nil
+		}
+
+		// A block mined long enough after the previous one may use
+		// the lowest difficulty.
+		if header.Time > previous.Time+2*targetSpacing {
+			return np.powLimitBits, nil
+		}
+
+		// Otherwise, the block must use the difficulty of the last block
+		// that was not mined with the lowest difficulty.
+		for ancestorHeight := height - 1; ; ancestorHeight-- {
+			ancestor := lookup(ancestorHeight)
+			if ancestor == nil {
+				return 0, fmt.Errorf(
+					"header at height [%v] is not known",
+					ancestorHeight,
+				)
+			}
+
+			if ancestorHeight%retargetInterval == 0 ||
+				ancestor.Bits != np.powLimitBits {
+				return ancestor.Bits, nil
+			}
+		}
+	}
+
+	if np.noRetargeting {
+		return previous.Bits, nil
+	}
+
+	first := lookup(height - retargetInterval)
+	if first == nil {
+		return 0, fmt.Errorf(
+			"header at height [%v] is not known",
+			height-retargetInterval,
+		)
+	}
+
+	actualTimespan := int64(previous.Time) - int64(first.Time)
+	if actualTimespan < targetTimespan/4 {
+		actualTimespan = targetTimespan / 4
+	}
+	if actualTimespan > targetTimespan*4 {
+		actualTimespan = targetTimespan * 4
+	}
+
+	newTarget := new(big.Int).Mul(
+		blockchain.CompactToBig(previous.Bits),
+		big.NewInt(actualTimespan),
+	)
+	newTarget.Div(newTarget, big.NewInt(targetTimespan))
+
+	if newTarget.Cmp(np.powLimit) > 0 {
+		newTarget = np.powLimit
+	}
+
+	return blockchain.BigToCompact(newTarget), nil
+}
+
+// hashToBig interprets the given hash as a little-endian number, the way
+// it is compared against the proof-of-work target.
+func hashToBig(hash bitcoin.Hash) *big.Int {
+	reversed := hash
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	return new(big.Int).SetBytes(reversed[:])
+}
+
+// chainWork computes the cumulative proof-of-work of the given headers.
+func chainWork(headers []*bitcoin.BlockHeader) *big.Int {
+	work := new(big.Int)
+	for _, header := range headers {
+		work.Add(work, blockchain.CalcWork(header.Bits))
+	}
+
+	return work
+}
@@ -0,0 +1,418 @@
+// Package headerchain implements a local chain of Bitcoin block headers
+// starting from a trusted checkpoint. Each header added to the chain is
+// validated against the proof-of-work and difficulty adjustment rules, and
+// the chain always follows the branch with the most cumulative work among the
+// branches it has seen. The local chain allows cross-checking block headers
+// and transaction Merkle proofs returned by untrusted Bitcoin servers.
+package headerchain
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// MaxHeadersBatch is the maximum number of headers requested from
+	// the source in a single call. It matches the limit enforced by Electrum
+	// servers.
+	MaxHeadersBatch = 2016
+	// MaxReorganizationDepth is the maximum number of blocks the local chain
+	// can be rolled back when the source follows a competing branch.
+	MaxReorganizationDepth = 144
+)
+
+var logger = log.Logger("keep-bitcoin-headerchain")
+
+// Source is a source of block headers the local chain is synchronized with.
+type Source interface {
+	// GetLatestBlockHeight gets the height of the latest block known to
+	// the source.
+	GetLatestBlockHeight() (uint, error)
+	// GetBlockHeaders gets count consecutive block headers starting from
+	// the given height.
+	GetBlockHeaders(startHeight uint, count uint) ([]*bitcoin.BlockHeader, error)
+}
+
+// HeaderChain is a local chain of validated Bitcoin block headers starting
+// from a trusted checkpoint.
+type HeaderChain struct {
+	mutex sync.RWMutex
+
+	params         *networkParams
+	config         Config
+	checkpointHash bitcoin.Hash
+
+	// persistence is used to store the validated headers. It is nil if the
+	// headers are kept only in memory.
+	persistence persistence.BasicHandle
+	// persistedSegments is the number of header segments currently stored
+	// using the persistence handle.
+	persistedSegments int
+
+	// headers holds the validated headers, the first one being
+	// the checkpoint header.
+	headers []*bitcoin.BlockHeader
+}
+
+// New creates a header chain for the given network. If the persistence
+// handle is not nil, the headers stored during previous runs are loaded and
+// validated again, and the headers accepted later are stored using that
+// handle. The chain is empty until the first call to Sync.
+func New(
+	network bitcoin.Network,
+	config Config,
+	handle persistence.BasicHandle,
+) (*HeaderChain, error) {
+	params, err := paramsForNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	// Starting from a difficulty adjustment height guarantees the headers
+	// needed to validate the difficulty of any header above the checkpoint
+	// are always part of the local chain.
+	if config.CheckpointHeight%retargetInterval != 0 {
+		return nil, fmt.Errorf(
+			"checkpoint height [%v] is not a difficulty adjustment height; "+
+				"it must be a multiple of [%v]",
+			config.CheckpointHeight,
+			retargetInterval,
+		)
+	}
+
+	checkpointHash, err := bitcoin.NewHashFromString(
+		config.CheckpointHash,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint hash: [%w]", err)
+	}
+
+	hc := &HeaderChain{
+		params:         params,
+		config:         config,
+		checkpointHash: checkpointHash,
+		persistence:    handle,
+	}
+
+	if handle != nil {
+		if err := hc.load(); err != nil {
+			return nil, fmt.Errorf("cannot load stored headers: [%w]", err)
+		}
+	}
+
+	return hc, nil
+}
+
+// TipHeight returns the height of the latest header in the local chain.
+// If the chain is empty, the checkpoint height is returned.
+func (hc *HeaderChain) TipHeight() uint {
+	hc.mutex.RLock()
+	defer hc.mutex.RUnlock()
+
+	return hc.tipHeight()
+}
+
+// Header returns the header at the given height. An error is returned if
+// the local chain does not contain a header at that height.
+func (hc *HeaderChain) Header(height uint) (*bitcoin.BlockHeader, error) {
+	hc.mutex.RLock()
+	defer hc.mutex.RUnlock()
+
+	header := hc.header(height)
+	if header == nil {
+		return nil, fmt.Errorf(
+			"block [%v] is not in the local header chain "+
+				"spanning blocks from [%v] to [%v]",
+			height,
+			hc.config.CheckpointHeight,
+			hc.tipHeight(),
+		)
+	}
+
+	// Return a copy so the caller cannot modify the local chain.
+	result := *header
+	return &result, nil
+}
+
+// VerifyMerkleProof checks whether the given Merkle proof proves
+// the inclusion of the transaction with the given hash in the block that is
+// part of the local chain.
+func (hc *HeaderChain) VerifyMerkleProof(
+	transactionHash bitcoin.Hash,
+	proof *bitcoin.TransactionMerkleProof,
+) error {
+	header, err := hc.Header(proof.BlockHeight)
+	if err != nil {
+		return err
+	}
+
+	current := transactionHash
+	position := proof.Position
+
+	for i, node := range proof.MerkleNodes {
+		nodeHash, err := bitcoin.NewHashFromString(
+			node,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid merkle node [%v]: [%w]", i, err)
+		}
+
+		var pair []byte
+		if position%2 == 0 {
+			pair = append(current[:], nodeHash[:]...)
+		} else {
+			pair = append(nodeHash[:], current[:]...)
+		}
+
+		current = bitcoin.ComputeHash(pair)
+		position /= 2
+	}
+
+	if position != 0 {
+		return fmt.Errorf(
+			"position [%v] does not match the merkle proof length [%v]",
+			proof.Position,
+			len(proof.MerkleNodes),
+		)
+	}
+
+	if current != header.MerkleRootHash {
+		return fmt.Errorf(
+			"merkle proof does not lead to the merkle root of block [%v]",
+			proof.BlockHeight,
+		)
+	}
+
+	return nil
+}
+
+// Sync extends the local chain with the headers known to the source. If
+// the source follows a branch competing with the local chain, the local
+// chain switches to that branch only if the branch has more cumulative work.
+// An error is returned if the source serves headers violating the consensus
+// rules or follows a branch that has less work than the local chain.
+func (hc *HeaderChain) Sync(source Source) error {
+	hc.mutex.Lock()
+	defer hc.mutex.Unlock()
+
+	if len(hc.headers) == 0 {
+		if err := hc.initializeCheckpoint(source); err != nil {
+			return err
+		}
+	}
+
+	latestHeight, err := source.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("cannot get latest block height: [%w]", err)
+	}
+
+	for hc.tipHeight() < latestHeight {
+		tipHeight := hc.tipHeight()
+
+		count := latestHeight - tipHeight
+		if count > MaxHeadersBatch {
+			count = MaxHeadersBatch
+		}
+
+		branch, err := fetchHeaders(source, tipHeight+1, count)
+		if err != nil {
+			return err
+		}
+
+		forkHeight := tipHeight
+		if branch[0].PreviousBlockHeaderHash != hc.header(tipHeight).Hash() {
+			forkHeight, err = hc.findForkHeight(source)
+			if err != nil {
+				return err
+			}
+
+			if forkHeight < tipHeight {
+				replacement, err := fetchHeaders(
+					source,
+					forkHeight+1,
+					tipHeight-forkHeight,
+				)
+				if err != nil {
+					return err
+				}
+
+				branch = append(replacement, branch...)
+			}
+		}
+
+		if err := hc.connect(forkHeight, branch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initializeCheckpoint fetches the checkpoint header from the source and
+// makes it the first header of the local chain.
+func (hc *HeaderChain) initializeCheckpoint(source Source) error {
+	headers, err := fetchHeaders(source, hc.config.CheckpointHeight, 1)
+	if err != nil {
+		return err
+	}
+
+	checkpoint := headers[0]
+	if checkpoint.Hash() != hc.checkpointHash {
+		return fmt.Errorf(
+			"source returned block [%s] at the checkpoint height [%v] "+
+				"while block [%s] was expected",
+			checkpoint.Hash().Hex(bitcoin.ReversedByteOrder),
+			hc.config.CheckpointHeight,
+			hc.checkpointHash.Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	hc.headers = []*bitcoin.BlockHeader{checkpoint}
+
+	return hc.persist(hc.config.CheckpointHeight)
+}
+
+// findForkHeight finds the height of the highest header shared by the local
+// chain and the source. The search is limited to MaxReorganizationDepth
+// blocks below the local tip and never goes below the checkpoint.
+func (hc *HeaderChain) findForkHeight(source Source) (uint, error) {
+	tipHeight := hc.tipHeight()
+
+	lowestHeight := hc.config.CheckpointHeight
+	if tipHeight-lowestHeight > MaxReorganizationDepth {
+		lowestHeight = tipHeight - MaxReorganizationDepth
+	}
+
+	headers, err := fetchHeaders(source, lowestHeight, tipHeight-lowestHeight+1)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(headers) - 1; i >= 0; i-- {
+		height := lowestHeight + uint(i)
+		if headers[i].Hash() == hc.header(height).Hash() {
+			return height, nil
+		}
+	}
+
+	return 0, fmt.Errorf(
+		"source follows a branch that does not share any of blocks "+
+			"from [%v] to [%v] with the local chain",
+		lowestHeight,
+		tipHeight,
+	)
+}
+
+// connect validates the given branch of headers placed right above the given
+// fork height and replaces the local headers above the fork height with
+// the branch. If the local chain has headers above the fork height, the
+// branch is accepted only if it has more cumulative work than them.
+func (hc *HeaderChain) connect(
+	forkHeight uint,
+	branch []*bitcoin.BlockHeader,
+) error {
+	lookup := func(height uint) *bitcoin.BlockHeader {
+		if height <= forkHeight {
+			return hc.header(height)
+		}
+
+		index := height - forkHeight - 1
+		if index < uint(len(branch)) {
+			return branch[index]
+		}
+
+		return nil
+	}
+
+	for i, header := range branch {
+		height := forkHeight + 1 + uint(i)
+		if err := hc.params.validate(header, height, lookup); err != nil {
+			return fmt.Errorf("invalid header at height [%v]: [%w]", height, err)
+		}
+	}
+
+	forkIndex := forkHeight - hc.config.CheckpointHeight
+
+	if tipHeight := hc.tipHeight(); forkHeight < tipHeight {
+		localWork := chainWork(hc.headers[forkIndex+1:])
+		branchWork := chainWork(branch)
+
+		if branchWork.Cmp(localWork) <= 0 {
+			return fmt.Errorf(
+				"branch forking at height [%v] does not have more work "+
+					"than the local chain",
+				forkHeight,
+			)
+		}
+
+		logger.Warnf(
+			"switching to branch forking at height [%v]; "+
+				"[%v] headers up to height [%v] are replaced",
+			forkHeight,
+			tipHeight-forkHeight,
+			tipHeight,
+		)
+	}
+
+	hc.headers = append(hc.headers[:forkIndex+1], branch...)
+
+	return hc.persist(forkHeight + 1)
+}
+
+func (hc *HeaderChain) tipHeight() uint {
+	if len(hc.headers) == 0 {
+		return hc.config.CheckpointHeight
+	}
+
+	return hc.config.CheckpointHeight + uint(len(hc.headers)) - 1
+}
+
+func (hc *HeaderChain) header(height uint) *bitcoin.BlockHeader {
+	if height < hc.config.CheckpointHeight {
+		return nil
+	}
+
+	index := height - hc.config.CheckpointHeight
+	if index >= uint(len(hc.headers)) {
+		return nil
+	}
+
+	return hc.headers[index]
+}
+
+// fetchHeaders gets exactly count headers starting from the given height
+// from the source.
+func fetchHeaders(
+	source Source,
+	startHeight uint,
+	count uint,
+) ([]*bitcoin.BlockHeader, error) {
+	headers, err := source.GetBlockHeaders(startHeight, count)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get [%v] headers starting from height [%v]: [%w]",
+			count,
+			startHeight,
+			err,
+		)
+	}
+
+	if uint(len(headers)) != count {
+		return nil, fmt.Errorf(
+			"source returned [%v] headers starting from height [%v] "+
+				"while [%v] were requested",
+			len(headers),
+			startHeight,
+			count,
+		)
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,699 @@
+package headerchain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/blockchain"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const regtestBits = 0x207fffff
+
+func TestHeaderChain_Sync(t *testing.T) {
+	source := newTestSource(t, 10)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "tip height", 10, uint64(headerChain.TipHeight()))
+
+	for height, expectedHeader := range source.headers {
+		header, err := headerChain.Header(uint(height))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Hash() != expectedHeader.Hash() {
+			t.Errorf("unexpected header at height [%v]", height)
+		}
+	}
+
+	_, err = headerChain.Header(11)
+	if err == nil {
+		t.Errorf("expected error for header above the tip")
+	}
+}
+
+func TestHeaderChain_Sync_CheckpointMismatch(t *testing.T) {
+	source := newTestSource(t, 10)
+
+	headerChain, err := New(
+		bitcoin.Regtest,
+		Config{
+			CheckpointHeight: 0,
+			CheckpointHash: source.headers[1].Hash().Hex(
+				bitcoin.ReversedByteOrder,
+			),
+		},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = headerChain.Sync(source)
+	assertErrorContains(t, err, "at the checkpoint height [0]")
+}
+
+func TestNew_CheckpointNotAtRetargetHeight(t *testing.T) {
+	_, err := New(
+		bitcoin.Mainnet,
+		Config{
+			CheckpointHeight: 2017,
+			CheckpointHash: "00000000000000000000000000000000" +
+				"0000000000000000000000000000abcd",
+		},
+		nil,
+	)
+	assertErrorContains(t, err, "checkpoint height [2017] is not")
+}
+
+func TestHeaderChain_Sync_InvalidProofOfWork(t *testing.T) {
+	source := newTestSource(t, 5)
+
+	invalidHeader := newTestHeader(source.headers[5], regtestBits, 0)
+	for hashToBig(invalidHeader.Hash()).Cmp(invalidHeader.Target()) <= 0 {
+		invalidHeader.Nonce++
+	}
+	source.headers = append(source.headers, invalidHeader)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	assertErrorContains(t, err, "does not meet the target")
+
+	testutils.AssertUintsEqual(t, "tip height", 0, uint64(headerChain.TipHeight()))
+}
+
+func TestHeaderChain_Sync_UnexpectedBits(t *testing.T) {
+	source := newTestSource(t, 5)
+
+	// The target is valid and met by the header hash, but it differs from
+	// the target required by the difficulty adjustment rules.
+	source.headers = append(
+		source.headers,
+		mineHeaders(source.headers[5], 1, 0x2000ffff, 0)...,
+	)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	assertErrorContains(t, err, "unexpected bits [0x2000ffff]")
+}
+
+func TestHeaderChain_Sync_DisconnectedHeader(t *testing.T) {
+	source := newTestSource(t, 5)
+
+	unrelated := newTestHeader(source.headers[5], regtestBits, 100)
+	source.headers = append(
+		source.headers,
+		mineHeaders(unrelated, 1, regtestBits, 0)...,
+	)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	assertErrorContains(t, err, "does not link to the previous header")
+}
+
+func TestHeaderChain_Sync_Reorganization(t *testing.T) {
+	source := newTestSource(t, 10)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace blocks above height 7 with a longer branch.
+	branch := mineHeaders(source.headers[7], 5, regtestBits, 100)
+	source.headers = append(source.headers[:8], branch...)
+
+	err = headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "tip height", 12, uint64(headerChain.TipHeight()))
+
+	header, err := headerChain.Header(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash() != branch[0].Hash() {
+		t.Errorf("expected header from the new branch at height 8")
+	}
+}
+
+func TestHeaderChain_Connect_LessWork(t *testing.T) {
+	source := newTestSource(t, 10)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	branch := mineHeaders(source.headers[7], 2, regtestBits, 100)
+
+	err = headerChain.connect(7, branch)
+	assertErrorContains(t, err, "does not have more work")
+
+	header, err := headerChain.Header(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash() != source.headers[8].Hash() {
+		t.Errorf("expected the local chain to be kept")
+	}
+}
+
+func TestHeaderChain_Persistence(t *testing.T) {
+	source := newTestSource(t, 10)
+	handle := newTestPersistenceHandle()
+
+	headerChain := newTestHeaderChain(t, source, handle)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloadedChain := newTestHeaderChain(t, source, handle)
+
+	testutils.AssertUintsEqual(
+		t,
+		"reloaded tip height",
+		10,
+		uint64(reloadedChain.TipHeight()),
+	)
+
+	header, err := reloadedChain.Header(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash() != source.headers[10].Hash() {
+		t.Errorf("unexpected reloaded header at height 10")
+	}
+}
+
+func TestHeaderChain_Persistence_CorruptedHeaders(t *testing.T) {
+	source := newTestSource(t, 10)
+	handle := newTestPersistenceHandle()
+
+	headerChain := newTestHeaderChain(t, source, handle)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the nonce of the header at height 5.
+	segment := handle.files[directoryName]["0"]
+	segment[5*bitcoin.BlockHeaderByteLength+79] ^= 0xff
+
+	reloadedChain := newTestHeaderChain(t, source, handle)
+
+	testutils.AssertUintsEqual(
+		t,
+		"reloaded tip height",
+		0,
+		uint64(reloadedChain.TipHeight()),
+	)
+
+	if _, ok := handle.files[directoryName]["0"]; ok {
+		t.Errorf("expected corrupted headers to be deleted")
+	}
+
+	err = reloadedChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"synced tip height",
+		10,
+		uint64(reloadedChain.TipHeight()),
+	)
+}
+
+func TestHeaderChain_VerifyMerkleProof(t *testing.T) {
+	source := newTestSource(t, 3)
+
+	transactionHashes := []bitcoin.Hash{
+		bitcoin.ComputeHash([]byte{1}),
+		bitcoin.ComputeHash([]byte{2}),
+		bitcoin.ComputeHash([]byte{3}),
+	}
+
+	// For an odd number of transactions, the last one is paired with itself.
+	leftNode := bitcoin.ComputeHash(
+		append(transactionHashes[0][:], transactionHashes[1][:]...),
+	)
+	rightNode := bitcoin.ComputeHash(
+		append(transactionHashes[2][:], transactionHashes[2][:]...),
+	)
+	merkleRoot := bitcoin.ComputeHash(append(leftNode[:], rightNode[:]...))
+
+	block := newTestHeader(source.headers[3], regtestBits, 0)
+	block.MerkleRootHash = merkleRoot
+	mine(block)
+	source.headers = append(source.headers, block)
+
+	headerChain := newTestHeaderChain(t, source, nil)
+
+	err := headerChain.Sync(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validProof := &bitcoin.TransactionMerkleProof{
+		BlockHeight: 4,
+		MerkleNodes: []string{
+			transactionHashes[0].Hex(bitcoin.ReversedByteOrder),
+			rightNode.Hex(bitcoin.ReversedByteOrder),
+		},
+		Position: 1,
+	}
+
+	var tests = map[string]struct {
+		transactionHash bitcoin.Hash
+		proof           *bitcoin.TransactionMerkleProof
+		expectedError   string
+	}{
+		"valid proof": {
+			transactionHash: transactionHashes[1],
+			proof:           validProof,
+		},
+		"wrong transaction": {
+			transactionHash: transactionHashes[0],
+			proof:           validProof,
+			expectedError:   "does not lead to the merkle root",
+		},
+		"wrong position": {
+			transactionHash: transactionHashes[1],
+			proof: &bitcoin.TransactionMerkleProof{
+				BlockHeight: 4,
+				MerkleNodes: validProof.MerkleNodes,
+				Position:    0,
+			},
+			expectedError: "does not lead to the merkle root",
+		},
+		"position out of range": {
+			transactionHash: transactionHashes[1],
+			proof: &bitcoin.TransactionMerkleProof{
+				BlockHeight: 4,
+				MerkleNodes: validProof.MerkleNodes,
+				Position:    5,
+			},
+			expectedError: "does not match the merkle proof length",
+		},
+		"wrong block": {
+			transactionHash: transactionHashes[1],
+			proof: &bitcoin.TransactionMerkleProof{
+				BlockHeight: 3,
+				MerkleNodes: validProof.MerkleNodes,
+				Position:    1,
+			},
+			expectedError: "does not lead to the merkle root",
+		},
+		"block not in the chain": {
+			transactionHash: transactionHashes[1],
+			proof: &bitcoin.TransactionMerkleProof{
+				BlockHeight: 5,
+				MerkleNodes: validProof.MerkleNodes,
+				Position:    1,
+			},
+			expectedError: "is not in the local header chain",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := headerChain.VerifyMerkleProof(test.transactionHash, test.proof)
+
+			if test.expectedError == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			assertErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestNetworkParams_RequiredBits_Retarget(t *testing.T) {
+	params, err := paramsForNetwork(bitcoin.Mainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		previousBits uint32
+		timespan     uint32
+		expectedBits uint32
+	}{
+		"expected timespan": {
+			previousBits: 0x1c7fff80,
+			timespan:     targetTimespan,
+			expectedBits: 0x1c7fff80,
+		},
+		"half of the expected timespan": {
+			previousBits: 0x1d00ffff,
+			timespan:     targetTimespan / 2,
+			expectedBits: 0x1c7fff80,
+		},
+		"timespan below the lower bound": {
+			previousBits: 0x1d00ffff,
+			timespan:     targetTimespan / 10,
+			expectedBits: 0x1c3fffc0,
+		},
+		"target above the proof-of-work limit": {
+			previousBits: 0x1d00ffff,
+			timespan:     targetTimespan * 2,
+			expectedBits: 0x1d00ffff,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			first := &bitcoin.BlockHeader{Bits: test.previousBits, Time: 1000}
+			previous := &bitcoin.BlockHeader{
+				Bits: test.previousBits,
+				Time: 1000 + test.timespan,
+			}
+
+			lookup := func(height uint) *bitcoin.BlockHeader {
+				switch height {
+				case 0:
+					return first
+				case retargetInterval - 1:
+					return previous
+				default:
+					return nil
+				}
+			}
+
+			bits, err := params.requiredBits(
+				&bitcoin.BlockHeader{},
+				retargetInterval,
+				lookup,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"required bits",
+				uint64(test.expectedBits),
+				uint64(bits),
+			)
+		})
+	}
+}
+
+func TestNetworkParams_RequiredBits_MinDifficultyBlocks(t *testing.T) {
+	params, err := paramsForNetwork(bitcoin.Testnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := map[uint]*bitcoin.BlockHeader{
+		2016: {Bits: 0x1c7fff80, Time: 1000},
+		2017: {Bits: 0x1c7fff80, Time: 1600},
+		2018: {Bits: 0x1d00ffff, Time: 4000},
+	}
+	lookup := func(height uint) *bitcoin.BlockHeader {
+		return headers[height]
+	}
+
+	// A block mined more than 20 minutes after the previous one may use
+	// the lowest difficulty.
+	bits, err := params.requiredBits(&bitcoin.BlockHeader{Time: 5201}, 2019, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertUintsEqual(t, "late block bits", 0x1d00ffff, uint64(bits))
+
+	// Otherwise, it must use the difficulty of the last regular block.
+	bits, err = params.requiredBits(&bitcoin.BlockHeader{Time: 4600}, 2019, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertUintsEqual(t, "regular block bits", 0x1c7fff80, uint64(bits))
+}
+
+func TestNetworkParams_RequiredBits_UnknownHeaders(t *testing.T) {
+	var tests = map[string]struct {
+		network       bitcoin.Network
+		headers       map[uint]*bitcoin.BlockHeader
+		header        *bitcoin.BlockHeader
+		height        uint
+		expectedError string
+	}{
+		"retarget without the first header of the period": {
+			network: bitcoin.Mainnet,
+			headers: map[uint]*bitcoin.BlockHeader{
+				4031: {Bits: 0x1d00ffff, Time: 1000},
+			},
+			header:        &bitcoin.BlockHeader{},
+			height:        4032,
+			expectedError: "header at height [2016] is not known",
+		},
+		"min difficulty walk below the known headers": {
+			network: bitcoin.Testnet,
+			headers: map[uint]*bitcoin.BlockHeader{
+				2017: {Bits: 0x1d00ffff, Time: 1000},
+				2018: {Bits: 0x1d00ffff, Time: 2200},
+			},
+			header:        &bitcoin.BlockHeader{Time: 2800},
+			height:        2019,
+			expectedError: "header at height [2016] is not known",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			params, err := paramsForNetwork(test.network)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lookup := func(height uint) *bitcoin.BlockHeader {
+				return test.headers[height]
+			}
+
+			_, err = params.requiredBits(test.header, test.height, lookup)
+			assertErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+type testSource struct {
+	headers []*bitcoin.BlockHeader
+}
+
+// newTestSource creates a source of a regtest chain with the given number of
+// blocks above the genesis block.
+func newTestSource(t *testing.T, blocks int) *testSource {
+	genesis := &bitcoin.BlockHeader{
+		Version: 1,
+		Time:    1600000000,
+		Bits:    regtestBits,
+	}
+
+	return &testSource{
+		headers: append(
+			[]*bitcoin.BlockHeader{genesis},
+			mineHeaders(genesis, blocks, regtestBits, 0)...,
+		),
+	}
+}
+
+func (ts *testSource) GetLatestBlockHeight() (uint, error) {
+	return uint(len(ts.headers) - 1), nil
+}
+
+func (ts *testSource) GetBlockHeaders(
+	startHeight uint,
+	count uint,
+) ([]*bitcoin.BlockHeader, error) {
+	if startHeight+count > uint(len(ts.headers)) {
+		return nil, fmt.Errorf("headers out of range")
+	}
+
+	return ts.headers[startHeight : startHeight+count], nil
+}
+
+func newTestHeaderChain(
+	t *testing.T,
+	source *testSource,
+	handle persistence.BasicHandle,
+) *HeaderChain {
+	headerChain, err := New(
+		bitcoin.Regtest,
+		Config{
+			CheckpointHeight: 0,
+			CheckpointHash: source.headers[0].Hash().Hex(
+				bitcoin.ReversedByteOrder,
+			),
+		},
+		handle,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return headerChain
+}
+
+// newTestHeader creates an unmined header on top of the previous header.
+// The salt makes the header distinct from other headers at the same height.
+func newTestHeader(
+	previous *bitcoin.BlockHeader,
+	bits uint32,
+	salt uint32,
+) *bitcoin.BlockHeader {
+	var merkleRootSeed [8]byte
+	binary.LittleEndian.PutUint32(merkleRootSeed[:], previous.Time)
+	binary.LittleEndian.PutUint32(merkleRootSeed[4:], salt)
+
+	return &bitcoin.BlockHeader{
+		Version:                 1,
+		PreviousBlockHeaderHash: previous.Hash(),
+		MerkleRootHash:          bitcoin.ComputeHash(merkleRootSeed[:]),
+		Time:                    previous.Time + targetSpacing,
+		Bits:                    bits,
+	}
+}
+
+func mineHeaders(
+	previous *bitcoin.BlockHeader,
+	count int,
+	bits uint32,
+	salt uint32,
+) []*bitcoin.BlockHeader {
+	headers := make([]*bitcoin.BlockHeader, 0, count)
+
+	for i := 0; i < count; i++ {
+		header := newTestHeader(previous, bits, salt)
+		mine(header)
+
+		headers = append(headers, header)
+		previous = header
+	}
+
+	return headers
+}
+
+func mine(header *bitcoin.BlockHeader) {
+	target := blockchain.CompactToBig(header.Bits)
+	for hashToBig(header.Hash()).Cmp(target) > 0 {
+		header.Nonce++
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, expected string) {
+	if err == nil {
+		t.Fatalf("expected error containing [%s]", expected)
+	}
+
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf(
+			"unexpected error\nexpected to contain: [%s]\nactual: [%v]",
+			expected,
+			err,
+		)
+	}
+}
+
+type testPersistenceHandle struct {
+	files map[string]map[string][]byte
+}
+
+func newTestPersistenceHandle() *testPersistenceHandle {
+	return &testPersistenceHandle{
+		files: make(map[string]map[string][]byte),
+	}
+}
+
+func (tph *testPersistenceHandle) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	if _, ok := tph.files[directory]; !ok {
+		tph.files[directory] = make(map[string][]byte)
+	}
+
+	tph.files[directory][name] = append([]byte{}, data...)
+
+	return nil
+}
+
+func (tph *testPersistenceHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	outputData := make(chan persistence.DataDescriptor)
+	outputErrors := make(chan error)
+
+	go func() {
+		for directory, files := range tph.files {
+			for name, content := range files {
+				outputData <- &testDescriptor{
+					name:      name,
+					directory: directory,
+					content:   content,
+				}
+			}
+		}
+
+		close(outputData)
+		close(outputErrors)
+	}()
+
+	return outputData, outputErrors
+}
+
+func (tph *testPersistenceHandle) Delete(directory string, name string) error {
+	delete(tph.files[directory], name)
+	return nil
+}
+
+type testDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (td *testDescriptor) Name() string {
+	return td.name
+}
+
+func (td *testDescriptor) Directory() string {
+	return td.directory
+}
+
+func (td *testDescriptor) Content() ([]byte, error) {
+	return td.content, nil
+}
@@ -0,0 +1,206 @@
+package headerchain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// directoryName is the name of the directory the headers are stored in.
+	directoryName = "headers"
+	// segmentSize is the number of headers stored in a single file.
+	segmentSize = 2016
+)
+
+// persist stores all headers from the given height up to the tip. Headers
+// are stored in segments of segmentSize consecutive headers, each segment
+// in a file named after the height of its first header. Stored segments
+// that are above the tip are removed.
+func (hc *HeaderChain) persist(fromHeight uint) error {
+	if hc.persistence == nil {
+		return nil
+	}
+
+	firstSegment := int((fromHeight - hc.config.CheckpointHeight) / segmentSize)
+	segmentsCount := (len(hc.headers) + segmentSize - 1) / segmentSize
+
+	for segment := firstSegment; segment < segmentsCount; segment++ {
+		start := segment * segmentSize
+		end := start + segmentSize
+		if end > len(hc.headers) {
+			end = len(hc.headers)
+		}
+
+		data := make([]byte, 0, (end-start)*bitcoin.BlockHeaderByteLength)
+		for _, header := range hc.headers[start:end] {
+			serialized := header.Serialize()
+			data = append(data, serialized[:]...)
+		}
+
+		if err := hc.persistence.Save(
+			data,
+			directoryName,
+			segmentName(hc.config.CheckpointHeight+uint(start)),
+		); err != nil {
+			return fmt.Errorf("cannot store headers segment: [%w]", err)
+		}
+	}
+
+	for segment := segmentsCount; segment < hc.persistedSegments; segment++ {
+		if err := hc.persistence.Delete(
+			directoryName,
+			segmentName(hc.config.CheckpointHeight+uint(segment*segmentSize)),
+		); err != nil {
+			return fmt.Errorf("cannot delete headers segment: [%w]", err)
+		}
+	}
+
+	hc.persistedSegments = segmentsCount
+
+	return nil
+}
+
+// load reads the stored headers and validates them again. The stored headers
+// are discarded if they do not start from the configured checkpoint or if
+// any of them is invalid.
+func (hc *HeaderChain) load() error {
+	segments := make(map[uint][]byte)
+
+	descriptorsChan, errorsChan := hc.persistence.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels. The reason
+	// for using two goroutines at the same time is that channels do not
+	// have to be buffered, and we do not know in what order the information
+	// is written to channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != directoryName {
+				continue
+			}
+
+			startHeight, err := strconv.ParseUint(descriptor.Name(), 10, 64)
+			if err != nil {
+				logger.Errorf(
+					"unexpected headers segment file [%v]",
+					descriptor.Name(),
+				)
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read headers segment file [%v]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			segments[uint(startHeight)] = content
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			logger.Errorf("could not load headers from disk: [%v]", err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	startHeights := make([]uint, 0, len(segments))
+	for startHeight := range segments {
+		startHeights = append(startHeights, startHeight)
+	}
+	sort.Slice(startHeights, func(i, j int) bool {
+		return startHeights[i] < startHeights[j]
+	})
+
+	// Segments stored by a chain with a different checkpoint cannot be reused.
+	// Delete them, so they do not clash with the segments of this chain.
+	hc.persistedSegments = 0
+	for _, startHeight := range startHeights {
+		if startHeight < hc.config.CheckpointHeight ||
+			(startHeight-hc.config.CheckpointHeight)%segmentSize != 0 {
+			if err := hc.persistence.Delete(
+				directoryName,
+				segmentName(startHeight),
+			); err != nil {
+				return fmt.Errorf("cannot delete headers segment: [%w]", err)
+			}
+			continue
+		}
+
+		segment := int((startHeight - hc.config.CheckpointHeight) / segmentSize)
+		if segment+1 > hc.persistedSegments {
+			hc.persistedSegments = segment + 1
+		}
+	}
+
+	headers := make([]*bitcoin.BlockHeader, 0)
+	for {
+		startHeight := hc.config.CheckpointHeight + uint(len(headers))
+
+		data, ok := segments[startHeight]
+		if !ok || len(data)%bitcoin.BlockHeaderByteLength != 0 {
+			break
+		}
+
+		for offset := 0; offset < len(data); offset += bitcoin.BlockHeaderByteLength {
+			var rawHeader [bitcoin.BlockHeaderByteLength]byte
+			copy(rawHeader[:], data[offset:])
+
+			header := &bitcoin.BlockHeader{}
+			header.Deserialize(rawHeader)
+			headers = append(headers, header)
+		}
+
+		if len(data) != segmentSize*bitcoin.BlockHeaderByteLength {
+			break
+		}
+	}
+
+	if len(headers) == 0 {
+		return hc.persist(hc.config.CheckpointHeight)
+	}
+
+	if headers[0].Hash() != hc.checkpointHash {
+		logger.Warnf(
+			"stored headers do not start from the checkpoint block [%s]; "+
+				"discarding them",
+			hc.checkpointHash.Hex(bitcoin.ReversedByteOrder),
+		)
+		return hc.persist(hc.config.CheckpointHeight)
+	}
+
+	hc.headers = headers[:1]
+	if err := hc.connect(hc.config.CheckpointHeight, headers[1:]); err != nil {
+		logger.Warnf("stored headers are invalid; discarding them: [%v]", err)
+		hc.headers = nil
+		return hc.persist(hc.config.CheckpointHeight)
+	}
+
+	logger.Infof(
+		"loaded stored headers from [%v] to [%v]",
+		hc.config.CheckpointHeight,
+		hc.tipHeight(),
+	)
+
+	return nil
+}
+
+func segmentName(startHeight uint) string {
+	return strconv.FormatUint(uint64(startHeight), 10)
+}
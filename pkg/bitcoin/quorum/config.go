@@ -0,0 +1,13 @@
+package quorum
+
+// Config holds configurable properties.
+type Config struct {
+	// ElectrumURLs are URLs of Electrum servers used as additional backends
+	// of the quorum, next to the primary backend of the Bitcoin chain client.
+	// URLs must be in format: `scheme://hostname:port`. The quorum is
+	// disabled if the list is empty.
+	ElectrumURLs []string
+	// Threshold is the minimum number of backends that must agree on a
+	// result. If zero, a simple majority of backends is required.
+	Threshold int
+}
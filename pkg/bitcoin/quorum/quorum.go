@@ -0,0 +1,609 @@
+package quorum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+var logger = log.Logger("keep-bitcoin-quorum")
+
+// Chain is a bitcoin.Chain decorator that fans each call out to multiple
+// backends and requires a quorum of them to agree on the result. It protects
+// the client against a single lying or lagging backend.
+//
+// Results of the following kinds are agreed on differently:
+//   - Transactions, spending transactions, block headers, Merkle proofs,
+//     transaction hashes and UTXO sets must be identical across at least
+//     threshold backends.
+//     Lists are compared regardless of the order of their elements.
+//   - Block heights, transaction confirmations and fee estimates are
+//     numbers that may slightly differ between honest backends, e.g. when
+//     one of them has not seen the latest block yet. The returned number is
+//     the greatest one reported, directly or by a greater number, by at least
+//     threshold backends. This way, a minority of backends cannot inflate
+//     the result.
+//
+// Transactions are broadcast through all backends and the broadcast is
+// considered successful if at least one backend accepted the transaction.
+type Chain struct {
+	backends  []bitcoin.Chain
+	threshold int
+
+	disagreementsCount   uint64
+	quorumFailuresCount  uint64
+	backendErrorsCount   uint64
+	backendDisagreements []uint64
+}
+
+// NewChain creates a new quorum Chain using the given backends. The threshold
+// is the minimum number of backends that must agree on a result. If the
+// threshold is zero, a simple majority of backends is required.
+func NewChain(backends []bitcoin.Chain, threshold int) (*Chain, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("at least one backend is required")
+	}
+
+	if threshold == 0 {
+		threshold = len(backends)/2 + 1
+	}
+
+	if threshold < 0 || threshold > len(backends) {
+		return nil, fmt.Errorf(
+			"threshold [%v] must be between 1 and the number of backends [%v]",
+			threshold,
+			len(backends),
+		)
+	}
+
+	return &Chain{
+		backends:             backends,
+		threshold:            threshold,
+		backendDisagreements: make([]uint64, len(backends)),
+	}, nil
+}
+
+// BackendsCount returns the number of backends of the quorum.
+func (c *Chain) BackendsCount() int {
+	return len(c.backends)
+}
+
+// DisagreementsCount returns the number of calls for which successful
+// responses of backends were not unanimous.
+func (c *Chain) DisagreementsCount() uint64 {
+	return atomic.LoadUint64(&c.disagreementsCount)
+}
+
+// QuorumFailuresCount returns the number of calls that failed because the
+// threshold number of backends did not agree on the result.
+func (c *Chain) QuorumFailuresCount() uint64 {
+	return atomic.LoadUint64(&c.quorumFailuresCount)
+}
+
+// BackendErrorsCount returns the number of errors returned by all backends.
+func (c *Chain) BackendErrorsCount() uint64 {
+	return atomic.LoadUint64(&c.backendErrorsCount)
+}
+
+// BackendDisagreementsCount returns the number of calls for which the backend
+// with the given index returned a result different from the agreed one.
+func (c *Chain) BackendDisagreementsCount(index int) uint64 {
+	return atomic.LoadUint64(&c.backendDisagreements[index])
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error.
+func (c *Chain) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	return agreeOnResult(
+		c,
+		"GetTransaction",
+		fanOut(c, func(backend bitcoin.Chain) (*bitcoin.Transaction, error) {
+			return backend.GetTransaction(transactionHash)
+		}),
+		transactionKey,
+	)
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+func (c *Chain) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	return agreeOnNumber(
+		c,
+		"GetTransactionConfirmations",
+		fanOut(c, func(backend bitcoin.Chain) (uint, error) {
+			return backend.GetTransactionConfirmations(transactionHash)
+		}),
+	)
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes. If the broadcast action could not be
+// done, this function returns an error. This function does not give any
+// guarantees regarding transaction mining. The transaction may be mined or
+// rejected eventually.
+func (c *Chain) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	responses := fanOut(c, func(backend bitcoin.Chain) (bool, error) {
+		return true, backend.BroadcastTransaction(transaction)
+	})
+
+	errs := make([]string, 0)
+	for i, response := range responses {
+		if response.err != nil {
+			c.recordBackendError("BroadcastTransaction", i, response.err)
+			errs = append(errs, response.err.Error())
+		}
+	}
+
+	// A single backend accepting the transaction is enough as the
+	// transaction is propagated over the network anyway.
+	if len(errs) == len(responses) {
+		return fmt.Errorf(
+			"all backends failed to broadcast the transaction: [%s]",
+			strings.Join(errs, "; "),
+		)
+	}
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error.
+func (c *Chain) GetLatestBlockHeight() (uint, error) {
+	return agreeOnNumber(
+		c,
+		"GetLatestBlockHeight",
+		fanOut(c, func(backend bitcoin.Chain) (uint, error) {
+			return backend.GetLatestBlockHeight()
+		}),
+	)
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error.
+func (c *Chain) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	return agreeOnResult(
+		c,
+		"GetBlockHeader",
+		fanOut(c, func(backend bitcoin.Chain) (*bitcoin.BlockHeader, error) {
+			return backend.GetBlockHeader(blockHeight)
+		}),
+		func(blockHeader *bitcoin.BlockHeader) string {
+			serialized := blockHeader.Serialize()
+			return hex.EncodeToString(serialized[:])
+		},
+	)
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided.
+func (c *Chain) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	return agreeOnResult(
+		c,
+		"GetTransactionMerkleProof",
+		fanOut(
+			c,
+			func(backend bitcoin.Chain) (*bitcoin.TransactionMerkleProof, error) {
+				return backend.GetTransactionMerkleProof(
+					transactionHash,
+					blockHeight,
+				)
+			},
+		),
+		func(proof *bitcoin.TransactionMerkleProof) string {
+			return fmt.Sprintf(
+				"%v:%v:%s",
+				proof.BlockHeight,
+				proof.Position,
+				strings.Join(proof.MerkleNodes, ","),
+			)
+		},
+	)
+}
+
+// GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. Note that taking an unlimited
+// transaction history may be time-consuming as this function fetches
+// complete transactions with all necessary data.
+func (c *Chain) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	return agreeOnResult(
+		c,
+		"GetTransactionsForPublicKeyHash",
+		fanOut(c, func(backend bitcoin.Chain) ([]*bitcoin.Transaction, error) {
+			return backend.GetTransactionsForPublicKeyHash(publicKeyHash, limit)
+		}),
+		transactionsKey,
+	)
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request.
+func (c *Chain) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	return agreeOnResult(
+		c,
+		"GetTxHashesForPublicKeyHash",
+		fanOut(c, func(backend bitcoin.Chain) ([]bitcoin.Hash, error) {
+			return backend.GetTxHashesForPublicKeyHash(publicKeyHash)
+		}),
+		func(txHashes []bitcoin.Hash) string {
+			keys := make([]string, len(txHashes))
+			for i, txHash := range txHashes {
+				keys[i] = txHash.Hex(bitcoin.InternalByteOrder)
+			}
+			return setKey(keys)
+		},
+	)
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order.
+func (c *Chain) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	return agreeOnResult(
+		c,
+		"GetMempoolForPublicKeyHash",
+		fanOut(c, func(backend bitcoin.Chain) ([]*bitcoin.Transaction, error) {
+			return backend.GetMempoolForPublicKeyHash(publicKeyHash)
+		}),
+		transactionsKey,
+	)
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+func (c *Chain) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return agreeOnResult(
+		c,
+		"GetUtxosForPublicKeyHash",
+		fanOut(
+			c,
+			func(backend bitcoin.Chain) ([]*bitcoin.UnspentTransactionOutput, error) {
+				return backend.GetUtxosForPublicKeyHash(publicKeyHash)
+			},
+		),
+		utxosKey,
+	)
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs.
+func (c *Chain) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return agreeOnResult(
+		c,
+		"GetMempoolUtxosForPublicKeyHash",
+		fanOut(
+			c,
+			func(backend bitcoin.Chain) ([]*bitcoin.UnspentTransactionOutput, error) {
+				return backend.GetMempoolUtxosForPublicKeyHash(publicKeyHash)
+			},
+		),
+		utxosKey,
+	)
+}
+
+// GetSpendingTransaction gets the confirmed transaction spending the given
+// outpoint. The returned bool value is false if the outpoint has not been
+// spent by any confirmed transaction. Spending transactions living in the
+// mempool at the moment of request are not taken into account.
+func (c *Chain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	result, err := agreeOnResult(
+		c,
+		"GetSpendingTransaction",
+		fanOut(c, func(backend bitcoin.Chain) (*spendingTransaction, error) {
+			transaction, found, err := backend.GetSpendingTransaction(outpoint)
+			if err != nil {
+				return nil, err
+			}
+			return &spendingTransaction{transaction, found}, nil
+		}),
+		func(result *spendingTransaction) string {
+			if !result.found {
+				return ""
+			}
+			return transactionKey(result.transaction)
+		},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result.transaction, result.found, nil
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Chain) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	return agreeOnNumber(
+		c,
+		"EstimateSatPerVByteFee",
+		fanOut(c, func(backend bitcoin.Chain) (int64, error) {
+			return backend.EstimateSatPerVByteFee(blocks)
+		}),
+	)
+}
+
+// GetCoinbaseTxHash gets the hash of the coinbase transaction for the given
+// block height.
+func (c *Chain) GetCoinbaseTxHash(blockHeight uint) (bitcoin.Hash, error) {
+	return agreeOnResult(
+		c,
+		"GetCoinbaseTxHash",
+		fanOut(c, func(backend bitcoin.Chain) (bitcoin.Hash, error) {
+			return backend.GetCoinbaseTxHash(blockHeight)
+		}),
+		func(txHash bitcoin.Hash) string {
+			return txHash.Hex(bitcoin.InternalByteOrder)
+		},
+	)
+}
+
+// response holds the outcome of a call executed against a single backend.
+type response[K interface{}] struct {
+	result K
+	err    error
+}
+
+// fanOut executes the given request against all backends concurrently and
+// returns their responses, in the order of backends.
+func fanOut[K interface{}](
+	c *Chain,
+	requestFn func(backend bitcoin.Chain) (K, error),
+) []*response[K] {
+	responses := make([]*response[K], len(c.backends))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(c.backends))
+
+	for i, backend := range c.backends {
+		go func(i int, backend bitcoin.Chain) {
+			defer wg.Done()
+
+			result, err := requestFn(backend)
+			responses[i] = &response[K]{result, err}
+		}(i, backend)
+	}
+
+	wg.Wait()
+
+	return responses
+}
+
+// agreeOnResult returns the result that is identical, according to the given
+// key function, across at least threshold responses. If there is no such
+// result, an error is returned.
+func agreeOnResult[K interface{}](
+	c *Chain,
+	method string,
+	responses []*response[K],
+	keyFn func(K) string,
+) (K, error) {
+	var agreed K
+
+	keys := make([]string, len(responses))
+	votes := make(map[string]int)
+	errs := make([]string, 0)
+
+	for i, response := range responses {
+		if response.err != nil {
+			c.recordBackendError(method, i, response.err)
+			errs = append(errs, response.err.Error())
+			continue
+		}
+
+		keys[i] = keyFn(response.result)
+		votes[keys[i]]++
+	}
+
+	agreedKey, agreedVotes := "", 0
+	for i, response := range responses {
+		// Iterate over responses instead of the votes map to make the
+		// selection deterministic in case of a tie.
+		if response.err == nil && votes[keys[i]] > agreedVotes {
+			agreed, agreedKey, agreedVotes = response.result, keys[i], votes[keys[i]]
+		}
+	}
+
+	if len(votes) > 1 {
+		atomic.AddUint64(&c.disagreementsCount, 1)
+
+		for i, response := range responses {
+			if response.err == nil && keys[i] != agreedKey {
+				atomic.AddUint64(&c.backendDisagreements[i], 1)
+			}
+		}
+
+		logger.Warnf(
+			"backends returned [%v] different results for [%s]; "+
+				"the most common result was returned by [%v] backends",
+			len(votes),
+			method,
+			agreedVotes,
+		)
+	}
+
+	if agreedVotes < c.threshold {
+		atomic.AddUint64(&c.quorumFailuresCount, 1)
+
+		var zero K
+		return zero, fmt.Errorf(
+			"quorum not reached for [%s]; the most common result was "+
+				"returned by [%v] backends while [%v] are required; "+
+				"backend errors: [%s]",
+			method,
+			agreedVotes,
+			c.threshold,
+			strings.Join(errs, "; "),
+		)
+	}
+
+	return agreed, nil
+}
+
+// agreeOnNumber returns the greatest number such that at least threshold
+// responses reported this number or a greater one. If there are not enough
+// successful responses, an error is returned.
+func agreeOnNumber[K uint | int64](
+	c *Chain,
+	method string,
+	responses []*response[K],
+) (K, error) {
+	values := make([]K, 0)
+	errs := make([]string, 0)
+
+	for i, response := range responses {
+		if response.err != nil {
+			c.recordBackendError(method, i, response.err)
+			errs = append(errs, response.err.Error())
+			continue
+		}
+
+		values = append(values, response.result)
+	}
+
+	if len(values) < c.threshold {
+		atomic.AddUint64(&c.quorumFailuresCount, 1)
+
+		return 0, fmt.Errorf(
+			"quorum not reached for [%s]; [%v] backends responded while "+
+				"[%v] are required; backend errors: [%s]",
+			method,
+			len(values),
+			c.threshold,
+			strings.Join(errs, "; "),
+		)
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+
+	agreed := values[c.threshold-1]
+
+	if values[0] != values[len(values)-1] {
+		atomic.AddUint64(&c.disagreementsCount, 1)
+
+		for i, response := range responses {
+			if response.err == nil && response.result != agreed {
+				atomic.AddUint64(&c.backendDisagreements[i], 1)
+			}
+		}
+
+		logger.Warnf(
+			"backends returned values between [%v] and [%v] for [%s]; "+
+				"returning [%v]",
+			values[len(values)-1],
+			values[0],
+			method,
+			agreed,
+		)
+	}
+
+	return agreed, nil
+}
+
+func (c *Chain) recordBackendError(method string, index int, err error) {
+	atomic.AddUint64(&c.backendErrorsCount, 1)
+
+	logger.Warnf(
+		"backend [%v] failed to execute [%s]: [%v]",
+		index,
+		method,
+		err,
+	)
+}
+
+// spendingTransaction is a result of the spending transaction query
+// returned by a single backend.
+type spendingTransaction struct {
+	transaction *bitcoin.Transaction
+	found       bool
+}
+
+// transactionKey returns the key identifying the given transaction along
+// with all its data, including witnesses.
+func transactionKey(transaction *bitcoin.Transaction) string {
+	return hex.EncodeToString(transaction.Serialize(bitcoin.Witness))
+}
+
+// transactionsKey returns the key identifying the given set of transactions.
+func transactionsKey(transactions []*bitcoin.Transaction) string {
+	keys := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		keys[i] = transactionKey(transaction)
+	}
+	return setKey(keys)
+}
+
+// utxosKey returns the key identifying the given set of UTXOs.
+func utxosKey(utxos []*bitcoin.UnspentTransactionOutput) string {
+	keys := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		keys[i] = fmt.Sprintf(
+			"%s:%v:%v",
+			utxo.Outpoint.TransactionHash.Hex(bitcoin.InternalByteOrder),
+			utxo.Outpoint.OutputIndex,
+			utxo.Value,
+		)
+	}
+	return setKey(keys)
+}
+
+// setKey returns a key identifying the given elements regardless of their
+// order.
+func setKey(keys []string) string {
+	sorted := make([]string, len(keys))
+	copy(sorted, keys)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
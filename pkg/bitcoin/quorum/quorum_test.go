@@ -0,0 +1,528 @@
+package quorum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+
+	testData "github.com/keep-network/keep-core/internal/testdata/bitcoin"
+)
+
+func TestNewChain(t *testing.T) {
+	var tests = map[string]struct {
+		backendsCount     int
+		threshold         int
+		expectedThreshold int
+		expectedError     error
+	}{
+		"default threshold for odd number of backends": {
+			backendsCount:     3,
+			threshold:         0,
+			expectedThreshold: 2,
+		},
+		"default threshold for even number of backends": {
+			backendsCount:     4,
+			threshold:         0,
+			expectedThreshold: 3,
+		},
+		"custom threshold": {
+			backendsCount:     4,
+			threshold:         4,
+			expectedThreshold: 4,
+		},
+		"threshold greater than the number of backends": {
+			backendsCount: 3,
+			threshold:     4,
+			expectedError: fmt.Errorf(
+				"threshold [4] must be between 1 and the number of backends [3]",
+			),
+		},
+		"negative threshold": {
+			backendsCount: 3,
+			threshold:     -1,
+			expectedError: fmt.Errorf(
+				"threshold [-1] must be between 1 and the number of backends [3]",
+			),
+		},
+		"no backends": {
+			backendsCount: 0,
+			threshold:     0,
+			expectedError: fmt.Errorf("at least one backend is required"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backends := make([]bitcoin.Chain, test.backendsCount)
+			for i := range backends {
+				backends[i] = &stubChain{}
+			}
+
+			chain, err := NewChain(backends, test.threshold)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedError == nil {
+				testutils.AssertIntsEqual(
+					t,
+					"threshold",
+					test.expectedThreshold,
+					chain.threshold,
+				)
+			}
+		})
+	}
+}
+
+func TestChain_GetLatestBlockHeight(t *testing.T) {
+	var tests = map[string]struct {
+		blockHeights                 []uint
+		errs                         []error
+		expectedBlockHeight          uint
+		expectedError                string
+		expectedDisagreements        uint64
+		expectedBackendDisagreements []uint64
+	}{
+		"unanimous backends": {
+			blockHeights:                 []uint{100, 100, 100},
+			errs:                         []error{nil, nil, nil},
+			expectedBlockHeight:          100,
+			expectedDisagreements:        0,
+			expectedBackendDisagreements: []uint64{0, 0, 0},
+		},
+		"one backend ahead": {
+			blockHeights:                 []uint{100, 101, 100},
+			errs:                         []error{nil, nil, nil},
+			expectedBlockHeight:          100,
+			expectedDisagreements:        1,
+			expectedBackendDisagreements: []uint64{0, 1, 0},
+		},
+		"one backend lagging": {
+			blockHeights:                 []uint{101, 100, 101},
+			errs:                         []error{nil, nil, nil},
+			expectedBlockHeight:          101,
+			expectedDisagreements:        1,
+			expectedBackendDisagreements: []uint64{0, 1, 0},
+		},
+		"one backend lying": {
+			blockHeights:                 []uint{100, 999999, 101},
+			errs:                         []error{nil, nil, nil},
+			expectedBlockHeight:          101,
+			expectedDisagreements:        1,
+			expectedBackendDisagreements: []uint64{1, 1, 0},
+		},
+		"one backend failing": {
+			blockHeights:                 []uint{100, 0, 100},
+			errs:                         []error{nil, fmt.Errorf("unavailable"), nil},
+			expectedBlockHeight:          100,
+			expectedDisagreements:        0,
+			expectedBackendDisagreements: []uint64{0, 0, 0},
+		},
+		"two backends failing": {
+			blockHeights: []uint{100, 0, 0},
+			errs: []error{
+				nil,
+				fmt.Errorf("unavailable"),
+				fmt.Errorf("unavailable"),
+			},
+			expectedError: "quorum not reached for [GetLatestBlockHeight]; " +
+				"[1] backends responded while [2] are required; " +
+				"backend errors: [unavailable; unavailable]",
+			expectedBackendDisagreements: []uint64{0, 0, 0},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backends := make([]bitcoin.Chain, len(test.blockHeights))
+			for i := range backends {
+				backends[i] = &stubChain{
+					blockHeight: test.blockHeights[i],
+					err:         test.errs[i],
+				}
+			}
+
+			chain, err := NewChain(backends, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			blockHeight, err := chain.GetLatestBlockHeight()
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+
+				testutils.AssertUintsEqual(
+					t,
+					"quorum failures count",
+					1,
+					chain.QuorumFailuresCount(),
+				)
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				testutils.AssertUintsEqual(
+					t,
+					"block height",
+					uint64(test.expectedBlockHeight),
+					uint64(blockHeight),
+				)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"disagreements count",
+				test.expectedDisagreements,
+				chain.DisagreementsCount(),
+			)
+
+			for i, expected := range test.expectedBackendDisagreements {
+				testutils.AssertUintsEqual(
+					t,
+					fmt.Sprintf("backend [%v] disagreements count", i),
+					expected,
+					chain.BackendDisagreementsCount(i),
+				)
+			}
+		})
+	}
+}
+
+func TestChain_GetTransaction(t *testing.T) {
+	transactionA :=
+		testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"].BitcoinTx
+	transactionB :=
+		testData.Transactions[bitcoin.Testnet]["input: P2PKH, output: P2SH, P2WPKH"].BitcoinTx
+
+	t.Run("quorum reached", func(t *testing.T) {
+		chain, err := NewChain(
+			[]bitcoin.Chain{
+				&stubChain{transaction: &transactionA},
+				&stubChain{transaction: &transactionB},
+				&stubChain{transaction: &transactionA},
+			},
+			2,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		transaction, err := chain.GetTransaction(transactionA.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(&transactionA, transaction) {
+			t.Errorf(
+				"unexpected transaction\nexpected: [%+v]\nactual:   [%+v]",
+				&transactionA,
+				transaction,
+			)
+		}
+
+		testutils.AssertUintsEqual(
+			t,
+			"disagreements count",
+			1,
+			chain.DisagreementsCount(),
+		)
+		testutils.AssertUintsEqual(
+			t,
+			"backend [1] disagreements count",
+			1,
+			chain.BackendDisagreementsCount(1),
+		)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		chain, err := NewChain(
+			[]bitcoin.Chain{
+				&stubChain{transaction: &transactionA},
+				&stubChain{transaction: &transactionB},
+				&stubChain{err: fmt.Errorf("unavailable")},
+			},
+			2,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = chain.GetTransaction(transactionA.Hash())
+
+		expectedError := "quorum not reached for [GetTransaction]; the most " +
+			"common result was returned by [1] backends while [2] are " +
+			"required; backend errors: [unavailable]"
+		if err == nil || err.Error() != expectedError {
+			t.Errorf(
+				"unexpected error\nexpected: [%v]\nactual:   [%v]",
+				expectedError,
+				err,
+			)
+		}
+
+		testutils.AssertUintsEqual(
+			t,
+			"quorum failures count",
+			1,
+			chain.QuorumFailuresCount(),
+		)
+		testutils.AssertUintsEqual(
+			t,
+			"backend errors count",
+			1,
+			chain.BackendErrorsCount(),
+		)
+	})
+}
+
+func TestChain_GetSpendingTransaction(t *testing.T) {
+	transaction :=
+		testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"].BitcoinTx
+
+	var tests = map[string]struct {
+		backends            []*stubChain
+		expectedTransaction *bitcoin.Transaction
+		expectedFound       bool
+	}{
+		"spent according to the quorum": {
+			backends: []*stubChain{
+				{transaction: &transaction},
+				{},
+				{transaction: &transaction},
+			},
+			expectedTransaction: &transaction,
+			expectedFound:       true,
+		},
+		"unspent according to the quorum": {
+			backends: []*stubChain{
+				{},
+				{transaction: &transaction},
+				{},
+			},
+			expectedTransaction: nil,
+			expectedFound:       false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain, err := NewChain(toChains(test.backends), 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actualTransaction, found, err := chain.GetSpendingTransaction(
+				transaction.Inputs[0].Outpoint,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(t, "found", test.expectedFound, found)
+
+			if !reflect.DeepEqual(test.expectedTransaction, actualTransaction) {
+				t.Errorf(
+					"unexpected transaction\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedTransaction,
+					actualTransaction,
+				)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"disagreements count",
+				1,
+				chain.DisagreementsCount(),
+			)
+		})
+	}
+}
+
+func TestChain_GetUtxosForPublicKeyHash(t *testing.T) {
+	utxoA := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0x01},
+			OutputIndex:     0,
+		},
+		Value: 1000,
+	}
+	utxoB := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0x02},
+			OutputIndex:     1,
+		},
+		Value: 2000,
+	}
+	utxoC := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0x02},
+			OutputIndex:     1,
+		},
+		Value: 2001,
+	}
+
+	chain, err := NewChain(
+		[]bitcoin.Chain{
+			&stubChain{utxos: []*bitcoin.UnspentTransactionOutput{utxoA, utxoB}},
+			// The same set in a different order is considered equal.
+			&stubChain{utxos: []*bitcoin.UnspentTransactionOutput{utxoB, utxoA}},
+			&stubChain{utxos: []*bitcoin.UnspentTransactionOutput{utxoA, utxoC}},
+		},
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utxos, err := chain.GetUtxosForPublicKeyHash([20]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []*bitcoin.UnspentTransactionOutput{utxoA, utxoB}
+	if !reflect.DeepEqual(expectedUtxos, utxos) {
+		t.Errorf(
+			"unexpected UTXOs\nexpected: [%+v]\nactual:   [%+v]",
+			expectedUtxos,
+			utxos,
+		)
+	}
+
+	for i, expected := range []uint64{0, 0, 1} {
+		testutils.AssertUintsEqual(
+			t,
+			fmt.Sprintf("backend [%v] disagreements count", i),
+			expected,
+			chain.BackendDisagreementsCount(i),
+		)
+	}
+}
+
+func TestChain_BroadcastTransaction(t *testing.T) {
+	transaction :=
+		testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"].BitcoinTx
+
+	t.Run("some backends failed", func(t *testing.T) {
+		backends := []*stubChain{
+			{err: fmt.Errorf("rejected")},
+			{},
+			{err: fmt.Errorf("unavailable")},
+		}
+
+		chain, err := NewChain(toChains(backends), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = chain.BroadcastTransaction(&transaction)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, backend := range backends {
+			testutils.AssertIntsEqual(
+				t,
+				fmt.Sprintf("backend [%v] broadcasts count", i),
+				1,
+				backend.broadcastsCount,
+			)
+		}
+
+		testutils.AssertUintsEqual(
+			t,
+			"backend errors count",
+			2,
+			chain.BackendErrorsCount(),
+		)
+	})
+
+	t.Run("all backends failed", func(t *testing.T) {
+		backends := []*stubChain{
+			{err: fmt.Errorf("rejected")},
+			{err: fmt.Errorf("unavailable")},
+		}
+
+		chain, err := NewChain(toChains(backends), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = chain.BroadcastTransaction(&transaction)
+
+		expectedError := "all backends failed to broadcast the transaction"
+		if err == nil || !strings.Contains(err.Error(), expectedError) {
+			t.Errorf(
+				"unexpected error\nexpected: containing [%v]\nactual:   [%v]",
+				expectedError,
+				err,
+			)
+		}
+	})
+}
+
+// stubChain is a bitcoin.Chain backend returning preconfigured results.
+// Methods not used in tests are not implemented and panic if called.
+type stubChain struct {
+	bitcoin.Chain
+
+	blockHeight     uint
+	transaction     *bitcoin.Transaction
+	utxos           []*bitcoin.UnspentTransactionOutput
+	err             error
+	broadcastsCount int
+}
+
+func (sc *stubChain) GetLatestBlockHeight() (uint, error) {
+	return sc.blockHeight, sc.err
+}
+
+func (sc *stubChain) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	return sc.transaction, sc.err
+}
+
+func (sc *stubChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	return sc.transaction, sc.transaction != nil, sc.err
+}
+
+func (sc *stubChain) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return sc.utxos, sc.err
+}
+
+func (sc *stubChain) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	sc.broadcastsCount++
+	return sc.err
+}
+
+func toChains(backends []*stubChain) []bitcoin.Chain {
+	chains := make([]bitcoin.Chain, len(backends))
+	for i, backend := range backends {
+		chains[i] = backend
+	}
+	return chains
+}
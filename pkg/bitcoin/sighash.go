@@ -0,0 +1,149 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SignatureHashAllType is the only signature hash type used by tBTC wallets.
+// It denotes that the signature commits to all inputs and outputs of the
+// transaction.
+const SignatureHashAllType = 0x01
+
+// ComputeSignatureHashPreimage computes the preimage of the signature hash
+// of the given input, assuming the SIGHASH_ALL signature hash type. The
+// signature hash itself is the double SHA-256 of the returned preimage.
+// The scriptCode is the script being satisfied by the input, i.e. the P2PKH
+// script for P2PKH and P2WPKH inputs and the redeem or witness script for
+// P2SH and P2WSH inputs, respectively. The value is the value of the spent
+// output and is taken into account only for witness inputs. The witness
+// flag determines whether the BIP-143 preimage format should be used.
+func (t *Transaction) ComputeSignatureHashPreimage(
+	inputIndex int,
+	scriptCode Script,
+	value int64,
+	witness bool,
+) ([]byte, error) {
+	if inputIndex < 0 || inputIndex >= len(t.Inputs) {
+		return nil, fmt.Errorf("input index [%v] out of range", inputIndex)
+	}
+
+	if witness {
+		return t.computeWitnessSignatureHashPreimage(
+			inputIndex,
+			scriptCode,
+			value,
+		)
+	}
+
+	return t.computeLegacySignatureHashPreimage(inputIndex, scriptCode)
+}
+
+// computeLegacySignatureHashPreimage computes the preimage of the signature
+// hash of the given non-witness input.
+func (t *Transaction) computeLegacySignatureHashPreimage(
+	inputIndex int,
+	scriptCode Script,
+) ([]byte, error) {
+	internal := newInternalTransaction()
+	internal.fromTransaction(t)
+
+	// All input scripts are cleared, except for the signed input whose
+	// script is replaced with the script code. Witness data are not
+	// part of the legacy preimage.
+	for i, txIn := range internal.TxIn {
+		txIn.Witness = nil
+
+		if i == inputIndex {
+			txIn.SignatureScript = scriptCode
+		} else {
+			txIn.SignatureScript = nil
+		}
+	}
+
+	buffer := bytes.NewBuffer(
+		make([]byte, 0, internal.SerializeSizeStripped()+4),
+	)
+	if err := internal.SerializeNoWitness(buffer); err != nil {
+		return nil, fmt.Errorf("cannot serialize transaction: [%v]", err)
+	}
+
+	sigHashType := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sigHashType, SignatureHashAllType)
+	buffer.Write(sigHashType)
+
+	return buffer.Bytes(), nil
+}
+
+// computeWitnessSignatureHashPreimage computes the preimage of the signature
+// hash of the given witness input, according to BIP-143.
+func (t *Transaction) computeWitnessSignatureHashPreimage(
+	inputIndex int,
+	scriptCode Script,
+	value int64,
+) ([]byte, error) {
+	uint32Bytes := func(value uint32) []byte {
+		result := make([]byte, 4)
+		binary.LittleEndian.PutUint32(result, value)
+		return result
+	}
+
+	doubleSha256 := func(data []byte) []byte {
+		first := sha256.Sum256(data)
+		second := sha256.Sum256(first[:])
+		return second[:]
+	}
+
+	var prevouts, sequences, outputs bytes.Buffer
+
+	for _, input := range t.Inputs {
+		prevouts.Write(input.Outpoint.TransactionHash[:])
+		prevouts.Write(uint32Bytes(input.Outpoint.OutputIndex))
+
+		sequences.Write(uint32Bytes(input.Sequence))
+	}
+
+	for _, output := range t.Outputs {
+		err := wire.WriteTxOut(
+			&outputs,
+			0,
+			0,
+			&wire.TxOut{Value: output.Value, PkScript: output.PublicKeyScript},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot serialize output: [%v]", err)
+		}
+	}
+
+	input := t.Inputs[inputIndex]
+
+	var preimage bytes.Buffer
+
+	version := t.SerializeVersion()
+	preimage.Write(version[:])
+	preimage.Write(doubleSha256(prevouts.Bytes()))
+	preimage.Write(doubleSha256(sequences.Bytes()))
+	preimage.Write(input.Outpoint.TransactionHash[:])
+	preimage.Write(uint32Bytes(input.Outpoint.OutputIndex))
+
+	if err := wire.WriteVarBytes(&preimage, 0, scriptCode); err != nil {
+		return nil, fmt.Errorf("cannot serialize script code: [%v]", err)
+	}
+
+	valueBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(valueBytes, uint64(value))
+	preimage.Write(valueBytes)
+
+	preimage.Write(uint32Bytes(input.Sequence))
+	preimage.Write(doubleSha256(outputs.Bytes()))
+
+	locktime := t.SerializeLocktime()
+	preimage.Write(locktime[:])
+	preimage.Write(uint32Bytes(SignatureHashAllType))
+
+	return preimage.Bytes(), nil
+}
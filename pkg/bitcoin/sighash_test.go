@@ -0,0 +1,151 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+func TestTransaction_ComputeSignatureHashPreimage(t *testing.T) {
+	publicKeyHash := [20]byte{0x01, 0x02, 0x03}
+
+	p2pkh, err := PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p2wpkh, err := PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Arbitrary script standing for a redeem or witness script.
+	script := Script{0x51, 0x52, 0x93, 0x53, 0x87}
+
+	transaction := &Transaction{
+		Version: 1,
+		Inputs: []*TransactionInput{
+			{
+				Outpoint: &TransactionOutpoint{
+					TransactionHash: Hash{0x01},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{0x01, 0x02},
+				Sequence:        0xffffffff,
+			},
+			{
+				Outpoint: &TransactionOutpoint{
+					TransactionHash: Hash{0x02},
+					OutputIndex:     1,
+				},
+				Witness:  [][]byte{{0x03}, {0x04}},
+				Sequence: 0xfffffffe,
+			},
+			{
+				Outpoint: &TransactionOutpoint{
+					TransactionHash: Hash{0x03},
+					OutputIndex:     2,
+				},
+				Witness:  [][]byte{{0x05}, {0x06}, script},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*TransactionOutput{
+			{Value: 100000, PublicKeyScript: p2wpkh},
+			{Value: 200000, PublicKeyScript: p2pkh},
+		},
+		Locktime: 500,
+	}
+
+	internal := newInternalTransaction()
+	internal.fromTransaction(transaction)
+
+	var tests = map[string]struct {
+		inputIndex int
+		scriptCode Script
+		value      int64
+		witness    bool
+	}{
+		"legacy P2PKH input": {
+			inputIndex: 0,
+			scriptCode: p2pkh,
+			witness:    false,
+		},
+		"legacy P2SH input": {
+			inputIndex: 0,
+			scriptCode: script,
+			witness:    false,
+		},
+		"witness P2WPKH input": {
+			inputIndex: 1,
+			scriptCode: p2pkh,
+			value:      300000,
+			witness:    true,
+		},
+		"witness P2WSH input": {
+			inputIndex: 2,
+			scriptCode: script,
+			value:      400000,
+			witness:    true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			preimage, err := transaction.ComputeSignatureHashPreimage(
+				test.inputIndex,
+				test.scriptCode,
+				test.value,
+				test.witness,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expectedSigHash []byte
+			if test.witness {
+				expectedSigHash, err = txscript.CalcWitnessSigHash(
+					test.scriptCode,
+					txscript.NewTxSigHashes(internal.MsgTx),
+					txscript.SigHashAll,
+					internal.MsgTx,
+					test.inputIndex,
+					test.value,
+				)
+			} else {
+				expectedSigHash, err = txscript.CalcSignatureHash(
+					test.scriptCode,
+					txscript.SigHashAll,
+					internal.MsgTx,
+					test.inputIndex,
+				)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			preimageSha256 := sha256.Sum256(preimage)
+			actualSigHash := sha256.Sum256(preimageSha256[:])
+
+			testutils.AssertBytesEqual(t, expectedSigHash, actualSigHash[:])
+		})
+	}
+}
+
+func TestTransaction_ComputeSignatureHashPreimage_InputOutOfRange(t *testing.T) {
+	transaction := &Transaction{Version: 1}
+
+	_, err := transaction.ComputeSignatureHashPreimage(0, Script{}, 0, true)
+
+	expectedError := "input index [0] out of range"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
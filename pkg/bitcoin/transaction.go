@@ -183,6 +183,18 @@ func (t *Transaction) WitnessHash() Hash {
 	return ComputeHash(t.Serialize(Witness))
 }
 
+// VirtualSize calculates the transaction's virtual size in vbytes, as defined
+// by BIP-0141. The virtual size is the transaction weight divided by 4 and
+// rounded up, where the weight is the size of the Standard serialization
+// format multiplied by 3 plus the size of the Witness serialization format.
+// For reference, see:
+// https://github.com/bitcoin/bips/blob/master/bip-0141.mediawiki#transaction-size-calculations
+func (t *Transaction) VirtualSize() int64 {
+	weight := int64(3*len(t.Serialize(Standard)) + len(t.Serialize(Witness)))
+
+	return (weight + 3) / 4
+}
+
 // TransactionOutpoint represents a Bitcoin transaction outpoint.
 // For reference, see:
 // https://developer.bitcoin.org/reference/transactions.html#outpoint-the-specific-part-of-a-specific-output
@@ -21,6 +21,7 @@ type TransactionBuilder struct {
 	internal    *internalTransaction
 	sigHashArgs []*inputSigHashArgs
 	sigHashes   []*big.Int
+	replaceable bool
 }
 
 // NewTransactionBuilder constructs a new TransactionBuilder instance.
@@ -72,7 +73,9 @@ func (tb *TransactionBuilder) AddPublicKeyHashInput(
 
 	// Deliberately set both `signatureScript` and `witness` arguments to nil
 	// because at this point, the input does not contain any signature data.
-	tb.internal.AddTxIn(wire.NewTxIn(outpoint, nil, nil))
+	txIn := wire.NewTxIn(outpoint, nil, nil)
+	txIn.Sequence = tb.inputSequence()
+	tb.internal.AddTxIn(txIn)
 
 	tb.sigHashArgs = append(tb.sigHashArgs, sigHashArgs)
 
@@ -122,11 +125,14 @@ func (tb *TransactionBuilder) AddScriptHashInput(
 	// that requirement by putting the redeem script to the correct field and
 	// let the AddSignatures method prepend it with the actual signature
 	// and public key.
+	var txIn *wire.TxIn
 	if sigHashArgs.witness {
-		tb.internal.AddTxIn(wire.NewTxIn(outpoint, nil, [][]byte{redeemScript}))
+		txIn = wire.NewTxIn(outpoint, nil, [][]byte{redeemScript})
 	} else {
-		tb.internal.AddTxIn(wire.NewTxIn(outpoint, redeemScript, nil))
+		txIn = wire.NewTxIn(outpoint, redeemScript, nil)
 	}
+	txIn.Sequence = tb.inputSequence()
+	tb.internal.AddTxIn(txIn)
 
 	tb.sigHashArgs = append(tb.sigHashArgs, sigHashArgs)
 
@@ -156,6 +162,51 @@ func (tb *TransactionBuilder) AddOutput(output *TransactionOutput) {
 	tb.internal.AddTxOut(wire.NewTxOut(output.Value, output.PublicKeyScript))
 }
 
+// SetLocktime sets the locktime of the built transaction. The Bitcoin network
+// enforces the locktime only if at least one transaction input is non-final
+// so, a non-zero locktime makes all inputs non-final, including those that
+// are added after this call. This is required to unlock UTXOs locked using
+// a script containing OP_CHECKLOCKTIMEVERIFY. This function must be called
+// before computing the signature hashes.
+func (tb *TransactionBuilder) SetLocktime(locktime uint32) {
+	tb.internal.LockTime = locktime
+
+	for _, input := range tb.internal.TxIn {
+		input.Sequence = tb.inputSequence()
+	}
+}
+
+// SignalReplaceability makes the built transaction signal its
+// replaceability, according to BIP-125, by setting sequence numbers of all
+// transaction inputs, including those that are added after this call, to
+// a value lower than 0xfffffffe. Such a transaction can be replaced with
+// a transaction paying a higher fee by nodes enforcing the BIP-125 rules.
+// Inputs with such sequence numbers are also non-final so the locktime of
+// the transaction is enforced. This function must be called before computing
+// the signature hashes.
+func (tb *TransactionBuilder) SignalReplaceability() {
+	tb.replaceable = true
+
+	for _, input := range tb.internal.TxIn {
+		input.Sequence = tb.inputSequence()
+	}
+}
+
+// inputSequence returns the sequence number that should be set for
+// transaction inputs, according to the transaction replaceability and
+// the current transaction locktime.
+func (tb *TransactionBuilder) inputSequence() uint32 {
+	if tb.replaceable {
+		return wire.MaxTxInSequenceNum - 2
+	}
+
+	if tb.internal.LockTime != 0 {
+		return wire.MaxTxInSequenceNum - 1
+	}
+
+	return wire.MaxTxInSequenceNum
+}
+
 // ComputeSignatureHashes computes the signature hashes for all transaction
 // inputs and stores them into the builder's state. Elements of the returned
 // slice are ordered in the same way as the transaction inputs they correspond
@@ -298,6 +349,20 @@ func (tb *TransactionBuilder) AddSignatures(
 	return tb.internal.toTransaction(), nil
 }
 
+// UnsignedTransaction returns the transaction being built without any
+// signature data, i.e. with empty signature scripts and witnesses of all
+// inputs. This is useful to present the transaction before it is signed.
+func (tb *TransactionBuilder) UnsignedTransaction() *Transaction {
+	transaction := tb.internal.toTransaction()
+
+	for _, input := range transaction.Inputs {
+		input.SignatureScript = nil
+		input.Witness = nil
+	}
+
+	return transaction
+}
+
 // TotalInputsValue returns the total value of transaction inputs.
 func (tb *TransactionBuilder) TotalInputsValue() int64 {
 	totalInputsValue := int64(0)
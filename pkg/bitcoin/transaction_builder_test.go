@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/btcd/wire"
+
 	"github.com/keep-network/keep-core/internal/testutils"
 )
 
@@ -215,6 +217,75 @@ func TestTransactionBuilder_AddOutput(t *testing.T) {
 	assertInternalOutput(t, builder, 0, output)
 }
 
+func TestTransactionBuilder_SetLocktime(t *testing.T) {
+	builder := NewTransactionBuilder(nil) // chain is not relevant here
+
+	// Add an input directly to simulate an input added before the call.
+	builder.internal.AddTxIn(&wire.TxIn{Sequence: wire.MaxTxInSequenceNum})
+
+	builder.SetLocktime(1642708064)
+
+	testutils.AssertIntsEqual(
+		t,
+		"internal locktime",
+		1642708064,
+		int(builder.internal.LockTime),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"existing input sequence",
+		uint64(wire.MaxTxInSequenceNum-1),
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"new input sequence",
+		uint64(wire.MaxTxInSequenceNum-1),
+		uint64(builder.inputSequence()),
+	)
+
+	builder.SetLocktime(0)
+
+	testutils.AssertUintsEqual(
+		t,
+		"input sequence after locktime reset",
+		uint64(wire.MaxTxInSequenceNum),
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
+}
+
+func TestTransactionBuilder_SignalReplaceability(t *testing.T) {
+	builder := NewTransactionBuilder(nil) // chain is not relevant here
+
+	// Add an input directly to simulate an input added before the call.
+	builder.internal.AddTxIn(&wire.TxIn{Sequence: wire.MaxTxInSequenceNum})
+
+	builder.SignalReplaceability()
+
+	testutils.AssertUintsEqual(
+		t,
+		"existing input sequence",
+		uint64(wire.MaxTxInSequenceNum-2),
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"new input sequence",
+		uint64(wire.MaxTxInSequenceNum-2),
+		uint64(builder.inputSequence()),
+	)
+
+	// The locktime must not override the replaceability signal.
+	builder.SetLocktime(1642708064)
+
+	testutils.AssertUintsEqual(
+		t,
+		"input sequence after locktime set",
+		uint64(wire.MaxTxInSequenceNum-2),
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
+}
+
 // The goal of this test is making sure that the TransactionBuilder can
 // produce proper signature hashes and apply signatures for all input types,
 // i.e. P2PKH, P2WPKH, P2SH, and P2WSH. This test uses transactions that
@@ -165,6 +165,12 @@ func TestTransaction_WitnessHash(t *testing.T) {
 	)
 }
 
+func TestTransaction_VirtualSize(t *testing.T) {
+	virtualSize := transactionFixture(t).VirtualSize()
+
+	testutils.AssertIntsEqual(t, "virtual size", 443, int(virtualSize))
+}
+
 // transactionFixture returns a real testnet transaction:
 // https://live.blockcypher.com/btc-testnet/tx/435d4aff6d4bc34134877bd3213c17970142fdd04d4113d534120033b9eecb2e.
 //
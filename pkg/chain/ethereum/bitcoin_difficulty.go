@@ -12,6 +12,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/tbtc/gen/contract"
 	"github.com/keep-network/keep-core/pkg/maintainer"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 )
 
 // Definitions of contract names.
@@ -212,6 +213,55 @@ func (bdc *BitcoinDifficultyChain) RetargetWithRefund(headers []*bitcoin.BlockHe
 	return err
 }
 
+// EstimateRetargetWithRefund estimates the cost and refund of the retarget
+// submitted via LightRelayMaintainerProxy for the given headers.
+func (bdc *BitcoinDifficultyChain) EstimateRetargetWithRefund(
+	headers []*bitcoin.BlockHeader,
+) (*submission.Estimate, error) {
+	if bdc.lightRelayMaintainerProxy == nil {
+		return nil, fmt.Errorf(
+			"LightRelayMaintainerProxy is disabled; cannot estimate " +
+				"retarget with refund",
+		)
+	}
+
+	var serializedHeaders []byte
+	for _, header := range headers {
+		serializedHeader := header.Serialize()
+		serializedHeaders = append(serializedHeaders, serializedHeader[:]...)
+	}
+
+	gasEstimate, err := bdc.lightRelayMaintainerProxy.RetargetGasEstimate(
+		serializedHeaders,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to estimate gas for retarget with refund: [%w]",
+			err,
+		)
+	}
+
+	gasOffset, err := bdc.lightRelayMaintainerProxy.RetargetGasOffset()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retarget gas offset: [%w]", err)
+	}
+
+	reimbursementPoolAddress, err :=
+		bdc.lightRelayMaintainerProxy.ReimbursementPool()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get ReimbursementPool address: [%w]",
+			err,
+		)
+	}
+
+	return bdc.estimateReimbursedSubmission(
+		gasEstimate,
+		gasOffset,
+		reimbursementPoolAddress,
+	)
+}
+
 // CurrentEpoch returns the number of the latest difficulty epoch which is
 // proven to the relay. If the genesis epoch's number is set correctly, and
 // retargets along the way have been legitimate, this equals the height of
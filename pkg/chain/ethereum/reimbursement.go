@@ -0,0 +1,113 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
+)
+
+// reimbursementPoolABI is the part of the ReimbursementPool contract ABI
+// required to read the rules the pool uses to refund submitters.
+const reimbursementPoolABI = `[
+	{
+		"inputs": [],
+		"name": "maxGasPrice",
+		"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "staticGas",
+		"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// estimateReimbursedSubmission builds the estimate of a submission that is
+// reimbursed by the ReimbursementPool contract deployed under the given
+// address. The gasEstimate is the result of the submission call simulation
+// and the gasOffset is the gas the maintainer proxy adds on top of the gas
+// used by the submission.
+func (bc *baseChain) estimateReimbursedSubmission(
+	gasEstimate uint64,
+	gasOffset *big.Int,
+	reimbursementPoolAddress common.Address,
+) (*submission.Estimate, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(reimbursementPoolABI))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to parse ReimbursementPool ABI: [%v]",
+			err,
+		)
+	}
+
+	reimbursementPool := bind.NewBoundContract(
+		reimbursementPoolAddress,
+		parsedABI,
+		bc.client,
+		nil,
+		nil,
+	)
+
+	callUint256 := func(method string) (*big.Int, error) {
+		var out []interface{}
+		if err := reimbursementPool.Call(
+			&bind.CallOpts{},
+			&out,
+			method,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"failed to call ReimbursementPool.%s: [%v]",
+				method,
+				err,
+			)
+		}
+
+		return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+	}
+
+	staticGas, err := callUint256("staticGas")
+	if err != nil {
+		return nil, err
+	}
+
+	maxGasPrice, err := callUint256("maxGasPrice")
+	if err != nil {
+		return nil, err
+	}
+
+	poolBalance, err := bc.client.BalanceAt(
+		context.Background(),
+		reimbursementPoolAddress,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get ReimbursementPool balance: [%v]",
+			err,
+		)
+	}
+
+	gasPrice, err := bc.client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: [%v]", err)
+	}
+
+	return &submission.Estimate{
+		GasEstimate: gasEstimate,
+		GasOffset:   gasOffset.Uint64(),
+		GasPrice:    gasPrice,
+		StaticGas:   staticGas.Uint64(),
+		MaxGasPrice: maxGasPrice,
+		PoolBalance: poolBalance,
+	}, nil
+}
@@ -23,10 +23,12 @@ import (
 	tbtcabi "github.com/keep-network/keep-core/pkg/chain/ethereum/tbtc/gen/abi"
 	tbtccontract "github.com/keep-network/keep-core/pkg/chain/ethereum/tbtc/gen/contract"
 	"github.com/keep-network/keep-core/pkg/internal/byteutils"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/subscription"
 	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
 )
 
@@ -957,6 +959,179 @@ func (tc *TbtcChain) DKGParameters() (*tbtc.DKGParameters, error) {
 	}, nil
 }
 
+func (tc *TbtcChain) GetInactivityClaimNonce(
+	walletID [32]byte,
+) (*big.Int, error) {
+	return tc.walletRegistry.InactivityClaimNonce(walletID)
+}
+
+// CalculateInactivityClaimHash calculates a 32-byte hash that is used
+// to produce a signature supporting the given inactivity claim of the wallet
+// with the given public key. The nonce parameter must be the current
+// inactivity claim nonce of the wallet.
+func (tc *TbtcChain) CalculateInactivityClaimHash(
+	walletPublicKey *ecdsa.PublicKey,
+	claim *tbtc.InactivityClaim,
+	nonce *big.Int,
+) (tbtc.InactivityClaimHash, error) {
+	walletPublicKeyBytes, err := convertPubKeyToChainFormat(walletPublicKey)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, fmt.Errorf(
+			"cannot convert wallet public key to chain format: [%v]",
+			err,
+		)
+	}
+
+	// Sort inactiveMembersIndexes slice in ascending order as expected
+	// by the on-chain contract.
+	inactiveMembersIndexes := make(
+		[]group.MemberIndex,
+		len(claim.InactiveMembersIndexes),
+	)
+	copy(inactiveMembersIndexes, claim.InactiveMembersIndexes)
+	sort.Slice(inactiveMembersIndexes, func(i, j int) bool {
+		return inactiveMembersIndexes[i] < inactiveMembersIndexes[j]
+	})
+
+	return calculateInactivityClaimHash(
+		tc.chainID,
+		nonce,
+		walletPublicKeyBytes[:],
+		inactiveMembersIndexes,
+		claim.HeartbeatFailed,
+	)
+}
+
+// calculateInactivityClaimHash computes the keccak256 hash for the given
+// inactivity claim parameters. It expects that the walletPublicKey is
+// a 64-byte uncompressed public key without the 04 prefix and
+// inactiveMembersIndexes slice is sorted in ascending order. Those
+// expectations are forced by the contract.
+func calculateInactivityClaimHash(
+	chainID *big.Int,
+	nonce *big.Int,
+	walletPublicKey []byte,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+) (tbtc.InactivityClaimHash, error) {
+	publicKeySize := 64
+
+	if len(walletPublicKey) != publicKeySize {
+		return tbtc.InactivityClaimHash{}, fmt.Errorf(
+			"wrong wallet public key length",
+		)
+	}
+
+	uint256Type, err := abi.NewType("uint256", "uint256", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	bytesType, err := abi.NewType("bytes", "bytes", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	uint256SliceType, err := abi.NewType("uint256[]", "uint256[]", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	boolType, err := abi.NewType("bool", "bool", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+
+	bytes, err := abi.Arguments{
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: bytesType},
+		{Type: uint256SliceType},
+		{Type: boolType},
+	}.Pack(
+		chainID,
+		nonce,
+		walletPublicKey,
+		convertMemberIndexesToChainFormat(inactiveMembersIndexes),
+		heartbeatFailed,
+	)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+
+	return tbtc.InactivityClaimHash(crypto.Keccak256Hash(bytes)), nil
+}
+
+// convertMemberIndexesToChainFormat converts the given member indexes to
+// the big integers expected by the on-chain contract.
+func convertMemberIndexesToChainFormat(
+	membersIndexes []group.MemberIndex,
+) []*big.Int {
+	result := make([]*big.Int, len(membersIndexes))
+	for i, memberIndex := range membersIndexes {
+		result[i] = big.NewInt(int64(memberIndex))
+	}
+
+	return result
+}
+
+func (tc *TbtcChain) SubmitInactivityClaim(
+	claim *tbtc.InactivityClaim,
+	nonce *big.Int,
+	signatures map[group.MemberIndex][]byte,
+	groupMembers chain.OperatorIDs,
+) error {
+	signingMembersIndexes, signaturesBytes, err := convertSignaturesToChainFormat(
+		signatures,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not convert signatures to chain format: [%v]",
+			err,
+		)
+	}
+
+	// Sort inactiveMembersIndexes slice in ascending order as expected
+	// by the on-chain contract.
+	inactiveMembersIndexes := make(
+		[]group.MemberIndex,
+		len(claim.InactiveMembersIndexes),
+	)
+	copy(inactiveMembersIndexes, claim.InactiveMembersIndexes)
+	sort.Slice(inactiveMembersIndexes, func(i, j int) bool {
+		return inactiveMembersIndexes[i] < inactiveMembersIndexes[j]
+	})
+
+	_, err = tc.walletRegistry.NotifyOperatorInactivity(
+		ecdsaabi.EcdsaInactivityClaim{
+			WalletID: claim.WalletID,
+			InactiveMembersIndices: convertMemberIndexesToChainFormat(
+				inactiveMembersIndexes,
+			),
+			HeartbeatFailed: claim.HeartbeatFailed,
+			Signatures:      signaturesBytes,
+			SigningMembersIndices: convertMemberIndexesToChainFormat(
+				signingMembersIndexes,
+			),
+		},
+		nonce,
+		groupMembers,
+	)
+
+	return err
+}
+
+func (tc *TbtcChain) IsWalletMember(
+	walletID [32]byte,
+	walletMembersIDs chain.OperatorIDs,
+	operator chain.Address,
+	walletMemberIndex group.MemberIndex,
+) (bool, error) {
+	return tc.walletRegistry.IsWalletMember(
+		walletID,
+		walletMembersIDs,
+		common.HexToAddress(operator.String()),
+		big.NewInt(int64(walletMemberIndex)),
+	)
+}
+
 func (tc *TbtcChain) PastDepositRevealedEvents(
 	filter *tbtc.DepositRevealedEventFilter,
 ) ([]*tbtc.DepositRevealedEvent, error) {
@@ -1121,6 +1296,151 @@ func (tc *TbtcChain) GetDepositRequest(
 	}, true, nil
 }
 
+func (tc *TbtcChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	// The moved funds sweep request key is built the same way as the
+	// deposit key, i.e. keccak256(movingFundsTxHash | movingFundsOutputIndex).
+	requestKey := buildDepositKey(movingFundsTxHash, movingFundsTxOutputIndex)
+
+	request, err := tc.bridge.MovedFundsSweepRequests(requestKey)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get moved funds sweep request for key [0x%x]: [%v]",
+			requestKey.Text(16),
+			err,
+		)
+	}
+
+	// Moved funds sweep request not found.
+	if request.CreatedAt == 0 {
+		return nil, false, nil
+	}
+
+	requestState, err := parseMovedFundsSweepRequestState(request.State)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot parse moved funds sweep request state: [%v]",
+			err,
+		)
+	}
+
+	return &tbtc.MovedFundsSweepRequest{
+		WalletPublicKeyHash: request.WalletPubKeyHash,
+		Value:               request.Value,
+		CreatedAt:           time.Unix(int64(request.CreatedAt), 0),
+		State:               requestState,
+	}, true, nil
+}
+
+func (tc *TbtcChain) IsMainUtxoSpent(
+	mainUtxoTxHash bitcoin.Hash,
+	mainUtxoTxOutputIndex uint32,
+) (bool, error) {
+	// The main UTXO key is built the same way as the deposit key, i.e.
+	// keccak256(mainUtxoTxHash | mainUtxoTxOutputIndex).
+	utxoKey := buildDepositKey(mainUtxoTxHash, mainUtxoTxOutputIndex)
+
+	isSpent, err := tc.bridge.SpentMainUTXOs(utxoKey)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot check spent main UTXO for key [0x%x]: [%v]",
+			utxoKey.Text(16),
+			err,
+		)
+	}
+
+	return isSpent, nil
+}
+
+func (tc *TbtcChain) GetFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) (*tbtc.FraudChallenge, bool, error) {
+	walletPublicKeyBytes, err := convertPubKeyToChainFormat(walletPublicKey)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot convert wallet public key to chain format: [%v]",
+			err,
+		)
+	}
+
+	challengeKey := crypto.Keccak256Hash(
+		append(walletPublicKeyBytes[:], sighash[:]...),
+	).Big()
+
+	challenge, err := tc.bridge.FraudChallenges(challengeKey)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get fraud challenge for key [0x%x]: [%v]",
+			challengeKey.Text(16),
+			err,
+		)
+	}
+
+	// Fraud challenge not found.
+	if challenge.ReportedAt == 0 {
+		return nil, false, nil
+	}
+
+	return &tbtc.FraudChallenge{
+		Challenger:    chain.Address(challenge.Challenger.Hex()),
+		DepositAmount: challenge.DepositAmount,
+		ReportedAt:    time.Unix(int64(challenge.ReportedAt), 0),
+		Resolved:      challenge.Resolved,
+	}, true, nil
+}
+
+func (tc *TbtcChain) SubmitFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	preimageSha256 [32]byte,
+	signature *tecdsa.Signature,
+) error {
+	walletPublicKeyBytes, err := convertPubKeyToChainFormat(walletPublicKey)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot convert wallet public key to chain format: [%v]",
+			err,
+		)
+	}
+
+	r, err := byteutils.LeftPadTo32Bytes(signature.R.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot convert signature R: [%v]", err)
+	}
+	s, err := byteutils.LeftPadTo32Bytes(signature.S.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot convert signature S: [%v]", err)
+	}
+
+	rsvSignature := tbtcabi.BitcoinTxRSVSignature{
+		// The Bridge uses ecrecover under the hood so the recovery ID must
+		// be converted to the Ethereum-specific V value.
+		V: uint8(27 + signature.RecoveryID),
+	}
+	copy(rsvSignature.R[:], r)
+	copy(rsvSignature.S[:], s)
+
+	fraudParameters, err := tc.bridge.FraudParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get fraud parameters: [%v]", err)
+	}
+
+	// The fraud challenge must be accompanied by a deposit.
+	_, err = tc.bridge.SubmitFraudChallenge(
+		walletPublicKeyBytes[:],
+		preimageSha256[:],
+		rsvSignature,
+		fraudParameters.FraudChallengeDepositAmount,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot submit fraud challenge: [%v]", err)
+	}
+
+	return nil
+}
+
 func (tc *TbtcChain) PastNewWalletRegisteredEvents(
 	filter *tbtc.NewWalletRegisteredEventFilter,
 ) ([]*tbtc.NewWalletRegisteredEvent, error) {
@@ -1167,6 +1487,96 @@ func (tc *TbtcChain) PastNewWalletRegisteredEvents(
 	return convertedEvents, err
 }
 
+func (tc *TbtcChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error) {
+	var startBlock uint64
+	var endBlock *uint64
+	var walletPublicKeyHash [][20]byte
+
+	if filter != nil {
+		startBlock = filter.StartBlock
+		endBlock = filter.EndBlock
+		walletPublicKeyHash = filter.WalletPublicKeyHash
+	}
+
+	events, err := tc.bridge.PastMovingFundsCommitmentSubmittedEvents(
+		startBlock,
+		endBlock,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedEvents := make([]*tbtc.MovingFundsCommitmentSubmittedEvent, 0)
+	for _, event := range events {
+		convertedEvent := &tbtc.MovingFundsCommitmentSubmittedEvent{
+			WalletPublicKeyHash: event.WalletPubKeyHash,
+			TargetWallets:       event.TargetWallets,
+			Submitter:           chain.Address(event.Submitter.Hex()),
+			BlockNumber:         event.Raw.BlockNumber,
+		}
+
+		convertedEvents = append(convertedEvents, convertedEvent)
+	}
+
+	sort.SliceStable(
+		convertedEvents,
+		func(i, j int) bool {
+			return convertedEvents[i].BlockNumber < convertedEvents[j].BlockNumber
+		},
+	)
+
+	return convertedEvents, err
+}
+
+func (tc *TbtcChain) PastMovingFundsCompletedEvents(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+) ([]*tbtc.MovingFundsCompletedEvent, error) {
+	var startBlock uint64
+	var endBlock *uint64
+	var walletPublicKeyHash [][20]byte
+
+	if filter != nil {
+		startBlock = filter.StartBlock
+		endBlock = filter.EndBlock
+		walletPublicKeyHash = filter.WalletPublicKeyHash
+	}
+
+	events, err := tc.bridge.PastMovingFundsCompletedEvents(
+		startBlock,
+		endBlock,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedEvents := make([]*tbtc.MovingFundsCompletedEvent, 0)
+	for _, event := range events {
+		// We can map the event.MovingFundsTxHash field directly to the
+		// bitcoin.Hash type. This is because the on-chain contract emits
+		// the hash in the bitcoin.InternalByteOrder.
+		convertedEvent := &tbtc.MovingFundsCompletedEvent{
+			WalletPublicKeyHash: event.WalletPubKeyHash,
+			MovingFundsTxHash:   event.MovingFundsTxHash,
+			BlockNumber:         event.Raw.BlockNumber,
+		}
+
+		convertedEvents = append(convertedEvents, convertedEvent)
+	}
+
+	sort.SliceStable(
+		convertedEvents,
+		func(i, j int) bool {
+			return convertedEvents[i].BlockNumber < convertedEvents[j].BlockNumber
+		},
+	)
+
+	return convertedEvents, err
+}
+
 func (tc *TbtcChain) GetWallet(
 	walletPublicKeyHash [20]byte,
 ) (*tbtc.WalletChainData, error) {
@@ -1230,6 +1640,26 @@ func computeMainUtxoHash(mainUtxo *bitcoin.UnspentTransactionOutput) [32]byte {
 	return mainUtxoHash
 }
 
+func (tc *TbtcChain) ComputeMovingFundsCommitmentHash(
+	targetWallets [][20]byte,
+) [32]byte {
+	return computeMovingFundsCommitmentHash(targetWallets)
+}
+
+func computeMovingFundsCommitmentHash(targetWallets [][20]byte) [32]byte {
+	// The Bridge contract computes the commitment hash using
+	// `keccak256(abi.encodePacked(targetWallets))`. Elements of arrays
+	// are padded to 32 bytes even in the packed encoding so each 20-byte
+	// target wallet must be right-padded with zeros.
+	packedWallets := make([]byte, 0, 32*len(targetWallets))
+	for _, targetWallet := range targetWallets {
+		packedWallets = append(packedWallets, targetWallet[:]...)
+		packedWallets = append(packedWallets, make([]byte, 12)...)
+	}
+
+	return crypto.Keccak256Hash(packedWallets)
+}
+
 func (tc *TbtcChain) BuildDepositKey(
 	fundingTxHash bitcoin.Hash,
 	fundingOutputIndex uint32,
@@ -1298,42 +1728,170 @@ func (tc *TbtcChain) GetPendingRedemptionRequest(
 	}, true, nil
 }
 
-func (tc *TbtcChain) SubmitRedemptionProofWithReimbursement(
-	transaction *bitcoin.Transaction,
-	proof *bitcoin.SpvProof,
-	mainUTXO bitcoin.UnspentTransactionOutput,
+func (tc *TbtcChain) NotifyRedemptionTimeout(
 	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
 ) error {
-	bitcoinTxInfo := tbtcabi.BitcoinTxInfo3{
-		Version:      transaction.SerializeVersion(),
-		InputVector:  transaction.SerializeInputs(),
-		OutputVector: transaction.SerializeOutputs(),
-		Locktime:     transaction.SerializeLocktime(),
-	}
-	redemptionProof := tbtcabi.BitcoinTxProof2{
-		MerkleProof:      proof.MerkleProof,
-		TxIndexInBlock:   big.NewInt(int64(proof.TxIndexInBlock)),
-		BitcoinHeaders:   proof.BitcoinHeaders,
-		CoinbasePreimage: proof.CoinbasePreimage,
-		CoinbaseProof:    proof.CoinbaseProof,
-	}
-	utxo := tbtcabi.BitcoinTxUTXO2{
-		TxHash:        mainUTXO.Outpoint.TransactionHash,
-		TxOutputIndex: mainUTXO.Outpoint.OutputIndex,
-		TxOutputValue: uint64(mainUTXO.Value),
+	walletMembersIDs, err := tc.getWalletMembersIDs(walletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf("cannot get wallet members IDs: [%v]", err)
 	}
 
-	gasEstimate, err := tc.maintainerProxy.SubmitRedemptionProofGasEstimate(
-		bitcoinTxInfo,
-		redemptionProof,
-		utxo,
+	_, err = tc.bridge.NotifyRedemptionTimeout(
 		walletPublicKeyHash,
+		walletMembersIDs,
+		redeemerOutputScript,
 	)
+
+	return err
+}
+
+// GetWalletOperators returns the addresses of the operators forming the
+// signing group of the given wallet, in the order of the signing group
+// members. An operator may appear multiple times if they control multiple
+// members of the signing group.
+func (tc *TbtcChain) GetWalletOperators(
+	walletPublicKeyHash [20]byte,
+) ([]chain.Address, error) {
+	walletMembersIDs, err := tc.getWalletMembersIDs(walletPublicKeyHash)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("cannot get wallet members IDs: [%v]", err)
 	}
 
-	// The original estimate for this contract call is too low and the call
+	operatorsAddresses, err := tc.sortitionPool.GetIDOperators(walletMembersIDs)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot convert operators' IDs to addresses: [%v]",
+			err,
+		)
+	}
+
+	// Should not happen as this is guaranteed by the contract but, just in case.
+	if len(walletMembersIDs) != len(operatorsAddresses) {
+		return nil, fmt.Errorf("operators IDs and addresses mismatch")
+	}
+
+	operators := make([]chain.Address, len(operatorsAddresses))
+	for i, operatorAddress := range operatorsAddresses {
+		operators[i] = chain.Address(operatorAddress.String())
+	}
+
+	return operators, nil
+}
+
+// getWalletMembersIDs returns the operator IDs of the signing group members
+// of the given wallet, in the order used to compute the members IDs hash
+// stored in the WalletRegistry. The IDs are recovered from the submitted DKG
+// result that produced the wallet.
+func (tc *TbtcChain) getWalletMembersIDs(
+	walletPublicKeyHash [20]byte,
+) ([]uint32, error) {
+	wallet, err := tc.bridge.Wallets(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get wallet for public key hash [0x%x]: [%v]",
+			walletPublicKeyHash,
+			err,
+		)
+	}
+
+	// Wallet not found.
+	if wallet.CreatedAt == 0 {
+		return nil, fmt.Errorf(
+			"no wallet for public key hash [0x%x]",
+			walletPublicKeyHash,
+		)
+	}
+
+	ecdsaWallet, err := tc.walletRegistry.GetWallet(wallet.EcdsaWalletID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get ECDSA wallet [0x%x]: [%v]",
+			wallet.EcdsaWalletID,
+			err,
+		)
+	}
+
+	events, err := tc.walletRegistry.PastDkgResultSubmittedEvents(
+		0,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past DKG result submitted events: [%v]",
+			err,
+		)
+	}
+
+	// The ECDSA wallet ID is the hash of the group public key. Multiple
+	// results with the same group public key may be submitted if some of
+	// them were challenged. Start from the latest one and take the first
+	// result whose members hash matches the one stored for the wallet.
+	for i := len(events) - 1; i >= 0; i-- {
+		result := events[i].Result
+
+		if crypto.Keccak256Hash(result.GroupPubKey) != wallet.EcdsaWalletID {
+			continue
+		}
+
+		// Misbehaved members are excluded from the wallet signing group.
+		// Their indexes are 1-based and sorted in ascending order.
+		misbehaved := make(map[uint8]bool)
+		for _, memberIndex := range result.MisbehavedMembersIndices {
+			misbehaved[memberIndex] = true
+		}
+
+		walletMembersIDs := make(chain.OperatorIDs, 0, len(result.Members))
+		for j, memberID := range result.Members {
+			if !misbehaved[uint8(j+1)] {
+				walletMembersIDs = append(walletMembersIDs, memberID)
+			}
+		}
+
+		membersIDsHash, err := computeOperatorsIDsHash(walletMembersIDs)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot compute members IDs hash: [%v]",
+				err,
+			)
+		}
+
+		if membersIDsHash == ecdsaWallet.MembersIdsHash {
+			return walletMembersIDs, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"no DKG result matching ECDSA wallet [0x%x]",
+		wallet.EcdsaWalletID,
+	)
+}
+
+func (tc *TbtcChain) SubmitRedemptionProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) error {
+	bitcoinTxInfo, redemptionProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitRedemptionProofGasEstimate(
+		bitcoinTxInfo,
+		redemptionProof,
+		utxo,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The original estimate for this contract call is too low and the call
 	// fails on reimbursing the submitter. Example:
 	// 0xe27a92883e0e64da8a3a54a15a260ea2f4d3d48470129ac5c09bfe9637d7e114
 	// Here we add a 20% margin to overcome the gas problems.
@@ -1352,43 +1910,96 @@ func (tc *TbtcChain) SubmitRedemptionProofWithReimbursement(
 	return err
 }
 
-func buildRedemptionKey(
+func (tc *TbtcChain) SubmitMovingFundsProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
 	walletPublicKeyHash [20]byte,
-	redeemerOutputScript bitcoin.Script,
-) (*big.Int, error) {
-	// The Bridge contract builds the redemption key using the length-prefixed
-	// redeemer output script.
-	prefixedRedeemerOutputScript, err := redeemerOutputScript.ToVarLenData()
+) error {
+	bitcoinTxInfo, movingFundsProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitMovingFundsProofGasEstimate(
+		bitcoinTxInfo,
+		movingFundsProof,
+		utxo,
+		walletPublicKeyHash,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("cannot build prefixed redeemer output script: [%v]", err)
+		return err
 	}
 
-	redeemerOutputScriptHash := crypto.Keccak256Hash(prefixedRedeemerOutputScript)
+	// Add a 20% margin to the original gas estimate, the same way as for
+	// other proofs, to overcome the gas problems on submitter reimbursement.
+	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2)
 
-	redemptionKey := crypto.Keccak256Hash(
-		append(redeemerOutputScriptHash[:], walletPublicKeyHash[:]...),
+	_, err = tc.maintainerProxy.SubmitMovingFundsProof(
+		bitcoinTxInfo,
+		movingFundsProof,
+		utxo,
+		walletPublicKeyHash,
+		ethutil.TransactionOptions{
+			GasLimit: uint64(gasEstimateWithMargin),
+		},
 	)
 
-	return redemptionKey.Big(), nil
+	return err
 }
 
-func (tc *TbtcChain) TxProofDifficultyFactor() (*big.Int, error) {
-	return tc.bridge.TxProofDifficultyFactor()
+func (tc *TbtcChain) SubmitMovedFundsSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+) error {
+	bitcoinTxInfo, sweepProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitMovedFundsSweepProofGasEstimate(
+		bitcoinTxInfo,
+		sweepProof,
+		utxo,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Add a 20% margin to the original gas estimate, the same way as for
+	// other proofs, to overcome the gas problems on submitter reimbursement.
+	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2)
+
+	_, err = tc.maintainerProxy.SubmitMovedFundsSweepProof(
+		bitcoinTxInfo,
+		sweepProof,
+		utxo,
+		ethutil.TransactionOptions{
+			GasLimit: uint64(gasEstimateWithMargin),
+		},
+	)
+
+	return err
 }
 
-func (tc *TbtcChain) SubmitDepositSweepProofWithReimbursement(
+// buildProofSubmissionArgs converts the given Bitcoin transaction, its SPV
+// proof and the wallet's main UTXO to the form expected by the proof
+// submission functions of the MaintainerProxy contract.
+func buildProofSubmissionArgs(
 	transaction *bitcoin.Transaction,
 	proof *bitcoin.SpvProof,
 	mainUTXO bitcoin.UnspentTransactionOutput,
-	vault common.Address,
-) error {
+) (tbtcabi.BitcoinTxInfo3, tbtcabi.BitcoinTxProof2, tbtcabi.BitcoinTxUTXO2) {
 	bitcoinTxInfo := tbtcabi.BitcoinTxInfo3{
 		Version:      transaction.SerializeVersion(),
 		InputVector:  transaction.SerializeInputs(),
 		OutputVector: transaction.SerializeOutputs(),
 		Locktime:     transaction.SerializeLocktime(),
 	}
-	sweepProof := tbtcabi.BitcoinTxProof2{
+	txProof := tbtcabi.BitcoinTxProof2{
 		MerkleProof:      proof.MerkleProof,
 		TxIndexInBlock:   big.NewInt(int64(proof.TxIndexInBlock)),
 		BitcoinHeaders:   proof.BitcoinHeaders,
@@ -1401,6 +2012,185 @@ func (tc *TbtcChain) SubmitDepositSweepProofWithReimbursement(
 		TxOutputValue: uint64(mainUTXO.Value),
 	}
 
+	return bitcoinTxInfo, txProof, utxo
+}
+
+// estimateProofSubmission builds the estimate of a proof submission made
+// via the MaintainerProxy contract, based on the given gas estimate of the
+// submission call and the gas offset the MaintainerProxy applies to it.
+func (tc *TbtcChain) estimateProofSubmission(
+	gasEstimate uint64,
+	gasOffset *big.Int,
+) (*submission.Estimate, error) {
+	reimbursementPoolAddress, err := tc.maintainerProxy.ReimbursementPool()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get ReimbursementPool address: [%v]",
+			err,
+		)
+	}
+
+	return tc.estimateReimbursedSubmission(
+		gasEstimate,
+		gasOffset,
+		reimbursementPoolAddress,
+	)
+}
+
+func (tc *TbtcChain) EstimateDepositSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	vault common.Address,
+) (*submission.Estimate, error) {
+	bitcoinTxInfo, sweepProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitDepositSweepProofGasEstimate(
+		bitcoinTxInfo,
+		sweepProof,
+		utxo,
+		vault,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gasOffset, err := tc.maintainerProxy.SubmitDepositSweepProofGasOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	return tc.estimateProofSubmission(gasEstimate, gasOffset)
+}
+
+func (tc *TbtcChain) EstimateRedemptionProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) (*submission.Estimate, error) {
+	bitcoinTxInfo, redemptionProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitRedemptionProofGasEstimate(
+		bitcoinTxInfo,
+		redemptionProof,
+		utxo,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gasOffset, err := tc.maintainerProxy.SubmitRedemptionProofGasOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	return tc.estimateProofSubmission(gasEstimate, gasOffset)
+}
+
+func (tc *TbtcChain) EstimateMovingFundsProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) (*submission.Estimate, error) {
+	bitcoinTxInfo, movingFundsProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitMovingFundsProofGasEstimate(
+		bitcoinTxInfo,
+		movingFundsProof,
+		utxo,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gasOffset, err := tc.maintainerProxy.SubmitMovingFundsProofGasOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	return tc.estimateProofSubmission(gasEstimate, gasOffset)
+}
+
+func (tc *TbtcChain) EstimateMovedFundsSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+) (*submission.Estimate, error) {
+	bitcoinTxInfo, sweepProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
+	gasEstimate, err := tc.maintainerProxy.SubmitMovedFundsSweepProofGasEstimate(
+		bitcoinTxInfo,
+		sweepProof,
+		utxo,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gasOffset, err := tc.maintainerProxy.SubmitMovedFundsSweepProofGasOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	return tc.estimateProofSubmission(gasEstimate, gasOffset)
+}
+
+func buildRedemptionKey(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+) (*big.Int, error) {
+	// The Bridge contract builds the redemption key using the length-prefixed
+	// redeemer output script.
+	prefixedRedeemerOutputScript, err := redeemerOutputScript.ToVarLenData()
+	if err != nil {
+		return nil, fmt.Errorf("cannot build prefixed redeemer output script: [%v]", err)
+	}
+
+	redeemerOutputScriptHash := crypto.Keccak256Hash(prefixedRedeemerOutputScript)
+
+	redemptionKey := crypto.Keccak256Hash(
+		append(redeemerOutputScriptHash[:], walletPublicKeyHash[:]...),
+	)
+
+	return redemptionKey.Big(), nil
+}
+
+func (tc *TbtcChain) TxProofDifficultyFactor() (*big.Int, error) {
+	return tc.bridge.TxProofDifficultyFactor()
+}
+
+func (tc *TbtcChain) SubmitDepositSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	vault common.Address,
+) error {
+	bitcoinTxInfo, sweepProof, utxo := buildProofSubmissionArgs(
+		transaction,
+		proof,
+		mainUTXO,
+	)
+
 	gasEstimate, err := tc.maintainerProxy.SubmitDepositSweepProofGasEstimate(
 		bitcoinTxInfo,
 		sweepProof,
@@ -1457,6 +2247,96 @@ func (tc *TbtcChain) GetRedemptionParameters() (
 	return
 }
 
+func (tc *TbtcChain) GetMovingFundsParameters() (
+	txMaxTotalFee uint64,
+	dustThreshold uint64,
+	timeoutResetDelay uint32,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	commitmentGasOffset uint16,
+	sweepTxMaxTotalFee uint64,
+	sweepTimeout uint32,
+	sweepTimeoutSlashingAmount *big.Int,
+	sweepTimeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	parameters, callErr := tc.bridge.MovingFundsParameters()
+	if callErr != nil {
+		err = callErr
+		return
+	}
+
+	txMaxTotalFee = parameters.MovingFundsTxMaxTotalFee
+	dustThreshold = parameters.MovingFundsDustThreshold
+	timeoutResetDelay = parameters.MovingFundsTimeoutResetDelay
+	timeout = parameters.MovingFundsTimeout
+	timeoutSlashingAmount = parameters.MovingFundsTimeoutSlashingAmount
+	timeoutNotifierRewardMultiplier = parameters.MovingFundsTimeoutNotifierRewardMultiplier
+	commitmentGasOffset = parameters.MovingFundsCommitmentGasOffset
+	sweepTxMaxTotalFee = parameters.MovedFundsSweepTxMaxTotalFee
+	sweepTimeout = parameters.MovedFundsSweepTimeout
+	sweepTimeoutSlashingAmount = parameters.MovedFundsSweepTimeoutSlashingAmount
+	sweepTimeoutNotifierRewardMultiplier = parameters.MovedFundsSweepTimeoutNotifierRewardMultiplier
+
+	return
+}
+
+func (tc *TbtcChain) GetWalletParameters() (
+	creationPeriod uint32,
+	creationMinBtcBalance uint64,
+	creationMaxBtcBalance uint64,
+	closureMinBtcBalance uint64,
+	maxAge uint32,
+	maxBtcTransfer uint64,
+	closingPeriod uint32,
+	err error,
+) {
+	parameters, callErr := tc.bridge.WalletParameters()
+	if callErr != nil {
+		err = callErr
+		return
+	}
+
+	creationPeriod = parameters.WalletCreationPeriod
+	creationMinBtcBalance = parameters.WalletCreationMinBtcBalance
+	creationMaxBtcBalance = parameters.WalletCreationMaxBtcBalance
+	closureMinBtcBalance = parameters.WalletClosureMinBtcBalance
+	maxAge = parameters.WalletMaxAge
+	maxBtcTransfer = parameters.WalletMaxBtcTransfer
+	closingPeriod = parameters.WalletClosingPeriod
+
+	return
+}
+
+func (tc *TbtcChain) GetLiveWalletsCount() (uint32, error) {
+	return tc.bridge.LiveWalletsCount()
+}
+
+func (tc *TbtcChain) SubmitMovingFundsCommitment(
+	walletPublicKeyHash [20]byte,
+	walletMainUTXO bitcoin.UnspentTransactionOutput,
+	walletMembersIDs []uint32,
+	walletMemberIndex uint32,
+	targetWallets [][20]byte,
+) error {
+	mainUtxo := tbtcabi.BitcoinTxUTXO{
+		TxHash:        walletMainUTXO.Outpoint.TransactionHash,
+		TxOutputIndex: walletMainUTXO.Outpoint.OutputIndex,
+		TxOutputValue: uint64(walletMainUTXO.Value),
+	}
+
+	_, err := tc.bridge.SubmitMovingFundsCommitment(
+		walletPublicKeyHash,
+		mainUtxo,
+		walletMembersIDs,
+		big.NewInt(int64(walletMemberIndex)),
+		targetWallets,
+	)
+
+	return err
+}
+
 func buildDepositKey(
 	fundingTxHash bitcoin.Hash,
 	fundingOutputIndex uint32,
@@ -1518,6 +2398,26 @@ func parseWalletState(value uint8) (tbtc.WalletState, error) {
 	}
 }
 
+func parseMovedFundsSweepRequestState(
+	value uint8,
+) (tbtc.MovedFundsSweepRequestState, error) {
+	switch value {
+	case 0:
+		return tbtc.MovedFundsStateUnknown, nil
+	case 1:
+		return tbtc.MovedFundsStatePending, nil
+	case 2:
+		return tbtc.MovedFundsStateProcessed, nil
+	case 3:
+		return tbtc.MovedFundsStateTimedOut, nil
+	default:
+		return 0, fmt.Errorf(
+			"unexpected moved funds sweep request state value: [%v]",
+			value,
+		)
+	}
+}
+
 func (tc *TbtcChain) ValidateDepositSweepProposal(
 	walletPublicKeyHash [20]byte,
 	proposal *tbtc.DepositSweepProposal,
@@ -1565,6 +2465,10 @@ func (tc *TbtcChain) GetDepositSweepMaxSize() (uint16, error) {
 	return tc.walletProposalValidator.DEPOSITSWEEPMAXSIZE()
 }
 
+func (tc *TbtcChain) GetDepositRefundSafetyMargin() (uint32, error) {
+	return tc.walletProposalValidator.DEPOSITREFUNDSAFETYMARGIN()
+}
+
 func (tc *TbtcChain) ValidateRedemptionProposal(
 	walletPublicKeyHash [20]byte,
 	proposal *tbtc.RedemptionProposal,
@@ -1654,3 +2558,101 @@ func (tc *TbtcChain) ValidateHeartbeatProposal(
 
 	return nil
 }
+
+func (tc *TbtcChain) ValidateMovingFundsProposal(
+	walletPublicKeyHash [20]byte,
+	walletMainUTXO *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) error {
+	abiProposal := tbtcabi.WalletProposalValidatorMovingFundsProposal{
+		WalletPubKeyHash: walletPublicKeyHash,
+		TargetWallets:    proposal.TargetWallets,
+		MovingFundsTxFee: proposal.MovingFundsTxFee,
+	}
+	abiMainUtxo := tbtcabi.BitcoinTxUTXO3{
+		TxHash:        walletMainUTXO.Outpoint.TransactionHash,
+		TxOutputIndex: walletMainUTXO.Outpoint.OutputIndex,
+		TxOutputValue: uint64(walletMainUTXO.Value),
+	}
+
+	valid, err := tc.walletProposalValidator.ValidateMovingFundsProposal(
+		abiProposal,
+		abiMainUtxo,
+	)
+	if err != nil {
+		return fmt.Errorf("validation failed: [%v]", err)
+	}
+
+	// Should never happen because `validateMovingFundsProposal` returns true
+	// or reverts (returns an error) but do the check just in case.
+	if !valid {
+		return fmt.Errorf("unexpected validation result")
+	}
+
+	return nil
+}
+
+func (tc *TbtcChain) ValidateMovedFundsSweepProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.MovedFundsSweepProposal,
+) error {
+	// The currently deployed WalletProposalValidator contract does not
+	// expose a moved funds sweep proposal validation function. The
+	// validation is performed against the Bridge state instead, following
+	// the rules enforced by the Bridge upon moved funds sweep proof
+	// submission.
+	wallet, err := tc.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf("cannot get wallet: [%v]", err)
+	}
+
+	if wallet.State != tbtc.StateLive &&
+		wallet.State != tbtc.StateMovingFunds {
+		return fmt.Errorf(
+			"source wallet is not in Live or MovingFunds state: [%v]",
+			wallet.State,
+		)
+	}
+
+	request, found, err := tc.GetMovedFundsSweepRequest(
+		proposal.MovingFundsTxHash,
+		proposal.MovingFundsTxOutputIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get moved funds sweep request: [%v]", err)
+	}
+
+	if !found {
+		return fmt.Errorf("moved funds sweep request not found")
+	}
+
+	if request.WalletPublicKeyHash != walletPublicKeyHash {
+		return fmt.Errorf(
+			"moved funds sweep request belongs to another wallet: [0x%x]",
+			request.WalletPublicKeyHash,
+		)
+	}
+
+	if request.State != tbtc.MovedFundsStatePending {
+		return fmt.Errorf(
+			"moved funds sweep request is not in Pending state: [%v]",
+			request.State,
+		)
+	}
+
+	if proposal.SweepTxFee == nil || proposal.SweepTxFee.Sign() <= 0 {
+		return fmt.Errorf("proposed transaction fee cannot be zero")
+	}
+
+	_, _, _, _, _, _, _, sweepTxMaxTotalFee, _, _, _, err :=
+		tc.GetMovingFundsParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get moving funds parameters: [%v]", err)
+	}
+
+	if proposal.SweepTxFee.Cmp(new(big.Int).SetUint64(sweepTxMaxTotalFee)) > 0 {
+		return fmt.Errorf("proposed transaction fee is too high")
+	}
+
+	return nil
+}
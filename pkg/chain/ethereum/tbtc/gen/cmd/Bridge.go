@@ -2158,8 +2158,8 @@ func bSubmitFraudChallengeCommand() *cobra.Command {
 		DisableFlagsInUseLine: true,
 	}
 
-	c.PreRunE = cmd.NonConstArgsChecker
-	cmd.InitNonConstFlags(c)
+	c.PreRunE = cmd.PayableArgsChecker
+	cmd.InitPayableFlags(c)
 
 	return c
 }
@@ -2200,6 +2200,7 @@ func bSubmitFraudChallenge(c *cobra.Command, args []string) error {
 			arg_walletPublicKey,
 			arg_preimageSha256,
 			arg_signature_json,
+			cmd.ValueFlagValue.Int,
 		)
 		if err != nil {
 			return err
@@ -2212,6 +2213,7 @@ func bSubmitFraudChallenge(c *cobra.Command, args []string) error {
 			arg_walletPublicKey,
 			arg_preimageSha256,
 			arg_signature_json,
+			cmd.ValueFlagValue.Int,
 			cmd.BlockFlagValue.Int,
 		)
 		if err != nil {
@@ -3347,6 +3347,7 @@ func (b *Bridge) SubmitFraudChallenge(
 	arg_walletPublicKey []byte,
 	arg_preimageSha256 []byte,
 	arg_signature abi.BitcoinTxRSVSignature,
+	value *big.Int,
 
 	transactionOptions ...chainutil.TransactionOptions,
 ) (*types.Transaction, error) {
@@ -3358,6 +3359,7 @@ func (b *Bridge) SubmitFraudChallenge(
 			arg_preimageSha256,
 			arg_signature,
 		),
+		" value: ", value,
 	)
 
 	b.transactionMutex.Lock()
@@ -3367,6 +3369,8 @@ func (b *Bridge) SubmitFraudChallenge(
 	transactorOptions := new(bind.TransactOpts)
 	*transactorOptions = *b.transactorOptions
 
+	transactorOptions.Value = value
+
 	if len(transactionOptions) > 1 {
 		return nil, fmt.Errorf(
 			"could not process multiple transaction options sets",
@@ -3392,7 +3396,7 @@ func (b *Bridge) SubmitFraudChallenge(
 		return transaction, b.errorResolver.ResolveError(
 			err,
 			b.transactorOptions.From,
-			nil,
+			value,
 			"submitFraudChallenge",
 			arg_walletPublicKey,
 			arg_preimageSha256,
@@ -3430,7 +3434,7 @@ func (b *Bridge) SubmitFraudChallenge(
 				return nil, b.errorResolver.ResolveError(
 					err,
 					b.transactorOptions.From,
-					nil,
+					value,
 					"submitFraudChallenge",
 					arg_walletPublicKey,
 					arg_preimageSha256,
@@ -3458,13 +3462,14 @@ func (b *Bridge) CallSubmitFraudChallenge(
 	arg_walletPublicKey []byte,
 	arg_preimageSha256 []byte,
 	arg_signature abi.BitcoinTxRSVSignature,
+	value *big.Int,
 	blockNumber *big.Int,
 ) error {
 	var result interface{} = nil
 
 	err := chainutil.CallAtBlock(
 		b.transactorOptions.From,
-		blockNumber, nil,
+		blockNumber, value,
 		b.contractABI,
 		b.caller,
 		b.errorResolver,
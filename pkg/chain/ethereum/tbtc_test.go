@@ -338,6 +338,34 @@ func TestComputeMainUtxoHash(t *testing.T) {
 	testutils.AssertBytesEqual(t, expectedMainUtxoHash, mainUtxoHash[:])
 }
 
+func TestComputeMovingFundsCommitmentHash(t *testing.T) {
+	toByte20 := func(s string) [20]byte {
+		bytes, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result [20]byte
+		copy(result[:], bytes)
+		return result
+	}
+
+	targetWallets := [][20]byte{
+		toByte20("3091d288521caec06ea912eacfd733edc5a36d6e"),
+		toByte20("c7302d75072d78be94eb8d36c4b77583c7abb06e"),
+	}
+
+	commitmentHash := computeMovingFundsCommitmentHash(targetWallets)
+
+	expectedCommitmentHash, err := hex.DecodeString(
+		"1831c7b893cf5c96e66cff92e365b39d60ce1b4f793c4c9477f0c4a5f06f018c",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBytesEqual(t, expectedCommitmentHash, commitmentHash[:])
+}
+
 // Test data based on: https://etherscan.io/tx/0x97c7a293127a604da77f7ef8daf4b19da2bf04327dd891b6d717eaef89bd8bca
 func TestBuildDepositKey(t *testing.T) {
 	fundingTxHash, err := bitcoin.NewHashFromString(
@@ -11,10 +11,11 @@ import (
 
 // Diagnostics describes data structure returned by the diagnostics endpoint.
 type Diagnostics struct {
-	ClientInfo     Client `json:"client_info"`
-	ConnectedPeers []Peer `json:"connected_peers"`
-	EthChainInfo   Chain  `json:"eth_chain_info"`
-	BtcChainInfo   Chain  `json:"btc_chain_info"`
+	ClientInfo     Client           `json:"client_info"`
+	ConnectedPeers []Peer           `json:"connected_peers"`
+	PeerReputation []PeerReputation `json:"peer_reputation"`
+	EthChainInfo   Chain            `json:"eth_chain_info"`
+	BtcChainInfo   Chain            `json:"btc_chain_info"`
 }
 
 // Client describes data structure of client information.
@@ -32,6 +33,18 @@ type Peer struct {
 	NetworkMultiAddresses []string `json:"multiaddrs"`
 }
 
+// PeerReputation describes data structure of peer reputation information.
+// Times are expressed as UNIX timestamps. Zero banned until timestamp means
+// the peer has never been banned.
+type PeerReputation struct {
+	ChainAddress string          `json:"chain_address"`
+	NetworkID    string          `json:"network_id"`
+	Score        float64         `json:"score"`
+	Faults       map[string]uint `json:"faults"`
+	LastFaultAt  int64           `json:"last_fault_at"`
+	BannedUntil  int64           `json:"banned_until"`
+}
+
 // Chain describes data structure of a chain information.
 type Chain struct {
 	LatestBlockNumber uint `json:"latest_block_number"`
@@ -83,6 +96,59 @@ func (r *Registry) RegisterConnectedPeersSource(
 	})
 }
 
+// RegisterPeerReputationSource registers the diagnostics source providing
+// information about reputations of peers that recently committed faults in
+// the network protocol.
+func (r *Registry) RegisterPeerReputationSource(
+	netProvider net.Provider,
+	signing chain.Signing,
+) {
+	r.RegisterDiagnosticSource("peer_reputation", func() string {
+		connectionManager := netProvider.ConnectionManager()
+
+		reputationsList := make([]PeerReputation, 0)
+		for _, reputation := range connectionManager.PeerReputations() {
+			// The chain address is not available for peers using keys of
+			// an invalid type. Their reputation is reported anyway.
+			var peerChainAddress string
+			peerPublicKey, err := connectionManager.GetPeerPublicKey(
+				reputation.NetworkID,
+			)
+			if err == nil {
+				address, err := signing.PublicKeyToAddress(peerPublicKey)
+				if err == nil {
+					peerChainAddress = address.String()
+				}
+			}
+
+			var bannedUntil int64
+			if !reputation.BannedUntil.IsZero() {
+				bannedUntil = reputation.BannedUntil.Unix()
+			}
+
+			reputationsList = append(reputationsList, PeerReputation{
+				ChainAddress: peerChainAddress,
+				NetworkID:    reputation.NetworkID,
+				Score:        reputation.Score,
+				Faults:       reputation.Faults,
+				LastFaultAt:  reputation.LastFaultAt.Unix(),
+				BannedUntil:  bannedUntil,
+			})
+		}
+
+		bytes, err := json.Marshal(reputationsList)
+		if err != nil {
+			logger.Errorf(
+				"error on serializing peer reputation to JSON: [%v]",
+				err,
+			)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
 // RegisterClientInfoSource registers the diagnostics source providing
 // information about the client itself.
 func (r *Registry) RegisterClientInfoSource(
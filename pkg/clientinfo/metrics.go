@@ -18,6 +18,7 @@ const (
 	ConnectedBootstrapCountMetricName = "connected_bootstrap_count"
 	EthConnectivityMetricName         = "eth_connectivity"
 	BtcConnectivityMetricName         = "btc_connectivity"
+	BtcQuorumMetricNamePrefix         = "btc_quorum"
 	ClientInfoMetricName              = "client_info"
 )
 
@@ -124,6 +125,67 @@ func (r *Registry) ObserveBtcConnectivity(
 	)
 }
 
+// BtcQuorum is a source of Bitcoin chain quorum metrics.
+type BtcQuorum interface {
+	// BackendsCount returns the number of backends of the quorum.
+	BackendsCount() int
+	// DisagreementsCount returns the number of calls for which successful
+	// responses of backends were not unanimous.
+	DisagreementsCount() uint64
+	// QuorumFailuresCount returns the number of calls that failed because
+	// the threshold number of backends did not agree on the result.
+	QuorumFailuresCount() uint64
+	// BackendErrorsCount returns the number of errors returned by all
+	// backends.
+	BackendErrorsCount() uint64
+	// BackendDisagreementsCount returns the number of calls for which the
+	// backend with the given index returned a result different from the
+	// agreed one.
+	BackendDisagreementsCount(index int) uint64
+}
+
+// ObserveBtcQuorum triggers an observation process of the btc_quorum_*
+// metrics exposing disagreements between Bitcoin chain backends.
+func (r *Registry) ObserveBtcQuorum(
+	btcQuorum BtcQuorum,
+	tick time.Duration,
+) {
+	tick = validateTick(tick, DefaultBitcoinMetricsTick)
+
+	r.observe(
+		fmt.Sprintf("%s_disagreements", BtcQuorumMetricNamePrefix),
+		func() float64 { return float64(btcQuorum.DisagreementsCount()) },
+		tick,
+	)
+
+	r.observe(
+		fmt.Sprintf("%s_failures", BtcQuorumMetricNamePrefix),
+		func() float64 { return float64(btcQuorum.QuorumFailuresCount()) },
+		tick,
+	)
+
+	r.observe(
+		fmt.Sprintf("%s_backend_errors", BtcQuorumMetricNamePrefix),
+		func() float64 { return float64(btcQuorum.BackendErrorsCount()) },
+		tick,
+	)
+
+	for i := 0; i < btcQuorum.BackendsCount(); i++ {
+		index := i
+		r.observe(
+			fmt.Sprintf(
+				"%s_backend_%d_disagreements",
+				BtcQuorumMetricNamePrefix,
+				index,
+			),
+			func() float64 {
+				return float64(btcQuorum.BackendDisagreementsCount(index))
+			},
+			tick,
+		)
+	}
+}
+
 // ObserveApplicationSource triggers an observation process of
 // application-specific metrics.
 func (r *Registry) ObserveApplicationSource(
@@ -114,6 +114,12 @@ func (lbc *localBitcoinChain) GetMempoolUtxosForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lbc *localBitcoinChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	panic("unsupported")
+}
+
 // SetBlockHeaders sets internal headers for testing purposes.
 func (lbc *localBitcoinChain) SetBlockHeaders(
 	blockHeaders map[uint]*bitcoin.BlockHeader,
@@ -2,12 +2,14 @@ package btcdiff
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ipfs/go-log/v2"
 
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 )
 
 var logger = log.Logger("keep-maintainer-btcdiff")
@@ -264,7 +266,20 @@ func (bdm *bitcoinDifficultyMaintainer) proveNextEpoch(ctx context.Context) (
 				)
 			}
 		} else {
-			if err := bdm.chain.RetargetWithRefund(headers); err != nil {
+			err := bdm.chain.RetargetWithRefund(headers)
+			if errors.Is(err, submission.ErrSkipped) {
+				// The retarget is not worth submitting at the current gas
+				// prices. Treat the epoch as not proven so the submission
+				// is attempted again after the idle backoff.
+				logger.Warnf(
+					"skipped submitting block headers from range [%d:%d]; "+
+						"the submission was skipped by the submission policy",
+					firstBlockHeaderHeight,
+					lastBlockHeaderHeight,
+				)
+				return false, nil
+			}
+			if err != nil {
 				return false, fmt.Errorf(
 					"failed to submit block headers from range [%d:%d] via "+
 						"RetargetWithRefund: [%w]",
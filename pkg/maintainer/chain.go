@@ -0,0 +1,206 @@
+package maintainer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
+)
+
+// BitcoinDifficultyChain is the Bitcoin difficulty chain able to estimate
+// the reimbursed submissions.
+type BitcoinDifficultyChain interface {
+	btcdiff.Chain
+
+	// EstimateRetargetWithRefund estimates the cost and refund of the
+	// RetargetWithRefund submission for the given headers.
+	EstimateRetargetWithRefund(
+		headers []*bitcoin.BlockHeader,
+	) (*submission.Estimate, error)
+}
+
+// SpvChain is the SPV chain able to estimate the reimbursed submissions.
+type SpvChain interface {
+	spv.Chain
+
+	// EstimateDepositSweepProofWithReimbursement estimates the cost and
+	// refund of the SubmitDepositSweepProofWithReimbursement submission.
+	EstimateDepositSweepProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+		vault common.Address,
+	) (*submission.Estimate, error)
+
+	// EstimateRedemptionProofWithReimbursement estimates the cost and refund
+	// of the SubmitRedemptionProofWithReimbursement submission.
+	EstimateRedemptionProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+		walletPublicKeyHash [20]byte,
+	) (*submission.Estimate, error)
+
+	// EstimateMovingFundsProofWithReimbursement estimates the cost and refund
+	// of the SubmitMovingFundsProofWithReimbursement submission.
+	EstimateMovingFundsProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+		walletPublicKeyHash [20]byte,
+	) (*submission.Estimate, error)
+
+	// EstimateMovedFundsSweepProofWithReimbursement estimates the cost and
+	// refund of the SubmitMovedFundsSweepProofWithReimbursement submission.
+	EstimateMovedFundsSweepProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+	) (*submission.Estimate, error)
+}
+
+// guardedBitcoinDifficultyChain is a BitcoinDifficultyChain whose reimbursed
+// submissions are sent according to the submission policy.
+type guardedBitcoinDifficultyChain struct {
+	BitcoinDifficultyChain
+
+	ctx    context.Context
+	policy *submission.Policy
+}
+
+func (gbdc *guardedBitcoinDifficultyChain) RetargetWithRefund(
+	headers []*bitcoin.BlockHeader,
+) error {
+	return gbdc.policy.Execute(
+		gbdc.ctx,
+		"retarget",
+		func() (*submission.Estimate, error) {
+			return gbdc.EstimateRetargetWithRefund(headers)
+		},
+		func() error {
+			return gbdc.BitcoinDifficultyChain.RetargetWithRefund(headers)
+		},
+	)
+}
+
+// guardedSpvChain is a SpvChain whose reimbursed submissions are sent
+// according to the submission policy.
+type guardedSpvChain struct {
+	SpvChain
+
+	ctx    context.Context
+	policy *submission.Policy
+}
+
+func (gsc *guardedSpvChain) SubmitDepositSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	vault common.Address,
+) error {
+	return gsc.policy.Execute(
+		gsc.ctx,
+		"deposit sweep proof",
+		func() (*submission.Estimate, error) {
+			return gsc.EstimateDepositSweepProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				vault,
+			)
+		},
+		func() error {
+			return gsc.SpvChain.SubmitDepositSweepProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				vault,
+			)
+		},
+	)
+}
+
+func (gsc *guardedSpvChain) SubmitRedemptionProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) error {
+	return gsc.policy.Execute(
+		gsc.ctx,
+		"redemption proof",
+		func() (*submission.Estimate, error) {
+			return gsc.EstimateRedemptionProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				walletPublicKeyHash,
+			)
+		},
+		func() error {
+			return gsc.SpvChain.SubmitRedemptionProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				walletPublicKeyHash,
+			)
+		},
+	)
+}
+
+func (gsc *guardedSpvChain) SubmitMovingFundsProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) error {
+	return gsc.policy.Execute(
+		gsc.ctx,
+		"moving funds proof",
+		func() (*submission.Estimate, error) {
+			return gsc.EstimateMovingFundsProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				walletPublicKeyHash,
+			)
+		},
+		func() error {
+			return gsc.SpvChain.SubmitMovingFundsProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+				walletPublicKeyHash,
+			)
+		},
+	)
+}
+
+func (gsc *guardedSpvChain) SubmitMovedFundsSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+) error {
+	return gsc.policy.Execute(
+		gsc.ctx,
+		"moved funds sweep proof",
+		func() (*submission.Estimate, error) {
+			return gsc.EstimateMovedFundsSweepProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+			)
+		},
+		func() error {
+			return gsc.SpvChain.SubmitMovedFundsSweepProofWithReimbursement(
+				transaction,
+				proof,
+				mainUTXO,
+			)
+		},
+	)
+}
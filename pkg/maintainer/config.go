@@ -2,11 +2,17 @@ package maintainer
 
 import (
 	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/fraud"
+	"github.com/keep-network/keep-core/pkg/maintainer/redemption"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 )
 
 // Config contains maintainer configuration.
 type Config struct {
 	BitcoinDifficulty btcdiff.Config
 	Spv               spv.Config
+	Fraud             fraud.Config
+	Redemption        redemption.Config
+	Submission        submission.Config
 }
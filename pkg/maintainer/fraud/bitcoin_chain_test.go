@@ -0,0 +1,219 @@
+package fraud
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+type localBitcoinChain struct {
+	mutex sync.Mutex
+
+	transactions             []*bitcoin.Transaction
+	transactionConfirmations map[bitcoin.Hash]uint
+}
+
+func newLocalBitcoinChain() *localBitcoinChain {
+	return &localBitcoinChain{
+		transactions:             make([]*bitcoin.Transaction, 0),
+		transactionConfirmations: make(map[bitcoin.Hash]uint),
+	}
+}
+
+func (lbc *localBitcoinChain) GetTransaction(transactionHash bitcoin.Hash) (
+	*bitcoin.Transaction,
+	error,
+) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	for _, transaction := range lbc.transactions {
+		if transaction.Hash() == transactionHash {
+			return transaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction not found")
+}
+
+func (lbc *localBitcoinChain) GetTransactionConfirmations(transactionHash bitcoin.Hash) (
+	uint,
+	error,
+) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	if transactionConfirmations, exists :=
+		lbc.transactionConfirmations[transactionHash]; exists {
+		return transactionConfirmations, nil
+	}
+
+	return 0, fmt.Errorf("transaction not found")
+}
+
+func (lbc *localBitcoinChain) BroadcastTransaction(transaction *bitcoin.Transaction) error {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	transactionHash := transaction.Hash()
+
+	for _, existingTransaction := range lbc.transactions {
+		if transactionHash == existingTransaction.Hash() {
+			return fmt.Errorf("transaction already exists")
+		}
+	}
+
+	lbc.transactions = append(lbc.transactions, transaction)
+
+	return nil
+}
+
+func (lbc *localBitcoinChain) GetLatestBlockHeight() (uint, error) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetBlockHeader(blockHeight uint) (
+	*bitcoin.BlockHeader,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	matchingTransactions := make([]*bitcoin.Transaction, 0)
+
+	isMatchingScript := func(script bitcoin.Script) bool {
+		return bytes.Equal(script, p2pkh) || bytes.Equal(script, p2wpkh)
+	}
+
+	// Just as Electrum does, consider both transactions paying to the given
+	// public key hash and transactions spending outputs locked on it.
+	isMatchingTransaction := func(transaction *bitcoin.Transaction) bool {
+		for _, output := range transaction.Outputs {
+			if isMatchingScript(output.PublicKeyScript) {
+				return true
+			}
+		}
+
+		for _, input := range transaction.Inputs {
+			for _, previousTransaction := range lbc.transactions {
+				if previousTransaction.Hash() != input.Outpoint.TransactionHash {
+					continue
+				}
+
+				outputIndex := int(input.Outpoint.OutputIndex)
+				if outputIndex < len(previousTransaction.Outputs) &&
+					isMatchingScript(
+						previousTransaction.Outputs[outputIndex].PublicKeyScript,
+					) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, transaction := range lbc.transactions {
+		if isMatchingTransaction(transaction) {
+			matchingTransactions = append(matchingTransactions, transaction)
+		}
+	}
+
+	if len(matchingTransactions) > limit {
+		return matchingTransactions[len(matchingTransactions)-limit:], nil
+	}
+
+	return matchingTransactions, nil
+}
+
+func (lbc *localBitcoinChain) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetMempoolForPublicKeyHash(publicKeyHash [20]byte) (
+	[]*bitcoin.Transaction,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	for _, transaction := range lbc.transactions {
+		for _, input := range transaction.Inputs {
+			if *input.Outpoint == *outpoint {
+				return transaction, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (lbc *localBitcoinChain) EstimateSatPerVByteFee(blocks uint32) (
+	int64,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) GetCoinbaseTxHash(blockHeight uint) (
+	bitcoin.Hash,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (lbc *localBitcoinChain) setTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+	transactionConfirmations uint,
+) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	lbc.transactionConfirmations[transactionHash] = transactionConfirmations
+}
@@ -0,0 +1,79 @@
+package fraud
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// Chain is an interface that provides the ability to communicate with the
+// on-chain Bridge contract in order to detect and challenge fraudulent
+// wallet signatures.
+type Chain interface {
+	// PastNewWalletRegisteredEvents fetches past new wallet registered events
+	// according to the provided filter or unfiltered if the filter is nil.
+	// Returned events are sorted by the block number in the ascending order,
+	// i.e. the latest event is at the end of the slice.
+	PastNewWalletRegisteredEvents(
+		filter *tbtc.NewWalletRegisteredEventFilter,
+	) ([]*tbtc.NewWalletRegisteredEvent, error)
+
+	// PastDepositRevealedEvents fetches past deposit revealed events according
+	// to the provided filter or unfiltered if the filter is nil. Returned
+	// events are sorted by the block number in the ascending order, i.e. the
+	// latest event is at the end of the slice.
+	PastDepositRevealedEvents(
+		filter *tbtc.DepositRevealedEventFilter,
+	) ([]*tbtc.DepositRevealedEvent, error)
+
+	// GetWallet gets the on-chain data for the given wallet. Returns an error
+	// if the wallet was not found.
+	GetWallet(walletPublicKeyHash [20]byte) (*tbtc.WalletChainData, error)
+
+	// GetDepositRequest gets the on-chain deposit request for the given
+	// funding transaction hash and output index. The returned bool value
+	// indicates whether the request was found or not.
+	GetDepositRequest(
+		fundingTxHash bitcoin.Hash,
+		fundingOutputIndex uint32,
+	) (*tbtc.DepositChainRequest, bool, error)
+
+	// GetMovedFundsSweepRequest gets the on-chain moved funds sweep request
+	// for the given moving funds transaction hash and output index.
+	// The returned bool value indicates whether the request was found or not.
+	GetMovedFundsSweepRequest(
+		movingFundsTxHash bitcoin.Hash,
+		movingFundsTxOutputIndex uint32,
+	) (*tbtc.MovedFundsSweepRequest, bool, error)
+
+	// IsMainUtxoSpent checks whether the Bridge recorded the given wallet
+	// main UTXO as spent by a proven wallet transaction.
+	IsMainUtxoSpent(
+		mainUtxoTxHash bitcoin.Hash,
+		mainUtxoTxOutputIndex uint32,
+	) (bool, error)
+
+	// ComputeMainUtxoHash computes the hash of the provided main UTXO
+	// according to the on-chain Bridge rules.
+	ComputeMainUtxoHash(mainUtxo *bitcoin.UnspentTransactionOutput) [32]byte
+
+	// GetFraudChallenge gets the on-chain fraud challenge for the given
+	// wallet public key and signature hash. The returned bool value indicates
+	// whether the challenge was found or not.
+	GetFraudChallenge(
+		walletPublicKey *ecdsa.PublicKey,
+		sighash [32]byte,
+	) (*tbtc.FraudChallenge, bool, error)
+
+	// SubmitFraudChallenge submits a fraud challenge against the wallet
+	// identified by the given public key. The preimageSha256 is the single
+	// SHA-256 of the preimage of the signed sighash. The challenge must be
+	// accompanied by a deposit which is paid by the caller.
+	SubmitFraudChallenge(
+		walletPublicKey *ecdsa.PublicKey,
+		preimageSha256 [32]byte,
+		signature *tecdsa.Signature,
+	) error
+}
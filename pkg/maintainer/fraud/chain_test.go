@@ -0,0 +1,315 @@
+package fraud
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+type submittedFraudChallenge struct {
+	walletPublicKey *ecdsa.PublicKey
+	preimageSha256  [32]byte
+	signature       *tecdsa.Signature
+}
+
+type localChain struct {
+	mutex sync.Mutex
+
+	pastNewWalletRegisteredEvents []*tbtc.NewWalletRegisteredEvent
+	pastDepositRevealedEvents     []*tbtc.DepositRevealedEvent
+	wallets                       map[[20]byte]*tbtc.WalletChainData
+	depositRequests               map[[32]byte]*tbtc.DepositChainRequest
+	movedFundsSweepRequests       map[[32]byte]*tbtc.MovedFundsSweepRequest
+	spentMainUtxos                map[[32]byte]bool
+	fraudChallenges               map[[32]byte]*tbtc.FraudChallenge
+	submittedFraudChallenges      []*submittedFraudChallenge
+}
+
+func newLocalChain() *localChain {
+	return &localChain{
+		pastNewWalletRegisteredEvents: make([]*tbtc.NewWalletRegisteredEvent, 0),
+		pastDepositRevealedEvents:     make([]*tbtc.DepositRevealedEvent, 0),
+		wallets:                       make(map[[20]byte]*tbtc.WalletChainData),
+		depositRequests:               make(map[[32]byte]*tbtc.DepositChainRequest),
+		movedFundsSweepRequests:       make(map[[32]byte]*tbtc.MovedFundsSweepRequest),
+		spentMainUtxos:                make(map[[32]byte]bool),
+		fraudChallenges:               make(map[[32]byte]*tbtc.FraudChallenge),
+		submittedFraudChallenges:      make([]*submittedFraudChallenge, 0),
+	}
+}
+
+func (lc *localChain) PastNewWalletRegisteredEvents(
+	filter *tbtc.NewWalletRegisteredEventFilter,
+) ([]*tbtc.NewWalletRegisteredEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	if filter != nil {
+		return nil, fmt.Errorf("unexpected filter")
+	}
+
+	return lc.pastNewWalletRegisteredEvents, nil
+}
+
+func (lc *localChain) addPastNewWalletRegisteredEvent(
+	event *tbtc.NewWalletRegisteredEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.pastNewWalletRegisteredEvents = append(
+		lc.pastNewWalletRegisteredEvents,
+		event,
+	)
+}
+
+func (lc *localChain) PastDepositRevealedEvents(
+	filter *tbtc.DepositRevealedEventFilter,
+) ([]*tbtc.DepositRevealedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	if filter == nil || len(filter.WalletPublicKeyHash) != 1 {
+		return nil, fmt.Errorf("unexpected filter")
+	}
+
+	events := make([]*tbtc.DepositRevealedEvent, 0)
+	for _, event := range lc.pastDepositRevealedEvents {
+		if event.WalletPublicKeyHash == filter.WalletPublicKeyHash[0] {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (lc *localChain) addPastDepositRevealedEvent(
+	event *tbtc.DepositRevealedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.pastDepositRevealedEvents = append(
+		lc.pastDepositRevealedEvents,
+		event,
+	)
+}
+
+func (lc *localChain) GetWallet(walletPublicKeyHash [20]byte) (
+	*tbtc.WalletChainData,
+	error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	walletChainData, ok := lc.wallets[walletPublicKeyHash]
+	if !ok {
+		return nil, fmt.Errorf("no wallet for given PKH")
+	}
+
+	return walletChainData, nil
+}
+
+func (lc *localChain) setWallet(
+	walletPublicKeyHash [20]byte,
+	walletChainData *tbtc.WalletChainData,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.wallets[walletPublicKeyHash] = walletChainData
+}
+
+func (lc *localChain) GetDepositRequest(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+) (*tbtc.DepositChainRequest, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	request, ok := lc.depositRequests[buildOutpointKey(
+		fundingTxHash,
+		fundingOutputIndex,
+	)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *localChain) setDepositRequest(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+	request *tbtc.DepositChainRequest,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.depositRequests[buildOutpointKey(fundingTxHash, fundingOutputIndex)] =
+		request
+}
+
+func (lc *localChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	request, ok := lc.movedFundsSweepRequests[buildOutpointKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *localChain) setMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+	request *tbtc.MovedFundsSweepRequest,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.movedFundsSweepRequests[buildOutpointKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)] = request
+}
+
+func (lc *localChain) IsMainUtxoSpent(
+	mainUtxoTxHash bitcoin.Hash,
+	mainUtxoTxOutputIndex uint32,
+) (bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.spentMainUtxos[buildOutpointKey(
+		mainUtxoTxHash,
+		mainUtxoTxOutputIndex,
+	)], nil
+}
+
+func (lc *localChain) setMainUtxoSpent(
+	mainUtxoTxHash bitcoin.Hash,
+	mainUtxoTxOutputIndex uint32,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.spentMainUtxos[buildOutpointKey(
+		mainUtxoTxHash,
+		mainUtxoTxOutputIndex,
+	)] = true
+}
+
+func (lc *localChain) ComputeMainUtxoHash(
+	mainUtxo *bitcoin.UnspentTransactionOutput,
+) [32]byte {
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, mainUtxo.Outpoint.OutputIndex)
+
+	valueBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBytes, uint64(mainUtxo.Value))
+
+	return sha256.Sum256(
+		append(
+			append(
+				mainUtxo.Outpoint.TransactionHash[:],
+				outputIndexBytes...,
+			), valueBytes...,
+		),
+	)
+}
+
+func (lc *localChain) GetFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) (*tbtc.FraudChallenge, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	challenge, ok := lc.fraudChallenges[buildFraudChallengeKey(
+		walletPublicKey,
+		sighash,
+	)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return challenge, true, nil
+}
+
+func (lc *localChain) setFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+	challenge *tbtc.FraudChallenge,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.fraudChallenges[buildFraudChallengeKey(walletPublicKey, sighash)] =
+		challenge
+}
+
+func (lc *localChain) SubmitFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	preimageSha256 [32]byte,
+	signature *tecdsa.Signature,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.submittedFraudChallenges = append(
+		lc.submittedFraudChallenges,
+		&submittedFraudChallenge{
+			walletPublicKey: walletPublicKey,
+			preimageSha256:  preimageSha256,
+			signature:       signature,
+		},
+	)
+
+	return nil
+}
+
+func (lc *localChain) getSubmittedFraudChallenges() []*submittedFraudChallenge {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.submittedFraudChallenges
+}
+
+func buildOutpointKey(
+	transactionHash bitcoin.Hash,
+	outputIndex uint32,
+) [32]byte {
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, outputIndex)
+
+	return sha256.Sum256(append(transactionHash[:], outputIndexBytes...))
+}
+
+func buildFraudChallengeKey(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) [32]byte {
+	walletPublicKeyBytes := elliptic.Marshal(
+		walletPublicKey.Curve,
+		walletPublicKey.X,
+		walletPublicKey.Y,
+	)
+
+	return sha256.Sum256(append(walletPublicKeyBytes, sighash[:]...))
+}
@@ -0,0 +1,70 @@
+package fraud
+
+import (
+	"time"
+)
+
+const (
+	// DefaultTransactionLimit is the default value for the limit of
+	// transactions returned for a given wallet public key hash. Fraudulent
+	// transactions are expected to be recent so there is no need to look
+	// far into the wallet's history.
+	DefaultTransactionLimit = 20
+
+	// DefaultMinConfirmations is the default value for the number of
+	// confirmations a wallet transaction must have before its signatures are
+	// considered fraudulent. Legitimate transactions are proven to the Bridge
+	// by the SPV maintainer shortly after they get enough confirmations. The
+	// value gives roughly three days for that to happen in order to avoid
+	// challenging legitimate yet unproven transactions and losing the
+	// challenge deposit.
+	DefaultMinConfirmations = 432
+
+	// DefaultPendingActionMaxConfirmations is the default value for the
+	// number of confirmations after which a wallet transaction matching
+	// a pending wallet action is no longer considered explained. A pending
+	// action may legitimately stay unproven for a while, e.g. when the SPV
+	// maintainer is down, but a wallet must not be able to steal revealed
+	// deposits forever just because they remain unswept. The value gives
+	// roughly two weeks for the pending action to be proven.
+	DefaultPendingActionMaxConfirmations = 2016
+
+	// DefaultRestartBackoffTime is the default value for restart back-off time.
+	DefaultRestartBackoffTime = 30 * time.Minute
+
+	// DefaultIdleBackOffTime is the default value for idle back-off time.
+	DefaultIdleBackOffTime = 30 * time.Minute
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// Enabled indicates whether the fraud maintainer should be started.
+	Enabled bool
+
+	// TransactionLimit sets the maximum number of confirmed transactions
+	// returned when getting transactions for a wallet public key hash. Only
+	// those transactions are inspected for fraudulent wallet signatures.
+	TransactionLimit int
+
+	// MinConfirmations is the number of confirmations a wallet transaction
+	// must have before signatures it contains can be challenged. This value
+	// must be high enough to let legitimate transactions be proven to the
+	// Bridge as the challenge deposit is lost if the challenge is defeated.
+	MinConfirmations uint
+
+	// PendingActionMaxConfirmations is the number of confirmations up to
+	// which a wallet transaction matching a pending wallet action, i.e.
+	// pending redemptions, an in-flight moving funds commitment, unswept
+	// deposits or pending moved funds sweep requests, is considered
+	// explained even though it has not been proven to the Bridge yet.
+	PendingActionMaxConfirmations uint
+
+	// RestartBackoffTime is a restart backoff which should be applied when the
+	// fraud maintainer is restarted. It helps to avoid being flooded with
+	// error logs in case of a permanent error in the fraud maintainer.
+	RestartBackoffTime time.Duration
+
+	// IdleBackoffTime is a wait time which should be applied between
+	// subsequent scans of wallet transactions.
+	IdleBackoffTime time.Duration
+}
@@ -0,0 +1,888 @@
+package fraud
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+var logger = log.Logger("keep-maintainer-fraud")
+
+func Initialize(
+	ctx context.Context,
+	config Config,
+	fraudChain Chain,
+	btcChain bitcoin.Chain,
+) {
+	fraudMaintainer := &fraudMaintainer{
+		config:     config,
+		fraudChain: fraudChain,
+		btcChain:   btcChain,
+	}
+
+	go fraudMaintainer.startControlLoop(ctx)
+}
+
+// fraudMaintainer is the part of maintainer responsible for watching wallet
+// signatures revealed on the Bitcoin chain and challenging the ones that
+// were not requested by the Bridge. The maintainer does not need any wallet
+// key material so any operator can run it as a watchtower.
+type fraudMaintainer struct {
+	config     Config
+	fraudChain Chain
+	btcChain   bitcoin.Chain
+}
+
+// startControlLoop starts the loop responsible for controlling the fraud
+// maintainer.
+func (fm *fraudMaintainer) startControlLoop(ctx context.Context) {
+	logger.Info("starting fraud maintainer")
+
+	defer func() {
+		logger.Info("stopping fraud maintainer")
+	}()
+
+	for {
+		err := fm.maintainFraud(ctx)
+		if err != nil {
+			logger.Errorf(
+				"error while maintaining fraud: [%v]; restarting maintainer",
+				err,
+			)
+		}
+
+		select {
+		case <-time.After(fm.config.RestartBackoffTime):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// maintainFraud periodically inspects wallet transactions and challenges
+// fraudulent wallet signatures.
+func (fm *fraudMaintainer) maintainFraud(ctx context.Context) error {
+	for {
+		logger.Info("starting fraud challenge task execution...")
+
+		if err := fm.challengeFraudulentSignatures(); err != nil {
+			return fmt.Errorf(
+				"error while challenging fraudulent signatures: [%v]",
+				err,
+			)
+		}
+
+		logger.Infof(
+			"fraud challenge task completed; next run in [%s]",
+			fm.config.IdleBackoffTime,
+		)
+
+		select {
+		case <-time.After(fm.config.IdleBackoffTime):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// challengeFraudulentSignatures looks for fraudulent signatures of all
+// wallets that can still be challenged and submits a fraud challenge for
+// each of them.
+func (fm *fraudMaintainer) challengeFraudulentSignatures() error {
+	walletPublicKeyHashes, err := getChallengeableWallets(fm.fraudChain)
+	if err != nil {
+		return fmt.Errorf("failed to get challengeable wallets: [%v]", err)
+	}
+
+	for _, walletPublicKeyHash := range walletPublicKeyHashes {
+		signatures, err := getFraudulentSignatures(
+			walletPublicKeyHash,
+			fm.config.TransactionLimit,
+			fm.config.MinConfirmations,
+			fm.config.PendingActionMaxConfirmations,
+			fm.btcChain,
+			fm.fraudChain,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to get fraudulent signatures of wallet [%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+		}
+
+		// All fraudulent signatures are challenged as any of the challenges
+		// may still be defeated, e.g. if the wallet manages to prove the
+		// transaction using the signature to the Bridge.
+		for _, signature := range signatures {
+			if err := submitFraudChallenge(
+				signature,
+				fm.fraudChain,
+			); err != nil {
+				return fmt.Errorf(
+					"failed to submit fraud challenge against wallet [%x]: [%v]",
+					walletPublicKeyHash,
+					err,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fraudulentSignature represents a wallet signature found on the Bitcoin
+// chain that is not explained by the Bridge state.
+type fraudulentSignature struct {
+	transactionHash bitcoin.Hash
+	inputIndex      int
+	walletPublicKey *ecdsa.PublicKey
+	// preimage is the preimage of the signature hash. The actual signature
+	// hash is the double SHA-256 of the preimage.
+	preimage  []byte
+	signature *tecdsa.Signature
+}
+
+// preimageSha256 returns the single SHA-256 of the signature hash preimage.
+func (fs *fraudulentSignature) preimageSha256() [32]byte {
+	return sha256.Sum256(fs.preimage)
+}
+
+// sighash returns the signature hash, i.e. the double SHA-256 of the
+// signature hash preimage.
+func (fs *fraudulentSignature) sighash() [32]byte {
+	preimageSha256 := fs.preimageSha256()
+	return sha256.Sum256(preimageSha256[:])
+}
+
+// getChallengeableWallets returns a list of unique wallet public key hashes
+// of all wallets that are in a state allowing to submit a fraud challenge
+// against them.
+func getChallengeableWallets(fraudChain Chain) ([][20]byte, error) {
+	events, err := fraudChain.PastNewWalletRegisteredEvents(nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past new wallet registered events: [%v]",
+			err,
+		)
+	}
+
+	cache := make(map[[20]byte]struct{})
+	var walletPublicKeyHashes [][20]byte
+
+	for _, event := range events {
+		walletPublicKeyHash := event.WalletPublicKeyHash
+
+		if _, exists := cache[walletPublicKeyHash]; exists {
+			continue
+		}
+		cache[walletPublicKeyHash] = struct{}{}
+
+		wallet, err := fraudChain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet: [%v]", err)
+		}
+
+		// Fraud challenges can only be submitted against wallets that are
+		// `Live`, `MovingFunds` or `Closing`.
+		if wallet.State != tbtc.StateLive &&
+			wallet.State != tbtc.StateMovingFunds &&
+			wallet.State != tbtc.StateClosing {
+			continue
+		}
+
+		walletPublicKeyHashes = append(
+			walletPublicKeyHashes,
+			walletPublicKeyHash,
+		)
+	}
+
+	return walletPublicKeyHashes, nil
+}
+
+// getFraudulentSignatures inspects the latest transactions of the given
+// wallet, along with transactions spending revealed deposits of the wallet,
+// and returns signatures produced by the wallet that are not explained by
+// the Bridge state. Transactions having less than the given
+// number of confirmations are skipped to let legitimate transactions be
+// proven first. Transactions having no more than the given number of pending
+// action confirmations are also considered explained if they match a pending
+// wallet action. Signatures that have already been challenged are not
+// returned.
+func getFraudulentSignatures(
+	walletPublicKeyHash [20]byte,
+	transactionLimit int,
+	minConfirmations uint,
+	pendingActionMaxConfirmations uint,
+	btcChain bitcoin.Chain,
+	fraudChain Chain,
+) ([]*fraudulentSignature, error) {
+	wallet, err := fraudChain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: [%v]", err)
+	}
+
+	transactions, err := btcChain.GetTransactionsForPublicKeyHash(
+		walletPublicKeyHash,
+		transactionLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get transactions for wallet: [%v]",
+			err,
+		)
+	}
+
+	// Transactions spending deposits to third parties do not pay the wallet
+	// public key hash so they must be looked for separately.
+	depositSpendingTransactions, err := getDepositSpendingTransactions(
+		walletPublicKeyHash,
+		btcChain,
+		fraudChain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get transactions spending deposits: [%v]",
+			err,
+		)
+	}
+
+	seenTransactions := make(map[bitcoin.Hash]bool)
+	for _, transaction := range transactions {
+		seenTransactions[transaction.Hash()] = true
+	}
+
+	for _, transaction := range depositSpendingTransactions {
+		if !seenTransactions[transaction.Hash()] {
+			seenTransactions[transaction.Hash()] = true
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	var fraudulentSignatures []*fraudulentSignature
+
+	for _, transaction := range transactions {
+		confirmations, err := btcChain.GetTransactionConfirmations(
+			transaction.Hash(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get transaction confirmations: [%v]",
+				err,
+			)
+		}
+
+		if confirmations < minConfirmations {
+			continue
+		}
+
+		acceptPendingActions := confirmations <= pendingActionMaxConfirmations
+
+		for inputIndex, input := range transaction.Inputs {
+			fraudulentSignature, err := getFraudulentSignature(
+				transaction,
+				inputIndex,
+				walletPublicKeyHash,
+				wallet,
+				acceptPendingActions,
+				btcChain,
+				fraudChain,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to check input [%s:%d] of transaction [%s]: [%v]",
+					input.Outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+					input.Outpoint.OutputIndex,
+					transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+					err,
+				)
+			}
+
+			if fraudulentSignature != nil {
+				fraudulentSignatures = append(
+					fraudulentSignatures,
+					fraudulentSignature,
+				)
+			}
+		}
+	}
+
+	return fraudulentSignatures, nil
+}
+
+// getDepositSpendingTransactions returns confirmed transactions spending
+// funding outputs of deposits revealed to the given wallet that have not
+// been swept yet. Spending a swept deposit is explained by the Bridge state
+// so such deposits are not inspected.
+func getDepositSpendingTransactions(
+	walletPublicKeyHash [20]byte,
+	btcChain bitcoin.Chain,
+	fraudChain Chain,
+) ([]*bitcoin.Transaction, error) {
+	events, err := fraudChain.PastDepositRevealedEvents(
+		&tbtc.DepositRevealedEventFilter{
+			WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past deposit revealed events: [%v]",
+			err,
+		)
+	}
+
+	var transactions []*bitcoin.Transaction
+
+	for _, event := range events {
+		fundingOutpoint := &bitcoin.TransactionOutpoint{
+			TransactionHash: event.FundingTxHash,
+			OutputIndex:     event.FundingOutputIndex,
+		}
+
+		isDepositPending, err := isDepositPendingSweep(
+			fundingOutpoint,
+			fraudChain,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isDepositPending {
+			continue
+		}
+
+		transaction, found, err := btcChain.GetSpendingTransaction(
+			fundingOutpoint,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get transaction spending deposit [%s:%d]: [%v]",
+				event.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+				event.FundingOutputIndex,
+				err,
+			)
+		}
+
+		if found {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+// getFraudulentSignature checks whether the given transaction input carries
+// a signature of the given wallet that is not explained by the Bridge state.
+// If pending actions are accepted, the input matching a pending wallet action
+// is considered explained as well. Returns nil if the input is not signed by
+// the wallet, the input is explained or the signature has already been
+// challenged.
+func getFraudulentSignature(
+	transaction *bitcoin.Transaction,
+	inputIndex int,
+	walletPublicKeyHash [20]byte,
+	wallet *tbtc.WalletChainData,
+	acceptPendingActions bool,
+	btcChain bitcoin.Chain,
+	fraudChain Chain,
+) (*fraudulentSignature, error) {
+	input := transaction.Inputs[inputIndex]
+
+	walletSignature, ok := extractWalletSignature(input, walletPublicKeyHash)
+	if !ok {
+		// The input is not signed by the wallet.
+		return nil, nil
+	}
+
+	isExplained, err := isInputExplained(
+		input.Outpoint.TransactionHash,
+		input.Outpoint.OutputIndex,
+		fraudChain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if input is explained: [%v]",
+			err,
+		)
+	}
+
+	if !isExplained && acceptPendingActions {
+		isExplained, err = isInputPending(
+			transaction,
+			inputIndex,
+			wallet,
+			btcChain,
+			fraudChain,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to check if input matches a pending action: [%v]",
+				err,
+			)
+		}
+	}
+
+	if isExplained {
+		return nil, nil
+	}
+
+	// The value of the spent output is committed to only by witness
+	// signatures.
+	var value int64
+	if walletSignature.witness {
+		previousTransaction, err := btcChain.GetTransaction(
+			input.Outpoint.TransactionHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get previous transaction: [%v]",
+				err,
+			)
+		}
+
+		outputIndex := int(input.Outpoint.OutputIndex)
+		if outputIndex >= len(previousTransaction.Outputs) {
+			return nil, fmt.Errorf(
+				"previous transaction has no output with index [%v]",
+				outputIndex,
+			)
+		}
+
+		value = previousTransaction.Outputs[outputIndex].Value
+	}
+
+	preimage, err := transaction.ComputeSignatureHashPreimage(
+		inputIndex,
+		walletSignature.scriptCode,
+		value,
+		walletSignature.witness,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to compute signature hash preimage: [%v]",
+			err,
+		)
+	}
+
+	fraudulentSignature := &fraudulentSignature{
+		transactionHash: transaction.Hash(),
+		inputIndex:      inputIndex,
+		walletPublicKey: walletSignature.publicKey,
+		preimage:        preimage,
+	}
+
+	sighash := fraudulentSignature.sighash()
+
+	signature, err := recoverableSignature(
+		walletSignature.signature,
+		walletSignature.publicKey,
+		sighash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to determine signature recovery ID: [%v]",
+			err,
+		)
+	}
+
+	fraudulentSignature.signature = signature
+
+	_, isChallenged, err := fraudChain.GetFraudChallenge(
+		walletSignature.publicKey,
+		sighash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fraud challenge: [%v]", err)
+	}
+
+	if isChallenged {
+		logger.Infof(
+			"signature of wallet [%x] used in input [%d] of "+
+				"transaction [%s] has already been challenged",
+			walletPublicKeyHash,
+			inputIndex,
+			transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+		)
+		return nil, nil
+	}
+
+	return fraudulentSignature, nil
+}
+
+// isInputExplained checks whether spending the given outpoint was requested
+// by the Bridge. That is the case if the outpoint is a swept deposit, a main
+// UTXO spent by a proven wallet transaction, or a processed moved funds
+// sweep request.
+func isInputExplained(
+	outpointTransactionHash bitcoin.Hash,
+	outpointIndex uint32,
+	fraudChain Chain,
+) (bool, error) {
+	depositRequest, found, err := fraudChain.GetDepositRequest(
+		outpointTransactionHash,
+		outpointIndex,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to get deposit request: [%v]", err)
+	}
+
+	if found && depositRequest.SweptAt.Unix() != 0 {
+		return true, nil
+	}
+
+	isSpent, err := fraudChain.IsMainUtxoSpent(
+		outpointTransactionHash,
+		outpointIndex,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to check if main UTXO is spent: [%v]",
+			err,
+		)
+	}
+
+	if isSpent {
+		return true, nil
+	}
+
+	movedFundsSweepRequest, found, err := fraudChain.GetMovedFundsSweepRequest(
+		outpointTransactionHash,
+		outpointIndex,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to get moved funds sweep request: [%v]",
+			err,
+		)
+	}
+
+	if found && movedFundsSweepRequest.State == tbtc.MovedFundsStateProcessed {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isInputPending checks whether spending the given transaction input matches
+// a wallet action that is pending on the Bridge and may have not been proven
+// yet. That is the case if the input spends a revealed deposit that has not
+// been swept yet or a pending moved funds sweep request. That is also the
+// case if the input spends the current wallet main UTXO while the wallet has
+// pending redemptions, an in-flight moving funds commitment or pending moved
+// funds sweep requests, or while the same transaction sweeps revealed
+// deposits.
+func isInputPending(
+	transaction *bitcoin.Transaction,
+	inputIndex int,
+	wallet *tbtc.WalletChainData,
+	btcChain bitcoin.Chain,
+	fraudChain Chain,
+) (bool, error) {
+	outpoint := transaction.Inputs[inputIndex].Outpoint
+
+	isDepositPending, err := isDepositPendingSweep(outpoint, fraudChain)
+	if err != nil {
+		return false, err
+	}
+
+	if isDepositPending {
+		return true, nil
+	}
+
+	movedFundsSweepRequest, found, err := fraudChain.GetMovedFundsSweepRequest(
+		outpoint.TransactionHash,
+		outpoint.OutputIndex,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to get moved funds sweep request: [%v]",
+			err,
+		)
+	}
+
+	if found && movedFundsSweepRequest.State == tbtc.MovedFundsStatePending {
+		return true, nil
+	}
+
+	isMainUtxo, err := isWalletMainUtxo(outpoint, wallet, btcChain, fraudChain)
+	if err != nil {
+		return false, err
+	}
+
+	if !isMainUtxo {
+		return false, nil
+	}
+
+	if wallet.PendingRedemptionsValue > 0 ||
+		wallet.PendingMovedFundsSweepRequestsCount > 0 {
+		return true, nil
+	}
+
+	if wallet.State == tbtc.StateMovingFunds &&
+		wallet.MovingFundsTargetWalletsCommitmentHash != [32]byte{} {
+		return true, nil
+	}
+
+	// Deposit sweep transactions spend the main UTXO along with the swept
+	// deposits.
+	for i, input := range transaction.Inputs {
+		if i == inputIndex {
+			continue
+		}
+
+		isDepositPending, err := isDepositPendingSweep(
+			input.Outpoint,
+			fraudChain,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if isDepositPending {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isDepositPendingSweep checks whether the given outpoint is a revealed
+// deposit that has not been swept yet.
+func isDepositPendingSweep(
+	outpoint *bitcoin.TransactionOutpoint,
+	fraudChain Chain,
+) (bool, error) {
+	depositRequest, found, err := fraudChain.GetDepositRequest(
+		outpoint.TransactionHash,
+		outpoint.OutputIndex,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to get deposit request: [%v]", err)
+	}
+
+	return found && depositRequest.SweptAt.Unix() == 0, nil
+}
+
+// isWalletMainUtxo checks whether the given outpoint is the current main
+// UTXO of the given wallet.
+func isWalletMainUtxo(
+	outpoint *bitcoin.TransactionOutpoint,
+	wallet *tbtc.WalletChainData,
+	btcChain bitcoin.Chain,
+	fraudChain Chain,
+) (bool, error) {
+	if wallet.MainUtxoHash == [32]byte{} {
+		return false, nil
+	}
+
+	transaction, err := btcChain.GetTransaction(outpoint.TransactionHash)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to get previous transaction: [%v]",
+			err,
+		)
+	}
+
+	outputIndex := int(outpoint.OutputIndex)
+	if outputIndex >= len(transaction.Outputs) {
+		return false, fmt.Errorf(
+			"previous transaction has no output with index [%v]",
+			outputIndex,
+		)
+	}
+
+	mainUtxoHash := fraudChain.ComputeMainUtxoHash(
+		&bitcoin.UnspentTransactionOutput{
+			Outpoint: outpoint,
+			Value:    transaction.Outputs[outputIndex].Value,
+		},
+	)
+
+	return mainUtxoHash == wallet.MainUtxoHash, nil
+}
+
+// walletSignature represents a wallet signature extracted from a transaction
+// input, along with the data necessary to compute the signed hash.
+type walletSignature struct {
+	signature *btcec.Signature
+	publicKey *ecdsa.PublicKey
+	// scriptCode is the script satisfied by the input.
+	scriptCode bitcoin.Script
+	// witness determines whether the input is a witness input.
+	witness bool
+}
+
+// extractWalletSignature extracts the signature of the given wallet from
+// the given transaction input. Supported inputs are P2PKH, P2WPKH and P2SH,
+// P2WSH ones with the signature and the wallet public key at the beginning
+// of the signature script or witness, which is the case for all inputs
+// signed by tBTC wallets. The returned bool value is false if the input
+// is not signed by the wallet.
+func extractWalletSignature(
+	input *bitcoin.TransactionInput,
+	walletPublicKeyHash [20]byte,
+) (*walletSignature, bool) {
+	var signatureBytes, publicKeyBytes []byte
+	var scriptCode bitcoin.Script
+	var witness bool
+
+	switch {
+	case len(input.Witness) == 2:
+		// P2WPKH input: <signature> <publicKey>.
+		p2pkh, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+		if err != nil {
+			return nil, false
+		}
+
+		signatureBytes = input.Witness[0]
+		publicKeyBytes = input.Witness[1]
+		scriptCode = p2pkh
+		witness = true
+	case len(input.Witness) == 3:
+		// P2WSH input: <signature> <publicKey> <witnessScript>.
+		signatureBytes = input.Witness[0]
+		publicKeyBytes = input.Witness[1]
+		scriptCode = input.Witness[2]
+		witness = true
+	case len(input.Witness) == 0:
+		pushes, err := txscript.PushedData(input.SignatureScript)
+		if err != nil {
+			return nil, false
+		}
+
+		switch len(pushes) {
+		case 2:
+			// P2PKH input: <signature> <publicKey>.
+			p2pkh, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+			if err != nil {
+				return nil, false
+			}
+
+			scriptCode = p2pkh
+		case 3:
+			// P2SH input: <signature> <publicKey> <redeemScript>.
+			scriptCode = pushes[2]
+		default:
+			return nil, false
+		}
+
+		signatureBytes = pushes[0]
+		publicKeyBytes = pushes[1]
+		witness = false
+	default:
+		return nil, false
+	}
+
+	publicKey, err := btcec.ParsePubKey(publicKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, false
+	}
+
+	if bitcoin.PublicKeyHash(publicKey.ToECDSA()) != walletPublicKeyHash {
+		return nil, false
+	}
+
+	// tBTC wallets sign all inputs using the SIGHASH_ALL type only. The
+	// sighash type is appended to the DER signature.
+	if len(signatureBytes) == 0 ||
+		signatureBytes[len(signatureBytes)-1] != bitcoin.SignatureHashAllType {
+		return nil, false
+	}
+
+	signature, err := btcec.ParseDERSignature(
+		signatureBytes[:len(signatureBytes)-1],
+		btcec.S256(),
+	)
+	if err != nil {
+		return nil, false
+	}
+
+	return &walletSignature{
+		signature:  signature,
+		publicKey:  publicKey.ToECDSA(),
+		scriptCode: scriptCode,
+		witness:    witness,
+	}, true
+}
+
+// recoverableSignature determines the recovery ID of the given signature
+// over the given hash, produced by the given public key. The recovery ID is
+// needed by the Bridge to verify the signature.
+func recoverableSignature(
+	signature *btcec.Signature,
+	publicKey *ecdsa.PublicKey,
+	hash [32]byte,
+) (*tecdsa.Signature, error) {
+	r := signature.R.Bytes()
+	s := signature.S.Bytes()
+
+	// The compact signature format is <header> <R> <S> where R and S are
+	// 32-byte big-endian values and the header is 27 plus the recovery ID.
+	compactSignature := make([]byte, 65)
+	copy(compactSignature[33-len(r):33], r)
+	copy(compactSignature[65-len(s):65], s)
+
+	expectedPublicKey := (*btcec.PublicKey)(publicKey).SerializeUncompressed()
+
+	for recoveryID := 0; recoveryID < 2; recoveryID++ {
+		compactSignature[0] = byte(27 + recoveryID)
+
+		recoveredPublicKey, _, err := btcec.RecoverCompact(
+			btcec.S256(),
+			compactSignature,
+			hash[:],
+		)
+		if err != nil {
+			continue
+		}
+
+		if bytes.Equal(
+			recoveredPublicKey.SerializeUncompressed(),
+			expectedPublicKey,
+		) {
+			return &tecdsa.Signature{
+				R:          signature.R,
+				S:          signature.S,
+				RecoveryID: int8(recoveryID),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("signature does not match the public key")
+}
+
+// submitFraudChallenge submits a fraud challenge for the given fraudulent
+// signature.
+func submitFraudChallenge(
+	fraudulentSignature *fraudulentSignature,
+	fraudChain Chain,
+) error {
+	logger.Warnf(
+		"submitting fraud challenge against wallet [%x] for signature "+
+			"used in input [%d] of transaction [%s]",
+		bitcoin.PublicKeyHash(fraudulentSignature.walletPublicKey),
+		fraudulentSignature.inputIndex,
+		fraudulentSignature.transactionHash.Hex(bitcoin.ReversedByteOrder),
+	)
+
+	if err := fraudChain.SubmitFraudChallenge(
+		fraudulentSignature.walletPublicKey,
+		fraudulentSignature.preimageSha256(),
+		fraudulentSignature.signature,
+	); err != nil {
+		return fmt.Errorf("failed to submit fraud challenge: [%v]", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,893 @@
+package fraud
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestGetChallengeableWallets(t *testing.T) {
+	fraudChain := newLocalChain()
+
+	walletStates := map[[20]byte]tbtc.WalletState{
+		{0x01}: tbtc.StateLive,
+		{0x02}: tbtc.StateMovingFunds,
+		{0x03}: tbtc.StateClosing,
+		{0x04}: tbtc.StateClosed,
+		{0x05}: tbtc.StateTerminated,
+	}
+
+	for _, walletPublicKeyHash := range [][20]byte{
+		{0x01}, {0x02}, {0x03}, {0x04}, {0x05},
+	} {
+		fraudChain.setWallet(walletPublicKeyHash, &tbtc.WalletChainData{
+			State: walletStates[walletPublicKeyHash],
+		})
+		fraudChain.addPastNewWalletRegisteredEvent(
+			&tbtc.NewWalletRegisteredEvent{
+				WalletPublicKeyHash: walletPublicKeyHash,
+			},
+		)
+	}
+
+	// Duplicated event must not result in a duplicated wallet.
+	fraudChain.addPastNewWalletRegisteredEvent(
+		&tbtc.NewWalletRegisteredEvent{
+			WalletPublicKeyHash: [20]byte{0x01},
+		},
+	)
+
+	walletPublicKeyHashes, err := getChallengeableWallets(fraudChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedWalletPublicKeyHashes := [][20]byte{{0x01}, {0x02}, {0x03}}
+	if diff := deep.Equal(
+		expectedWalletPublicKeyHashes,
+		walletPublicKeyHashes,
+	); diff != nil {
+		t.Errorf("invalid wallets: %v", diff)
+	}
+}
+
+func TestGetFraudulentSignatures(t *testing.T) {
+	transactionLimit := 20
+	minConfirmations := uint(100)
+	pendingActionMaxConfirmations := uint(500)
+
+	btcChain := newLocalBitcoinChain()
+	fraudChain := newLocalChain()
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		walletPrivateKey.PubKey().ToECDSA(),
+	)
+
+	fraudChain.setWallet(walletPublicKeyHash, &tbtc.WalletChainData{
+		State: tbtc.StateLive,
+	})
+
+	// The funding transaction creates a set of UTXOs locked on the wallet
+	// public key hash. Each UTXO is spent by a separate transaction signed
+	// by the wallet.
+	fundingTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xff},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+	}
+	for i := 0; i < 7; i++ {
+		script, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The output with index 6 is a legacy P2PKH output.
+		if i == 6 {
+			script, err = bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		fundingTransaction.Outputs = append(
+			fundingTransaction.Outputs,
+			&bitcoin.TransactionOutput{
+				Value:           int64(100000 * (i + 1)),
+				PublicKeyScript: script,
+			},
+		)
+	}
+
+	if err := btcChain.BroadcastTransaction(fundingTransaction); err != nil {
+		t.Fatal(err)
+	}
+	btcChain.setTransactionConfirmations(fundingTransaction.Hash(), 1000)
+
+	spendingTransactions := make([]*bitcoin.Transaction, 7)
+	for i := range spendingTransactions {
+		spendingTransactions[i] = newSignedTransaction(
+			t,
+			walletPrivateKey,
+			fundingTransaction,
+			uint32(i),
+		)
+
+		if err := btcChain.BroadcastTransaction(
+			spendingTransactions[i],
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		confirmations := uint(1000)
+		// Transaction 2 is not confirmed enough.
+		if i == 2 {
+			confirmations = minConfirmations - 1
+		}
+
+		btcChain.setTransactionConfirmations(
+			spendingTransactions[i].Hash(),
+			confirmations,
+		)
+	}
+
+	// Transaction 0 spends the main UTXO and was proven to the Bridge.
+	fraudChain.setMainUtxoSpent(fundingTransaction.Hash(), 0)
+	// Transaction 1 is unexplained.
+	// Transaction 2 is unexplained but has not enough confirmations yet.
+	// Transaction 3 sweeps a deposit.
+	fraudChain.setDepositRequest(
+		fundingTransaction.Hash(),
+		3,
+		&tbtc.DepositChainRequest{
+			RevealedAt: time.Unix(1000, 0),
+			SweptAt:    time.Unix(2000, 0),
+		},
+	)
+	// Transaction 4 sweeps moved funds.
+	fraudChain.setMovedFundsSweepRequest(
+		fundingTransaction.Hash(),
+		4,
+		&tbtc.MovedFundsSweepRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			State:               tbtc.MovedFundsStateProcessed,
+		},
+	)
+	// Transaction 5 is unexplained but has already been challenged.
+	fraudChain.setFraudChallenge(
+		walletPrivateKey.PubKey().ToECDSA(),
+		computeSigHash(
+			t,
+			spendingTransactions[5],
+			0,
+			fundingTransaction.Outputs[5],
+		),
+		&tbtc.FraudChallenge{ReportedAt: time.Unix(3000, 0)},
+	)
+	// Transaction 6 is unexplained and spends a legacy P2PKH output.
+
+	fraudulentSignatures, err := getFraudulentSignatures(
+		walletPublicKeyHash,
+		transactionLimit,
+		minConfirmations,
+		pendingActionMaxConfirmations,
+		btcChain,
+		fraudChain,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTransactions := []*bitcoin.Transaction{
+		spendingTransactions[1],
+		spendingTransactions[6],
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"fraudulent signatures count",
+		len(expectedTransactions),
+		len(fraudulentSignatures),
+	)
+
+	for i, fraudulentSignature := range fraudulentSignatures {
+		expectedTransactionHash := expectedTransactions[i].Hash()
+		testutils.AssertBytesEqual(
+			t,
+			expectedTransactionHash[:],
+			fraudulentSignature.transactionHash[:],
+		)
+
+		testutils.AssertIntsEqual(
+			t,
+			"input index",
+			0,
+			fraudulentSignature.inputIndex,
+		)
+
+		// Make sure the signature along with the recovery ID can be used
+		// to recover the wallet public key from the signature hash.
+		sighash := fraudulentSignature.sighash()
+
+		compactSignature := make([]byte, 65)
+		compactSignature[0] = byte(27 + fraudulentSignature.signature.RecoveryID)
+		fraudulentSignature.signature.R.FillBytes(compactSignature[1:33])
+		fraudulentSignature.signature.S.FillBytes(compactSignature[33:65])
+
+		recoveredPublicKey, _, err := btcec.RecoverCompact(
+			btcec.S256(),
+			compactSignature,
+			sighash[:],
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !recoveredPublicKey.IsEqual(walletPrivateKey.PubKey()) {
+			t.Errorf("recovered public key does not match the wallet one")
+		}
+	}
+}
+
+func TestGetFraudulentSignatures_PendingActions(t *testing.T) {
+	minConfirmations := uint(100)
+	pendingActionMaxConfirmations := uint(500)
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		walletPrivateKey.PubKey().ToECDSA(),
+	)
+
+	script, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xff},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{Value: 100000, PublicKeyScript: script},
+		},
+	}
+
+	fundingOutpoint := &bitcoin.TransactionOutpoint{
+		TransactionHash: fundingTransaction.Hash(),
+		OutputIndex:     0,
+	}
+
+	// The outpoint of another deposit that may be swept along with the
+	// main UTXO.
+	depositOutpoint := &bitcoin.TransactionOutpoint{
+		TransactionHash: bitcoin.Hash{0xdd},
+		OutputIndex:     1,
+	}
+
+	mainUtxoHash := newLocalChain().ComputeMainUtxoHash(
+		&bitcoin.UnspentTransactionOutput{
+			Outpoint: fundingOutpoint,
+			Value:    fundingTransaction.Outputs[0].Value,
+		},
+	)
+
+	unsweptDeposit := &tbtc.DepositChainRequest{
+		RevealedAt: time.Unix(1000, 0),
+		SweptAt:    time.Unix(0, 0),
+	}
+
+	var tests = map[string]struct {
+		wallet              *tbtc.WalletChainData
+		depositRequests     map[*bitcoin.TransactionOutpoint]*tbtc.DepositChainRequest
+		movedFundsState     tbtc.MovedFundsSweepRequestState
+		additionalOutpoints []*bitcoin.TransactionOutpoint
+		confirmations       uint
+		expectedFraudulent  bool
+	}{
+		"unswept deposit": {
+			wallet: &tbtc.WalletChainData{State: tbtc.StateLive},
+			depositRequests: map[*bitcoin.TransactionOutpoint]*tbtc.DepositChainRequest{
+				fundingOutpoint: unsweptDeposit,
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: false,
+		},
+		"unswept deposit with too many confirmations": {
+			wallet: &tbtc.WalletChainData{State: tbtc.StateLive},
+			depositRequests: map[*bitcoin.TransactionOutpoint]*tbtc.DepositChainRequest{
+				fundingOutpoint: unsweptDeposit,
+			},
+			confirmations:      pendingActionMaxConfirmations + 1,
+			expectedFraudulent: true,
+		},
+		"pending moved funds sweep request": {
+			wallet:             &tbtc.WalletChainData{State: tbtc.StateLive},
+			movedFundsState:    tbtc.MovedFundsStatePending,
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: false,
+		},
+		"main UTXO with pending redemptions": {
+			wallet: &tbtc.WalletChainData{
+				State:                   tbtc.StateLive,
+				MainUtxoHash:            mainUtxoHash,
+				PendingRedemptionsValue: 50000,
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: false,
+		},
+		"main UTXO with moving funds commitment": {
+			wallet: &tbtc.WalletChainData{
+				State:                                  tbtc.StateMovingFunds,
+				MainUtxoHash:                           mainUtxoHash,
+				MovingFundsTargetWalletsCommitmentHash: [32]byte{0x01},
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: false,
+		},
+		"main UTXO with pending moved funds sweep requests": {
+			wallet: &tbtc.WalletChainData{
+				State:                               tbtc.StateLive,
+				MainUtxoHash:                        mainUtxoHash,
+				PendingMovedFundsSweepRequestsCount: 1,
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: false,
+		},
+		"main UTXO swept along with unswept deposit": {
+			wallet: &tbtc.WalletChainData{
+				State:        tbtc.StateLive,
+				MainUtxoHash: mainUtxoHash,
+			},
+			depositRequests: map[*bitcoin.TransactionOutpoint]*tbtc.DepositChainRequest{
+				depositOutpoint: unsweptDeposit,
+			},
+			additionalOutpoints: []*bitcoin.TransactionOutpoint{depositOutpoint},
+			confirmations:       pendingActionMaxConfirmations,
+			expectedFraudulent:  false,
+		},
+		"main UTXO without pending actions": {
+			wallet: &tbtc.WalletChainData{
+				State:        tbtc.StateLive,
+				MainUtxoHash: mainUtxoHash,
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: true,
+		},
+		"main UTXO with pending redemptions and too many confirmations": {
+			wallet: &tbtc.WalletChainData{
+				State:                   tbtc.StateLive,
+				MainUtxoHash:            mainUtxoHash,
+				PendingRedemptionsValue: 50000,
+			},
+			confirmations:      pendingActionMaxConfirmations + 1,
+			expectedFraudulent: true,
+		},
+		"not main UTXO with pending redemptions": {
+			wallet: &tbtc.WalletChainData{
+				State:                   tbtc.StateLive,
+				MainUtxoHash:            [32]byte{0x02},
+				PendingRedemptionsValue: 50000,
+			},
+			confirmations:      pendingActionMaxConfirmations,
+			expectedFraudulent: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := newLocalBitcoinChain()
+			fraudChain := newLocalChain()
+
+			fraudChain.setWallet(walletPublicKeyHash, test.wallet)
+
+			for outpoint, depositRequest := range test.depositRequests {
+				fraudChain.setDepositRequest(
+					outpoint.TransactionHash,
+					outpoint.OutputIndex,
+					depositRequest,
+				)
+			}
+
+			if test.movedFundsState != tbtc.MovedFundsStateUnknown {
+				fraudChain.setMovedFundsSweepRequest(
+					fundingOutpoint.TransactionHash,
+					fundingOutpoint.OutputIndex,
+					&tbtc.MovedFundsSweepRequest{
+						WalletPublicKeyHash: walletPublicKeyHash,
+						State:               test.movedFundsState,
+					},
+				)
+			}
+
+			spendingTransaction := newSignedTransaction(
+				t,
+				walletPrivateKey,
+				fundingTransaction,
+				0,
+				test.additionalOutpoints...,
+			)
+
+			for _, transaction := range []*bitcoin.Transaction{
+				fundingTransaction,
+				spendingTransaction,
+			} {
+				if err := btcChain.BroadcastTransaction(
+					transaction,
+				); err != nil {
+					t.Fatal(err)
+				}
+				btcChain.setTransactionConfirmations(
+					transaction.Hash(),
+					test.confirmations,
+				)
+			}
+
+			fraudulentSignatures, err := getFraudulentSignatures(
+				walletPublicKeyHash,
+				DefaultTransactionLimit,
+				minConfirmations,
+				pendingActionMaxConfirmations,
+				btcChain,
+				fraudChain,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"fraudulent",
+				test.expectedFraudulent,
+				len(fraudulentSignatures) > 0,
+			)
+		})
+	}
+}
+
+func TestGetFraudulentSignatures_DepositSpends(t *testing.T) {
+	minConfirmations := uint(100)
+	pendingActionMaxConfirmations := uint(500)
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		walletPrivateKey.PubKey().ToECDSA(),
+	)
+
+	deposit := &tbtc.Deposit{
+		Depositor:           chain.Address("0x934B98637cA318a4D6E7CA6ffd1690b8e77df637"),
+		BlindingFactor:      [8]byte{0xf9, 0xf0, 0xc9, 0x0d, 0x00, 0x03, 0x95, 0x23},
+		WalletPublicKeyHash: walletPublicKeyHash,
+		RefundPublicKeyHash: [20]byte{0xaa},
+		RefundLocktime:      [4]byte{0x60, 0xbc, 0xea, 0x61},
+	}
+
+	depositScript, err := deposit.Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	depositScriptHash, err := bitcoin.PayToWitnessScriptHash(
+		bitcoin.WitnessScriptHash(depositScript),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thiefScript, err := bitcoin.PayToWitnessPublicKeyHash([20]byte{0xee})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		confirmations      uint
+		sweptAt            time.Time
+		expectedFraudulent bool
+	}{
+		"unswept deposit spent beyond the pending action window": {
+			confirmations:      pendingActionMaxConfirmations + 1,
+			sweptAt:            time.Unix(0, 0),
+			expectedFraudulent: true,
+		},
+		"unswept deposit spent within the pending action window": {
+			confirmations:      pendingActionMaxConfirmations,
+			sweptAt:            time.Unix(0, 0),
+			expectedFraudulent: false,
+		},
+		"swept deposit": {
+			confirmations:      pendingActionMaxConfirmations + 1,
+			sweptAt:            time.Unix(2000, 0),
+			expectedFraudulent: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := newLocalBitcoinChain()
+			fraudChain := newLocalChain()
+
+			fraudChain.setWallet(walletPublicKeyHash, &tbtc.WalletChainData{
+				State: tbtc.StateLive,
+			})
+
+			fundingTransaction := &bitcoin.Transaction{
+				Version: 1,
+				Inputs: []*bitcoin.TransactionInput{
+					{
+						Outpoint: &bitcoin.TransactionOutpoint{
+							TransactionHash: bitcoin.Hash{0xff},
+							OutputIndex:     0,
+						},
+						SignatureScript: []byte{},
+						Sequence:        0xffffffff,
+					},
+				},
+				Outputs: []*bitcoin.TransactionOutput{
+					{Value: 500000, PublicKeyScript: depositScriptHash},
+				},
+			}
+
+			// The wallet spends the deposit to a third party so the
+			// transaction does not pay the wallet public key hash.
+			theftTransaction := &bitcoin.Transaction{
+				Version: 1,
+				Inputs: []*bitcoin.TransactionInput{
+					{
+						Outpoint: &bitcoin.TransactionOutpoint{
+							TransactionHash: fundingTransaction.Hash(),
+							OutputIndex:     0,
+						},
+						SignatureScript: []byte{},
+						Sequence:        0xffffffff,
+					},
+				},
+				Outputs: []*bitcoin.TransactionOutput{
+					{Value: 499000, PublicKeyScript: thiefScript},
+				},
+			}
+
+			preimage, err := theftTransaction.ComputeSignatureHashPreimage(
+				0,
+				depositScript,
+				fundingTransaction.Outputs[0].Value,
+				true,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			preimageSha256 := sha256.Sum256(preimage)
+			sighash := sha256.Sum256(preimageSha256[:])
+
+			signature, err := walletPrivateKey.Sign(sighash[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			theftTransaction.Inputs[0].Witness = [][]byte{
+				append(signature.Serialize(), bitcoin.SignatureHashAllType),
+				walletPrivateKey.PubKey().SerializeCompressed(),
+				depositScript,
+			}
+
+			for _, transaction := range []*bitcoin.Transaction{
+				fundingTransaction,
+				theftTransaction,
+			} {
+				if err := btcChain.BroadcastTransaction(transaction); err != nil {
+					t.Fatal(err)
+				}
+				btcChain.setTransactionConfirmations(
+					transaction.Hash(),
+					test.confirmations,
+				)
+			}
+
+			fraudChain.addPastDepositRevealedEvent(
+				&tbtc.DepositRevealedEvent{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					FundingTxHash:       fundingTransaction.Hash(),
+					FundingOutputIndex:  0,
+				},
+			)
+			fraudChain.setDepositRequest(
+				fundingTransaction.Hash(),
+				0,
+				&tbtc.DepositChainRequest{
+					RevealedAt: time.Unix(1000, 0),
+					SweptAt:    test.sweptAt,
+				},
+			)
+
+			fraudulentSignatures, err := getFraudulentSignatures(
+				walletPublicKeyHash,
+				DefaultTransactionLimit,
+				minConfirmations,
+				pendingActionMaxConfirmations,
+				btcChain,
+				fraudChain,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !test.expectedFraudulent {
+				testutils.AssertIntsEqual(
+					t,
+					"fraudulent signatures count",
+					0,
+					len(fraudulentSignatures),
+				)
+				return
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"fraudulent signatures count",
+				1,
+				len(fraudulentSignatures),
+			)
+
+			actualSighash := fraudulentSignatures[0].sighash()
+			testutils.AssertBytesEqual(t, sighash[:], actualSighash[:])
+		})
+	}
+}
+
+func TestFraudMaintainer_ChallengeFraudulentSignatures(t *testing.T) {
+	btcChain := newLocalBitcoinChain()
+	fraudChain := newLocalChain()
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		walletPrivateKey.PubKey().ToECDSA(),
+	)
+
+	fraudChain.setWallet(walletPublicKeyHash, &tbtc.WalletChainData{
+		State: tbtc.StateLive,
+	})
+	fraudChain.addPastNewWalletRegisteredEvent(
+		&tbtc.NewWalletRegisteredEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+		},
+	)
+
+	script, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xff},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{Value: 100000, PublicKeyScript: script},
+			{Value: 200000, PublicKeyScript: script},
+		},
+	}
+
+	// Both transactions are unexplained so a fraud challenge should be
+	// submitted for each of them.
+	transactions := []*bitcoin.Transaction{
+		fundingTransaction,
+		newSignedTransaction(t, walletPrivateKey, fundingTransaction, 0),
+		newSignedTransaction(t, walletPrivateKey, fundingTransaction, 1),
+	}
+
+	for _, transaction := range transactions {
+		if err := btcChain.BroadcastTransaction(transaction); err != nil {
+			t.Fatal(err)
+		}
+		btcChain.setTransactionConfirmations(transaction.Hash(), 1000)
+	}
+
+	fraudMaintainer := &fraudMaintainer{
+		config: Config{
+			TransactionLimit:              DefaultTransactionLimit,
+			MinConfirmations:              DefaultMinConfirmations,
+			PendingActionMaxConfirmations: DefaultPendingActionMaxConfirmations,
+		},
+		fraudChain: fraudChain,
+		btcChain:   btcChain,
+	}
+
+	if err := fraudMaintainer.challengeFraudulentSignatures(); err != nil {
+		t.Fatal(err)
+	}
+
+	submittedChallenges := fraudChain.getSubmittedFraudChallenges()
+
+	testutils.AssertIntsEqual(
+		t,
+		"submitted fraud challenges count",
+		2,
+		len(submittedChallenges),
+	)
+
+	for i, submittedChallenge := range submittedChallenges {
+		if !(*btcec.PublicKey)(submittedChallenge.walletPublicKey).IsEqual(
+			walletPrivateKey.PubKey(),
+		) {
+			t.Errorf("invalid wallet public key of challenge [%v]", i)
+		}
+
+		expectedSigHash := computeSigHash(
+			t,
+			transactions[i+1],
+			0,
+			fundingTransaction.Outputs[i],
+		)
+		actualSigHash := sha256.Sum256(submittedChallenge.preimageSha256[:])
+		testutils.AssertBytesEqual(t, expectedSigHash[:], actualSigHash[:])
+	}
+}
+
+// newSignedTransaction creates a transaction spending the given output of
+// the given previous transaction, signed with the given private key. The
+// input is a witness one if the spent output is P2WPKH and a legacy one
+// otherwise. Additional outpoints are spent by unsigned inputs following
+// the signed one.
+func newSignedTransaction(
+	t *testing.T,
+	privateKey *btcec.PrivateKey,
+	previousTransaction *bitcoin.Transaction,
+	outputIndex uint32,
+	additionalOutpoints ...*bitcoin.TransactionOutpoint,
+) *bitcoin.Transaction {
+	previousOutput := previousTransaction.Outputs[outputIndex]
+
+	outputScript, err := bitcoin.PayToWitnessPublicKeyHash([20]byte{0xee})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: previousTransaction.Hash(),
+					OutputIndex:     outputIndex,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           previousOutput.Value - 1000,
+				PublicKeyScript: outputScript,
+			},
+		},
+	}
+
+	for _, outpoint := range additionalOutpoints {
+		transaction.Inputs = append(
+			transaction.Inputs,
+			&bitcoin.TransactionInput{
+				Outpoint:        outpoint,
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		)
+	}
+
+	witness := bitcoin.GetScriptType(previousOutput.PublicKeyScript) ==
+		bitcoin.P2WPKHScript
+
+	sighash := computeSigHash(t, transaction, 0, previousOutput)
+
+	signature, err := privateKey.Sign(sighash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatureBytes := append(
+		signature.Serialize(),
+		bitcoin.SignatureHashAllType,
+	)
+	publicKeyBytes := privateKey.PubKey().SerializeCompressed()
+
+	if witness {
+		transaction.Inputs[0].Witness = [][]byte{signatureBytes, publicKeyBytes}
+	} else {
+		signatureScript, err := txscript.NewScriptBuilder().
+			AddData(signatureBytes).
+			AddData(publicKeyBytes).
+			Script()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		transaction.Inputs[0].SignatureScript = signatureScript
+	}
+
+	return transaction
+}
+
+// computeSigHash computes the signature hash of the given input of the given
+// transaction. The input is assumed to spend the given P2PKH or P2WPKH
+// previous output.
+func computeSigHash(
+	t *testing.T,
+	transaction *bitcoin.Transaction,
+	inputIndex int,
+	previousOutput *bitcoin.TransactionOutput,
+) [32]byte {
+	publicKeyHash, err := bitcoin.ExtractPublicKeyHash(
+		previousOutput.PublicKeyScript,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scriptCode, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	witness := bitcoin.GetScriptType(previousOutput.PublicKeyScript) ==
+		bitcoin.P2WPKHScript
+
+	preimage, err := transaction.ComputeSignatureHashPreimage(
+		inputIndex,
+		scriptCode,
+		previousOutput.Value,
+		witness,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preimageSha256 := sha256.Sum256(preimage)
+	return sha256.Sum256(preimageSha256[:])
+}
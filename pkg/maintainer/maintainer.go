@@ -4,9 +4,15 @@ import (
 	"context"
 	"github.com/ipfs/go-log/v2"
 
+	"github.com/keep-network/keep-common/pkg/persistence"
+
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/fraud"
+	"github.com/keep-network/keep-core/pkg/maintainer/redemption"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 )
 
 var logger = log.Logger("keep-maintainer")
@@ -15,24 +21,62 @@ func Initialize(
 	ctx context.Context,
 	config Config,
 	btcChain bitcoin.Chain,
-	btcDiffChain btcdiff.Chain,
-	spvChain spv.Chain,
+	btcDiffChain BitcoinDifficultyChain,
+	spvChain SpvChain,
+	fraudChain fraud.Chain,
+	redemptionChain redemption.Chain,
+	spvCheckpointPersistence persistence.BasicHandle,
+	clientInfo *clientinfo.Registry,
 ) {
 	// If none of the maintainers was specified in the config (i.e. no option was
 	// provided to the `maintainer` command), all maintainers should be launched.
 	launchAll := !config.BitcoinDifficulty.Enabled &&
-		!config.Spv.Enabled
+		!config.Spv.Enabled &&
+		!config.Fraud.Enabled &&
+		!config.Redemption.Enabled
 
 	if launchAll {
 		logger.Info("initializing all maintainer modules...")
 	}
 
+	// The submission policy is shared by all maintainers sending submissions
+	// reimbursed by the reimbursement pool.
+	submissionPolicy := submission.NewPolicy(config.Submission)
+
+	if clientInfo != nil {
+		// only if client info endpoint is configured
+		clientInfo.ObserveApplicationSource(
+			"maintainer",
+			map[string]clientinfo.Source{
+				"submissions_submitted": func() float64 {
+					return float64(
+						submissionPolicy.DecisionsCount(submission.DecisionSubmit),
+					)
+				},
+				"submissions_delayed": func() float64 {
+					return float64(
+						submissionPolicy.DecisionsCount(submission.DecisionDelay),
+					)
+				},
+				"submissions_skipped": func() float64 {
+					return float64(
+						submissionPolicy.DecisionsCount(submission.DecisionSkip),
+					)
+				},
+			},
+		)
+	}
+
 	if config.BitcoinDifficulty.Enabled || launchAll {
 		btcdiff.Initialize(
 			ctx,
 			config.BitcoinDifficulty,
 			btcChain,
-			btcDiffChain,
+			&guardedBitcoinDifficultyChain{
+				BitcoinDifficultyChain: btcDiffChain,
+				ctx:                    ctx,
+				policy:                 submissionPolicy,
+			},
 		)
 	}
 
@@ -40,9 +84,31 @@ func Initialize(
 		spv.Initialize(
 			ctx,
 			config.Spv,
-			spvChain,
+			&guardedSpvChain{
+				SpvChain: spvChain,
+				ctx:      ctx,
+				policy:   submissionPolicy,
+			},
 			btcDiffChain,
 			btcChain,
+			spvCheckpointPersistence,
+		)
+	}
+
+	if config.Fraud.Enabled || launchAll {
+		fraud.Initialize(
+			ctx,
+			config.Fraud,
+			fraudChain,
+			btcChain,
+		)
+	}
+
+	if config.Redemption.Enabled || launchAll {
+		redemption.Initialize(
+			ctx,
+			config.Redemption,
+			redemptionChain,
 		)
 	}
 
@@ -0,0 +1,73 @@
+package redemption
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// Chain is an interface that provides the ability to communicate with the
+// on-chain Bridge contract in order to detect and notify timed-out
+// redemption requests.
+type Chain interface {
+	// BlockCounter returns the chain's block counter.
+	BlockCounter() (chain.BlockCounter, error)
+
+	// AverageBlockTime returns the average time of a single block of
+	// the chain.
+	AverageBlockTime() time.Duration
+
+	// PastRedemptionRequestedEvents fetches past redemption requested events
+	// according to the provided filter or unfiltered if the filter is nil.
+	// Returned events are sorted by the block number in the ascending order,
+	// i.e. the latest event is at the end of the slice.
+	PastRedemptionRequestedEvents(
+		filter *tbtc.RedemptionRequestedEventFilter,
+	) ([]*tbtc.RedemptionRequestedEvent, error)
+
+	// BuildRedemptionKey calculates a redemption key for the given redemption
+	// request which is an identifier for a redemption at the given time
+	// on-chain.
+	BuildRedemptionKey(
+		walletPublicKeyHash [20]byte,
+		redeemerOutputScript bitcoin.Script,
+	) (*big.Int, error)
+
+	// GetPendingRedemptionRequest gets the on-chain pending redemption request
+	// for the given wallet public key hash and redeemer output script.
+	// The returned bool value indicates whether the request was found or not.
+	GetPendingRedemptionRequest(
+		walletPublicKeyHash [20]byte,
+		redeemerOutputScript bitcoin.Script,
+	) (*tbtc.RedemptionRequest, bool, error)
+
+	// GetRedemptionParameters gets the current value of parameters relevant
+	// for the redemption process.
+	GetRedemptionParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		txMaxTotalFee uint64,
+		timeout uint32,
+		timeoutSlashingAmount *big.Int,
+		timeoutNotifierRewardMultiplier uint32,
+		err error,
+	)
+
+	// GetWallet gets the on-chain data for the given wallet. Returns an error
+	// if the wallet was not found.
+	GetWallet(walletPublicKeyHash [20]byte) (*tbtc.WalletChainData, error)
+
+	// NotifyRedemptionTimeout notifies the Bridge that the wallet identified
+	// by the given public key hash did not process the pending redemption
+	// request targeting the given redeemer output script within the
+	// redemption timeout. The redeemer is refunded and the wallet operators
+	// are slashed.
+	NotifyRedemptionTimeout(
+		walletPublicKeyHash [20]byte,
+		redeemerOutputScript bitcoin.Script,
+	) error
+}
@@ -0,0 +1,256 @@
+package redemption
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+type notifiedRedemptionTimeout struct {
+	walletPublicKeyHash  [20]byte
+	redeemerOutputScript bitcoin.Script
+}
+
+type localChain struct {
+	mutex sync.Mutex
+
+	blockCounter                    *mockBlockCounter
+	averageBlockTime                time.Duration
+	redemptionTimeout               uint32
+	pastRedemptionRequestedEvents   []*tbtc.RedemptionRequestedEvent
+	redemptionRequestedEventFilters []*tbtc.RedemptionRequestedEventFilter
+	pendingRedemptionRequests       map[[32]byte]*tbtc.RedemptionRequest
+	wallets                         map[[20]byte]*tbtc.WalletChainData
+	notifyRedemptionTimeoutErrors   map[[32]byte]error
+	notifiedRedemptionTimeouts      []*notifiedRedemptionTimeout
+}
+
+func newLocalChain() *localChain {
+	return &localChain{
+		blockCounter:                    &mockBlockCounter{},
+		averageBlockTime:                12 * time.Second,
+		pastRedemptionRequestedEvents:   make([]*tbtc.RedemptionRequestedEvent, 0),
+		redemptionRequestedEventFilters: make([]*tbtc.RedemptionRequestedEventFilter, 0),
+		pendingRedemptionRequests:       make(map[[32]byte]*tbtc.RedemptionRequest),
+		wallets:                         make(map[[20]byte]*tbtc.WalletChainData),
+		notifyRedemptionTimeoutErrors:   make(map[[32]byte]error),
+		notifiedRedemptionTimeouts:      make([]*notifiedRedemptionTimeout, 0),
+	}
+}
+
+func (lc *localChain) BlockCounter() (chain.BlockCounter, error) {
+	return lc.blockCounter, nil
+}
+
+func (lc *localChain) AverageBlockTime() time.Duration {
+	return lc.averageBlockTime
+}
+
+func (lc *localChain) PastRedemptionRequestedEvents(
+	filter *tbtc.RedemptionRequestedEventFilter,
+) ([]*tbtc.RedemptionRequestedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.redemptionRequestedEventFilters = append(
+		lc.redemptionRequestedEventFilters,
+		filter,
+	)
+
+	events := make([]*tbtc.RedemptionRequestedEvent, 0)
+	for _, event := range lc.pastRedemptionRequestedEvents {
+		if event.BlockNumber >= filter.StartBlock {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (lc *localChain) addPastRedemptionRequestedEvent(
+	event *tbtc.RedemptionRequestedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.pastRedemptionRequestedEvents = append(
+		lc.pastRedemptionRequestedEvents,
+		event,
+	)
+}
+
+func (lc *localChain) BuildRedemptionKey(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+) (*big.Int, error) {
+	key := buildRedemptionKey(walletPublicKeyHash, redeemerOutputScript)
+	return new(big.Int).SetBytes(key[:]), nil
+}
+
+func (lc *localChain) GetPendingRedemptionRequest(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+) (*tbtc.RedemptionRequest, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	request, ok := lc.pendingRedemptionRequests[buildRedemptionKey(
+		walletPublicKeyHash,
+		redeemerOutputScript,
+	)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *localChain) setPendingRedemptionRequest(
+	walletPublicKeyHash [20]byte,
+	request *tbtc.RedemptionRequest,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.pendingRedemptionRequests[buildRedemptionKey(
+		walletPublicKeyHash,
+		request.RedeemerOutputScript,
+	)] = request
+}
+
+func (lc *localChain) GetRedemptionParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	txMaxTotalFee uint64,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	timeout = lc.redemptionTimeout
+	return
+}
+
+func (lc *localChain) setRedemptionTimeout(timeout uint32) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.redemptionTimeout = timeout
+}
+
+func (lc *localChain) GetWallet(walletPublicKeyHash [20]byte) (
+	*tbtc.WalletChainData,
+	error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	walletChainData, ok := lc.wallets[walletPublicKeyHash]
+	if !ok {
+		return nil, fmt.Errorf("no wallet for given PKH")
+	}
+
+	return walletChainData, nil
+}
+
+func (lc *localChain) setWallet(
+	walletPublicKeyHash [20]byte,
+	walletChainData *tbtc.WalletChainData,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.wallets[walletPublicKeyHash] = walletChainData
+}
+
+func (lc *localChain) NotifyRedemptionTimeout(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	key := buildRedemptionKey(walletPublicKeyHash, redeemerOutputScript)
+
+	if err, ok := lc.notifyRedemptionTimeoutErrors[key]; ok {
+		return err
+	}
+
+	lc.notifiedRedemptionTimeouts = append(
+		lc.notifiedRedemptionTimeouts,
+		&notifiedRedemptionTimeout{
+			walletPublicKeyHash:  walletPublicKeyHash,
+			redeemerOutputScript: redeemerOutputScript,
+		},
+	)
+
+	delete(lc.pendingRedemptionRequests, key)
+
+	return nil
+}
+
+func (lc *localChain) setNotifyRedemptionTimeoutError(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+	err error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.notifyRedemptionTimeoutErrors[buildRedemptionKey(
+		walletPublicKeyHash,
+		redeemerOutputScript,
+	)] = err
+}
+
+func buildRedemptionKey(
+	walletPublicKeyHash [20]byte,
+	redeemerOutputScript bitcoin.Script,
+) [32]byte {
+	return sha256.Sum256(append(walletPublicKeyHash[:], redeemerOutputScript...))
+}
+
+type mockBlockCounter struct {
+	mutex        sync.Mutex
+	currentBlock uint64
+}
+
+func (mbc *mockBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	panic("unsupported")
+}
+
+func (mbc *mockBlockCounter) BlockHeightWaiter(blockNumber uint64) (
+	<-chan uint64,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (mbc *mockBlockCounter) CurrentBlock() (uint64, error) {
+	mbc.mutex.Lock()
+	defer mbc.mutex.Unlock()
+
+	return mbc.currentBlock, nil
+}
+
+func (mbc *mockBlockCounter) setCurrentBlock(block uint64) {
+	mbc.mutex.Lock()
+	defer mbc.mutex.Unlock()
+
+	mbc.currentBlock = block
+}
+
+func (mbc *mockBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	panic("unsupported")
+}
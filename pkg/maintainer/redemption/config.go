@@ -0,0 +1,41 @@
+package redemption
+
+import (
+	"time"
+)
+
+const (
+	// DefaultLookBackPeriod is the default value for the period after
+	// the redemption timeout during which timed-out requests are still
+	// looked for. Timed-out requests are expected to be notified soon after
+	// the timeout elapses so there is no need to look far into the past.
+	DefaultLookBackPeriod = 7 * 24 * time.Hour
+
+	// DefaultRestartBackoffTime is the default value for restart back-off time.
+	DefaultRestartBackoffTime = 30 * time.Minute
+
+	// DefaultIdleBackOffTime is the default value for idle back-off time.
+	DefaultIdleBackOffTime = 30 * time.Minute
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// Enabled indicates whether the redemption timeout maintainer should
+	// be started.
+	Enabled bool
+
+	// LookBackPeriod is the period after the redemption timeout during which
+	// timed-out redemption requests are still looked for. Requests made
+	// earlier than the redemption timeout and the look-back period ago are
+	// not inspected.
+	LookBackPeriod time.Duration
+
+	// RestartBackoffTime is a restart backoff which should be applied when the
+	// redemption timeout maintainer is restarted. It helps to avoid being
+	// flooded with error logs in case of a permanent error in the maintainer.
+	RestartBackoffTime time.Duration
+
+	// IdleBackoffTime is a wait time which should be applied between
+	// subsequent scans of pending redemption requests.
+	IdleBackoffTime time.Duration
+}
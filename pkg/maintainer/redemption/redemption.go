@@ -0,0 +1,294 @@
+package redemption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+var logger = log.Logger("keep-maintainer-redemption")
+
+func Initialize(
+	ctx context.Context,
+	config Config,
+	chain Chain,
+) {
+	redemptionMaintainer := &redemptionMaintainer{
+		config: config,
+		chain:  chain,
+	}
+
+	go redemptionMaintainer.startControlLoop(ctx)
+}
+
+// redemptionMaintainer is the part of maintainer responsible for notifying
+// redemption requests that were not processed by wallets within the
+// redemption timeout. The notification refunds the redeemer and slashes
+// operators of the wallet. Any operator can run it as the notifier is not
+// required to hold any wallet key material.
+type redemptionMaintainer struct {
+	config Config
+	chain  Chain
+}
+
+// startControlLoop starts the loop responsible for controlling the
+// redemption timeout maintainer.
+func (rm *redemptionMaintainer) startControlLoop(ctx context.Context) {
+	logger.Info("starting redemption timeout maintainer")
+
+	defer func() {
+		logger.Info("stopping redemption timeout maintainer")
+	}()
+
+	for {
+		err := rm.maintainRedemptions(ctx)
+		if err != nil {
+			logger.Errorf(
+				"error while maintaining redemptions: [%v]; restarting maintainer",
+				err,
+			)
+		}
+
+		select {
+		case <-time.After(rm.config.RestartBackoffTime):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// maintainRedemptions periodically inspects pending redemption requests and
+// notifies the timed-out ones.
+func (rm *redemptionMaintainer) maintainRedemptions(ctx context.Context) error {
+	for {
+		logger.Info("starting redemption timeout task execution...")
+
+		if err := rm.notifyTimedOutRedemptions(); err != nil {
+			return fmt.Errorf(
+				"error while notifying timed-out redemptions: [%v]",
+				err,
+			)
+		}
+
+		logger.Infof(
+			"redemption timeout task completed; next run in [%s]",
+			rm.config.IdleBackoffTime,
+		)
+
+		select {
+		case <-time.After(rm.config.IdleBackoffTime):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// notifyTimedOutRedemptions looks for timed-out redemption requests and
+// submits a timeout notification for each of them. A failed notification
+// does not prevent notifying the remaining requests as the request could
+// have been notified by someone else in the meantime.
+func (rm *redemptionMaintainer) notifyTimedOutRedemptions() error {
+	timedOutRedemptions, err := getTimedOutRedemptions(
+		rm.chain,
+		rm.config.LookBackPeriod,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get timed-out redemptions: [%v]", err)
+	}
+
+	logger.Infof("found [%d] timed-out redemptions", len(timedOutRedemptions))
+
+	for _, redemption := range timedOutRedemptions {
+		logger.Infof(
+			"notifying timeout of redemption from wallet [0x%x] "+
+				"to output script [0x%x] requested at [%s]",
+			redemption.walletPublicKeyHash,
+			redemption.redeemerOutputScript,
+			redemption.requestedAt,
+		)
+
+		if err := rm.chain.NotifyRedemptionTimeout(
+			redemption.walletPublicKeyHash,
+			redemption.redeemerOutputScript,
+		); err != nil {
+			logger.Errorf(
+				"failed to notify timeout of redemption from wallet [0x%x] "+
+					"to output script [0x%x]: [%v]",
+				redemption.walletPublicKeyHash,
+				redemption.redeemerOutputScript,
+				err,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"notified timeout of redemption from wallet [0x%x] "+
+				"to output script [0x%x]",
+			redemption.walletPublicKeyHash,
+			redemption.redeemerOutputScript,
+		)
+	}
+
+	return nil
+}
+
+// timedOutRedemption represents a pending redemption request that was not
+// processed by the wallet within the redemption timeout.
+type timedOutRedemption struct {
+	walletPublicKeyHash  [20]byte
+	redeemerOutputScript bitcoin.Script
+	requestedAt          time.Time
+}
+
+// getTimedOutRedemptions returns pending redemption requests whose
+// redemption timeout elapsed before the given time and whose timeout can be
+// notified, i.e. their wallets are `Live`, `MovingFunds` or `Terminated`.
+// Only requests made within the redemption timeout and the look-back period
+// before the given time are considered. The returned requests are sorted by
+// the request time, from the oldest to the newest.
+func getTimedOutRedemptions(
+	chain Chain,
+	lookBackPeriod time.Duration,
+	now time.Time,
+) ([]*timedOutRedemption, error) {
+	_, _, _, _, timeout, _, _, err := chain.GetRedemptionParameters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemption parameters: [%v]", err)
+	}
+	requestTimeout := time.Duration(timeout) * time.Second
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block counter: [%v]", err)
+	}
+
+	currentBlockNumber, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: [%v]", err)
+	}
+
+	// The event filter expects a block range, so the period is estimated in
+	// blocks using the average block time of the host chain. The range is
+	// made a little wider by a constant factor of 1000 blocks to not omit
+	// events on the edge of the range if the actual block time is lesser
+	// than the assumed one.
+	filterLookbackBlocks :=
+		uint64((requestTimeout+lookBackPeriod)/chain.AverageBlockTime()) + 1000
+
+	filterStartBlock := uint64(0)
+	if currentBlockNumber > filterLookbackBlocks {
+		filterStartBlock = currentBlockNumber - filterLookbackBlocks
+	}
+
+	events, err := chain.PastRedemptionRequestedEvents(
+		&tbtc.RedemptionRequestedEventFilter{
+			StartBlock: filterStartBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past redemption requested events: [%v]",
+			err,
+		)
+	}
+
+	// There may be multiple events targeting the same redemption key
+	// (i.e. the same wallet and output script pair) but the Bridge allows
+	// only for one pending request with the given key at the same time.
+	// Deduplicate the events as the pending request is fetched for the key
+	// anyway.
+	eventsSet := make(map[string]*tbtc.RedemptionRequestedEvent)
+	for _, event := range events {
+		redemptionKey, err := chain.BuildRedemptionKey(
+			event.WalletPublicKeyHash,
+			event.RedeemerOutputScript,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build redemption key: [%v]", err)
+		}
+
+		eventsSet[hexutils.Encode(redemptionKey.Bytes())] = event
+	}
+
+	// Cache of wallet states allowing to notify the timeout. It helps
+	// to limit the number of calls to the chain as one wallet usually
+	// handles multiple redemptions.
+	notifiableWallets := make(map[[20]byte]bool)
+
+	timedOutRedemptions := make([]*timedOutRedemption, 0)
+	for redemptionKey, event := range eventsSet {
+		request, found, err := chain.GetPendingRedemptionRequest(
+			event.WalletPublicKeyHash,
+			event.RedeemerOutputScript,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get pending redemption request: [%v]",
+				err,
+			)
+		}
+
+		if !found {
+			logger.Debugf(
+				"redemption request [%s] is no longer pending",
+				redemptionKey,
+			)
+			continue
+		}
+
+		if !now.After(request.RequestedAt.Add(requestTimeout)) {
+			continue
+		}
+
+		notifiable, cached := notifiableWallets[event.WalletPublicKeyHash]
+		if !cached {
+			wallet, err := chain.GetWallet(event.WalletPublicKeyHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get wallet: [%v]", err)
+			}
+
+			// Redemption timeouts can only be notified for wallets that
+			// are `Live`, `MovingFunds` or `Terminated`.
+			notifiable = wallet.State == tbtc.StateLive ||
+				wallet.State == tbtc.StateMovingFunds ||
+				wallet.State == tbtc.StateTerminated
+
+			notifiableWallets[event.WalletPublicKeyHash] = notifiable
+		}
+
+		if !notifiable {
+			logger.Warnf(
+				"redemption request [%s] timed out but its wallet [0x%x] "+
+					"is in a state not allowing to notify the timeout",
+				redemptionKey,
+				event.WalletPublicKeyHash,
+			)
+			continue
+		}
+
+		timedOutRedemptions = append(
+			timedOutRedemptions,
+			&timedOutRedemption{
+				walletPublicKeyHash:  event.WalletPublicKeyHash,
+				redeemerOutputScript: event.RedeemerOutputScript,
+				requestedAt:          request.RequestedAt,
+			},
+		)
+	}
+
+	sort.SliceStable(timedOutRedemptions, func(i, j int) bool {
+		return timedOutRedemptions[i].requestedAt.Before(
+			timedOutRedemptions[j].requestedAt,
+		)
+	})
+
+	return timedOutRedemptions, nil
+}
@@ -0,0 +1,186 @@
+package redemption
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+var (
+	liveWallet       = [20]byte{0x01}
+	closedWallet     = [20]byte{0x02}
+	terminatedWallet = [20]byte{0x03}
+
+	scriptA = bitcoin.Script{0x00, 0x14, 0x0a}
+	scriptB = bitcoin.Script{0x00, 0x14, 0x0b}
+	scriptC = bitcoin.Script{0x00, 0x14, 0x0c}
+	scriptD = bitcoin.Script{0x00, 0x14, 0x0d}
+	scriptE = bitcoin.Script{0x00, 0x14, 0x0e}
+	scriptF = bitcoin.Script{0x00, 0x14, 0x0f}
+)
+
+func TestGetTimedOutRedemptions(t *testing.T) {
+	now := time.Now()
+	timeout := 24 * time.Hour
+
+	localChain := setupLocalChain(now, timeout)
+
+	timedOutRedemptions, err := getTimedOutRedemptions(
+		localChain,
+		48*time.Hour,
+		now,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The look-back range covers the timeout and the look-back period,
+	// i.e. 72 hours, which is 21600 blocks of 12 seconds, plus the
+	// constant margin of 1000 blocks.
+	testutils.AssertIntsEqual(
+		t,
+		"filters count",
+		1,
+		len(localChain.redemptionRequestedEventFilters),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"filter start block",
+		977400,
+		localChain.redemptionRequestedEventFilters[0].StartBlock,
+	)
+
+	expectedTimedOutRedemptions := []*timedOutRedemption{
+		{
+			walletPublicKeyHash:  liveWallet,
+			redeemerOutputScript: scriptA,
+			requestedAt:          now.Add(-2 * timeout),
+		},
+		{
+			walletPublicKeyHash:  terminatedWallet,
+			redeemerOutputScript: scriptE,
+			requestedAt:          now.Add(-timeout - time.Second),
+		},
+	}
+
+	if !reflect.DeepEqual(expectedTimedOutRedemptions, timedOutRedemptions) {
+		t.Errorf(
+			"unexpected timed-out redemptions\nexpected: %v\nactual:   %v",
+			expectedTimedOutRedemptions,
+			timedOutRedemptions,
+		)
+	}
+}
+
+func TestRedemptionMaintainer_NotifyTimedOutRedemptions(t *testing.T) {
+	now := time.Now()
+	timeout := 24 * time.Hour
+
+	localChain := setupLocalChain(now, timeout)
+
+	// The first notification fails, e.g. because someone else has notified
+	// the timeout in the meantime. It must not prevent notifying the second
+	// timed-out redemption.
+	localChain.setNotifyRedemptionTimeoutError(
+		liveWallet,
+		scriptA,
+		fmt.Errorf("execution reverted"),
+	)
+
+	redemptionMaintainer := &redemptionMaintainer{
+		config: Config{
+			LookBackPeriod: 48 * time.Hour,
+		},
+		chain: localChain,
+	}
+
+	err := redemptionMaintainer.notifyTimedOutRedemptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedNotifications := []*notifiedRedemptionTimeout{
+		{
+			walletPublicKeyHash:  terminatedWallet,
+			redeemerOutputScript: scriptE,
+		},
+	}
+
+	if !reflect.DeepEqual(
+		expectedNotifications,
+		localChain.notifiedRedemptionTimeouts,
+	) {
+		t.Errorf(
+			"unexpected notifications\nexpected: %v\nactual:   %v",
+			expectedNotifications,
+			localChain.notifiedRedemptionTimeouts,
+		)
+	}
+}
+
+// setupLocalChain sets up a chain with the following redemption requests:
+//   - timed-out request of a live wallet (A), requested twice,
+//   - request of a live wallet that has not timed out yet (B),
+//   - request of a live wallet that is no longer pending (C),
+//   - timed-out request of a closed wallet (D),
+//   - request of a terminated wallet that has just timed out (E),
+//   - timed-out request of a live wallet made before the look-back range (F).
+func setupLocalChain(now time.Time, timeout time.Duration) *localChain {
+	localChain := newLocalChain()
+	localChain.blockCounter.setCurrentBlock(1000000)
+	localChain.setRedemptionTimeout(uint32(timeout.Seconds()))
+
+	localChain.setWallet(liveWallet, &tbtc.WalletChainData{
+		State: tbtc.StateLive,
+	})
+	localChain.setWallet(closedWallet, &tbtc.WalletChainData{
+		State: tbtc.StateClosed,
+	})
+	localChain.setWallet(terminatedWallet, &tbtc.WalletChainData{
+		State: tbtc.StateTerminated,
+	})
+
+	requests := []struct {
+		wallet      [20]byte
+		script      bitcoin.Script
+		blocks      []uint64
+		requestedAt time.Time
+		pending     bool
+	}{
+		{liveWallet, scriptA, []uint64{985000, 986000}, now.Add(-2 * timeout), true},
+		{liveWallet, scriptB, []uint64{995000}, now.Add(-timeout / 2), true},
+		{liveWallet, scriptC, []uint64{980000}, now.Add(-2 * timeout), false},
+		{closedWallet, scriptD, []uint64{980000}, now.Add(-2 * timeout), true},
+		{terminatedWallet, scriptE, []uint64{992000}, now.Add(-timeout - time.Second), true},
+		{liveWallet, scriptF, []uint64{970000}, now.Add(-4 * timeout), true},
+	}
+
+	for _, request := range requests {
+		for _, block := range request.blocks {
+			localChain.addPastRedemptionRequestedEvent(
+				&tbtc.RedemptionRequestedEvent{
+					WalletPublicKeyHash:  request.wallet,
+					RedeemerOutputScript: request.script,
+					BlockNumber:          block,
+				},
+			)
+		}
+
+		if request.pending {
+			localChain.setPendingRedemptionRequest(
+				request.wallet,
+				&tbtc.RedemptionRequest{
+					RedeemerOutputScript: request.script,
+					RequestedAt:          request.requestedAt,
+				},
+			)
+		}
+	}
+
+	return localChain
+}
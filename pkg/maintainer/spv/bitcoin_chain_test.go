@@ -131,14 +131,42 @@ func (lbc *localBitcoinChain) GetTransactionsForPublicKeyHash(
 
 	matchingTransactions := make([]*bitcoin.Transaction, 0)
 
-	for _, transaction := range lbc.transactions {
+	isMatchingScript := func(script bitcoin.Script) bool {
+		return bytes.Equal(script, p2pkh) || bytes.Equal(script, p2wpkh)
+	}
+
+	// Just as Electrum does, consider both transactions paying to the given
+	// public key hash and transactions spending outputs locked on it.
+	isMatchingTransaction := func(transaction *bitcoin.Transaction) bool {
 		for _, output := range transaction.Outputs {
-			script := output.PublicKeyScript
-			if bytes.Equal(script, p2pkh) || bytes.Equal(script, p2wpkh) {
-				matchingTransactions = append(matchingTransactions, transaction)
-				break
+			if isMatchingScript(output.PublicKeyScript) {
+				return true
 			}
 		}
+
+		for _, input := range transaction.Inputs {
+			for _, previousTransaction := range lbc.transactions {
+				if previousTransaction.Hash() != input.Outpoint.TransactionHash {
+					continue
+				}
+
+				outputIndex := int(input.Outpoint.OutputIndex)
+				if outputIndex < len(previousTransaction.Outputs) &&
+					isMatchingScript(
+						previousTransaction.Outputs[outputIndex].PublicKeyScript,
+					) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, transaction := range lbc.transactions {
+		if isMatchingTransaction(transaction) {
+			matchingTransactions = append(matchingTransactions, transaction)
+		}
 	}
 
 	if len(matchingTransactions) > limit {
@@ -173,6 +201,12 @@ func (lbc *localBitcoinChain) GetMempoolUtxosForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lbc *localBitcoinChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	panic("unsupported")
+}
+
 func (lbc *localBitcoinChain) EstimateSatPerVByteFee(blocks uint32) (
 	int64,
 	error,
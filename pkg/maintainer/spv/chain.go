@@ -81,4 +81,51 @@ type Chain interface {
 	PastRedemptionRequestedEvents(
 		filter *tbtc.RedemptionRequestedEventFilter,
 	) ([]*tbtc.RedemptionRequestedEvent, error)
+
+	// SubmitMovingFundsProofWithReimbursement submits the moving funds proof
+	// via MaintainerProxy. The caller is reimbursed.
+	SubmitMovingFundsProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+		walletPublicKeyHash [20]byte,
+	) error
+
+	// SubmitMovedFundsSweepProofWithReimbursement submits the moved funds
+	// sweep proof via MaintainerProxy. The caller is reimbursed.
+	SubmitMovedFundsSweepProofWithReimbursement(
+		transaction *bitcoin.Transaction,
+		proof *bitcoin.SpvProof,
+		mainUTXO bitcoin.UnspentTransactionOutput,
+	) error
+
+	// GetMovedFundsSweepRequest gets the on-chain moved funds sweep request
+	// for the given moving funds transaction hash and output index.
+	// The returned bool value indicates whether the request was found or not.
+	GetMovedFundsSweepRequest(
+		movingFundsTxHash bitcoin.Hash,
+		movingFundsTxOutputIndex uint32,
+	) (*tbtc.MovedFundsSweepRequest, bool, error)
+
+	// ComputeMovingFundsCommitmentHash computes the hash of the moving funds
+	// commitment, i.e. the given list of target wallets, the same way as the
+	// Bridge does it on-chain.
+	ComputeMovingFundsCommitmentHash(targetWallets [][20]byte) [32]byte
+
+	// PastMovingFundsCommitmentSubmittedEvents fetches past moving funds
+	// commitment submitted events according to the provided filter or
+	// unfiltered if the filter is nil. Returned events are sorted by the block
+	// number in the ascending order, i.e. the latest event is at the end of the
+	// slice.
+	PastMovingFundsCommitmentSubmittedEvents(
+		filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+	) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error)
+
+	// PastMovingFundsCompletedEvents fetches past moving funds completed
+	// events according to the provided filter or unfiltered if the filter
+	// is nil. Returned events are sorted by the block number in the ascending
+	// order, i.e. the latest event is at the end of the slice.
+	PastMovingFundsCompletedEvents(
+		filter *tbtc.MovingFundsCompletedEventFilter,
+	) ([]*tbtc.MovingFundsCompletedEvent, error)
 }
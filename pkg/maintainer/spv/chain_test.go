@@ -30,6 +30,19 @@ type submittedDepositSweepProof struct {
 	vault       common.Address
 }
 
+type submittedMovingFundsProof struct {
+	transaction         *bitcoin.Transaction
+	proof               *bitcoin.SpvProof
+	mainUTXO            bitcoin.UnspentTransactionOutput
+	walletPublicKeyHash [20]byte
+}
+
+type submittedMovedFundsSweepProof struct {
+	transaction *bitcoin.Transaction
+	proof       *bitcoin.SpvProof
+	mainUTXO    bitcoin.UnspentTransactionOutput
+}
+
 type localChain struct {
 	mutex sync.Mutex
 
@@ -42,6 +55,12 @@ type localChain struct {
 	pastRedemptionRequestedEvents map[[32]byte][]*tbtc.RedemptionRequestedEvent
 	pastDepositRevealedEvents     map[[32]byte][]*tbtc.DepositRevealedEvent
 
+	movedFundsSweepRequests                  map[[32]byte]*tbtc.MovedFundsSweepRequest
+	submittedMovingFundsProofs               []*submittedMovingFundsProof
+	submittedMovedFundsSweepProofs           []*submittedMovedFundsSweepProof
+	pastMovingFundsCommitmentSubmittedEvents map[[32]byte][]*tbtc.MovingFundsCommitmentSubmittedEvent
+	pastMovingFundsCompletedEvents           map[[32]byte][]*tbtc.MovingFundsCompletedEvent
+
 	txProofDifficultyFactor *big.Int
 	currentEpoch            uint64
 	currentEpochDifficulty  *big.Int
@@ -57,6 +76,18 @@ func newLocalChain() *localChain {
 		submittedDepositSweepProofs:   make([]*submittedDepositSweepProof, 0),
 		pastRedemptionRequestedEvents: make(map[[32]byte][]*tbtc.RedemptionRequestedEvent),
 		pastDepositRevealedEvents:     make(map[[32]byte][]*tbtc.DepositRevealedEvent),
+		movedFundsSweepRequests:       make(map[[32]byte]*tbtc.MovedFundsSweepRequest),
+		submittedMovingFundsProofs:    make([]*submittedMovingFundsProof, 0),
+		submittedMovedFundsSweepProofs: make(
+			[]*submittedMovedFundsSweepProof,
+			0,
+		),
+		pastMovingFundsCommitmentSubmittedEvents: make(
+			map[[32]byte][]*tbtc.MovingFundsCommitmentSubmittedEvent,
+		),
+		pastMovingFundsCompletedEvents: make(
+			map[[32]byte][]*tbtc.MovingFundsCompletedEvent,
+		),
 	}
 }
 
@@ -491,6 +522,224 @@ func buildPastRedemptionRequestedEventsKey(
 	return sha256.Sum256(buffer.Bytes()), nil
 }
 
+func (lc *localChain) SubmitMovingFundsProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+	walletPublicKeyHash [20]byte,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.submittedMovingFundsProofs = append(
+		lc.submittedMovingFundsProofs,
+		&submittedMovingFundsProof{
+			transaction:         transaction,
+			proof:               proof,
+			mainUTXO:            mainUTXO,
+			walletPublicKeyHash: walletPublicKeyHash,
+		},
+	)
+
+	return nil
+}
+
+func (lc *localChain) getSubmittedMovingFundsProofs() []*submittedMovingFundsProof {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.submittedMovingFundsProofs
+}
+
+func (lc *localChain) SubmitMovedFundsSweepProofWithReimbursement(
+	transaction *bitcoin.Transaction,
+	proof *bitcoin.SpvProof,
+	mainUTXO bitcoin.UnspentTransactionOutput,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.submittedMovedFundsSweepProofs = append(
+		lc.submittedMovedFundsSweepProofs,
+		&submittedMovedFundsSweepProof{
+			transaction: transaction,
+			proof:       proof,
+			mainUTXO:    mainUTXO,
+		},
+	)
+
+	return nil
+}
+
+func (lc *localChain) getSubmittedMovedFundsSweepProofs() []*submittedMovedFundsSweepProof {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.submittedMovedFundsSweepProofs
+}
+
+func (lc *localChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	// Moved funds sweep requests are keyed the same way as deposit requests.
+	requestKey := buildDepositRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+
+	request, ok := lc.movedFundsSweepRequests[requestKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *localChain) setMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+	request *tbtc.MovedFundsSweepRequest,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	requestKey := buildDepositRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+	lc.movedFundsSweepRequests[requestKey] = request
+}
+
+func (lc *localChain) ComputeMovingFundsCommitmentHash(
+	targetWallets [][20]byte,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	for _, targetWallet := range targetWallets {
+		buffer.Write(targetWallet[:])
+	}
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+func (lc *localChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCommitmentSubmittedEventsKey(filter)
+
+	events, ok := lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey]
+	if !ok {
+		return nil, fmt.Errorf("no events for given filter")
+	}
+
+	return events, nil
+}
+
+func (lc *localChain) addPastMovingFundsCommitmentSubmittedEvent(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+	event *tbtc.MovingFundsCommitmentSubmittedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCommitmentSubmittedEventsKey(filter)
+
+	lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey] = append(
+		lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey],
+		event,
+	)
+}
+
+func buildPastMovingFundsCommitmentSubmittedEventsKey(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) [32]byte {
+	if filter == nil {
+		return [32]byte{}
+	}
+
+	return buildPastWalletEventsKey(
+		filter.StartBlock,
+		filter.EndBlock,
+		filter.WalletPublicKeyHash,
+	)
+}
+
+func (lc *localChain) PastMovingFundsCompletedEvents(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+) ([]*tbtc.MovingFundsCompletedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCompletedEventsKey(filter)
+
+	events, ok := lc.pastMovingFundsCompletedEvents[eventsKey]
+	if !ok {
+		return nil, fmt.Errorf("no events for given filter")
+	}
+
+	return events, nil
+}
+
+func (lc *localChain) addPastMovingFundsCompletedEvent(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+	event *tbtc.MovingFundsCompletedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCompletedEventsKey(filter)
+
+	lc.pastMovingFundsCompletedEvents[eventsKey] = append(
+		lc.pastMovingFundsCompletedEvents[eventsKey],
+		event,
+	)
+}
+
+func buildPastMovingFundsCompletedEventsKey(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+) [32]byte {
+	if filter == nil {
+		return [32]byte{}
+	}
+
+	return buildPastWalletEventsKey(
+		filter.StartBlock,
+		filter.EndBlock,
+		filter.WalletPublicKeyHash,
+	)
+}
+
+func buildPastWalletEventsKey(
+	startBlock uint64,
+	endBlock *uint64,
+	walletPublicKeyHashes [][20]byte,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	startBlockBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(startBlockBytes, startBlock)
+	buffer.Write(startBlockBytes)
+
+	if endBlock != nil {
+		endBlockBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(endBlockBytes, *endBlock)
+		buffer.Write(endBlockBytes)
+	}
+
+	for _, walletPublicKeyHash := range walletPublicKeyHashes {
+		buffer.Write(walletPublicKeyHash[:])
+	}
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
 type mockBlockCounter struct {
 	mutex        sync.Mutex
 	currentBlock uint64
@@ -0,0 +1,314 @@
+package spv
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// checkpointDirectory is the name of the persistence directory holding
+	// the SPV maintainer checkpoint.
+	checkpointDirectory = "spv"
+	// checkpointFileName is the name of the file holding the SPV maintainer
+	// checkpoint.
+	checkpointFileName = "checkpoint"
+
+	// checkpointReorgDepth is the number of the most recent host chain blocks
+	// that may still be affected by a chain reorganization. Events emitted
+	// in those blocks are scanned again in the next loop and decisions about
+	// transactions made within those blocks are not trusted until they
+	// become deep enough.
+	checkpointReorgDepth = 64
+)
+
+// checkpoint holds the state of the SPV maintainer scanning that allows
+// subsequent loops to be incremental. Without the checkpoint, every loop
+// would have to scan the whole history depth of host chain events and
+// evaluate all recent Bitcoin transactions of the found wallets again.
+type checkpoint struct {
+	// ProofTypes holds the checkpoints of specific proof types. The map key
+	// is the name of the wallet action type the proof type refers to.
+	ProofTypes map[string]*proofTypeCheckpoint `json:"proofTypes"`
+}
+
+func newCheckpoint() *checkpoint {
+	return &checkpoint{
+		ProofTypes: make(map[string]*proofTypeCheckpoint),
+	}
+}
+
+// proofType returns the checkpoint of the given proof type. A new empty
+// checkpoint is created if the proof type has not been scanned yet.
+func (c *checkpoint) proofType(
+	action tbtc.WalletActionType,
+) *proofTypeCheckpoint {
+	key := action.String()
+
+	if _, ok := c.ProofTypes[key]; !ok {
+		c.ProofTypes[key] = newProofTypeCheckpoint()
+	}
+
+	return c.ProofTypes[key]
+}
+
+// proofTypeCheckpoint holds the scanning state of a specific proof type.
+type proofTypeCheckpoint struct {
+	// LastScannedBlock is the host chain block up to which the events were
+	// scanned. Zero if events have never been scanned.
+	LastScannedBlock uint64 `json:"lastScannedBlock"`
+	// Wallets are the wallets whose transactions should be checked, in the
+	// order they were first observed.
+	Wallets []*checkpointWallet `json:"wallets"`
+	// SettledTransactions holds hashes of the Bitcoin transactions that were
+	// determined to be already proven or not relevant for the proof type.
+	// The map key is the transaction hash in the reversed byte order and
+	// the value is the host chain block at which the decision was made.
+	SettledTransactions map[string]uint64 `json:"settledTransactions"`
+}
+
+// checkpointWallet is a wallet tracked by the proofTypeCheckpoint.
+type checkpointWallet struct {
+	// PublicKeyHash is the hex representation of the wallet public key hash.
+	PublicKeyHash string `json:"publicKeyHash"`
+	// LatestEventBlock is the host chain block of the latest event that
+	// made the wallet tracked.
+	LatestEventBlock uint64 `json:"latestEventBlock"`
+}
+
+func newProofTypeCheckpoint() *proofTypeCheckpoint {
+	return &proofTypeCheckpoint{
+		Wallets:             make([]*checkpointWallet, 0),
+		SettledTransactions: make(map[string]uint64),
+	}
+}
+
+// scanStartBlock returns the host chain block from which the events should
+// be scanned. If events have never been scanned, the whole history depth is
+// covered. Otherwise, the scan starts from the last scanned block, going back
+// by the reorg depth to catch events affected by a chain reorganization.
+func (ptc *proofTypeCheckpoint) scanStartBlock(
+	currentBlock uint64,
+	historyDepth uint64,
+) uint64 {
+	historyStartBlock := historyStartBlock(currentBlock, historyDepth)
+
+	if ptc.LastScannedBlock == 0 ||
+		ptc.LastScannedBlock < historyStartBlock+checkpointReorgDepth {
+		return historyStartBlock
+	}
+
+	return ptc.LastScannedBlock - checkpointReorgDepth
+}
+
+// recordScan records the result of the events scan performed at the given
+// current block. Wallets observed in the scan become tracked while wallets
+// and settled transactions that fall out of the history depth are pruned.
+func (ptc *proofTypeCheckpoint) recordScan(
+	activities []*walletActivity,
+	currentBlock uint64,
+	historyDepth uint64,
+) {
+	trackedWallets := make(map[string]*checkpointWallet)
+	for _, wallet := range ptc.Wallets {
+		trackedWallets[wallet.PublicKeyHash] = wallet
+	}
+
+	for _, activity := range activities {
+		key := hex.EncodeToString(activity.walletPublicKeyHash[:])
+
+		if wallet, ok := trackedWallets[key]; ok {
+			if activity.latestEventBlock > wallet.LatestEventBlock {
+				wallet.LatestEventBlock = activity.latestEventBlock
+			}
+			continue
+		}
+
+		wallet := &checkpointWallet{
+			PublicKeyHash:    key,
+			LatestEventBlock: activity.latestEventBlock,
+		}
+		trackedWallets[key] = wallet
+		ptc.Wallets = append(ptc.Wallets, wallet)
+	}
+
+	historyStartBlock := historyStartBlock(currentBlock, historyDepth)
+
+	wallets := make([]*checkpointWallet, 0, len(ptc.Wallets))
+	for _, wallet := range ptc.Wallets {
+		if wallet.LatestEventBlock >= historyStartBlock {
+			wallets = append(wallets, wallet)
+		}
+	}
+	ptc.Wallets = wallets
+
+	for transactionHash, block := range ptc.SettledTransactions {
+		if block < historyStartBlock {
+			delete(ptc.SettledTransactions, transactionHash)
+		}
+	}
+
+	ptc.LastScannedBlock = currentBlock
+}
+
+// walletPublicKeyHashes returns public key hashes of the tracked wallets,
+// in the order they were first observed.
+func (ptc *proofTypeCheckpoint) walletPublicKeyHashes() [][20]byte {
+	walletPublicKeyHashes := make([][20]byte, 0, len(ptc.Wallets))
+
+	for _, wallet := range ptc.Wallets {
+		bytes, err := hex.DecodeString(wallet.PublicKeyHash)
+		if err != nil || len(bytes) != 20 {
+			logger.Errorf(
+				"skipping invalid checkpoint wallet public key hash [%s]",
+				wallet.PublicKeyHash,
+			)
+			continue
+		}
+
+		var walletPublicKeyHash [20]byte
+		copy(walletPublicKeyHash[:], bytes)
+
+		walletPublicKeyHashes = append(walletPublicKeyHashes, walletPublicKeyHash)
+	}
+
+	return walletPublicKeyHashes
+}
+
+// isSettled returns true if the given transaction was determined to be
+// already proven or not relevant for the proof type and the decision was
+// made deep enough to not be affected by a chain reorganization.
+func (ptc *proofTypeCheckpoint) isSettled(
+	transactionHash bitcoin.Hash,
+	currentBlock uint64,
+) bool {
+	block, ok := ptc.SettledTransactions[transactionHash.Hex(bitcoin.ReversedByteOrder)]
+	return ok && block+checkpointReorgDepth <= currentBlock
+}
+
+// settle records the given transaction as already proven or not relevant
+// for the proof type. The block of an already settled transaction is not
+// updated so the decision becomes trusted once the first block it was made
+// at becomes deep enough.
+func (ptc *proofTypeCheckpoint) settle(
+	transactionHash bitcoin.Hash,
+	currentBlock uint64,
+) {
+	key := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	if _, ok := ptc.SettledTransactions[key]; !ok {
+		ptc.SettledTransactions[key] = currentBlock
+	}
+}
+
+// unsettle removes the given transaction from the settled ones. It must be
+// called when a transaction turns out to be unproven, e.g. because a proof
+// submission was reverted by a chain reorganization.
+func (ptc *proofTypeCheckpoint) unsettle(transactionHash bitcoin.Hash) {
+	delete(
+		ptc.SettledTransactions,
+		transactionHash.Hex(bitcoin.ReversedByteOrder),
+	)
+}
+
+// historyStartBlock returns the first block of the history depth.
+func historyStartBlock(currentBlock uint64, historyDepth uint64) uint64 {
+	if historyDepth > currentBlock {
+		return 0
+	}
+
+	return currentBlock - historyDepth
+}
+
+// loadCheckpoint loads the checkpoint persisted using the given handle.
+// An empty checkpoint is returned if no checkpoint was persisted yet.
+func loadCheckpoint(handle persistence.BasicHandle) (*checkpoint, error) {
+	var content []byte
+	var readErrors []error
+
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels. The reason
+	// for using two goroutines at the same time - one for descriptors and
+	// one for errors - is that channels do not have to be buffered, and we
+	// do not know in what order the information is written to channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != checkpointDirectory ||
+				descriptor.Name() != checkpointFileName {
+				continue
+			}
+
+			descriptorContent, err := descriptor.Content()
+			if err != nil {
+				readErrors = append(readErrors, err)
+				continue
+			}
+
+			content = descriptorContent
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			readErrors = append(readErrors, err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if len(readErrors) > 0 {
+		return nil, fmt.Errorf("could not read checkpoint: %v", readErrors)
+	}
+
+	if content == nil {
+		return newCheckpoint(), nil
+	}
+
+	checkpoint := newCheckpoint()
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("could not unmarshal checkpoint: [%v]", err)
+	}
+
+	for _, proofTypeCheckpoint := range checkpoint.ProofTypes {
+		if proofTypeCheckpoint.Wallets == nil {
+			proofTypeCheckpoint.Wallets = make([]*checkpointWallet, 0)
+		}
+		if proofTypeCheckpoint.SettledTransactions == nil {
+			proofTypeCheckpoint.SettledTransactions = make(map[string]uint64)
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// save persists the checkpoint using the given handle.
+func (c *checkpoint) save(handle persistence.BasicHandle) error {
+	content, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("could not marshal checkpoint: [%v]", err)
+	}
+
+	if err := handle.Save(
+		content,
+		checkpointDirectory,
+		checkpointFileName,
+	); err != nil {
+		return fmt.Errorf("could not save checkpoint: [%w]", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package spv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestProofTypeCheckpoint_ScanStartBlock(t *testing.T) {
+	tests := map[string]struct {
+		lastScannedBlock   uint64
+		currentBlock       uint64
+		historyDepth       uint64
+		expectedStartBlock uint64
+	}{
+		"never scanned": {
+			lastScannedBlock:   0,
+			currentBlock:       10000,
+			historyDepth:       1000,
+			expectedStartBlock: 9000,
+		},
+		"never scanned with history depth exceeding current block": {
+			lastScannedBlock:   0,
+			currentBlock:       500,
+			historyDepth:       1000,
+			expectedStartBlock: 0,
+		},
+		"scanned recently": {
+			lastScannedBlock:   9900,
+			currentBlock:       10000,
+			historyDepth:       1000,
+			expectedStartBlock: 9900 - checkpointReorgDepth,
+		},
+		"scanned with reorg margin at history start": {
+			lastScannedBlock:   9000 + checkpointReorgDepth,
+			currentBlock:       10000,
+			historyDepth:       1000,
+			expectedStartBlock: 9000,
+		},
+		"scanned before history start": {
+			lastScannedBlock:   8000,
+			currentBlock:       10000,
+			historyDepth:       1000,
+			expectedStartBlock: 9000,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			checkpoint := newProofTypeCheckpoint()
+			checkpoint.LastScannedBlock = test.lastScannedBlock
+
+			testutils.AssertUintsEqual(
+				t,
+				"scan start block",
+				test.expectedStartBlock,
+				checkpoint.scanStartBlock(test.currentBlock, test.historyDepth),
+			)
+		})
+	}
+}
+
+func TestProofTypeCheckpoint_RecordScan(t *testing.T) {
+	wallet1 := [20]byte{0x01}
+	wallet2 := [20]byte{0x02}
+	wallet3 := [20]byte{0x03}
+
+	settledTxHash := bitcoin.Hash{0x01}
+	staleTxHash := bitcoin.Hash{0x02}
+
+	checkpoint := newProofTypeCheckpoint()
+	checkpoint.recordScan(
+		[]*walletActivity{
+			{walletPublicKeyHash: wallet1, latestEventBlock: 9100},
+			{walletPublicKeyHash: wallet2, latestEventBlock: 9500},
+		},
+		10000,
+		1000,
+	)
+	checkpoint.settle(staleTxHash, 9050)
+	checkpoint.settle(settledTxHash, 9600)
+
+	// The history start moves to block 9200 so the first wallet and the
+	// transaction settled at block 9050 should be pruned.
+	checkpoint.recordScan(
+		[]*walletActivity{
+			{walletPublicKeyHash: wallet3, latestEventBlock: 10100},
+			{walletPublicKeyHash: wallet2, latestEventBlock: 10150},
+		},
+		10200,
+		1000,
+	)
+
+	testutils.AssertUintsEqual(
+		t,
+		"last scanned block",
+		10200,
+		checkpoint.LastScannedBlock,
+	)
+
+	expectedWallets := [][20]byte{wallet2, wallet3}
+	if !reflect.DeepEqual(expectedWallets, checkpoint.walletPublicKeyHashes()) {
+		t.Errorf(
+			"unexpected wallets\nexpected: %v\nactual:   %v\n",
+			expectedWallets,
+			checkpoint.walletPublicKeyHashes(),
+		)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"latest event block of the second wallet",
+		10150,
+		checkpoint.Wallets[0].LatestEventBlock,
+	)
+
+	expectedSettledTransactions := map[string]uint64{
+		settledTxHash.Hex(bitcoin.ReversedByteOrder): 9600,
+	}
+	if !reflect.DeepEqual(
+		expectedSettledTransactions,
+		checkpoint.SettledTransactions,
+	) {
+		t.Errorf(
+			"unexpected settled transactions\nexpected: %v\nactual:   %v\n",
+			expectedSettledTransactions,
+			checkpoint.SettledTransactions,
+		)
+	}
+}
+
+func TestProofTypeCheckpoint_Settlement(t *testing.T) {
+	txHash := bitcoin.Hash{0x01}
+
+	checkpoint := newProofTypeCheckpoint()
+
+	if checkpoint.isSettled(txHash, 1000) {
+		t.Fatal("transaction should not be settled")
+	}
+
+	checkpoint.settle(txHash, 1000)
+
+	if checkpoint.isSettled(txHash, 1000+checkpointReorgDepth-1) {
+		t.Error("transaction settled within the reorg depth should not be trusted")
+	}
+
+	// Settling again must not move the settlement block forward.
+	checkpoint.settle(txHash, 1050)
+
+	if !checkpoint.isSettled(txHash, 1000+checkpointReorgDepth) {
+		t.Error("transaction settled beyond the reorg depth should be trusted")
+	}
+
+	checkpoint.unsettle(txHash)
+
+	if checkpoint.isSettled(txHash, 2000) {
+		t.Error("unsettled transaction should not be settled")
+	}
+}
+
+func TestCheckpoint_SaveLoad(t *testing.T) {
+	handle := &mockPersistenceHandle{
+		saved: make(map[string]persistence.DataDescriptor),
+	}
+
+	loadedCheckpoint, err := loadCheckpoint(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loadedCheckpoint.ProofTypes) != 0 {
+		t.Fatalf(
+			"expected empty checkpoint; has [%v] proof types",
+			len(loadedCheckpoint.ProofTypes),
+		)
+	}
+
+	checkpoint := newCheckpoint()
+
+	depositSweepCheckpoint := checkpoint.proofType(tbtc.ActionDepositSweep)
+	depositSweepCheckpoint.recordScan(
+		[]*walletActivity{
+			{walletPublicKeyHash: [20]byte{0x01}, latestEventBlock: 9500},
+		},
+		10000,
+		1000,
+	)
+	depositSweepCheckpoint.settle(bitcoin.Hash{0x01}, 9900)
+
+	checkpoint.proofType(tbtc.ActionRedemption).recordScan(nil, 10000, 1000)
+
+	if err := checkpoint.save(handle); err != nil {
+		t.Fatal(err)
+	}
+
+	// Save again to make sure the checkpoint is overwritten.
+	if err := checkpoint.save(handle); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedCheckpoint, err = loadCheckpoint(handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(checkpoint, loadedCheckpoint) {
+		t.Errorf(
+			"unexpected checkpoint\nexpected: %+v\nactual:   %+v\n",
+			checkpoint,
+			loadedCheckpoint,
+		)
+	}
+}
+
+type mockPersistenceHandle struct {
+	saved map[string]persistence.DataDescriptor
+}
+
+func (mph *mockPersistenceHandle) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	mph.saved[directory+"/"+name] = &mockDescriptor{
+		name:      name,
+		directory: directory,
+		content:   data,
+	}
+
+	return nil
+}
+
+func (mph *mockPersistenceHandle) Snapshot(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	panic("not implemented")
+}
+
+func (mph *mockPersistenceHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	outputData := make(chan persistence.DataDescriptor, len(mph.saved))
+	outputErrors := make(chan error)
+
+	for _, descriptor := range mph.saved {
+		outputData <- descriptor
+	}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (mph *mockPersistenceHandle) Archive(directory string) error {
+	panic("not implemented")
+}
+
+func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
+	panic("not implemented")
+}
+
+type mockDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (md *mockDescriptor) Name() string {
+	return md.name
+}
+
+func (md *mockDescriptor) Directory() string {
+	return md.directory
+}
+
+func (md *mockDescriptor) Content() ([]byte, error) {
+	return md.content, nil
+}
@@ -65,4 +65,11 @@ type Config struct {
 	// IdleBackoffTime is a wait time which should be applied when there are no
 	// more transaction proofs to submit.
 	IdleBackoffTime time.Duration
+
+	// ResetCheckpoint indicates whether the persisted scanning checkpoint
+	// should be discarded on start. The checkpoint makes subsequent loops
+	// of the SPV maintainer incremental. Resetting it forces the maintainer
+	// to scan the whole history depth again, e.g. after the history depth
+	// was changed or the checkpoint got out of sync with the chains.
+	ResetCheckpoint bool
 }
@@ -74,7 +74,7 @@ func submitDepositSweepProof(
 		vault,
 	); err != nil {
 		return fmt.Errorf(
-			"failed to submit deposit sweep proof with reimbursement: [%v]",
+			"failed to submit deposit sweep proof with reimbursement: [%w]",
 			err,
 		)
 	}
@@ -223,6 +223,7 @@ func convertVaultAddress(vault *chain.Address) common.Address {
 }
 
 func getUnprovenDepositSweepTransactions(
+	checkpoint *proofTypeCheckpoint,
 	historyDepth uint64,
 	transactionLimit int,
 	btcChain bitcoin.Chain,
@@ -242,8 +243,9 @@ func getUnprovenDepositSweepTransactions(
 	}
 
 	// Calculate the starting block of the range in which the events will be
-	// searched for.
-	startBlock := currentBlock - historyDepth
+	// searched for. If the events were already scanned in the previous
+	// loops, only the recent blocks are scanned.
+	startBlock := checkpoint.scanStartBlock(currentBlock, historyDepth)
 
 	events, err :=
 		spvChain.PastDepositRevealedEvents(
@@ -258,11 +260,12 @@ func getUnprovenDepositSweepTransactions(
 		)
 	}
 
-	// There will often be multiple events emitted for a single wallet. Prepare
-	// a list of unique wallet public key hashes.
-	walletPublicKeyHashes := uniqueWalletPublicKeyHashes(
-		events,
-	)
+	// There will often be multiple events emitted for a single wallet. Record
+	// the unique wallets in the checkpoint. Wallets observed in the previous
+	// loops remain tracked as long as their events are within the history
+	// depth.
+	checkpoint.recordScan(uniqueWalletActivities(events), currentBlock, historyDepth)
+	walletPublicKeyHashes := checkpoint.walletPublicKeyHashes()
 
 	unprovenDepositSweepTransactions := []*bitcoin.Transaction{}
 
@@ -297,6 +300,11 @@ func getUnprovenDepositSweepTransactions(
 		}
 
 		for _, transaction := range walletTransactions {
+			if checkpoint.isSettled(transaction.Hash(), currentBlock) {
+				// The transaction was already proven or is not relevant.
+				continue
+			}
+
 			isUnproven, err :=
 				isUnprovenDepositSweepTransaction(
 					transaction,
@@ -312,12 +320,19 @@ func getUnprovenDepositSweepTransactions(
 				)
 			}
 
-			if isUnproven {
-				unprovenDepositSweepTransactions = append(
-					unprovenDepositSweepTransactions,
-					transaction,
-				)
+			if !isUnproven {
+				checkpoint.settle(transaction.Hash(), currentBlock)
+				continue
 			}
+
+			// The transaction may have been settled before but turned out
+			// to be unproven, e.g. due to a chain reorganization.
+			checkpoint.unsettle(transaction.Hash())
+
+			unprovenDepositSweepTransactions = append(
+				unprovenDepositSweepTransactions,
+				transaction,
+			)
 		}
 	}
 
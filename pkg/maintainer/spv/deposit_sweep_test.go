@@ -316,23 +316,24 @@ func TestGetUnprovenDepositSweepTransactions(t *testing.T) {
 	// Add deposit events for the wallets. Only wallet public key hash field
 	// is relevant as those events are just used to get a list of distinct
 	// wallets who likely performed deposit sweeps recently. The block number
-	// field is just to make them distinguishable while reading.
+	// field must be within the history depth as wallets with older events
+	// are not tracked by the checkpoint.
 	events := []*tbtc.DepositRevealedEvent{
 		{
 			WalletPublicKeyHash: wallets[0].walletPublicKeyHash,
-			BlockNumber:         100,
+			BlockNumber:         996,
 		},
 		{
 			WalletPublicKeyHash: wallets[0].walletPublicKeyHash,
-			BlockNumber:         200,
+			BlockNumber:         997,
 		},
 		{
 			WalletPublicKeyHash: wallets[1].walletPublicKeyHash,
-			BlockNumber:         300,
+			BlockNumber:         998,
 		},
 		{
 			WalletPublicKeyHash: wallets[1].walletPublicKeyHash,
-			BlockNumber:         400,
+			BlockNumber:         999,
 		},
 	}
 
@@ -349,6 +350,7 @@ func TestGetUnprovenDepositSweepTransactions(t *testing.T) {
 	}
 
 	transactions, err := getUnprovenDepositSweepTransactions(
+		newProofTypeCheckpoint(),
 		historyDepth,
 		transactionLimit,
 		btcChain,
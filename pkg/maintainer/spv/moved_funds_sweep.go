@@ -0,0 +1,440 @@
+package spv
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// SubmitMovedFundsSweepProof prepares moved funds sweep proof for the given
+// transaction and submits it to the on-chain contract. If the number of
+// required confirmations is `0`, an error is returned.
+func SubmitMovedFundsSweepProof(
+	transactionHash bitcoin.Hash,
+	requiredConfirmations uint,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) error {
+	return submitMovedFundsSweepProof(
+		transactionHash,
+		requiredConfirmations,
+		btcChain,
+		spvChain,
+		bitcoin.AssembleSpvProof,
+	)
+}
+
+func submitMovedFundsSweepProof(
+	transactionHash bitcoin.Hash,
+	requiredConfirmations uint,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+	spvProofAssembler spvProofAssembler,
+) error {
+	if requiredConfirmations == 0 {
+		return fmt.Errorf(
+			"provided required confirmations count must be greater than 0",
+		)
+	}
+
+	transaction, proof, err := spvProofAssembler(
+		transactionHash,
+		requiredConfirmations,
+		btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to assemble transaction spv proof: [%v]",
+			err,
+		)
+	}
+
+	mainUTXO, err := parseMovedFundsSweepTransactionInputs(
+		btcChain,
+		spvChain,
+		transaction,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while parsing transaction inputs: [%v]",
+			err,
+		)
+	}
+
+	if err := spvChain.SubmitMovedFundsSweepProofWithReimbursement(
+		transaction,
+		proof,
+		mainUTXO,
+	); err != nil {
+		return fmt.Errorf(
+			"failed to submit moved funds sweep proof with "+
+				"reimbursement: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// parseMovedFundsSweepTransactionInputs parses the transaction's inputs and
+// returns the main UTXO of the wallet. If the transaction does not spend the
+// wallet's main UTXO, the returned main UTXO is zero-filled.
+func parseMovedFundsSweepTransactionInputs(
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+	transaction *bitcoin.Transaction,
+) (bitcoin.UnspentTransactionOutput, error) {
+	// Represents the main UTXO of the moved funds sweep transaction. Nil if
+	// there was no main UTXO.
+	var mainUTXO *bitcoin.UnspentTransactionOutput = nil
+
+	// This flag checks if the moved funds input has been found during
+	// inputs processing.
+	var movedFundsFound = false
+
+	// Perform a sanity check: a moved funds sweep transaction must have
+	// exactly one output.
+	if len(transaction.Outputs) != 1 {
+		return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+			"moved funds sweep transaction has more than one output",
+		)
+	}
+
+	for _, input := range transaction.Inputs {
+		outpointTransactionHash := input.Outpoint.TransactionHash
+		outpointIndex := input.Outpoint.OutputIndex
+
+		// Both the moved funds input and the main UTXO input are P2PKH or
+		// P2WPKH so the only way to tell them apart is to look for the
+		// moved funds sweep request.
+		_, found, err := spvChain.GetMovedFundsSweepRequest(
+			outpointTransactionHash,
+			outpointIndex,
+		)
+		if err != nil {
+			return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+				"failed to get moved funds sweep request: [%v]",
+				err,
+			)
+		}
+
+		if found {
+			if movedFundsFound {
+				return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+					"moved funds sweep transaction has more than one " +
+						"moved funds input",
+				)
+			}
+
+			movedFundsFound = true
+			continue
+		}
+
+		// The input is not a moved funds sweep request so it must be the
+		// main UTXO. There should be at most one main UTXO.
+		if mainUTXO != nil {
+			return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+				"moved funds sweep transaction has more than one " +
+					"non-moved funds input",
+			)
+		}
+
+		previousTransaction, err := btcChain.GetTransaction(
+			outpointTransactionHash,
+		)
+		if err != nil {
+			return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+				"failed to get previous transaction: [%v]",
+				err,
+			)
+		}
+
+		mainUTXO = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: outpointTransactionHash,
+				OutputIndex:     outpointIndex,
+			},
+			Value: previousTransaction.Outputs[outpointIndex].Value,
+		}
+	}
+
+	if !movedFundsFound {
+		return bitcoin.UnspentTransactionOutput{}, fmt.Errorf(
+			"moved funds sweep transaction has no moved funds input",
+		)
+	}
+
+	// If none of the input was main UTXO, return zero-filled main UTXO.
+	if mainUTXO == nil {
+		mainUTXO = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{},
+				OutputIndex:     0,
+			},
+			Value: 0,
+		}
+	}
+
+	return *mainUTXO, nil
+}
+
+func getUnprovenMovedFundsSweepTransactions(
+	checkpoint *proofTypeCheckpoint,
+	historyDepth uint64,
+	transactionLimit int,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) (
+	[]*bitcoin.Transaction,
+	error,
+) {
+	blockCounter, err := spvChain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block: [%v]", err)
+	}
+
+	// Calculate the starting block of the range in which the events will be
+	// searched for. If the events were already scanned in the previous
+	// loops, only the recent blocks are scanned.
+	startBlock := checkpoint.scanStartBlock(currentBlock, historyDepth)
+
+	events, err := spvChain.PastMovingFundsCompletedEvents(
+		&tbtc.MovingFundsCompletedEventFilter{
+			StartBlock: startBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past moving funds completed events: [%v]",
+			err,
+		)
+	}
+
+	// Moved funds sweep transactions are made by target wallets of the
+	// completed moving funds. Prepare a list of unique target wallets based
+	// on outputs of the proven moving funds transactions.
+	targetWallets, err := uniqueMovingFundsTargetWallets(
+		events,
+		btcChain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get moving funds target wallets: [%v]",
+			err,
+		)
+	}
+
+	checkpoint.recordScan(targetWallets, currentBlock, historyDepth)
+	walletPublicKeyHashes := checkpoint.walletPublicKeyHashes()
+
+	var unprovenMovedFundsSweepTransactions []*bitcoin.Transaction
+
+	for _, walletPublicKeyHash := range walletPublicKeyHashes {
+		wallet, err := spvChain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet: [%v]", err)
+		}
+
+		if wallet.State != tbtc.StateLive &&
+			wallet.State != tbtc.StateMovingFunds {
+			// The wallet can only submit moved funds sweep proof if it's
+			// `Live` or `MovingFunds`. If the state is different skip it.
+			logger.Infof(
+				"skipped proving moved funds sweep transactions for wallet "+
+					"[%x] because of wallet state [%v]",
+				walletPublicKeyHash,
+				wallet.State,
+			)
+			continue
+		}
+
+		walletTransactions, err := btcChain.GetTransactionsForPublicKeyHash(
+			walletPublicKeyHash,
+			transactionLimit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get transactions for wallet: [%v]",
+				err,
+			)
+		}
+
+		for _, transaction := range walletTransactions {
+			if checkpoint.isSettled(transaction.Hash(), currentBlock) {
+				// The transaction was already proven or is not relevant.
+				continue
+			}
+
+			isUnproven, err := isUnprovenMovedFundsSweepTransaction(
+				transaction,
+				walletPublicKeyHash,
+				btcChain,
+				spvChain,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to check if transaction is an unproven moved "+
+						"funds sweep transaction: [%v]",
+					err,
+				)
+			}
+
+			if !isUnproven {
+				checkpoint.settle(transaction.Hash(), currentBlock)
+				continue
+			}
+
+			// The transaction may have been settled before but turned out
+			// to be unproven, e.g. due to a chain reorganization.
+			checkpoint.unsettle(transaction.Hash())
+
+			unprovenMovedFundsSweepTransactions = append(
+				unprovenMovedFundsSweepTransactions,
+				transaction,
+			)
+		}
+	}
+
+	return unprovenMovedFundsSweepTransactions, nil
+}
+
+// uniqueMovingFundsTargetWallets returns activities of unique wallets funds
+// were moved to by the moving funds transactions referenced by the given
+// events.
+func uniqueMovingFundsTargetWallets(
+	events []*tbtc.MovingFundsCompletedEvent,
+	btcChain bitcoin.Chain,
+) ([]*walletActivity, error) {
+	cache := make(map[[20]byte]*walletActivity)
+	var activities []*walletActivity
+
+	for _, event := range events {
+		movingFundsTransaction, err := btcChain.GetTransaction(
+			event.MovingFundsTxHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get moving funds transaction: [%v]",
+				err,
+			)
+		}
+
+		for _, output := range movingFundsTransaction.Outputs {
+			publicKeyHash, err := bitcoin.ExtractPublicKeyHash(
+				output.PublicKeyScript,
+			)
+			if err != nil {
+				// Moving funds transactions pay only to P2PKH or P2WPKH
+				// so this output is not of our interest.
+				continue
+			}
+
+			activities = observeWalletActivity(
+				activities,
+				cache,
+				publicKeyHash,
+				event.BlockNumber,
+			)
+		}
+	}
+
+	return activities, nil
+}
+
+func isUnprovenMovedFundsSweepTransaction(
+	transaction *bitcoin.Transaction,
+	walletPublicKeyHash [20]byte,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) (bool, error) {
+	// A moved funds sweep transaction must have exactly one output that
+	// transfers funds to the wallet itself.
+	if len(transaction.Outputs) != 1 {
+		return false, nil
+	}
+
+	isWalletOutput, err := isWalletChangeOutput(
+		walletPublicKeyHash,
+		transaction.Outputs[0],
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to check if output is wallet output: [%v]",
+			err,
+		)
+	}
+
+	if !isWalletOutput {
+		return false, nil
+	}
+
+	// A moved funds sweep transaction spends the moved funds UTXO and
+	// optionally the wallet main UTXO.
+	if len(transaction.Inputs) != 1 && len(transaction.Inputs) != 2 {
+		return false, nil
+	}
+
+	mainUtxoFound := false
+	movedFundsFound := false
+
+	for _, input := range transaction.Inputs {
+		request, found, err := spvChain.GetMovedFundsSweepRequest(
+			input.Outpoint.TransactionHash,
+			input.Outpoint.OutputIndex,
+		)
+		if err != nil {
+			return false, fmt.Errorf(
+				"failed to get moved funds sweep request: [%v]",
+				err,
+			)
+		}
+
+		if found {
+			// If the request is not pending or belongs to another wallet,
+			// the transaction is either already proven or it's not a moved
+			// funds sweep transaction of this wallet at all.
+			if movedFundsFound ||
+				request.WalletPublicKeyHash != walletPublicKeyHash ||
+				request.State != tbtc.MovedFundsStatePending {
+				return false, nil
+			}
+
+			movedFundsFound = true
+			continue
+		}
+
+		// If the given input is not a moved funds sweep request, it must be
+		// the current main UTXO of the wallet.
+		if mainUtxoFound {
+			return false, nil
+		}
+
+		isMainUtxo, err := isInputCurrentWalletsMainUTXO(
+			input.Outpoint.TransactionHash,
+			input.Outpoint.OutputIndex,
+			walletPublicKeyHash,
+			btcChain,
+			spvChain,
+		)
+		if err != nil {
+			return false, fmt.Errorf(
+				"failed to check if input is the main UTXO: [%v]",
+				err,
+			)
+		}
+
+		if !isMainUtxo {
+			return false, nil
+		}
+
+		mainUtxoFound = true
+	}
+
+	return movedFundsFound, nil
+}
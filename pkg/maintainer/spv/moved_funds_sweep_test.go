@@ -0,0 +1,327 @@
+package spv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestSubmitMovedFundsSweepProof(t *testing.T) {
+	requiredConfirmations := uint(6)
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	// Transaction holding the wallet main UTXO.
+	mainUtxoTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf1}, OutputIndex: 0},
+		},
+		[][20]byte{walletPublicKeyHash},
+		500000,
+	)
+	// Moving funds transaction of another wallet. The wallet is the second
+	// target wallet.
+	movingFundsTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf2}, OutputIndex: 0},
+		},
+		[][20]byte{{0x02}, walletPublicKeyHash},
+		200000,
+	)
+
+	var tests = map[string]struct {
+		hasMainUtxo      bool
+		expectedMainUtxo bitcoin.UnspentTransactionOutput
+	}{
+		"with main UTXO": {
+			hasMainUtxo: true,
+			expectedMainUtxo: bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: mainUtxoTransaction.Hash(),
+					OutputIndex:     0,
+				},
+				Value: 500000,
+			},
+		},
+		"without main UTXO": {
+			hasMainUtxo: false,
+			expectedMainUtxo: bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{},
+					OutputIndex:     0,
+				},
+				Value: 0,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := newLocalBitcoinChain()
+			spvChain := newLocalChain()
+
+			outpoints := []*bitcoin.TransactionOutpoint{
+				{TransactionHash: movingFundsTransaction.Hash(), OutputIndex: 1},
+			}
+			if test.hasMainUtxo {
+				outpoints = append(
+					[]*bitcoin.TransactionOutpoint{
+						{TransactionHash: mainUtxoTransaction.Hash(), OutputIndex: 0},
+					},
+					outpoints...,
+				)
+			}
+
+			sweepTransaction := newTestTransaction(
+				t,
+				outpoints,
+				[][20]byte{walletPublicKeyHash},
+				650000,
+			)
+
+			for _, transaction := range []*bitcoin.Transaction{
+				mainUtxoTransaction,
+				movingFundsTransaction,
+				sweepTransaction,
+			} {
+				if err := btcChain.BroadcastTransaction(transaction); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			spvChain.setMovedFundsSweepRequest(
+				movingFundsTransaction.Hash(),
+				1,
+				&tbtc.MovedFundsSweepRequest{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					Value:               200000,
+					State:               tbtc.MovedFundsStatePending,
+				},
+			)
+
+			// Just a mock proof.
+			proof := &bitcoin.SpvProof{
+				MerkleProof:    []byte{0x01},
+				TxIndexInBlock: 2,
+				BitcoinHeaders: []byte{0x03},
+			}
+
+			mockSpvProofAssembler := func(
+				hash bitcoin.Hash,
+				confirmations uint,
+				btcChain bitcoin.Chain,
+			) (*bitcoin.Transaction, *bitcoin.SpvProof, error) {
+				if hash == sweepTransaction.Hash() &&
+					confirmations == requiredConfirmations {
+					return sweepTransaction, proof, nil
+				}
+
+				return nil, nil, fmt.Errorf("error while assembling spv proof")
+			}
+
+			err := submitMovedFundsSweepProof(
+				sweepTransaction.Hash(),
+				requiredConfirmations,
+				btcChain,
+				spvChain,
+				mockSpvProofAssembler,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			submittedProofs := spvChain.getSubmittedMovedFundsSweepProofs()
+
+			testutils.AssertIntsEqual(t, "proofs count", 1, len(submittedProofs))
+
+			submittedProof := submittedProofs[0]
+
+			expectedTransactionHash := sweepTransaction.Hash()
+			actualTransactionHash := submittedProof.transaction.Hash()
+			testutils.AssertBytesEqual(
+				t,
+				expectedTransactionHash[:],
+				actualTransactionHash[:],
+			)
+
+			if diff := deep.Equal(proof, submittedProof.proof); diff != nil {
+				t.Errorf("invalid proof: %v", diff)
+			}
+
+			if diff := deep.Equal(
+				test.expectedMainUtxo,
+				submittedProof.mainUTXO,
+			); diff != nil {
+				t.Errorf("invalid main UTXO: %v", diff)
+			}
+		})
+	}
+}
+
+func TestGetUnprovenMovedFundsSweepTransactions(t *testing.T) {
+	// Set an arbitrary history depth and transaction limit.
+	historyDepth := uint64(5)
+	transactionLimit := 10
+
+	btcChain := newLocalBitcoinChain()
+	spvChain := newLocalChain()
+
+	// Set a predictable current block.
+	currentBlock := uint64(1000)
+	blockCounter := newMockBlockCounter()
+	blockCounter.SetCurrentBlock(currentBlock)
+	spvChain.setBlockCounter(blockCounter)
+
+	sourceWallet := [20]byte{0x01}
+	// Wallet 1 is Live and has a main UTXO. Its moved funds sweep request
+	// is still pending.
+	wallet1 := [20]byte{0x02}
+	// Wallet 2 is Live and has no main UTXO. Its moved funds sweep request
+	// has already been processed.
+	wallet2 := [20]byte{0x03}
+	// Wallet 3 is Closed so its transactions must be skipped.
+	wallet3 := [20]byte{0x04}
+
+	movingFundsTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf1}, OutputIndex: 0},
+		},
+		[][20]byte{wallet1, wallet2, wallet3},
+		100000,
+	)
+
+	wallet1MainUtxoTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf2}, OutputIndex: 0},
+		},
+		// The second output makes it a redemption-like transaction.
+		[][20]byte{wallet1, {0xee}},
+		400000,
+	)
+	wallet1SweepTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: wallet1MainUtxoTransaction.Hash(), OutputIndex: 0},
+			{TransactionHash: movingFundsTransaction.Hash(), OutputIndex: 0},
+		},
+		[][20]byte{wallet1},
+		499000,
+	)
+	wallet2SweepTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: movingFundsTransaction.Hash(), OutputIndex: 1},
+		},
+		[][20]byte{wallet2},
+		99000,
+	)
+	wallet3SweepTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: movingFundsTransaction.Hash(), OutputIndex: 2},
+		},
+		[][20]byte{wallet3},
+		99000,
+	)
+
+	for _, transaction := range []*bitcoin.Transaction{
+		movingFundsTransaction,
+		wallet1MainUtxoTransaction,
+		wallet1SweepTransaction,
+		wallet2SweepTransaction,
+		wallet3SweepTransaction,
+	} {
+		if err := btcChain.BroadcastTransaction(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spvChain.setWallet(wallet1, &tbtc.WalletChainData{
+		MainUtxoHash: spvChain.ComputeMainUtxoHash(
+			&bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: wallet1MainUtxoTransaction.Hash(),
+					OutputIndex:     0,
+				},
+				Value: 400000,
+			},
+		),
+		State: tbtc.StateLive,
+	})
+	spvChain.setWallet(wallet2, &tbtc.WalletChainData{
+		MainUtxoHash: spvChain.ComputeMainUtxoHash(
+			&bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: wallet2SweepTransaction.Hash(),
+					OutputIndex:     0,
+				},
+				Value: 99000,
+			},
+		),
+		State: tbtc.StateLive,
+	})
+	spvChain.setWallet(wallet3, &tbtc.WalletChainData{
+		State: tbtc.StateClosed,
+	})
+
+	requestStates := map[[20]byte]tbtc.MovedFundsSweepRequestState{
+		wallet1: tbtc.MovedFundsStatePending,
+		wallet2: tbtc.MovedFundsStateProcessed,
+		wallet3: tbtc.MovedFundsStatePending,
+	}
+	for i, wallet := range [][20]byte{wallet1, wallet2, wallet3} {
+		spvChain.setMovedFundsSweepRequest(
+			movingFundsTransaction.Hash(),
+			uint32(i),
+			&tbtc.MovedFundsSweepRequest{
+				WalletPublicKeyHash: wallet,
+				Value:               100000,
+				State:               requestStates[wallet],
+			},
+		)
+	}
+
+	spvChain.addPastMovingFundsCompletedEvent(
+		&tbtc.MovingFundsCompletedEventFilter{
+			StartBlock: currentBlock - historyDepth,
+		},
+		&tbtc.MovingFundsCompletedEvent{
+			WalletPublicKeyHash: sourceWallet,
+			MovingFundsTxHash:   movingFundsTransaction.Hash(),
+			BlockNumber:         996,
+		},
+	)
+
+	transactions, err := getUnprovenMovedFundsSweepTransactions(
+		newProofTypeCheckpoint(),
+		historyDepth,
+		transactionLimit,
+		btcChain,
+		spvChain,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactionsHashes := make([]bitcoin.Hash, len(transactions))
+	for i, transaction := range transactions {
+		transactionsHashes[i] = transaction.Hash()
+	}
+
+	expectedTransactionsHashes := []bitcoin.Hash{
+		wallet1SweepTransaction.Hash(),
+	}
+
+	if diff := deep.Equal(expectedTransactionsHashes, transactionsHashes); diff != nil {
+		t.Errorf("invalid unproven transaction hashes: %v", diff)
+	}
+}
@@ -0,0 +1,306 @@
+package spv
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// SubmitMovingFundsProof prepares moving funds proof for the given
+// transaction and submits it to the on-chain contract. If the number of
+// required confirmations is `0`, an error is returned.
+func SubmitMovingFundsProof(
+	transactionHash bitcoin.Hash,
+	requiredConfirmations uint,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) error {
+	return submitMovingFundsProof(
+		transactionHash,
+		requiredConfirmations,
+		btcChain,
+		spvChain,
+		bitcoin.AssembleSpvProof,
+	)
+}
+
+func submitMovingFundsProof(
+	transactionHash bitcoin.Hash,
+	requiredConfirmations uint,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+	spvProofAssembler spvProofAssembler,
+) error {
+	if requiredConfirmations == 0 {
+		return fmt.Errorf(
+			"provided required confirmations count must be greater than 0",
+		)
+	}
+
+	transaction, proof, err := spvProofAssembler(
+		transactionHash,
+		requiredConfirmations,
+		btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to assemble transaction spv proof: [%v]",
+			err,
+		)
+	}
+
+	mainUTXO, walletPublicKeyHash, err := parseMovingFundsTransactionInput(
+		btcChain,
+		transaction,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while parsing transaction inputs: [%v]",
+			err,
+		)
+	}
+
+	if err := spvChain.SubmitMovingFundsProofWithReimbursement(
+		transaction,
+		proof,
+		mainUTXO,
+		walletPublicKeyHash,
+	); err != nil {
+		return fmt.Errorf(
+			"failed to submit moving funds proof with reimbursement: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// parseMovingFundsTransactionInput parses the transaction's input and
+// returns the main UTXO and the wallet public key hash.
+func parseMovingFundsTransactionInput(
+	btcChain bitcoin.Chain,
+	transaction *bitcoin.Transaction,
+) (bitcoin.UnspentTransactionOutput, [20]byte, error) {
+	// Perform a sanity check: a moving funds transaction must have exactly
+	// one input.
+	if len(transaction.Inputs) != 1 {
+		return bitcoin.UnspentTransactionOutput{}, [20]byte{}, fmt.Errorf(
+			"moving funds transaction has more than one input",
+		)
+	}
+
+	input := transaction.Inputs[0]
+
+	// Get data of the input transaction whose output is spent by the moving
+	// funds transaction.
+	inputTx, err := btcChain.GetTransaction(input.Outpoint.TransactionHash)
+	if err != nil {
+		return bitcoin.UnspentTransactionOutput{}, [20]byte{}, fmt.Errorf(
+			"cannot get input transaction data: [%v]",
+			err,
+		)
+	}
+
+	// Get the specific output spent by the moving funds transaction.
+	spentOutput := inputTx.Outputs[input.Outpoint.OutputIndex]
+
+	// Build the main UTXO object based on available data.
+	mainUtxo := bitcoin.UnspentTransactionOutput{
+		Outpoint: input.Outpoint,
+		Value:    spentOutput.Value,
+	}
+
+	// Extract the wallet public key hash from script.
+	walletPublicKeyHash, err := bitcoin.ExtractPublicKeyHash(
+		spentOutput.PublicKeyScript,
+	)
+	if err != nil {
+		return bitcoin.UnspentTransactionOutput{}, [20]byte{}, fmt.Errorf(
+			"cannot extract wallet public key hash: [%v]",
+			err,
+		)
+	}
+
+	return mainUtxo, walletPublicKeyHash, nil
+}
+
+func getUnprovenMovingFundsTransactions(
+	checkpoint *proofTypeCheckpoint,
+	historyDepth uint64,
+	transactionLimit int,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) (
+	[]*bitcoin.Transaction,
+	error,
+) {
+	blockCounter, err := spvChain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block: [%v]", err)
+	}
+
+	// Calculate the starting block of the range in which the events will be
+	// searched for. If the events were already scanned in the previous
+	// loops, only the recent blocks are scanned.
+	startBlock := checkpoint.scanStartBlock(currentBlock, historyDepth)
+
+	events, err := spvChain.PastMovingFundsCommitmentSubmittedEvents(
+		&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+			StartBlock: startBlock,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past moving funds commitment submitted events: [%v]",
+			err,
+		)
+	}
+
+	// A wallet may submit its commitment more than once if the previous
+	// moving funds attempt timed out. Prepare a list of unique wallet public
+	// key hashes and record them in the checkpoint.
+	checkpoint.recordScan(uniqueWalletActivities(events), currentBlock, historyDepth)
+	walletPublicKeyHashes := checkpoint.walletPublicKeyHashes()
+
+	var unprovenMovingFundsTransactions []*bitcoin.Transaction
+
+	for _, walletPublicKeyHash := range walletPublicKeyHashes {
+		wallet, err := spvChain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wallet: [%v]", err)
+		}
+
+		if wallet.State != tbtc.StateMovingFunds {
+			// The wallet can only submit moving funds proof if it's
+			// `MovingFunds`. If the state is different skip it.
+			logger.Infof(
+				"skipped proving moving funds transactions for wallet [%x] "+
+					"because of wallet state [%v]",
+				walletPublicKeyHash,
+				wallet.State,
+			)
+			continue
+		}
+
+		walletTransactions, err := btcChain.GetTransactionsForPublicKeyHash(
+			walletPublicKeyHash,
+			transactionLimit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get transactions for wallet: [%v]",
+				err,
+			)
+		}
+
+		for _, transaction := range walletTransactions {
+			if checkpoint.isSettled(transaction.Hash(), currentBlock) {
+				// The transaction was already proven or is not relevant.
+				continue
+			}
+
+			isUnproven, err := isUnprovenMovingFundsTransaction(
+				transaction,
+				walletPublicKeyHash,
+				wallet.MovingFundsTargetWalletsCommitmentHash,
+				btcChain,
+				spvChain,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to check if transaction is an unproven moving "+
+						"funds transaction: [%v]",
+					err,
+				)
+			}
+
+			if !isUnproven {
+				checkpoint.settle(transaction.Hash(), currentBlock)
+				continue
+			}
+
+			// The transaction may have been settled before but turned out
+			// to be unproven, e.g. due to a chain reorganization.
+			checkpoint.unsettle(transaction.Hash())
+
+			unprovenMovingFundsTransactions = append(
+				unprovenMovingFundsTransactions,
+				transaction,
+			)
+		}
+	}
+
+	return unprovenMovingFundsTransactions, nil
+}
+
+func isUnprovenMovingFundsTransaction(
+	transaction *bitcoin.Transaction,
+	walletPublicKeyHash [20]byte,
+	targetWalletsCommitmentHash [32]byte,
+	btcChain bitcoin.Chain,
+	spvChain Chain,
+) (bool, error) {
+	// If the transaction does not have exactly one input, it cannot be a
+	// moving funds transaction.
+	if len(transaction.Inputs) != 1 {
+		return false, nil
+	}
+
+	singleInput := transaction.Inputs[0]
+
+	// Check whether the single input is the current wallet main UTXO.
+	isMainUtxo, err := isInputCurrentWalletsMainUTXO(
+		singleInput.Outpoint.TransactionHash,
+		singleInput.Outpoint.OutputIndex,
+		walletPublicKeyHash,
+		btcChain,
+		spvChain,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"failed to check if input is the main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// If the single input is not the current main UTXO of the wallet, the
+	// transaction is either a moving funds transaction that is already
+	// proven or it's not a moving funds transaction at all.
+	if !isMainUtxo {
+		return false, nil
+	}
+
+	// All the outputs must transfer funds to the target wallets committed
+	// on-chain, in the same order as in the commitment.
+	targetWallets := make([][20]byte, len(transaction.Outputs))
+	for i, output := range transaction.Outputs {
+		scriptType := bitcoin.GetScriptType(output.PublicKeyScript)
+		if scriptType != bitcoin.P2PKHScript &&
+			scriptType != bitcoin.P2WPKHScript {
+			return false, nil
+		}
+
+		targetWallet, err := bitcoin.ExtractPublicKeyHash(
+			output.PublicKeyScript,
+		)
+		if err != nil {
+			return false, fmt.Errorf(
+				"cannot extract target wallet public key hash: [%v]",
+				err,
+			)
+		}
+
+		targetWallets[i] = targetWallet
+	}
+
+	commitmentHash := spvChain.ComputeMovingFundsCommitmentHash(targetWallets)
+
+	return bytes.Equal(commitmentHash[:], targetWalletsCommitmentHash[:]), nil
+}
@@ -0,0 +1,319 @@
+package spv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestSubmitMovingFundsProof(t *testing.T) {
+	requiredConfirmations := uint(6)
+
+	btcChain := newLocalBitcoinChain()
+	spvChain := newLocalChain()
+
+	walletPublicKeyHash := [20]byte{0x01}
+	targetWallets := [][20]byte{{0x02}, {0x03}}
+
+	// Record the transaction holding the wallet main UTXO and the moving
+	// funds transaction spending it on the local BTC chain.
+	mainUtxoTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xff}, OutputIndex: 0},
+		},
+		[][20]byte{walletPublicKeyHash},
+		500000,
+	)
+	movingFundsTransaction := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: mainUtxoTransaction.Hash(), OutputIndex: 0},
+		},
+		targetWallets,
+		249000,
+	)
+
+	for _, transaction := range []*bitcoin.Transaction{
+		mainUtxoTransaction,
+		movingFundsTransaction,
+	} {
+		if err := btcChain.BroadcastTransaction(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Just a mock proof.
+	proof := &bitcoin.SpvProof{
+		MerkleProof:    []byte{0x01},
+		TxIndexInBlock: 2,
+		BitcoinHeaders: []byte{0x03},
+	}
+
+	mockSpvProofAssembler := func(
+		hash bitcoin.Hash,
+		confirmations uint,
+		btcChain bitcoin.Chain,
+	) (*bitcoin.Transaction, *bitcoin.SpvProof, error) {
+		if hash == movingFundsTransaction.Hash() &&
+			confirmations == requiredConfirmations {
+			return movingFundsTransaction, proof, nil
+		}
+
+		return nil, nil, fmt.Errorf("error while assembling spv proof")
+	}
+
+	err := submitMovingFundsProof(
+		movingFundsTransaction.Hash(),
+		requiredConfirmations,
+		btcChain,
+		spvChain,
+		mockSpvProofAssembler,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submittedProofs := spvChain.getSubmittedMovingFundsProofs()
+
+	testutils.AssertIntsEqual(t, "proofs count", 1, len(submittedProofs))
+
+	submittedProof := submittedProofs[0]
+
+	expectedTransactionHash := movingFundsTransaction.Hash()
+	actualTransactionHash := submittedProof.transaction.Hash()
+	testutils.AssertBytesEqual(
+		t,
+		expectedTransactionHash[:],
+		actualTransactionHash[:],
+	)
+
+	if diff := deep.Equal(proof, submittedProof.proof); diff != nil {
+		t.Errorf("invalid proof: %v", diff)
+	}
+
+	expectedMainUtxo := bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: mainUtxoTransaction.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 500000,
+	}
+	if diff := deep.Equal(expectedMainUtxo, submittedProof.mainUTXO); diff != nil {
+		t.Errorf("invalid main UTXO: %v", diff)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		walletPublicKeyHash[:],
+		submittedProof.walletPublicKeyHash[:],
+	)
+}
+
+func TestGetUnprovenMovingFundsTransactions(t *testing.T) {
+	// Set an arbitrary history depth and transaction limit.
+	historyDepth := uint64(5)
+	transactionLimit := 10
+
+	btcChain := newLocalBitcoinChain()
+	spvChain := newLocalChain()
+
+	// Set a predictable current block.
+	currentBlock := uint64(1000)
+	blockCounter := newMockBlockCounter()
+	blockCounter.SetCurrentBlock(currentBlock)
+	spvChain.setBlockCounter(blockCounter)
+
+	targetWallets := [][20]byte{{0x0a}, {0x0b}}
+
+	// Wallet 1 is in the MovingFunds state. Its main UTXO is held by
+	// Transaction 1 which is a deposit sweep-like transaction with two
+	// inputs. Transaction 2 moves funds to the committed target wallets
+	// while Transaction 3 spends the same main UTXO but pays to wallets
+	// that are in the wrong order so it does not match the commitment.
+	wallet1 := [20]byte{0x01}
+	wallet1Transaction1 := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf1}, OutputIndex: 0},
+			{TransactionHash: bitcoin.Hash{0xf1}, OutputIndex: 1},
+		},
+		[][20]byte{wallet1},
+		300000,
+	)
+	wallet1Transaction2 := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: wallet1Transaction1.Hash(), OutputIndex: 0},
+		},
+		targetWallets,
+		149000,
+	)
+	wallet1Transaction3 := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: wallet1Transaction1.Hash(), OutputIndex: 0},
+		},
+		[][20]byte{targetWallets[1], targetWallets[0]},
+		149000,
+	)
+
+	// Wallet 2 committed to move funds but it is not in the MovingFunds
+	// state anymore. Its transactions must be skipped.
+	wallet2 := [20]byte{0x02}
+	wallet2Transaction1 := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: bitcoin.Hash{0xf2}, OutputIndex: 0},
+			{TransactionHash: bitcoin.Hash{0xf2}, OutputIndex: 1},
+		},
+		[][20]byte{wallet2},
+		300000,
+	)
+	wallet2Transaction2 := newTestTransaction(
+		t,
+		[]*bitcoin.TransactionOutpoint{
+			{TransactionHash: wallet2Transaction1.Hash(), OutputIndex: 0},
+		},
+		targetWallets,
+		149000,
+	)
+
+	for _, transaction := range []*bitcoin.Transaction{
+		wallet1Transaction1,
+		wallet1Transaction2,
+		wallet1Transaction3,
+		wallet2Transaction1,
+		wallet2Transaction2,
+	} {
+		if err := btcChain.BroadcastTransaction(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spvChain.setWallet(wallet1, &tbtc.WalletChainData{
+		MainUtxoHash: spvChain.ComputeMainUtxoHash(
+			&bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: wallet1Transaction1.Hash(),
+					OutputIndex:     0,
+				},
+				Value: 300000,
+			},
+		),
+		MovingFundsTargetWalletsCommitmentHash: spvChain.
+			ComputeMovingFundsCommitmentHash(targetWallets),
+		State: tbtc.StateMovingFunds,
+	})
+	spvChain.setWallet(wallet2, &tbtc.WalletChainData{
+		MainUtxoHash: spvChain.ComputeMainUtxoHash(
+			&bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: wallet2Transaction1.Hash(),
+					OutputIndex:     0,
+				},
+				Value: 300000,
+			},
+		),
+		MovingFundsTargetWalletsCommitmentHash: spvChain.
+			ComputeMovingFundsCommitmentHash(targetWallets),
+		State: tbtc.StateLive,
+	})
+
+	// Add commitment events for the wallets. Only the wallet public key hash
+	// field is relevant as those events are just used to get a list of
+	// distinct wallets who likely moved funds recently. The block number
+	// field must be within the history depth as wallets with older events
+	// are not tracked by the checkpoint.
+	events := []*tbtc.MovingFundsCommitmentSubmittedEvent{
+		{
+			WalletPublicKeyHash: wallet1,
+			BlockNumber:         996,
+		},
+		{
+			WalletPublicKeyHash: wallet1,
+			BlockNumber:         997,
+		},
+		{
+			WalletPublicKeyHash: wallet2,
+			BlockNumber:         998,
+		},
+	}
+
+	for _, event := range events {
+		spvChain.addPastMovingFundsCommitmentSubmittedEvent(
+			&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+				StartBlock: currentBlock - historyDepth,
+			},
+			event,
+		)
+	}
+
+	transactions, err := getUnprovenMovingFundsTransactions(
+		newProofTypeCheckpoint(),
+		historyDepth,
+		transactionLimit,
+		btcChain,
+		spvChain,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactionsHashes := make([]bitcoin.Hash, len(transactions))
+	for i, transaction := range transactions {
+		transactionsHashes[i] = transaction.Hash()
+	}
+
+	expectedTransactionsHashes := []bitcoin.Hash{
+		wallet1Transaction2.Hash(),
+	}
+
+	if diff := deep.Equal(expectedTransactionsHashes, transactionsHashes); diff != nil {
+		t.Errorf("invalid unproven transaction hashes: %v", diff)
+	}
+}
+
+// newTestTransaction creates an unsigned transaction spending the given
+// outpoints and transferring the given value to the P2WPKH address of each
+// of the given wallets.
+func newTestTransaction(
+	t *testing.T,
+	outpoints []*bitcoin.TransactionOutpoint,
+	walletPublicKeyHashes [][20]byte,
+	value int64,
+) *bitcoin.Transaction {
+	inputs := make([]*bitcoin.TransactionInput, len(outpoints))
+	for i, outpoint := range outpoints {
+		inputs[i] = &bitcoin.TransactionInput{
+			Outpoint:        outpoint,
+			SignatureScript: []byte{},
+			Sequence:        0xffffffff,
+		}
+	}
+
+	outputs := make([]*bitcoin.TransactionOutput, len(walletPublicKeyHashes))
+	for i, walletPublicKeyHash := range walletPublicKeyHashes {
+		script, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputs[i] = &bitcoin.TransactionOutput{
+			Value:           value,
+			PublicKeyScript: script,
+		}
+	}
+
+	return &bitcoin.Transaction{
+		Version:  1,
+		Inputs:   inputs,
+		Outputs:  outputs,
+		Locktime: 0,
+	}
+}
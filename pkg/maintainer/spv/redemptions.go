@@ -68,7 +68,7 @@ func submitRedemptionProof(
 		walletPublicKeyHash,
 	); err != nil {
 		return fmt.Errorf(
-			"failed to submit redemption proof with reimbursement: [%v]",
+			"failed to submit redemption proof with reimbursement: [%w]",
 			err,
 		)
 	}
@@ -124,6 +124,7 @@ func parseRedemptionTransactionInput(
 }
 
 func getUnprovenRedemptionTransactions(
+	checkpoint *proofTypeCheckpoint,
 	historyDepth uint64,
 	transactionLimit int,
 	btcChain bitcoin.Chain,
@@ -143,8 +144,9 @@ func getUnprovenRedemptionTransactions(
 	}
 
 	// Calculate the starting block of the range in which the events will be
-	// searched for.
-	startBlock := currentBlock - historyDepth
+	// searched for. If the events were already scanned in the previous
+	// loops, only the recent blocks are scanned.
+	startBlock := checkpoint.scanStartBlock(currentBlock, historyDepth)
 
 	events, err :=
 		spvChain.PastRedemptionRequestedEvents(
@@ -159,11 +161,12 @@ func getUnprovenRedemptionTransactions(
 		)
 	}
 
-	// There will often be multiple events emitted for a single wallet. Prepare
-	// a list of unique wallet public key hashes.
-	walletPublicKeyHashes := uniqueWalletPublicKeyHashes(
-		events,
-	)
+	// There will often be multiple events emitted for a single wallet. Record
+	// the unique wallets in the checkpoint. Wallets observed in the previous
+	// loops remain tracked as long as their events are within the history
+	// depth.
+	checkpoint.recordScan(uniqueWalletActivities(events), currentBlock, historyDepth)
+	walletPublicKeyHashes := checkpoint.walletPublicKeyHashes()
 
 	var unprovenRedemptionTransactions []*bitcoin.Transaction
 
@@ -198,6 +201,11 @@ func getUnprovenRedemptionTransactions(
 		}
 
 		for _, transaction := range walletTransactions {
+			if checkpoint.isSettled(transaction.Hash(), currentBlock) {
+				// The transaction was already proven or is not relevant.
+				continue
+			}
+
 			isUnproven, err :=
 				isUnprovenRedemptionTransaction(
 					transaction,
@@ -213,12 +221,19 @@ func getUnprovenRedemptionTransactions(
 				)
 			}
 
-			if isUnproven {
-				unprovenRedemptionTransactions = append(
-					unprovenRedemptionTransactions,
-					transaction,
-				)
+			if !isUnproven {
+				checkpoint.settle(transaction.Hash(), currentBlock)
+				continue
 			}
+
+			// The transaction may have been settled before but turned out
+			// to be unproven, e.g. due to a chain reorganization.
+			checkpoint.unsettle(transaction.Hash())
+
+			unprovenRedemptionTransactions = append(
+				unprovenRedemptionTransactions,
+				transaction,
+			)
 		}
 	}
 
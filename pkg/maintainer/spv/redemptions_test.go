@@ -255,23 +255,24 @@ func TestGetUnprovenRedemptionTransactions(t *testing.T) {
 	// Add redemption events for the wallets. Only wallet public key hash field
 	// is relevant as those events are just used to get a list of distinct
 	// wallets who likely performed redemptions recently. The block number field
-	// is just to make them distinguishable while reading.
+	// must be within the history depth as wallets with older events are not
+	// tracked by the checkpoint.
 	events := []*tbtc.RedemptionRequestedEvent{
 		{
 			WalletPublicKeyHash: wallets[0].walletPublicKeyHash,
-			BlockNumber:         100,
+			BlockNumber:         996,
 		},
 		{
 			WalletPublicKeyHash: wallets[0].walletPublicKeyHash,
-			BlockNumber:         200,
+			BlockNumber:         997,
 		},
 		{
 			WalletPublicKeyHash: wallets[1].walletPublicKeyHash,
-			BlockNumber:         300,
+			BlockNumber:         998,
 		},
 		{
 			WalletPublicKeyHash: wallets[1].walletPublicKeyHash,
-			BlockNumber:         400,
+			BlockNumber:         999,
 		},
 	}
 
@@ -288,6 +289,7 @@ func TestGetUnprovenRedemptionTransactions(t *testing.T) {
 	}
 
 	transactions, err := getUnprovenRedemptionTransactions(
+		newProofTypeCheckpoint(),
 		historyDepth,
 		transactionLimit,
 		btcChain,
@@ -3,7 +3,7 @@ package spv
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -12,8 +12,11 @@ import (
 
 	"github.com/ipfs/go-log/v2"
 
+	"github.com/keep-network/keep-common/pkg/persistence"
+
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/submission"
 )
 
 var logger = log.Logger("keep-maintainer-spv")
@@ -21,18 +24,41 @@ var logger = log.Logger("keep-maintainer-spv")
 // The length of the Bitcoin difficulty epoch in blocks.
 const difficultyEpochLength = 2016
 
+// Initialize starts the SPV maintainer. The checkpointPersistence is used to
+// persist the scanning checkpoint between the maintainer restarts. It can be
+// nil in which case the checkpoint is kept only in memory.
 func Initialize(
 	ctx context.Context,
 	config Config,
 	spvChain Chain,
 	btcDiffChain btcdiff.Chain,
 	btcChain bitcoin.Chain,
+	checkpointPersistence persistence.BasicHandle,
 ) {
+	checkpoint := newCheckpoint()
+
+	if config.ResetCheckpoint {
+		logger.Info("resetting SPV maintainer checkpoint")
+	} else if checkpointPersistence != nil {
+		loadedCheckpoint, err := loadCheckpoint(checkpointPersistence)
+		if err != nil {
+			logger.Errorf(
+				"cannot load SPV maintainer checkpoint; starting with "+
+					"an empty one: [%v]",
+				err,
+			)
+		} else {
+			checkpoint = loadedCheckpoint
+		}
+	}
+
 	spvMaintainer := &spvMaintainer{
-		config:       config,
-		spvChain:     spvChain,
-		btcDiffChain: btcDiffChain,
-		btcChain:     btcChain,
+		config:                config,
+		spvChain:              spvChain,
+		btcDiffChain:          btcDiffChain,
+		btcChain:              btcChain,
+		checkpoint:            checkpoint,
+		checkpointPersistence: checkpointPersistence,
 	}
 
 	go spvMaintainer.startControlLoop(ctx)
@@ -52,6 +78,14 @@ var proofTypes = map[tbtc.WalletActionType]struct {
 		unprovenTransactionsGetter: getUnprovenRedemptionTransactions,
 		transactionProofSubmitter:  SubmitRedemptionProof,
 	},
+	tbtc.ActionMovingFunds: {
+		unprovenTransactionsGetter: getUnprovenMovingFundsTransactions,
+		transactionProofSubmitter:  SubmitMovingFundsProof,
+	},
+	tbtc.ActionMovedFundsSweep: {
+		unprovenTransactionsGetter: getUnprovenMovedFundsSweepTransactions,
+		transactionProofSubmitter:  SubmitMovedFundsSweepProof,
+	},
 }
 
 type spvMaintainer struct {
@@ -59,6 +93,13 @@ type spvMaintainer struct {
 	spvChain     Chain
 	btcDiffChain btcdiff.Chain
 	btcChain     bitcoin.Chain
+
+	// checkpoint holds the scanning state allowing subsequent loops to be
+	// incremental.
+	checkpoint *checkpoint
+	// checkpointPersistence is used to persist the checkpoint. Nil if the
+	// checkpoint should be kept only in memory.
+	checkpointPersistence persistence.BasicHandle
 }
 
 func (sm *spvMaintainer) startControlLoop(ctx context.Context) {
@@ -90,10 +131,17 @@ func (sm *spvMaintainer) maintainSpv(ctx context.Context) error {
 		for action, v := range proofTypes {
 			logger.Infof("starting [%s] proof task execution...", action)
 
-			if err := sm.proveTransactions(
+			err := sm.proveTransactions(
+				sm.checkpoint.proofType(action),
 				v.unprovenTransactionsGetter,
 				v.transactionProofSubmitter,
-			); err != nil {
+			)
+
+			// The checkpoint is saved even if proving failed as the scan
+			// results recorded so far are still valid.
+			sm.saveCheckpoint()
+
+			if err != nil {
 				return fmt.Errorf(
 					"error while proving [%s] transactions: [%v]",
 					action,
@@ -117,9 +165,25 @@ func (sm *spvMaintainer) maintainSpv(ctx context.Context) error {
 	}
 }
 
+// saveCheckpoint persists the current checkpoint if the checkpoint
+// persistence is configured. Failures are only logged as the checkpoint
+// is an optimization and the maintainer can work without it.
+func (sm *spvMaintainer) saveCheckpoint() {
+	if sm.checkpointPersistence == nil {
+		return
+	}
+
+	if err := sm.checkpoint.save(sm.checkpointPersistence); err != nil {
+		logger.Errorf("cannot save SPV maintainer checkpoint: [%v]", err)
+	}
+}
+
 // unprovenTransactionsGetter is a type representing a function that is
-// used to get unproven Bitcoin transactions.
+// used to get unproven Bitcoin transactions. The checkpoint is used to
+// limit the scanned events range and skip already settled transactions.
+// It is updated with the results of the scan.
 type unprovenTransactionsGetter func(
+	checkpoint *proofTypeCheckpoint,
 	historyDepth uint64,
 	transactionLimit int,
 	btcChain bitcoin.Chain,
@@ -142,10 +206,12 @@ type transactionProofSubmitter func(
 // unprovenTransactionsGetter, build the SPV proofs, and submits them using
 // the provided transactionProofSubmitter.
 func (sm *spvMaintainer) proveTransactions(
+	checkpoint *proofTypeCheckpoint,
 	unprovenTransactionsGetter unprovenTransactionsGetter,
 	transactionProofSubmitter transactionProofSubmitter,
 ) error {
 	transactions, err := unprovenTransactionsGetter(
+		checkpoint,
 		sm.config.HistoryDepth,
 		sm.config.TransactionLimit,
 		sm.btcChain,
@@ -208,6 +274,16 @@ func (sm *spvMaintainer) proveTransactions(
 			sm.btcChain,
 			sm.spvChain,
 		)
+		if errors.Is(err, submission.ErrSkipped) {
+			// The proof is not worth submitting at the current gas prices.
+			// The transaction will be proven later.
+			logger.Warnf(
+				"skipped proving transaction [%s]; the submission "+
+					"was skipped by the submission policy",
+				transactionHashStr,
+			)
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -427,26 +503,59 @@ func getProofInfo(
 // walletEvent is a type constraint representing wallet-related chain events.
 type walletEvent interface {
 	GetWalletPublicKeyHash() [20]byte
+	GetBlockNumber() uint64
 }
 
-// uniqueWalletPublicKeyHashes parses the list of wallet-related events and
-// returns a list of unique wallet public key hashes.
-func uniqueWalletPublicKeyHashes[T walletEvent](events []T) [][20]byte {
-	cache := make(map[string]struct{})
-	var publicKeyHashes [][20]byte
-
-	for _, event := range events {
-		key := event.GetWalletPublicKeyHash()
-		strKey := hex.EncodeToString(key[:])
+// walletActivity describes wallet-related chain events observed for
+// the given wallet.
+type walletActivity struct {
+	walletPublicKeyHash [20]byte
+	latestEventBlock    uint64
+}
 
-		// Check for uniqueness
-		if _, exists := cache[strKey]; !exists {
-			cache[strKey] = struct{}{}
-			publicKeyHashes = append(publicKeyHashes, key)
+// observeWalletActivity records an event of the given wallet emitted at
+// the given block. The activities slice holds unique wallets in the order
+// they were first observed and the cache maps the wallet public key hash
+// to the wallet activity.
+func observeWalletActivity(
+	activities []*walletActivity,
+	cache map[[20]byte]*walletActivity,
+	walletPublicKeyHash [20]byte,
+	eventBlock uint64,
+) []*walletActivity {
+	if activity, exists := cache[walletPublicKeyHash]; exists {
+		if eventBlock > activity.latestEventBlock {
+			activity.latestEventBlock = eventBlock
 		}
+		return activities
+	}
+
+	activity := &walletActivity{
+		walletPublicKeyHash: walletPublicKeyHash,
+		latestEventBlock:    eventBlock,
+	}
+	cache[walletPublicKeyHash] = activity
+
+	return append(activities, activity)
+}
+
+// uniqueWalletActivities parses the list of wallet-related events and
+// returns activities of unique wallets, in the order they first appear in
+// the events list.
+func uniqueWalletActivities[T walletEvent](events []T) []*walletActivity {
+	cache := make(map[[20]byte]*walletActivity)
+	var activities []*walletActivity
+
+	for _, event := range events {
+		activities = observeWalletActivity(
+			activities,
+			cache,
+			event.GetWalletPublicKeyHash(),
+			event.GetBlockNumber(),
+		)
 	}
 
-	return publicKeyHashes
+	return activities
 }
 
 // spvProofAssembler is a type representing a function that is used
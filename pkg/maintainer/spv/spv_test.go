@@ -151,7 +151,7 @@ func TestGetProofInfo(t *testing.T) {
 	}
 }
 
-func TestUniqueWalletPublicKeyHashes(t *testing.T) {
+func TestUniqueWalletActivities(t *testing.T) {
 	bytesFromHex := func(str string) []byte {
 		value, err := hex.DecodeString(str)
 		if err != nil {
@@ -172,47 +172,65 @@ func TestUniqueWalletPublicKeyHashes(t *testing.T) {
 			WalletPublicKeyHash: bytes20FromHex(
 				"4cc32253cc0bcd0cf9cfc79ed7b21d10df207f0d",
 			),
+			BlockNumber: 100,
 		},
 		&tbtc.DepositRevealedEvent{
 			WalletPublicKeyHash: bytes20FromHex(
 				"ddbd706d13dbd06038519c7621ac5de167bd3fd6",
 			),
+			BlockNumber: 101,
 		},
 		&tbtc.DepositRevealedEvent{
 			WalletPublicKeyHash: bytes20FromHex(
 				"4cc32253cc0bcd0cf9cfc79ed7b21d10df207f0d",
 			),
+			BlockNumber: 102,
 		},
 		&tbtc.DepositRevealedEvent{
 			WalletPublicKeyHash: bytes20FromHex(
 				"1016a8ff380e8907c82a88158019917e65c16ac4",
 			),
+			BlockNumber: 103,
 		},
 		&tbtc.DepositRevealedEvent{
 			WalletPublicKeyHash: bytes20FromHex(
 				"1016a8ff380e8907c82a88158019917e65c16ac4",
 			),
+			BlockNumber: 104,
 		},
 		&tbtc.DepositRevealedEvent{
 			WalletPublicKeyHash: bytes20FromHex(
 				"2c35ed9921fa35482c3cb3ae1190d87ede65dfd8",
 			),
+			BlockNumber: 105,
 		},
 	}
-	walletKeyHashes := uniqueWalletPublicKeyHashes(events)
+	activities := uniqueWalletActivities(events)
 
-	expectedWalletKeyHashes := [][20]byte{
-		bytes20FromHex("4cc32253cc0bcd0cf9cfc79ed7b21d10df207f0d"),
-		bytes20FromHex("ddbd706d13dbd06038519c7621ac5de167bd3fd6"),
-		bytes20FromHex("1016a8ff380e8907c82a88158019917e65c16ac4"),
-		bytes20FromHex("2c35ed9921fa35482c3cb3ae1190d87ede65dfd8"),
+	expectedActivities := []*walletActivity{
+		{
+			walletPublicKeyHash: bytes20FromHex("4cc32253cc0bcd0cf9cfc79ed7b21d10df207f0d"),
+			latestEventBlock:    102,
+		},
+		{
+			walletPublicKeyHash: bytes20FromHex("ddbd706d13dbd06038519c7621ac5de167bd3fd6"),
+			latestEventBlock:    101,
+		},
+		{
+			walletPublicKeyHash: bytes20FromHex("1016a8ff380e8907c82a88158019917e65c16ac4"),
+			latestEventBlock:    104,
+		},
+		{
+			walletPublicKeyHash: bytes20FromHex("2c35ed9921fa35482c3cb3ae1190d87ede65dfd8"),
+			latestEventBlock:    105,
+		},
 	}
 
-	if !reflect.DeepEqual(expectedWalletKeyHashes, walletKeyHashes) {
+	if !reflect.DeepEqual(expectedActivities, activities) {
 		t.Errorf(
-			"unexpected wallet public key hashes\nexpected: %v\nactual:   %v\n",
-			expectedWalletKeyHashes,
-			walletKeyHashes,
+			"unexpected wallet activities\nexpected: %v\nactual:   %v\n",
+			expectedActivities,
+			activities,
 		)
 	}
 }
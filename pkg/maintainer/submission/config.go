@@ -0,0 +1,56 @@
+package submission
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+)
+
+const (
+	// DefaultDelayBackoffTime is the default value for the wait time applied
+	// before a delayed submission is evaluated again.
+	DefaultDelayBackoffTime = 5 * time.Minute
+
+	// DefaultMaxDelayTime is the default value for the maximum time
+	// a submission can be delayed before it is skipped.
+	DefaultMaxDelayTime = 1 * time.Hour
+)
+
+var (
+	// DefaultMaxUncoveredCost is the default value for the maximum
+	// uncovered cost of a submission that is still sent. By default, the
+	// refund must cover the whole cost of the submission.
+	DefaultMaxUncoveredCost = ethereum.WrapWei(big.NewInt(0))
+
+	// DefaultSkipUncoveredCost is the default value for the uncovered cost
+	// above which a submission is skipped right away, without being delayed.
+	DefaultSkipUncoveredCost = ethereum.WrapWei(
+		big.NewInt(50000000000000000), // 0.05 ether
+	)
+)
+
+// Config holds configurable properties of the submission policy.
+type Config struct {
+	// Disabled indicates whether the submission policy should be bypassed.
+	// If set, all submissions are sent regardless of their cost.
+	Disabled bool
+
+	// MaxUncoveredCost is the maximum part of the submission cost that
+	// is not covered by the refund for which the submission is still sent.
+	MaxUncoveredCost ethereum.Wei
+
+	// SkipUncoveredCost is the uncovered cost above which the submission is
+	// skipped right away. Submissions whose uncovered cost is between
+	// MaxUncoveredCost and SkipUncoveredCost are delayed in expectation of
+	// a gas price drop or a reimbursement pool top-up.
+	SkipUncoveredCost ethereum.Wei
+
+	// DelayBackoffTime is a wait time which should be applied before
+	// a delayed submission is evaluated again.
+	DelayBackoffTime time.Duration
+
+	// MaxDelayTime is the maximum time a submission can be delayed. Once
+	// it elapses, the submission is skipped.
+	MaxDelayTime time.Duration
+}
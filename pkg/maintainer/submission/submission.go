@@ -0,0 +1,254 @@
+// Package submission provides a policy deciding whether maintainer
+// submissions reimbursed by the reimbursement pool are worth sending at
+// the current gas prices.
+package submission
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("keep-maintainer-submission")
+
+// ErrSkipped is returned when the submission was skipped by the policy
+// because the refund does not cover its cost.
+var ErrSkipped = fmt.Errorf("submission skipped by the submission policy")
+
+// Decision represents the decision made by the policy about a submission.
+type Decision int
+
+const (
+	// DecisionSubmit means the submission should be sent right away.
+	DecisionSubmit Decision = iota
+	// DecisionDelay means the submission should be evaluated again later.
+	DecisionDelay
+	// DecisionSkip means the submission should not be sent.
+	DecisionSkip
+)
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionSubmit:
+		return "Submit"
+	case DecisionDelay:
+		return "Delay"
+	case DecisionSkip:
+		return "Skip"
+	default:
+		panic("unknown submission decision")
+	}
+}
+
+// Estimate holds the information required to assess the cost of
+// a submission and the refund the submitter gets from the reimbursement pool.
+type Estimate struct {
+	// GasEstimate is the gas expected to be used by the submission. It is
+	// obtained by simulating the submission call.
+	GasEstimate uint64
+	// GasOffset is the gas added by the maintainer proxy contract to the gas
+	// used by the submission to account for the gas spent outside the
+	// measured part of the call.
+	GasOffset uint64
+	// GasPrice is the current gas price.
+	GasPrice *big.Int
+	// StaticGas is the gas the reimbursement pool adds to every refund to
+	// account for the cost of the refund itself.
+	StaticGas uint64
+	// MaxGasPrice is the maximum gas price the reimbursement pool refunds.
+	MaxGasPrice *big.Int
+	// PoolBalance is the current balance of the reimbursement pool.
+	PoolBalance *big.Int
+}
+
+// Cost returns the estimated cost of the submission, in wei.
+func (e *Estimate) Cost() *big.Int {
+	return new(big.Int).Mul(
+		new(big.Int).SetUint64(e.GasEstimate),
+		e.GasPrice,
+	)
+}
+
+// Refund returns the estimated refund of the submission, in wei. The
+// reimbursement pool refunds the used gas along with the gas offset and
+// the static gas, at the current gas price capped by the pool's maximum
+// gas price. The refund is not sent at all if the pool balance is too low.
+func (e *Estimate) Refund() *big.Int {
+	gasPrice := e.GasPrice
+	if gasPrice.Cmp(e.MaxGasPrice) > 0 {
+		gasPrice = e.MaxGasPrice
+	}
+
+	refundedGas := new(big.Int).SetUint64(e.GasEstimate)
+	refundedGas.Add(refundedGas, new(big.Int).SetUint64(e.GasOffset))
+	refundedGas.Add(refundedGas, new(big.Int).SetUint64(e.StaticGas))
+
+	refund := new(big.Int).Mul(refundedGas, gasPrice)
+	if e.PoolBalance.Cmp(refund) < 0 {
+		return big.NewInt(0)
+	}
+
+	return refund
+}
+
+// UncoveredCost returns the part of the estimated submission cost that is
+// not covered by the refund, in wei. Zero is returned if the refund covers
+// the whole cost.
+func (e *Estimate) UncoveredCost() *big.Int {
+	uncoveredCost := new(big.Int).Sub(e.Cost(), e.Refund())
+	if uncoveredCost.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	return uncoveredCost
+}
+
+// Policy decides whether reimbursed submissions should be sent, delayed or
+// skipped, based on their estimated cost and refund. Policy is shared by
+// all maintainers sending reimbursed submissions.
+type Policy struct {
+	config Config
+
+	submittedCount uint64
+	delayedCount   uint64
+	skippedCount   uint64
+}
+
+// NewPolicy creates a new submission policy with the given config.
+func NewPolicy(config Config) *Policy {
+	if config.DelayBackoffTime == 0 {
+		config.DelayBackoffTime = DefaultDelayBackoffTime
+	}
+	if config.MaxDelayTime == 0 {
+		config.MaxDelayTime = DefaultMaxDelayTime
+	}
+	if config.MaxUncoveredCost.Int == nil {
+		config.MaxUncoveredCost = *DefaultMaxUncoveredCost
+	}
+	if config.SkipUncoveredCost.Int == nil {
+		config.SkipUncoveredCost = *DefaultSkipUncoveredCost
+	}
+
+	return &Policy{config: config}
+}
+
+// Evaluate makes the decision about the submission with the given estimate.
+func (p *Policy) Evaluate(estimate *Estimate) Decision {
+	uncoveredCost := estimate.UncoveredCost()
+
+	if uncoveredCost.Cmp(p.config.MaxUncoveredCost.Int) <= 0 {
+		return DecisionSubmit
+	}
+
+	if uncoveredCost.Cmp(p.config.SkipUncoveredCost.Int) <= 0 {
+		return DecisionDelay
+	}
+
+	return DecisionSkip
+}
+
+// Execute evaluates the submission using the given estimator and sends it
+// using the given submitter if the policy allows for it. A delayed
+// submission is evaluated again after the delay backoff time, until the
+// maximum delay time elapses and the submission gets skipped. ErrSkipped
+// is returned if the submission was skipped. The description is used for
+// logging purposes only.
+func (p *Policy) Execute(
+	ctx context.Context,
+	description string,
+	estimator func() (*Estimate, error),
+	submitter func() error,
+) error {
+	if p.config.Disabled {
+		atomic.AddUint64(&p.submittedCount, 1)
+		return submitter()
+	}
+
+	delayStart := time.Now()
+
+	for {
+		estimate, err := estimator()
+		if err != nil {
+			return fmt.Errorf(
+				"cannot estimate [%s] submission: [%w]",
+				description,
+				err,
+			)
+		}
+
+		decision := p.Evaluate(estimate)
+
+		if decision == DecisionDelay &&
+			time.Since(delayStart) >= p.config.MaxDelayTime {
+			logger.Warnf(
+				"[%s] submission has been delayed for more than [%s]",
+				description,
+				p.config.MaxDelayTime,
+			)
+			decision = DecisionSkip
+		}
+
+		switch decision {
+		case DecisionSubmit:
+			atomic.AddUint64(&p.submittedCount, 1)
+
+			logger.Infof(
+				"sending [%s] submission; estimated cost: [%v wei], "+
+					"estimated refund: [%v wei]",
+				description,
+				estimate.Cost(),
+				estimate.Refund(),
+			)
+
+			return submitter()
+		case DecisionDelay:
+			atomic.AddUint64(&p.delayedCount, 1)
+
+			logger.Warnf(
+				"delaying [%s] submission by [%s]; estimated cost: "+
+					"[%v wei], estimated refund: [%v wei]",
+				description,
+				p.config.DelayBackoffTime,
+				estimate.Cost(),
+				estimate.Refund(),
+			)
+
+			select {
+			case <-time.After(p.config.DelayBackoffTime):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case DecisionSkip:
+			atomic.AddUint64(&p.skippedCount, 1)
+
+			logger.Warnf(
+				"skipping [%s] submission; estimated cost: [%v wei], "+
+					"estimated refund: [%v wei]",
+				description,
+				estimate.Cost(),
+				estimate.Refund(),
+			)
+
+			return ErrSkipped
+		}
+	}
+}
+
+// DecisionsCount returns the number of times the given decision was made
+// by the policy.
+func (p *Policy) DecisionsCount(decision Decision) uint64 {
+	switch decision {
+	case DecisionSubmit:
+		return atomic.LoadUint64(&p.submittedCount)
+	case DecisionDelay:
+		return atomic.LoadUint64(&p.delayedCount)
+	case DecisionSkip:
+		return atomic.LoadUint64(&p.skippedCount)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,323 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := map[string]struct {
+		gasPrice              int64
+		maxGasPrice           int64
+		poolBalance           int64
+		expectedCost          int64
+		expectedRefund        int64
+		expectedUncoveredCost int64
+	}{
+		"gas price below max gas price": {
+			gasPrice:              10,
+			maxGasPrice:           20,
+			poolBalance:           1000000,
+			expectedCost:          1000,
+			expectedRefund:        1600,
+			expectedUncoveredCost: 0,
+		},
+		"gas price above max gas price": {
+			gasPrice:              40,
+			maxGasPrice:           20,
+			poolBalance:           1000000,
+			expectedCost:          4000,
+			expectedRefund:        3200,
+			expectedUncoveredCost: 800,
+		},
+		"pool balance too low": {
+			gasPrice:              10,
+			maxGasPrice:           20,
+			poolBalance:           1599,
+			expectedCost:          1000,
+			expectedRefund:        0,
+			expectedUncoveredCost: 1000,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			estimate := &Estimate{
+				GasEstimate: 100,
+				GasOffset:   40,
+				GasPrice:    big.NewInt(test.gasPrice),
+				StaticGas:   20,
+				MaxGasPrice: big.NewInt(test.maxGasPrice),
+				PoolBalance: big.NewInt(test.poolBalance),
+			}
+
+			testutils.AssertBigIntsEqual(
+				t,
+				"cost",
+				big.NewInt(test.expectedCost),
+				estimate.Cost(),
+			)
+			testutils.AssertBigIntsEqual(
+				t,
+				"refund",
+				big.NewInt(test.expectedRefund),
+				estimate.Refund(),
+			)
+			testutils.AssertBigIntsEqual(
+				t,
+				"uncovered cost",
+				big.NewInt(test.expectedUncoveredCost),
+				estimate.UncoveredCost(),
+			)
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy := NewPolicy(Config{
+		MaxUncoveredCost:  *ethereum.WrapWei(big.NewInt(100)),
+		SkipUncoveredCost: *ethereum.WrapWei(big.NewInt(1000)),
+	})
+
+	tests := map[string]struct {
+		gasPrice         int64
+		expectedDecision Decision
+	}{
+		"refund covers the cost": {
+			gasPrice:         10,
+			expectedDecision: DecisionSubmit,
+		},
+		"uncovered cost below the max uncovered cost": {
+			gasPrice:         11, // uncovered cost: 100
+			expectedDecision: DecisionSubmit,
+		},
+		"uncovered cost above the max uncovered cost": {
+			gasPrice:         12, // uncovered cost: 200
+			expectedDecision: DecisionDelay,
+		},
+		"uncovered cost below the skip uncovered cost": {
+			gasPrice:         20, // uncovered cost: 1000
+			expectedDecision: DecisionDelay,
+		},
+		"uncovered cost above the skip uncovered cost": {
+			gasPrice:         21, // uncovered cost: 1100
+			expectedDecision: DecisionSkip,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			decision := policy.Evaluate(&Estimate{
+				GasEstimate: 100,
+				GasPrice:    big.NewInt(test.gasPrice),
+				MaxGasPrice: big.NewInt(10),
+				PoolBalance: big.NewInt(1000000),
+			})
+
+			if test.expectedDecision != decision {
+				t.Errorf(
+					"unexpected decision\nexpected: %v\nactual:   %v\n",
+					test.expectedDecision,
+					decision,
+				)
+			}
+		})
+	}
+}
+
+func TestPolicy_Execute(t *testing.T) {
+	estimateWithGasPrice := func(gasPrice int64) *Estimate {
+		// Uncovered cost is zero for gas price of 10 or lower, 100 for
+		// gas price of 11 and 1000 for gas price of 20.
+		return &Estimate{
+			GasEstimate: 100,
+			GasPrice:    big.NewInt(gasPrice),
+			MaxGasPrice: big.NewInt(10),
+			PoolBalance: big.NewInt(1000000),
+		}
+	}
+
+	estimateErr := fmt.Errorf("execution reverted")
+
+	config := Config{
+		MaxUncoveredCost:  *ethereum.WrapWei(big.NewInt(0)),
+		SkipUncoveredCost: *ethereum.WrapWei(big.NewInt(500)),
+		DelayBackoffTime:  10 * time.Millisecond,
+		MaxDelayTime:      100 * time.Millisecond,
+	}
+
+	tests := map[string]struct {
+		config              Config
+		gasPrices           []int64
+		estimateErr         error
+		expectedSubmissions int
+		expectedErr         error
+		expectedSubmitted   uint64
+		expectedDelayed     uint64
+		expectedSkipped     uint64
+	}{
+		"submitted right away": {
+			config:              config,
+			gasPrices:           []int64{10},
+			expectedSubmissions: 1,
+			expectedSubmitted:   1,
+		},
+		"submitted after delay": {
+			config:              config,
+			gasPrices:           []int64{11, 11, 10},
+			expectedSubmissions: 1,
+			expectedSubmitted:   1,
+			expectedDelayed:     2,
+		},
+		"skipped right away": {
+			config:          config,
+			gasPrices:       []int64{20},
+			expectedErr:     ErrSkipped,
+			expectedSkipped: 1,
+		},
+		"skipped after max delay time": {
+			config:          config,
+			gasPrices:       []int64{11},
+			expectedErr:     ErrSkipped,
+			expectedDelayed: 1,
+			expectedSkipped: 1,
+		},
+		"estimation failed": {
+			config:      config,
+			estimateErr: estimateErr,
+			expectedErr: estimateErr,
+		},
+		"policy disabled": {
+			config: Config{
+				Disabled: true,
+			},
+			gasPrices:           []int64{20},
+			expectedSubmissions: 1,
+			expectedSubmitted:   1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy := NewPolicy(test.config)
+
+			estimations := 0
+			estimator := func() (*Estimate, error) {
+				if test.estimateErr != nil {
+					return nil, test.estimateErr
+				}
+
+				// Use the last gas price once all were used.
+				index := estimations
+				if index >= len(test.gasPrices) {
+					index = len(test.gasPrices) - 1
+				}
+				estimations++
+
+				return estimateWithGasPrice(test.gasPrices[index]), nil
+			}
+
+			submissions := 0
+			submitter := func() error {
+				submissions++
+				return nil
+			}
+
+			err := policy.Execute(
+				context.Background(),
+				"test",
+				estimator,
+				submitter,
+			)
+
+			if test.expectedErr != nil {
+				testutils.AssertAnyErrorInChainMatchesTarget(
+					t,
+					test.expectedErr,
+					err,
+				)
+			} else {
+				testutils.AssertErrorsSame(t, nil, err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"submissions",
+				test.expectedSubmissions,
+				submissions,
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"submitted decisions",
+				test.expectedSubmitted,
+				policy.DecisionsCount(DecisionSubmit),
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"skipped decisions",
+				test.expectedSkipped,
+				policy.DecisionsCount(DecisionSkip),
+			)
+
+			// The exact number of delays before the maximum delay time
+			// elapses depends on timing so only check the lower bound.
+			delayed := policy.DecisionsCount(DecisionDelay)
+			if delayed < test.expectedDelayed ||
+				(test.expectedDelayed == 0 && delayed != 0) {
+				t.Errorf(
+					"unexpected delayed decisions\nexpected: %v\nactual:   %v\n",
+					test.expectedDelayed,
+					delayed,
+				)
+			}
+		})
+	}
+}
+
+func TestPolicy_Execute_ContextCancelled(t *testing.T) {
+	policy := NewPolicy(Config{
+		MaxUncoveredCost:  *ethereum.WrapWei(big.NewInt(0)),
+		SkipUncoveredCost: *ethereum.WrapWei(big.NewInt(500)),
+		DelayBackoffTime:  time.Hour,
+		MaxDelayTime:      time.Hour,
+	})
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		10*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	err := policy.Execute(
+		ctx,
+		"test",
+		func() (*Estimate, error) {
+			return &Estimate{
+				GasEstimate: 100,
+				GasPrice:    big.NewInt(11),
+				MaxGasPrice: big.NewInt(10),
+				PoolBalance: big.NewInt(1000000),
+			}, nil
+		},
+		func() error {
+			t.Fatal("submission should not be sent")
+			return nil
+		},
+	)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v\n",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+}
@@ -73,6 +73,8 @@ type channel struct {
 	unmarshalersByType map[string]func() net.TaggedUnmarshaler
 
 	retransmissionTicker *retransmission.Ticker
+
+	reputation *reputationManager
 }
 
 type messageHandler struct {
@@ -292,6 +294,7 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 func (c *channel) processPubsubMessage(pubsubMessage *pubsub.Message) error {
 	var messageProto pb.BroadcastNetworkMessage
 	if err := proto.Unmarshal(pubsubMessage.Data, &messageProto); err != nil {
+		c.reputation.recordFault(pubsubMessage.GetFrom(), malformedMessageFault)
 		return err
 	}
 
@@ -306,16 +309,22 @@ func (c *channel) processContainerMessage(
 	// from our map of unmarshallers.
 	unmarshaled, err := c.getUnmarshalingContainerByType(string(message.Type))
 	if err != nil {
+		c.reputation.recordUnknownMessageType(
+			proposedSender,
+			string(message.Type),
+		)
 		return err
 	}
 
 	if err := unmarshaled.Unmarshal(message.GetPayload()); err != nil {
+		c.reputation.recordFault(proposedSender, malformedPayloadFault)
 		return err
 	}
 
 	// Construct an identifier from the sender.
 	senderIdentifier := &identity{}
 	if err := senderIdentifier.Unmarshal(message.Sender); err != nil {
+		c.reputation.recordFault(proposedSender, malformedSenderFault)
 		return err
 	}
 
@@ -323,6 +332,7 @@ func (c *channel) processContainerMessage(
 	//     Test that the proposed sender (outer layer) matches the
 	//     sender identifier we grab from the message (inner layer).
 	if proposedSender != senderIdentifier.id {
+		c.reputation.recordFault(proposedSender, senderMismatchFault)
 		return fmt.Errorf(
 			"outer layer sender [%v] does not match inner layer sender [%v]",
 			proposedSender,
@@ -332,6 +342,7 @@ func (c *channel) processContainerMessage(
 
 	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(senderIdentifier.pubKey)
 	if err != nil {
+		c.reputation.recordFault(proposedSender, invalidSenderKeyFault)
 		return fmt.Errorf(
 			"sender [%v] with key [%v] is not of correct type",
 			senderIdentifier.id,
@@ -399,20 +410,42 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 		)
 	}
 
-	return c.validator.RegisterTopicValidator(c.name, createTopicValidator(filter))
+	return c.validator.RegisterTopicValidator(
+		c.name,
+		createTopicValidator(filter, c.reputation),
+	)
 }
 
-func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
-	return func(_ context.Context, _ peer.ID, message *pubsub.Message) bool {
+// createTopicValidator creates a pubsub validator accepting messages whose
+// authors pass the given filter. Authors of rejected messages are charged
+// with a fault only if the message was received directly from them. Rejected
+// messages are not marked as seen by the pubsub router so the same message
+// forwarded by other peers would otherwise be charged multiple times.
+func createTopicValidator(
+	filter net.BroadcastChannelFilter,
+	reputation *reputationManager,
+) pubsub.Validator {
+	return func(_ context.Context, from peer.ID, message *pubsub.Message) bool {
 		authorPublicKey, err := extractPublicKey(message.GetFrom())
 		if err != nil {
 			logger.Warnf(
 				"could not retrieve message author public key: [%v]",
 				err,
 			)
+			if from == message.GetFrom() {
+				reputation.recordFault(from, invalidSenderKeyFault)
+			}
 			return false
 		}
-		return filter(authorPublicKey)
+
+		if !filter(authorPublicKey) {
+			if from == message.GetFrom() {
+				reputation.recordFault(from, filterRejectionFault)
+			}
+			return false
+		}
+
+		return true
 	}
 }
 
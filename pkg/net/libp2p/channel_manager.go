@@ -36,6 +36,8 @@ type channelManager struct {
 
 	topicsMutex sync.Mutex
 	topics      map[string]*pubsub.Topic
+
+	reputation *reputationManager
 }
 
 func newChannelManager(
@@ -43,6 +45,7 @@ func newChannelManager(
 	identity *identity,
 	p2phost host.Host,
 	retransmissionTicker *retransmission.Ticker,
+	reputation *reputationManager,
 ) (*channelManager, error) {
 	floodsub, err := pubsub.NewFloodSub(
 		ctx,
@@ -51,6 +54,7 @@ func newChannelManager(
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
 		pubsub.WithPeerOutboundQueueSize(libp2pPeerOutboundQueueSize),
 		pubsub.WithValidateQueueSize(libp2pValidationQueueSize),
+		pubsub.WithBlacklist(reputation),
 	)
 	if err != nil {
 		return nil, err
@@ -64,6 +68,7 @@ func newChannelManager(
 		retransmissionTicker: retransmissionTicker,
 		forwarders:           make(map[string]pubsub.RelayCancelFunc),
 		topics:               make(map[string]*pubsub.Topic),
+		reputation:           reputation,
 	}, nil
 }
 
@@ -129,6 +134,7 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersByType:   make(map[string]func() net.TaggedUnmarshaler),
 		retransmissionTicker: cm.retransmissionTicker,
+		reputation:           cm.reputation,
 	}
 
 	go channel.handleMessages(cm.ctx)
@@ -140,10 +140,16 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 
 type connectionManager struct {
 	host.Host
+
+	reputation *reputationManager
 }
 
-func newConnectionManager(ctx context.Context, host host.Host) *connectionManager {
-	connectionManager := &connectionManager{host}
+func newConnectionManager(
+	ctx context.Context,
+	host host.Host,
+	reputation *reputationManager,
+) *connectionManager {
+	connectionManager := &connectionManager{host, reputation}
 
 	go connectionManager.monitorConnectedPeers(ctx)
 
@@ -230,6 +236,10 @@ func (cm *connectionManager) IsConnected(address string) bool {
 	return cm.Network().Connectedness(peerInfos[0].ID) == libp2pnet.Connected
 }
 
+func (cm *connectionManager) PeerReputations() []net.PeerReputation {
+	return cm.reputation.reputations()
+}
+
 func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 	ticker := time.NewTicker(ConnectedPeersCheckTick)
 	defer ticker.Stop()
@@ -311,12 +321,15 @@ func Connect(
 		return nil, err
 	}
 
+	reputation := newReputationManager(identity.id)
+
 	host, err := discoverAndListen(
 		ctx,
 		identity,
 		config.Port,
 		config.AnnouncedAddresses,
 		firewall,
+		reputation,
 	)
 	if err != nil {
 		return nil, err
@@ -324,7 +337,25 @@ func Connect(
 
 	host.Network().Notify(buildNotifiee())
 
-	broadcastChannelManager, err := newChannelManager(ctx, identity, host, ticker)
+	reputation.setBanHandler(func(peerID peer.ID) {
+		if err := host.Network().ClosePeer(peerID); err != nil {
+			logger.Errorf(
+				"failed to disconnect banned peer [%v]: [%v]",
+				peerID,
+				err,
+			)
+		}
+	})
+
+	go reputation.monitorReputations(ctx)
+
+	broadcastChannelManager, err := newChannelManager(
+		ctx,
+		identity,
+		host,
+		ticker,
+		reputation,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -359,7 +390,11 @@ func Connect(
 		return nil, fmt.Errorf("bootstrap failed: [%v]", err)
 	}
 
-	provider.connectionManager = newConnectionManager(ctx, provider.host)
+	provider.connectionManager = newConnectionManager(
+		ctx,
+		provider.host,
+		reputation,
+	)
 
 	// Instantiates and starts the connection management background process.
 	watchtower.NewGuard(
@@ -379,6 +414,7 @@ func discoverAndListen(
 	port int,
 	announcedAddresses []string,
 	firewall net.Firewall,
+	reputation *reputationManager,
 ) (host.Host, error) {
 	var err error
 
@@ -417,6 +453,7 @@ func discoverAndListen(
 		libp2p.Identity(identity.privKey),
 		libp2p.Security(handshakeID, transport),
 		libp2p.ConnectionManager(connectionManager),
+		libp2p.ConnectionGater(reputation),
 	}
 
 	if addresses := parseMultiaddresses(announcedAddresses); len(addresses) > 0 {
@@ -0,0 +1,416 @@
+package libp2p
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Compile time assertions of custom types
+var _ pubsub.Blacklist = (*reputationManager)(nil)
+var _ connmgr.ConnectionGater = (*reputationManager)(nil)
+
+// faultType determines the type of fault committed by a peer in the network
+// protocol.
+type faultType string
+
+const (
+	// malformedMessageFault is committed by a peer publishing a message that
+	// is not a valid broadcast network message.
+	malformedMessageFault faultType = "malformed_message"
+	// unknownMessageTypeFault is committed by a peer publishing a message of
+	// a type no unmarshaler is registered for. This can happen for honest
+	// peers running a different client version so the fault has a low weight
+	// and is recorded only once per message type within
+	// unknownMessageTypeWindow.
+	unknownMessageTypeFault faultType = "unknown_message_type"
+	// malformedPayloadFault is committed by a peer publishing a message whose
+	// payload cannot be unmarshaled.
+	malformedPayloadFault faultType = "malformed_payload"
+	// malformedSenderFault is committed by a peer publishing a message whose
+	// sender identity cannot be unmarshaled.
+	malformedSenderFault faultType = "malformed_sender"
+	// senderMismatchFault is committed by a peer publishing a message whose
+	// sender identity does not match the message author.
+	senderMismatchFault faultType = "sender_mismatch"
+	// invalidSenderKeyFault is committed by a peer publishing a message whose
+	// sender key is not of the operator key type.
+	invalidSenderKeyFault faultType = "invalid_sender_key"
+	// filterRejectionFault is committed by a peer publishing a message
+	// rejected by the channel filter, e.g. a peer that is not a group member.
+	filterRejectionFault faultType = "filter_rejection"
+)
+
+// faultWeights determines how much each fault type increases the reputation
+// score of the peer. A peer is banned once its score reaches
+// reputationBanThreshold.
+var faultWeights = map[faultType]float64{
+	malformedMessageFault:   20,
+	unknownMessageTypeFault: 1,
+	malformedPayloadFault:   20,
+	malformedSenderFault:    20,
+	senderMismatchFault:     50,
+	invalidSenderKeyFault:   50,
+	filterRejectionFault:    5,
+}
+
+const (
+	// reputationScoreHalfLife is the time after which the reputation score
+	// of a peer is reduced by half.
+	reputationScoreHalfLife = 10 * time.Minute
+	// reputationBanThreshold is the reputation score at which the peer gets
+	// banned.
+	reputationBanThreshold = 100.0
+	// reputationBanDuration is the duration of the ban.
+	reputationBanDuration = 1 * time.Hour
+	// reputationPruneThreshold is the reputation score below which the peer
+	// is no longer tracked, unless it is banned.
+	reputationPruneThreshold = 1.0
+	// reputationCheckTick is the amount of time between periodic prunings of
+	// the tracked peers.
+	reputationCheckTick = 10 * time.Minute
+	// unknownMessageTypeWindow is the period within which messages of the
+	// same unknown type published by the same peer count as a single fault.
+	unknownMessageTypeWindow = 1 * time.Hour
+)
+
+// peerRecord holds the reputation of a single peer.
+type peerRecord struct {
+	// score is the reputation score as of scoredAt.
+	score    float64
+	scoredAt time.Time
+
+	faults      map[faultType]uint
+	lastFaultAt time.Time
+	bannedUntil time.Time
+}
+
+// decayedScore returns the reputation score decayed as of the given time.
+func (pr *peerRecord) decayedScore(now time.Time) float64 {
+	elapsed := now.Sub(pr.scoredAt)
+	if elapsed <= 0 {
+		return pr.score
+	}
+
+	return pr.score * math.Pow(
+		0.5,
+		float64(elapsed)/float64(reputationScoreHalfLife),
+	)
+}
+
+func (pr *peerRecord) isBanned(now time.Time) bool {
+	return now.Before(pr.bannedUntil)
+}
+
+// reputationManager tracks faults committed by peers in the network protocol
+// and temporarily bans peers whose reputation score reaches the ban threshold.
+//
+// The reputation is fed to the pubsub router as its blacklist so messages
+// authored or forwarded by banned peers are dropped. Scoring parameters
+// of the pubsub router itself are not used as they are supported only by
+// gossipsub while the client uses floodsub. The reputation also serves as
+// the connection gater of the host so banned peers cannot reconnect.
+type reputationManager struct {
+	localPeerID peer.ID
+
+	mutex sync.Mutex
+	peers map[peer.ID]*peerRecord
+	// unknownMessageTypes holds, for each peer, the time at which the peer
+	// was last penalized for publishing a message of the given unknown type.
+	unknownMessageTypes map[peer.ID]map[string]time.Time
+	// banHandler is called once the peer gets banned for its faults.
+	banHandler func(peerID peer.ID)
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+func newReputationManager(localPeerID peer.ID) *reputationManager {
+	return &reputationManager{
+		localPeerID:         localPeerID,
+		peers:               make(map[peer.ID]*peerRecord),
+		unknownMessageTypes: make(map[peer.ID]map[string]time.Time),
+		now:                 time.Now,
+	}
+}
+
+// setBanHandler sets the function called once a peer gets banned for its
+// faults, e.g. to disconnect the peer.
+func (rm *reputationManager) setBanHandler(handler func(peerID peer.ID)) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	rm.banHandler = handler
+}
+
+// recordFault records the fault committed by the given peer and bans the
+// peer if its reputation score reaches the ban threshold. Faults of the
+// local peer are ignored.
+func (rm *reputationManager) recordFault(peerID peer.ID, fault faultType) {
+	if len(peerID) == 0 || peerID == rm.localPeerID {
+		return
+	}
+
+	rm.mutex.Lock()
+
+	now := rm.now()
+
+	record, ok := rm.peers[peerID]
+	if !ok {
+		record = &peerRecord{faults: make(map[faultType]uint)}
+		rm.peers[peerID] = record
+	}
+
+	record.score = record.decayedScore(now) + faultWeights[fault]
+	record.scoredAt = now
+	record.faults[fault]++
+	record.lastFaultAt = now
+
+	banned := false
+	if record.score >= reputationBanThreshold && !record.isBanned(now) {
+		record.bannedUntil = now.Add(reputationBanDuration)
+		banned = true
+	}
+
+	score := record.score
+	bannedUntil := record.bannedUntil
+	banHandler := rm.banHandler
+
+	rm.mutex.Unlock()
+
+	logger.Debugf(
+		"peer [%v] committed fault [%v]; reputation score: [%.2f]",
+		peerID,
+		fault,
+		score,
+	)
+
+	if banned {
+		logger.Warnf(
+			"banning peer [%v] until [%v]; reputation score [%.2f] "+
+				"reached the ban threshold [%v]",
+			peerID,
+			bannedUntil,
+			score,
+			reputationBanThreshold,
+		)
+
+		if banHandler != nil {
+			banHandler(peerID)
+		}
+	}
+}
+
+// recordUnknownMessageType records the unknown message type fault committed
+// by the given peer publishing a message of the given type. The fault is
+// recorded only if the peer was not penalized for the same message type
+// within unknownMessageTypeWindow so honest peers running a different client
+// version are not banned just because they keep publishing messages unknown
+// to this client.
+func (rm *reputationManager) recordUnknownMessageType(
+	peerID peer.ID,
+	messageType string,
+) {
+	if len(peerID) == 0 || peerID == rm.localPeerID {
+		return
+	}
+
+	rm.mutex.Lock()
+
+	now := rm.now()
+
+	messageTypes, ok := rm.unknownMessageTypes[peerID]
+	if !ok {
+		messageTypes = make(map[string]time.Time)
+		rm.unknownMessageTypes[peerID] = messageTypes
+	}
+
+	penalizedAt, ok := messageTypes[messageType]
+	if ok && now.Sub(penalizedAt) < unknownMessageTypeWindow {
+		rm.mutex.Unlock()
+		return
+	}
+
+	messageTypes[messageType] = now
+
+	rm.mutex.Unlock()
+
+	rm.recordFault(peerID, unknownMessageTypeFault)
+}
+
+// isBanned returns true if the given peer is currently banned.
+func (rm *reputationManager) isBanned(peerID peer.ID) bool {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	record, ok := rm.peers[peerID]
+	if !ok {
+		return false
+	}
+
+	return record.isBanned(rm.now())
+}
+
+// Add bans the given peer for the ban duration. It is called by the pubsub
+// router when a peer is blacklisted explicitly.
+func (rm *reputationManager) Add(peerID peer.ID) bool {
+	if peerID == rm.localPeerID {
+		return false
+	}
+
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	now := rm.now()
+
+	record, ok := rm.peers[peerID]
+	if !ok {
+		record = &peerRecord{faults: make(map[faultType]uint), scoredAt: now}
+		rm.peers[peerID] = record
+	}
+
+	record.bannedUntil = now.Add(reputationBanDuration)
+
+	return true
+}
+
+// Contains returns true if the given peer is currently banned. It is called
+// by the pubsub router for each incoming message and peer.
+func (rm *reputationManager) Contains(peerID peer.ID) bool {
+	return rm.isBanned(peerID)
+}
+
+// InterceptPeerDial does not allow to dial banned peers.
+func (rm *reputationManager) InterceptPeerDial(peerID peer.ID) bool {
+	return !rm.isBanned(peerID)
+}
+
+// InterceptAddrDial does not allow to dial banned peers.
+func (rm *reputationManager) InterceptAddrDial(
+	peerID peer.ID,
+	_ ma.Multiaddr,
+) bool {
+	return !rm.isBanned(peerID)
+}
+
+// InterceptAccept allows all inbound connections as the remote peer is not
+// known yet.
+func (rm *reputationManager) InterceptAccept(_ libp2pnet.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured does not allow connections with banned peers.
+func (rm *reputationManager) InterceptSecured(
+	_ libp2pnet.Direction,
+	peerID peer.ID,
+	_ libp2pnet.ConnMultiaddrs,
+) bool {
+	return !rm.isBanned(peerID)
+}
+
+// InterceptUpgraded allows all upgraded connections as banned peers were
+// already rejected once the connection was secured.
+func (rm *reputationManager) InterceptUpgraded(
+	_ libp2pnet.Conn,
+) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// reputations returns reputations of all tracked peers, sorted from the
+// highest reputation score.
+func (rm *reputationManager) reputations() []net.PeerReputation {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	now := rm.now()
+
+	reputations := make([]net.PeerReputation, 0, len(rm.peers))
+	for peerID, record := range rm.peers {
+		faults := make(map[string]uint, len(record.faults))
+		for fault, count := range record.faults {
+			faults[string(fault)] = count
+		}
+
+		reputations = append(reputations, net.PeerReputation{
+			NetworkID:   peerID.String(),
+			Score:       record.decayedScore(now),
+			Faults:      faults,
+			LastFaultAt: record.lastFaultAt,
+			BannedUntil: record.bannedUntil,
+		})
+	}
+
+	sort.SliceStable(reputations, func(i, j int) bool {
+		return reputations[i].Score > reputations[j].Score
+	})
+
+	return reputations
+}
+
+// prune stops tracking peers that are not banned and whose reputation score
+// decayed below the prune threshold. It also forgets unknown message types
+// peers were penalized for before the current unknownMessageTypeWindow.
+func (rm *reputationManager) prune() {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	now := rm.now()
+
+	for peerID, record := range rm.peers {
+		if record.isBanned(now) {
+			continue
+		}
+
+		if record.decayedScore(now) < reputationPruneThreshold {
+			delete(rm.peers, peerID)
+		}
+	}
+
+	for peerID, messageTypes := range rm.unknownMessageTypes {
+		for messageType, penalizedAt := range messageTypes {
+			if now.Sub(penalizedAt) >= unknownMessageTypeWindow {
+				delete(messageTypes, messageType)
+			}
+		}
+
+		if len(messageTypes) == 0 {
+			delete(rm.unknownMessageTypes, peerID)
+		}
+	}
+}
+
+func (rm *reputationManager) monitorReputations(ctx context.Context) {
+	ticker := time.NewTicker(reputationCheckTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			rm.prune()
+
+			bannedPeers := 0
+			for _, reputation := range rm.reputations() {
+				if reputation.BannedUntil.After(rm.now()) {
+					bannedPeers++
+				}
+			}
+
+			if bannedPeers > 0 {
+				logger.Infof("number of banned peers: [%v]", bannedPeers)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package libp2p
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestReputationManager_RecordFault(t *testing.T) {
+	reputation, clock := newTestReputationManager("local")
+
+	bannedPeers := make([]peer.ID, 0)
+	reputation.setBanHandler(func(peerID peer.ID) {
+		bannedPeers = append(bannedPeers, peerID)
+	})
+
+	reputation.recordFault("peer-1", senderMismatchFault)
+	testutils.AssertBoolsEqual(
+		t,
+		"peer banned after the first fault",
+		false,
+		reputation.Contains("peer-1"),
+	)
+
+	reputation.recordFault("peer-1", senderMismatchFault)
+	testutils.AssertBoolsEqual(
+		t,
+		"peer banned after the second fault",
+		true,
+		reputation.Contains("peer-1"),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"peer dial allowed",
+		false,
+		reputation.InterceptPeerDial("peer-1"),
+	)
+
+	// Faults committed while banned must not extend the ban or call the
+	// ban handler again.
+	reputation.recordFault("peer-1", senderMismatchFault)
+
+	testutils.AssertIntsEqual(t, "banned peers", 1, len(bannedPeers))
+	if bannedPeers[0] != "peer-1" {
+		t.Errorf("unexpected banned peer: [%v]", bannedPeers[0])
+	}
+
+	// Faults of other peers and the local peer do not ban them.
+	reputation.recordFault("peer-2", unknownMessageTypeFault)
+	reputation.recordFault("local", senderMismatchFault)
+	reputation.recordFault("local", senderMismatchFault)
+
+	testutils.AssertBoolsEqual(
+		t,
+		"other peer banned",
+		false,
+		reputation.Contains("peer-2"),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"local peer banned",
+		false,
+		reputation.Contains("local"),
+	)
+
+	reputations := reputation.reputations()
+	testutils.AssertIntsEqual(t, "tracked peers", 2, len(reputations))
+	testutils.AssertStringsEqual(
+		t,
+		"worst peer",
+		peer.ID("peer-1").String(),
+		reputations[0].NetworkID,
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"sender mismatch faults",
+		3,
+		int(reputations[0].Faults[string(senderMismatchFault)]),
+	)
+	assertScore(t, 150, reputations[0].Score)
+	assertScore(t, 1, reputations[1].Score)
+
+	// The ban expires after the ban duration.
+	*clock = clock.Add(reputationBanDuration)
+
+	testutils.AssertBoolsEqual(
+		t,
+		"peer banned after the ban duration",
+		false,
+		reputation.Contains("peer-1"),
+	)
+}
+
+func TestReputationManager_ScoreDecay(t *testing.T) {
+	reputation, clock := newTestReputationManager("local")
+
+	reputation.recordFault("peer-1", malformedPayloadFault)
+	reputation.recordFault("peer-1", malformedPayloadFault)
+
+	*clock = clock.Add(reputationScoreHalfLife)
+
+	assertScore(t, 20, reputation.reputations()[0].Score)
+
+	// The fault is added to the decayed score.
+	reputation.recordFault("peer-1", malformedPayloadFault)
+
+	assertScore(t, 40, reputation.reputations()[0].Score)
+
+	*clock = clock.Add(2 * reputationScoreHalfLife)
+
+	assertScore(t, 10, reputation.reputations()[0].Score)
+}
+
+func TestReputationManager_Prune(t *testing.T) {
+	reputation, clock := newTestReputationManager("local")
+
+	reputation.recordFault("peer-1", filterRejectionFault)
+	for i := 0; i < 2; i++ {
+		reputation.recordFault("peer-2", invalidSenderKeyFault)
+	}
+
+	// After 3 half-lives, the first peer's score decays below the prune
+	// threshold. The second peer stays banned.
+	*clock = clock.Add(3 * reputationScoreHalfLife)
+
+	reputation.prune()
+
+	reputations := reputation.reputations()
+	testutils.AssertIntsEqual(t, "tracked peers", 1, len(reputations))
+	testutils.AssertStringsEqual(
+		t,
+		"tracked peer",
+		peer.ID("peer-2").String(),
+		reputations[0].NetworkID,
+	)
+
+	// Once the ban expires, the second peer is pruned as well.
+	*clock = clock.Add(reputationBanDuration)
+
+	reputation.prune()
+
+	testutils.AssertIntsEqual(
+		t,
+		"tracked peers",
+		0,
+		len(reputation.reputations()),
+	)
+}
+
+func TestReputationManager_RecordUnknownMessageType(t *testing.T) {
+	reputation, clock := newTestReputationManager("local")
+
+	assertUnknownMessageTypeFaults := func(description string, expected int) {
+		reputations := reputation.reputations()
+		testutils.AssertIntsEqual(t, "tracked peers", 1, len(reputations))
+		testutils.AssertIntsEqual(
+			t,
+			description,
+			expected,
+			int(reputations[0].Faults[string(unknownMessageTypeFault)]),
+		)
+	}
+
+	for i := 0; i < 10; i++ {
+		reputation.recordUnknownMessageType("peer-1", "type-1")
+	}
+	reputation.recordUnknownMessageType("local", "type-1")
+
+	assertUnknownMessageTypeFaults("faults for a repeated type", 1)
+
+	reputation.recordUnknownMessageType("peer-1", "type-2")
+
+	assertUnknownMessageTypeFaults("faults for another type", 2)
+
+	// Within the window, the same type is still not penalized again.
+	*clock = clock.Add(unknownMessageTypeWindow - time.Second)
+
+	reputation.recordUnknownMessageType("peer-1", "type-1")
+
+	assertUnknownMessageTypeFaults("faults within the window", 2)
+
+	// Once the window passes, the type is penalized again.
+	*clock = clock.Add(time.Second)
+
+	reputation.recordUnknownMessageType("peer-1", "type-1")
+
+	assertUnknownMessageTypeFaults("faults after the window", 3)
+
+	// Pruning forgets types penalized before the current window.
+	*clock = clock.Add(unknownMessageTypeWindow)
+
+	reputation.prune()
+
+	testutils.AssertIntsEqual(
+		t,
+		"peers with penalized unknown message types",
+		0,
+		len(reputation.unknownMessageTypes),
+	)
+}
+
+func TestReputationManager_Add(t *testing.T) {
+	reputation, _ := newTestReputationManager("local")
+
+	testutils.AssertBoolsEqual(
+		t,
+		"peer added",
+		true,
+		reputation.Add("peer-1"),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"local peer added",
+		false,
+		reputation.Add("local"),
+	)
+
+	testutils.AssertBoolsEqual(
+		t,
+		"peer banned",
+		true,
+		reputation.Contains("peer-1"),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"local peer banned",
+		false,
+		reputation.Contains("local"),
+	)
+}
+
+func TestProcessContainerMessage_RecordsFaults(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	senderID := testPeerID(t, operatorPublicKey)
+
+	reputation, _ := newTestReputationManager("local")
+
+	channel := &channel{
+		unmarshalersByType: make(map[string]func() net.TaggedUnmarshaler),
+		reputation:         reputation,
+	}
+
+	// Repeated messages of the same unknown type count as a single fault.
+	for i := 0; i < 3; i++ {
+		err = channel.processContainerMessage(
+			senderID,
+			&pb.BroadcastNetworkMessage{Type: []byte("unknown")},
+		)
+		if err == nil {
+			t.Fatal("expected processing of unknown message type to fail")
+		}
+	}
+
+	err = channel.processPubsubMessage(
+		&pubsub.Message{
+			Message: &pubsubpb.Message{
+				From: []byte(senderID),
+				Data: []byte{0xff, 0xff, 0xff},
+			},
+		},
+	)
+	if err == nil {
+		t.Fatal("expected processing of malformed message to fail")
+	}
+
+	reputations := reputation.reputations()
+	testutils.AssertIntsEqual(t, "tracked peers", 1, len(reputations))
+	testutils.AssertIntsEqual(
+		t,
+		"unknown message type faults",
+		1,
+		int(reputations[0].Faults[string(unknownMessageTypeFault)]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"malformed message faults",
+		1,
+		int(reputations[0].Faults[string(malformedMessageFault)]),
+	)
+}
+
+func TestCreateTopicValidator_RecordsFaults(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorID := testPeerID(t, operatorPublicKey)
+
+	reputation, _ := newTestReputationManager("local")
+
+	validator := createTopicValidator(
+		func(publicKey *operator.PublicKey) bool { return false },
+		reputation,
+	)
+
+	message := &pubsub.Message{
+		Message: &pubsubpb.Message{From: []byte(authorID)},
+	}
+
+	// The message forwarded by another peer must not be charged.
+	if validator(context.Background(), "forwarder", message) {
+		t.Fatal("expected the message to be rejected")
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"tracked peers",
+		0,
+		len(reputation.reputations()),
+	)
+
+	if validator(context.Background(), authorID, message) {
+		t.Fatal("expected the message to be rejected")
+	}
+
+	reputations := reputation.reputations()
+	testutils.AssertIntsEqual(t, "tracked peers", 1, len(reputations))
+	testutils.AssertIntsEqual(
+		t,
+		"filter rejection faults",
+		1,
+		int(reputations[0].Faults[string(filterRejectionFault)]),
+	)
+}
+
+// newTestReputationManager creates a reputation manager whose clock can be
+// moved forward with the returned pointer.
+func newTestReputationManager(
+	localPeerID peer.ID,
+) (*reputationManager, *time.Time) {
+	clock := time.Unix(1700000000, 0)
+
+	reputation := newReputationManager(localPeerID)
+	reputation.now = func() time.Time {
+		return clock
+	}
+
+	return reputation, &clock
+}
+
+func testPeerID(t *testing.T, operatorPublicKey *operator.PublicKey) peer.ID {
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return peerID
+}
+
+func assertScore(t *testing.T, expected float64, actual float64) {
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Errorf(
+			"unexpected score\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
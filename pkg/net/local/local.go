@@ -135,3 +135,7 @@ func (lcm *localConnectionManager) AddrStrings() []string {
 func (lcm *localConnectionManager) IsConnected(address string) bool {
 	panic("not implemented")
 }
+
+func (lcm *localConnectionManager) PeerReputations() []net.PeerReputation {
+	return make([]net.PeerReputation, 0)
+}
@@ -2,6 +2,7 @@ package net
 
 import (
 	"context"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/internal/pb"
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -88,6 +89,29 @@ type ConnectionManager interface {
 	AddrStrings() []string
 
 	IsConnected(address string) bool
+
+	// PeerReputations returns reputations of peers that recently committed
+	// faults in the network protocol.
+	PeerReputations() []PeerReputation
+}
+
+// PeerReputation describes the reputation of a network peer. The reputation
+// score grows with each fault committed by the peer, e.g. sending a malformed
+// message, and decays over time. Peers whose score reaches the ban threshold
+// are temporarily banned.
+type PeerReputation struct {
+	// NetworkID is the network identifier of the peer.
+	NetworkID string
+	// Score is the current, decayed reputation score of the peer. The higher
+	// the score, the worse the reputation.
+	Score float64
+	// Faults holds the number of faults committed by the peer, by fault type.
+	Faults map[string]uint
+	// LastFaultAt is the time of the last fault committed by the peer.
+	LastFaultAt time.Time
+	// BannedUntil is the time the peer is banned until. Zero value means
+	// the peer has never been banned.
+	BannedUntil time.Time
 }
 
 // TaggedUnmarshaler is an interface that includes the proto.Unmarshaler
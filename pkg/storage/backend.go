@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// FileBackend denotes the backend storing data in separate files in the
+	// storage directory. This is the default backend.
+	FileBackend = "file"
+	// BboltBackend denotes the backend storing data in a single bbolt
+	// database file in the storage directory.
+	BboltBackend = "bbolt"
+)
+
+const (
+	// currentArea holds the current data of a key store handle.
+	currentArea = "current"
+	// archiveArea holds the archived data of a key store handle.
+	archiveArea = "archive"
+	// snapshotArea holds the snapshots taken by a key store handle.
+	snapshotArea = "snapshot"
+	// quarantineArea holds the data of a key store handle that were found
+	// invalid. The quarantined data are never returned when reading the
+	// current data.
+	quarantineArea = "quarantine"
+)
+
+// keyStoreAreas are all areas of a key store handle.
+var keyStoreAreas = []string{
+	currentArea,
+	archiveArea,
+	snapshotArea,
+	quarantineArea,
+}
+
+// maxNameLength is the maximum length of directory and data names. It is the
+// same as the limit of the file backend so data stored by any backend can be
+// migrated to any other backend.
+const maxNameLength = 128
+
+// Backend is the underlying backend of the client's persistent storage.
+// Backends store data passed to the handles as-is; the data are already
+// encrypted by the storage.
+type Backend interface {
+	// KeyStoreHandle returns the handle of the key store data stored under
+	// the given directory.
+	KeyStoreHandle(dir string) (KeyStoreHandle, error)
+	// WorkHandle returns the handle of the work data stored under the given
+	// directory.
+	WorkHandle(dir string) (persistence.BasicHandle, error)
+	// Walk calls the given function for each entry stored by the backend,
+	// including the archived, snapshot and quarantined key store data.
+	// Walking stops on the first error returned by the function.
+	Walk(walkFn func(entry *Entry) error) error
+	// Put stores the given entry as-is. It is meant to be used only to
+	// migrate data between backends.
+	Put(entry *Entry) error
+	// Close releases resources held by the backend.
+	Close() error
+}
+
+// KeyStoreHandle is a protected persistence handle that, additionally,
+// allows to quarantine invalid data.
+type KeyStoreHandle interface {
+	persistence.ProtectedHandle
+
+	// Quarantine moves the data with the given name from the given directory
+	// of the current data to the same directory of the quarantine. The
+	// quarantined data name is suffixed with a timestamp so the previously
+	// quarantined data are never overwritten.
+	Quarantine(directory string, name string) error
+}
+
+// Entry is a single piece of data stored by a backend.
+type Entry struct {
+	// Namespace is either the key store or the work namespace.
+	Namespace string
+	// Handle is the directory of the handle the data were stored with.
+	Handle string
+	// Area is the area of the key store handle holding the data. It is
+	// empty for work data.
+	Area string
+	// Directory is the directory the data were stored in.
+	Directory string
+	// Name is the name the data were stored under.
+	Name string
+	// Data is the stored data.
+	Data []byte
+}
+
+// String returns the path of the entry.
+func (e *Entry) String() string {
+	return path.Join(e.Namespace, e.Handle, e.Area, e.Directory, e.Name)
+}
+
+// validate checks whether the entry can be stored by a backend.
+func (e *Entry) validate() error {
+	switch e.Namespace {
+	case keyStoreDirName:
+		if !isKeyStoreArea(e.Area) {
+			return fmt.Errorf("unknown key store area [%s]", e.Area)
+		}
+	case workDirName:
+		if len(e.Area) != 0 {
+			return fmt.Errorf("work data must not have an area")
+		}
+	default:
+		return fmt.Errorf("unknown namespace [%s]", e.Namespace)
+	}
+
+	for _, name := range []string{e.Handle, e.Directory, e.Name} {
+		if _, err := normalizeName(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewBackend creates the backend determined by the given config. The
+// backend stores data in the storage directory.
+func NewBackend(config Config) (Backend, error) {
+	rootDir := filepath.Clean(config.Dir)
+
+	switch config.Backend {
+	case "", FileBackend:
+		return newFileBackend(rootDir)
+	case BboltBackend:
+		return newBboltBackend(rootDir)
+	default:
+		return nil, fmt.Errorf(
+			"unsupported storage backend [%s]",
+			config.Backend,
+		)
+	}
+}
+
+// normalizeName normalizes the given directory or data name the same way
+// the file system does and makes sure it is a single, non-empty path
+// element not exceeding the maximum name length.
+func normalizeName(name string) (string, error) {
+	normalized := strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	if len(normalized) == 0 || strings.Contains(normalized, "/") {
+		return "", fmt.Errorf("invalid name [%s]", name)
+	}
+
+	if len(normalized) > maxNameLength {
+		return "", fmt.Errorf(
+			"the maximum name length of [%v] exceeded for [%v]",
+			maxNameLength,
+			name,
+		)
+	}
+
+	return normalized, nil
+}
+
+func isKeyStoreArea(area string) bool {
+	for _, keyStoreArea := range keyStoreAreas {
+		if area == keyStoreArea {
+			return true
+		}
+	}
+
+	return false
+}
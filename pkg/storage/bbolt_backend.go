@@ -0,0 +1,561 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// bboltFileName is the name of the bbolt database file created in the
+	// storage directory.
+	bboltFileName = "storage.db"
+	// bboltOpenTimeout is the maximum time to wait for the lock of the bbolt
+	// database file. The lock is held by the process that opened the
+	// database so only one process can use the database at a time.
+	bboltOpenTimeout = 5 * time.Second
+)
+
+// bboltBackend stores data in a single bbolt database file. The data are
+// organized in nested buckets reflecting the directory layout of the file
+// backend: namespace, handle, area (for key store handles only), directory.
+// The data are stored under their names in the directory bucket.
+type bboltBackend struct {
+	db *bolt.DB
+}
+
+// newBboltBackend creates the bbolt backend storing data in the database
+// file placed in the given directory. The database file is created if it
+// does not exist.
+func newBboltBackend(rootDir string) (*bboltBackend, error) {
+	if err := persistence.CheckStoragePermission(rootDir); err != nil {
+		return nil, err
+	}
+
+	dbPath := filepath.Join(rootDir, bboltFileName)
+
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: bboltOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot open bbolt database [%s]; make sure it is not "+
+				"used by another process: [%w]",
+			dbPath,
+			err,
+		)
+	}
+
+	return &bboltBackend{db}, nil
+}
+
+func (bb *bboltBackend) KeyStoreHandle(dir string) (KeyStoreHandle, error) {
+	handle, err := bb.newHandle(keyStoreDirName, dir, currentArea)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bboltKeyStoreHandle{handle}, nil
+}
+
+func (bb *bboltBackend) WorkHandle(dir string) (persistence.BasicHandle, error) {
+	handle, err := bb.newHandle(workDirName, dir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &bboltWorkHandle{handle}, nil
+}
+
+// newHandle creates the bucket of the handle with the given directory, if it
+// does not exist, and returns the handle. The area is the area holding the
+// current data of the handle.
+func (bb *bboltBackend) newHandle(
+	namespace string,
+	dir string,
+	area string,
+) (bboltHandle, error) {
+	handleName, err := normalizeName(dir)
+	if err != nil {
+		return bboltHandle{}, err
+	}
+
+	err = bb.db.Update(func(tx *bolt.Tx) error {
+		_, err := createBucket(tx, namespace, handleName)
+		return err
+	})
+	if err != nil {
+		return bboltHandle{}, fmt.Errorf(
+			"cannot create bucket of handle [%s]: [%w]",
+			dir,
+			err,
+		)
+	}
+
+	return bboltHandle{
+		db:        bb.db,
+		namespace: namespace,
+		handle:    handleName,
+		area:      area,
+	}, nil
+}
+
+func (bb *bboltBackend) Walk(walkFn func(entry *Entry) error) error {
+	entries := make([]*Entry, 0)
+
+	// Entries are collected first so the read transaction is not held
+	// while the walk function is executed.
+	err := bb.db.View(func(tx *bolt.Tx) error {
+		for _, namespace := range []string{keyStoreDirName, workDirName} {
+			namespaceBucket := tx.Bucket([]byte(namespace))
+			if namespaceBucket == nil {
+				continue
+			}
+
+			for _, handle := range listBuckets(namespaceBucket) {
+				handleBucket := namespaceBucket.Bucket([]byte(handle))
+
+				if namespace == workDirName {
+					entries = append(entries, collectEntries(
+						handleBucket,
+						&Entry{Namespace: namespace, Handle: handle},
+					)...)
+					continue
+				}
+
+				for _, area := range keyStoreAreas {
+					areaBucket := handleBucket.Bucket([]byte(area))
+					if areaBucket == nil {
+						continue
+					}
+
+					entries = append(entries, collectEntries(
+						areaBucket,
+						&Entry{Namespace: namespace, Handle: handle, Area: area},
+					)...)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := walkFn(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bb *bboltBackend) Put(entry *Entry) error {
+	if err := entry.validate(); err != nil {
+		return fmt.Errorf("invalid entry [%s]: [%w]", entry, err)
+	}
+
+	handle, _ := normalizeName(entry.Handle)
+	directory, _ := normalizeName(entry.Directory)
+	name, _ := normalizeName(entry.Name)
+
+	return bb.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBucket(
+			tx,
+			bucketPath(entry.Namespace, handle, entry.Area, directory)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(name), entry.Data)
+	})
+}
+
+func (bb *bboltBackend) Close() error {
+	return bb.db.Close()
+}
+
+// bboltHandle implements operations common for all handles of the bbolt
+// backend.
+type bboltHandle struct {
+	db        *bolt.DB
+	namespace string
+	handle    string
+	// area is the area holding the current data of the handle. It is empty
+	// for work handles.
+	area string
+}
+
+func (bh *bboltHandle) Save(data []byte, directory string, name string) error {
+	return bh.put(bh.area, directory, name, data)
+}
+
+func (bh *bboltHandle) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
+	dataChannel := make(chan persistence.DataDescriptor)
+	errorChannel := make(chan error)
+
+	go func() {
+		defer close(dataChannel)
+		defer close(errorChannel)
+
+		var entries []*Entry
+		err := bh.db.View(func(tx *bolt.Tx) error {
+			bucket := getBucket(
+				tx,
+				bucketPath(bh.namespace, bh.handle, bh.area, "")...,
+			)
+			if bucket == nil {
+				return fmt.Errorf("bucket of handle [%s] not found", bh.handle)
+			}
+
+			entries = collectEntries(bucket, &Entry{})
+			return nil
+		})
+		if err != nil {
+			errorChannel <- fmt.Errorf(
+				"could not read data of handle [%s]: [%v]",
+				bh.handle,
+				err,
+			)
+			return
+		}
+
+		for _, entry := range entries {
+			dataChannel <- &bboltDescriptor{entry}
+		}
+	}()
+
+	return dataChannel, errorChannel
+}
+
+// put stores the data under the given name in the given directory of the
+// given area of the handle.
+func (bh *bboltHandle) put(
+	area string,
+	directory string,
+	name string,
+	data []byte,
+) error {
+	directory, err := normalizeName(directory)
+	if err != nil {
+		return err
+	}
+
+	name, err = normalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	return bh.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBucket(
+			tx,
+			bucketPath(bh.namespace, bh.handle, area, directory)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(name), data)
+	})
+}
+
+// bboltKeyStoreHandle is a key store handle of the bbolt backend.
+type bboltKeyStoreHandle struct {
+	bboltHandle
+}
+
+func (bksh *bboltKeyStoreHandle) Archive(directory string) error {
+	directory, err := normalizeName(directory)
+	if err != nil {
+		return err
+	}
+
+	return bksh.db.Update(func(tx *bolt.Tx) error {
+		currentBucket := getBucket(
+			tx,
+			bucketPath(bksh.namespace, bksh.handle, currentArea)...,
+		)
+		if currentBucket == nil || currentBucket.Bucket([]byte(directory)) == nil {
+			return fmt.Errorf("directory [%s] not found", directory)
+		}
+
+		archiveBucket, err := createBucket(
+			tx,
+			bucketPath(bksh.namespace, bksh.handle, archiveArea, directory)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Same as in the file backend, the archived data are appended to
+		// the data archived before.
+		err = currentBucket.Bucket([]byte(directory)).ForEach(
+			func(name, data []byte) error {
+				if data == nil {
+					return nil
+				}
+
+				return archiveBucket.Put(name, data)
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return currentBucket.DeleteBucket([]byte(directory))
+	})
+}
+
+func (bksh *bboltKeyStoreHandle) Snapshot(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	snapshotSuffix := fmt.Sprintf(".%d", time.Now().UnixMilli())
+
+	name, err := normalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	if len(name)+len(snapshotSuffix) > maxNameLength {
+		return fmt.Errorf(
+			"the maximum name length of [%v] exceeded for [%v]",
+			maxNameLength-len(snapshotSuffix),
+			name,
+		)
+	}
+
+	return bksh.putUnique(snapshotArea, directory, name+snapshotSuffix, data)
+}
+
+func (bksh *bboltKeyStoreHandle) Quarantine(directory string, name string) error {
+	directory, err := normalizeName(directory)
+	if err != nil {
+		return err
+	}
+
+	name, err = normalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	return bksh.db.Update(func(tx *bolt.Tx) error {
+		currentBucket := getBucket(
+			tx,
+			bucketPath(bksh.namespace, bksh.handle, currentArea, directory)...,
+		)
+		if currentBucket == nil || currentBucket.Get([]byte(name)) == nil {
+			return fmt.Errorf(
+				"data [%s] not found in directory [%s]",
+				name,
+				directory,
+			)
+		}
+
+		quarantineBucket, err := createBucket(
+			tx,
+			bucketPath(bksh.namespace, bksh.handle, quarantineArea, directory)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		quarantinedName := []byte(
+			fmt.Sprintf("%s.%d", name, time.Now().UnixMilli()),
+		)
+		if quarantineBucket.Get(quarantinedName) != nil {
+			return fmt.Errorf(
+				"quarantined data [%s] already exist",
+				quarantinedName,
+			)
+		}
+
+		err = quarantineBucket.Put(
+			quarantinedName,
+			currentBucket.Get([]byte(name)),
+		)
+		if err != nil {
+			return err
+		}
+
+		return currentBucket.Delete([]byte(name))
+	})
+}
+
+// putUnique stores the data the same way as put but fails if data with the
+// given name already exist.
+func (bksh *bboltKeyStoreHandle) putUnique(
+	area string,
+	directory string,
+	name string,
+	data []byte,
+) error {
+	directory, err := normalizeName(directory)
+	if err != nil {
+		return err
+	}
+
+	return bksh.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBucket(
+			tx,
+			bucketPath(bksh.namespace, bksh.handle, area, directory)...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// very unlikely but better fail than overwrite existing data
+		if bucket.Get([]byte(name)) != nil {
+			return fmt.Errorf(
+				"could not create unique snapshot; " +
+					"snapshot name collision has been detected",
+			)
+		}
+
+		return bucket.Put([]byte(name), data)
+	})
+}
+
+// bboltWorkHandle is a work handle of the bbolt backend.
+type bboltWorkHandle struct {
+	bboltHandle
+}
+
+func (bwh *bboltWorkHandle) Delete(directory string, name string) error {
+	directory, err := normalizeName(directory)
+	if err != nil {
+		return err
+	}
+
+	name, err = normalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	return bwh.db.Update(func(tx *bolt.Tx) error {
+		bucket := getBucket(
+			tx,
+			bucketPath(bwh.namespace, bwh.handle, "", directory)...,
+		)
+		if bucket == nil || bucket.Get([]byte(name)) == nil {
+			return fmt.Errorf(
+				"data [%s] not found in directory [%s]",
+				name,
+				directory,
+			)
+		}
+
+		return bucket.Delete([]byte(name))
+	})
+}
+
+// bboltDescriptor is a data descriptor of data read from the bbolt backend.
+type bboltDescriptor struct {
+	entry *Entry
+}
+
+func (bd *bboltDescriptor) Name() string {
+	return bd.entry.Name
+}
+
+func (bd *bboltDescriptor) Directory() string {
+	return bd.entry.Directory
+}
+
+func (bd *bboltDescriptor) Content() ([]byte, error) {
+	return bd.entry.Data, nil
+}
+
+// bucketPath returns the path of nested buckets leading to the bucket of the
+// given directory. Empty elements are skipped.
+func bucketPath(elements ...string) []string {
+	path := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if len(element) > 0 {
+			path = append(path, element)
+		}
+	}
+
+	return path
+}
+
+// getBucket returns the bucket with the given path or nil if it does not
+// exist.
+func getBucket(tx *bolt.Tx, path ...string) *bolt.Bucket {
+	bucket := tx.Bucket([]byte(path[0]))
+	for _, name := range path[1:] {
+		if bucket == nil {
+			return nil
+		}
+
+		bucket = bucket.Bucket([]byte(name))
+	}
+
+	return bucket
+}
+
+// createBucket returns the bucket with the given path. The bucket and its
+// parents are created if they do not exist.
+func createBucket(tx *bolt.Tx, path ...string) (*bolt.Bucket, error) {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(path[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range path[1:] {
+		bucket, err = bucket.CreateBucketIfNotExists([]byte(name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
+
+// listBuckets returns names of the nested buckets of the given bucket.
+func listBuckets(bucket *bolt.Bucket) []string {
+	names := make([]string, 0)
+
+	_ = bucket.ForEach(func(name, value []byte) error {
+		// Nested buckets have nil values.
+		if value == nil {
+			names = append(names, string(name))
+		}
+		return nil
+	})
+
+	return names
+}
+
+// collectEntries returns entries holding data stored in the directory
+// buckets nested in the given bucket. Entries are based on the given
+// template. The data are copied as they are valid only during the
+// transaction.
+func collectEntries(bucket *bolt.Bucket, template *Entry) []*Entry {
+	entries := make([]*Entry, 0)
+
+	for _, directory := range listBuckets(bucket) {
+		_ = bucket.Bucket([]byte(directory)).ForEach(
+			func(name, data []byte) error {
+				if data == nil {
+					return nil
+				}
+
+				entry := *template
+				entry.Directory = directory
+				entry.Name = string(name)
+				entry.Data = append([]byte{}, data...)
+
+				entries = append(entries, &entry)
+				return nil
+			},
+		)
+	}
+
+	return entries
+}
@@ -0,0 +1,281 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// fileBackend stores data in separate files, under `keystore` and `work`
+// directories of the storage directory. Key store handles keep their data
+// in `current`, `archive`, `snapshot` and `quarantine` subdirectories.
+type fileBackend struct {
+	keyStoreDir string
+	workDir     string
+}
+
+// newFileBackend creates the file backend storing data in the given
+// directory.
+func newFileBackend(rootDir string) (*fileBackend, error) {
+	if err := persistence.EnsureDirectoryExists(
+		rootDir,
+		keyStoreDirName,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory for keystore: [%w]",
+			err,
+		)
+	}
+
+	if err := persistence.EnsureDirectoryExists(
+		rootDir,
+		workDirName,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory for work: [%w]",
+			err,
+		)
+	}
+
+	return &fileBackend{
+		keyStoreDir: filepath.Join(rootDir, keyStoreDirName),
+		workDir:     filepath.Join(rootDir, workDirName),
+	}, nil
+}
+
+func (fb *fileBackend) KeyStoreHandle(dir string) (KeyStoreHandle, error) {
+	path, err := ensureHandleDirectory(fb.keyStoreDir, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	diskHandle, err := persistence.NewProtectedDiskHandle(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+	}
+
+	return &fileKeyStoreHandle{
+		ProtectedHandle: diskHandle,
+		path:            path,
+	}, nil
+}
+
+func (fb *fileBackend) WorkHandle(dir string) (persistence.BasicHandle, error) {
+	path, err := ensureHandleDirectory(fb.workDir, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	diskHandle, err := persistence.NewBasicDiskHandle(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+	}
+
+	return diskHandle, nil
+}
+
+func (fb *fileBackend) Walk(walkFn func(entry *Entry) error) error {
+	keyStoreHandles, err := listDirectories(fb.keyStoreDir)
+	if err != nil {
+		return err
+	}
+
+	for _, handle := range keyStoreHandles {
+		for _, area := range keyStoreAreas {
+			if err := walkDirectories(
+				filepath.Join(fb.keyStoreDir, handle, area),
+				&Entry{Namespace: keyStoreDirName, Handle: handle, Area: area},
+				walkFn,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	workHandles, err := listDirectories(fb.workDir)
+	if err != nil {
+		return err
+	}
+
+	for _, handle := range workHandles {
+		if err := walkDirectories(
+			filepath.Join(fb.workDir, handle),
+			&Entry{Namespace: workDirName, Handle: handle},
+			walkFn,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fb *fileBackend) Put(entry *Entry) error {
+	if err := entry.validate(); err != nil {
+		return fmt.Errorf("invalid entry [%s]: [%w]", entry, err)
+	}
+
+	namespaceDir := fb.keyStoreDir
+	if entry.Namespace == workDirName {
+		namespaceDir = fb.workDir
+	}
+
+	dirPath := filepath.Join(
+		namespaceDir,
+		entry.Handle,
+		entry.Area,
+		entry.Directory,
+	)
+	if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
+		return fmt.Errorf("cannot create directory [%s]: [%w]", dirPath, err)
+	}
+
+	return persistence.Write(filepath.Join(dirPath, entry.Name), entry.Data)
+}
+
+func (fb *fileBackend) Close() error {
+	return nil
+}
+
+// fileKeyStoreHandle is a key store handle of the file backend.
+type fileKeyStoreHandle struct {
+	persistence.ProtectedHandle
+
+	path string
+}
+
+func (fksh *fileKeyStoreHandle) Quarantine(directory string, name string) error {
+	currentDirPath := filepath.Join(fksh.path, currentArea)
+
+	filePath := filepath.Join(currentDirPath, directory, name)
+	if !strings.HasPrefix(filePath, currentDirPath+string(filepath.Separator)) {
+		return fmt.Errorf(
+			"file [%s] in directory [%s] is outside of the key store",
+			name,
+			directory,
+		)
+	}
+
+	quarantineDirPath := filepath.Join(
+		fksh.path,
+		quarantineArea,
+		filepath.Base(filepath.Dir(filePath)),
+	)
+	if err := os.MkdirAll(quarantineDirPath, 0700); err != nil {
+		return fmt.Errorf(
+			"cannot create quarantine directory [%s]: [%w]",
+			quarantineDirPath,
+			err,
+		)
+	}
+
+	quarantinedFilePath := filepath.Join(
+		quarantineDirPath,
+		fmt.Sprintf("%s.%d", filepath.Base(filePath), time.Now().UnixMilli()),
+	)
+	if _, err := os.Stat(quarantinedFilePath); !os.IsNotExist(err) {
+		return fmt.Errorf(
+			"quarantined file [%s] already exists",
+			quarantinedFilePath,
+		)
+	}
+
+	if err := os.Rename(filePath, quarantinedFilePath); err != nil {
+		return fmt.Errorf(
+			"cannot move file [%s] to quarantine: [%w]",
+			filePath,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// ensureHandleDirectory creates the directory of a handle under the given
+// parent directory, if it does not exist, and returns its path.
+func ensureHandleDirectory(parentDir string, dir string) (string, error) {
+	if err := persistence.EnsureDirectoryExists(parentDir, dir); err != nil {
+		return "", fmt.Errorf(
+			"cannot create storage directory [%s] in [%s]: [%w]",
+			dir,
+			parentDir,
+			err,
+		)
+	}
+
+	return filepath.Join(parentDir, dir), nil
+}
+
+// walkDirectories calls the given function for each file stored in
+// subdirectories of the given directory. Entries passed to the function are
+// based on the given template. The given directory may not exist.
+func walkDirectories(
+	dirPath string,
+	template *Entry,
+	walkFn func(entry *Entry) error,
+) error {
+	directories, err := listDirectories(dirPath)
+	if err != nil {
+		return err
+	}
+
+	for _, directory := range directories {
+		files, err := os.ReadDir(filepath.Join(dirPath, directory))
+		if err != nil {
+			return fmt.Errorf(
+				"cannot read directory [%s]: [%w]",
+				filepath.Join(dirPath, directory),
+				err,
+			)
+		}
+
+		for _, file := range files {
+			if !file.Type().IsRegular() {
+				continue
+			}
+
+			filePath := filepath.Join(dirPath, directory, file.Name())
+
+			data, err := persistence.Read(filePath)
+			if err != nil {
+				return fmt.Errorf("cannot read file [%s]: [%w]", filePath, err)
+			}
+
+			entry := *template
+			entry.Directory = directory
+			entry.Name = file.Name()
+			entry.Data = data
+
+			if err := walkFn(&entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listDirectories returns names of subdirectories of the given directory.
+// An empty list is returned if the given directory does not exist.
+func listDirectories(dirPath string) ([]string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read directory [%s]: [%w]", dirPath, err)
+	}
+
+	directories := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			directories = append(directories, entry.Name())
+		}
+	}
+
+	return directories, nil
+}
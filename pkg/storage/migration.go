@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Migrate copies all data stored by the backend determined by the given
+// config to the given target backend created in the same storage directory.
+// The data are copied as-is, without being decrypted, including the archived,
+// snapshot and quarantined key store data. The target backend must be empty
+// so no data are ever overwritten. Once copied, the data are read back from
+// the target backend and compared with the source data. The source data are
+// left untouched. Returns the number of migrated entries.
+func Migrate(config Config, targetBackend string) (int, error) {
+	if backendName(config.Backend) == backendName(targetBackend) {
+		return 0, fmt.Errorf(
+			"source and target backends must be different; both are [%s]",
+			backendName(targetBackend),
+		)
+	}
+
+	source, err := NewBackend(config)
+	if err != nil {
+		return 0, fmt.Errorf("cannot open source backend: [%w]", err)
+	}
+	defer source.Close()
+
+	target, err := NewBackend(Config{Dir: config.Dir, Backend: targetBackend})
+	if err != nil {
+		return 0, fmt.Errorf("cannot open target backend: [%w]", err)
+	}
+	defer target.Close()
+
+	targetEntries, err := readEntries(target)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read target backend: [%w]", err)
+	}
+
+	if len(targetEntries) > 0 {
+		return 0, fmt.Errorf(
+			"target backend is not empty; it holds [%v] entries",
+			len(targetEntries),
+		)
+	}
+
+	sourceEntries, err := readEntries(source)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read source backend: [%w]", err)
+	}
+
+	for _, entry := range sourceEntries {
+		if err := target.Put(entry); err != nil {
+			return 0, fmt.Errorf(
+				"cannot migrate entry [%s]: [%w]",
+				entry,
+				err,
+			)
+		}
+	}
+
+	targetEntries, err = readEntries(target)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read migrated entries: [%w]", err)
+	}
+
+	if len(targetEntries) != len(sourceEntries) {
+		return 0, fmt.Errorf(
+			"unexpected number of migrated entries; "+
+				"expected [%v], got [%v]",
+			len(sourceEntries),
+			len(targetEntries),
+		)
+	}
+
+	for path, sourceEntry := range sourceEntries {
+		targetEntry, ok := targetEntries[path]
+		if !ok {
+			return 0, fmt.Errorf("entry [%s] was not migrated", path)
+		}
+
+		if !bytes.Equal(sourceEntry.Data, targetEntry.Data) {
+			return 0, fmt.Errorf("migrated entry [%s] differs", path)
+		}
+	}
+
+	return len(sourceEntries), nil
+}
+
+// readEntries reads all entries stored by the given backend. Entries are
+// keyed by their normalized paths.
+func readEntries(backend Backend) (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+
+	err := backend.Walk(func(entry *Entry) error {
+		entries[entry.String()] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// backendName returns the name of the given backend, resolving the default
+// one.
+func backendName(backend string) string {
+	if len(backend) == 0 {
+		return FileBackend
+	}
+
+	return backend
+}
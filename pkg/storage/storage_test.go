@@ -0,0 +1,277 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+const testEncryptionPassword = "password"
+
+func TestStorage_KeyStorePersistence(t *testing.T) {
+	for _, backend := range []string{FileBackend, BboltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			storage := initializeTestStorage(t, t.TempDir(), backend)
+
+			keyStorePersistence, err := storage.InitializeKeyStorePersistence("tbtc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saveTestData(t, keyStorePersistence, "wallet-1", "/membership_1")
+			saveTestData(t, keyStorePersistence, "wallet-1", "/membership_2")
+			saveTestData(t, keyStorePersistence, "wallet-2", "/membership_1")
+
+			assertStoredData(
+				t,
+				keyStorePersistence,
+				[]string{
+					"wallet-1/membership_1",
+					"wallet-1/membership_2",
+					"wallet-2/membership_1",
+				},
+			)
+
+			quarantine, ok := keyStorePersistence.(interface {
+				Quarantine(directory string, name string) error
+			})
+			if !ok {
+				t.Fatal("key store persistence does not support quarantine")
+			}
+
+			if err := quarantine.Quarantine("wallet-1", "membership_2"); err != nil {
+				t.Fatal(err)
+			}
+
+			err = quarantine.Quarantine("wallet-1", "membership_3")
+			if err == nil {
+				t.Error("expected quarantine of non-existing data to fail")
+			}
+
+			assertStoredData(
+				t,
+				keyStorePersistence,
+				[]string{"wallet-1/membership_1", "wallet-2/membership_1"},
+			)
+
+			if err := keyStorePersistence.Snapshot(
+				[]byte("snapshot"),
+				"wallet-2",
+				"/membership_1",
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := keyStorePersistence.Archive("wallet-2"); err != nil {
+				t.Fatal(err)
+			}
+
+			assertStoredData(
+				t,
+				keyStorePersistence,
+				[]string{"wallet-1/membership_1"},
+			)
+
+			if err := storage.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestStorage_WorkPersistence(t *testing.T) {
+	for _, backend := range []string{FileBackend, BboltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			storage := initializeTestStorage(t, t.TempDir(), backend)
+
+			workPersistence, err := storage.InitializeWorkPersistence("tbtc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saveTestData(t, workPersistence, "history", "entry-1")
+			saveTestData(t, workPersistence, "history", "entry-2")
+
+			if err := workPersistence.Delete("history", "entry-1"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := workPersistence.Delete("history", "entry-1"); err == nil {
+				t.Error("expected deletion of non-existing data to fail")
+			}
+
+			assertStoredData(
+				t,
+				workPersistence,
+				[]string{"history/entry-2"},
+			)
+
+			if err := storage.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	storageDir := t.TempDir()
+
+	storage := initializeTestStorage(t, storageDir, FileBackend)
+
+	keyStorePersistence, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saveTestData(t, keyStorePersistence, "wallet-1", "/membership_1")
+	saveTestData(t, keyStorePersistence, "wallet-2", "/membership_1")
+
+	if err := keyStorePersistence.Archive("wallet-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	workPersistence, err := storage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saveTestData(t, workPersistence, "history", "entry-1")
+
+	migrated, err := Migrate(Config{Dir: storageDir}, BboltBackend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "migrated entries", 3, migrated)
+
+	// Migrating again must not overwrite the migrated data.
+	_, err = Migrate(Config{Dir: storageDir}, BboltBackend)
+	if err == nil || !strings.Contains(err.Error(), "target backend is not empty") {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	_, err = Migrate(Config{Dir: storageDir, Backend: BboltBackend}, BboltBackend)
+	if err == nil || !strings.Contains(err.Error(), "must be different") {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	migratedStorage := initializeTestStorage(t, storageDir, BboltBackend)
+
+	migratedKeyStorePersistence, err := migratedStorage.InitializeKeyStorePersistence(
+		"tbtc",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStoredData(
+		t,
+		migratedKeyStorePersistence,
+		[]string{"wallet-1/membership_1"},
+	)
+
+	migratedWorkPersistence, err := migratedStorage.InitializeWorkPersistence(
+		"tbtc",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStoredData(
+		t,
+		migratedWorkPersistence,
+		[]string{"history/entry-1"},
+	)
+
+	// The archived data must be migrated as well.
+	archived := 0
+	err = migratedStorage.backend.Walk(func(entry *Entry) error {
+		if entry.Area == archiveArea {
+			archived++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "archived entries", 1, archived)
+
+	if err := migratedStorage.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func initializeTestStorage(t *testing.T, dir string, backend string) Storage {
+	storage, err := Initialize(
+		Config{Dir: dir, Backend: backend},
+		testEncryptionPassword,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return storage
+}
+
+func saveTestData(
+	t *testing.T,
+	handle persistence.RWHandle,
+	directory string,
+	name string,
+) {
+	if err := handle.Save(
+		[]byte(testDataPath(directory, name)),
+		directory,
+		name,
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// assertStoredData checks the handle holds exactly the data saved with
+// saveTestData under the given paths.
+func assertStoredData(
+	t *testing.T,
+	handle persistence.RWHandle,
+	expectedPaths []string,
+) {
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	go func() {
+		for err := range errorsChan {
+			t.Errorf("unexpected read error: [%v]", err)
+		}
+	}()
+
+	actualPaths := make([]string, 0)
+	for descriptor := range descriptorsChan {
+		content, err := descriptor.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path := testDataPath(descriptor.Directory(), descriptor.Name())
+
+		testutils.AssertStringsEqual(t, "content of "+path, path, string(content))
+
+		actualPaths = append(actualPaths, path)
+	}
+
+	sort.Strings(actualPaths)
+
+	testutils.AssertStringsEqual(
+		t,
+		"stored data",
+		strings.Join(expectedPaths, ","),
+		strings.Join(actualPaths, ","),
+	)
+}
+
+func testDataPath(directory string, name string) string {
+	return directory + "/" + strings.TrimPrefix(name, "/")
+}
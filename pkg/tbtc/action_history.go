@@ -0,0 +1,386 @@
+package tbtc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+const (
+	// actionHistoryDirectory is the name of the work persistence directory
+	// holding the action history entries.
+	actionHistoryDirectory = "action_history"
+	// actionHistoryRecentEntriesLimit is the maximum number of the most
+	// recent action history entries kept in memory and exposed through
+	// the diagnostics endpoint. The full history is available on disk.
+	actionHistoryRecentEntriesLimit = 100
+)
+
+// ActionHistoryEvent represents a type of the event recorded in the action
+// history.
+type ActionHistoryEvent string
+
+const (
+	// EventCoordinationResult is recorded once the coordination procedure
+	// of the given wallet completes in the given coordination window.
+	EventCoordinationResult ActionHistoryEvent = "CoordinationResult"
+	// EventActionStarted is recorded once the execution of a wallet action
+	// starts.
+	EventActionStarted ActionHistoryEvent = "ActionStarted"
+	// EventActionFinished is recorded once the execution of a wallet action
+	// finishes, either with success or with an error.
+	EventActionFinished ActionHistoryEvent = "ActionFinished"
+)
+
+// ActionHistoryFault represents a coordination fault recorded in the
+// action history.
+type ActionHistoryFault struct {
+	Culprit   string `json:"culprit"`
+	FaultType string `json:"faultType"`
+}
+
+// ActionHistoryEntry represents a single entry of the action history.
+// Fields irrelevant for the given event are left empty.
+type ActionHistoryEntry struct {
+	// Sequence is the number of the entry. Entries are numbered consecutively
+	// in the order they were recorded.
+	Sequence  uint64             `json:"sequence"`
+	Timestamp time.Time          `json:"timestamp"`
+	Event     ActionHistoryEvent `json:"event"`
+	// WalletPublicKeyHash is the 0x-prefixed hex representation of the
+	// public key hash of the wallet the entry refers to.
+	WalletPublicKeyHash string `json:"walletPublicKeyHash"`
+	// Action is the type of the wallet action. For the coordination result
+	// event, this is the type of the action proposed by the leader.
+	Action string `json:"action"`
+	// CoordinationBlock is the first block of the coordination window. Set
+	// only for the coordination result event.
+	CoordinationBlock uint64 `json:"coordinationBlock,omitempty"`
+	// Leader is the address of the coordination leader. Set only for the
+	// coordination result event.
+	Leader string `json:"leader,omitempty"`
+	// Leading is true if this node was the coordination leader, i.e. it
+	// made the proposal. If false, this node followed the leader's proposal.
+	// Set only for the coordination result event.
+	Leading bool `json:"leading,omitempty"`
+	// Faults are the coordination faults detected in the coordination
+	// window. Set only for the coordination result event.
+	Faults []ActionHistoryFault `json:"faults,omitempty"`
+	// Error is the error the wallet action terminated with. Set only for
+	// the action finished event of an action that failed.
+	Error string `json:"error,omitempty"`
+	// BitcoinTransactionHash is the hash of the Bitcoin transaction produced
+	// by the wallet action, in the reversed byte order. Set only for the
+	// action finished event of an action that broadcasted a transaction.
+	BitcoinTransactionHash string `json:"bitcoinTransactionHash,omitempty"`
+}
+
+// actionHistory is an append-only store of the coordination results and
+// wallet actions executed by the node. Entries are persisted using the
+// work persistence so they survive the client restart.
+type actionHistory struct {
+	mutex sync.Mutex
+
+	persistence persistence.BasicHandle
+
+	nextSequence uint64
+	// recentEntries holds the most recent entries, up to the
+	// actionHistoryRecentEntriesLimit, in the order they were recorded.
+	recentEntries []*ActionHistoryEntry
+}
+
+// newActionHistory creates a new instance of the actionHistory and loads
+// the most recent entries persisted using the given persistence handle.
+func newActionHistory(
+	persistence persistence.BasicHandle,
+) (*actionHistory, error) {
+	descriptors, err := readActionHistoryDescriptors(persistence)
+	if err != nil {
+		return nil, err
+	}
+
+	ah := &actionHistory{
+		persistence:   persistence,
+		recentEntries: make([]*ActionHistoryEntry, 0),
+	}
+
+	if len(descriptors) > 0 {
+		lastSequence, _ := parseActionHistorySequence(
+			descriptors[len(descriptors)-1].Name(),
+		)
+		ah.nextSequence = lastSequence + 1
+	}
+
+	if len(descriptors) > actionHistoryRecentEntriesLimit {
+		descriptors = descriptors[len(descriptors)-actionHistoryRecentEntriesLimit:]
+	}
+
+	for _, descriptor := range descriptors {
+		entry, err := unmarshalActionHistoryEntry(descriptor)
+		if err != nil {
+			logger.Errorf("could not load action history entry: [%v]", err)
+			continue
+		}
+
+		ah.recentEntries = append(ah.recentEntries, entry)
+	}
+
+	return ah, nil
+}
+
+// record assigns the sequence number and timestamp to the given entry,
+// persists it and adds it to the recent entries. A persistence failure is
+// logged and does not prevent the entry from being added to the recent
+// entries.
+func (ah *actionHistory) record(entry *ActionHistoryEntry) {
+	ah.mutex.Lock()
+	defer ah.mutex.Unlock()
+
+	entry.Sequence = ah.nextSequence
+	entry.Timestamp = time.Now().UTC()
+	ah.nextSequence++
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		logger.Errorf(
+			"could not marshal action history entry [%v]: [%v]",
+			entry.Sequence,
+			err,
+		)
+	} else if err := ah.persistence.Save(
+		entryBytes,
+		actionHistoryDirectory,
+		actionHistoryFileName(entry.Sequence),
+	); err != nil {
+		logger.Errorf(
+			"could not persist action history entry [%v]: [%v]",
+			entry.Sequence,
+			err,
+		)
+	}
+
+	ah.recentEntries = append(ah.recentEntries, entry)
+	if len(ah.recentEntries) > actionHistoryRecentEntriesLimit {
+		ah.recentEntries = ah.recentEntries[1:]
+	}
+}
+
+// recordCoordinationResult records the given coordination result.
+// The operatorAddress is the address of the node's operator and is used to
+// determine whether the node was the coordination leader.
+func (ah *actionHistory) recordCoordinationResult(
+	result *coordinationResult,
+	operatorAddress chain.Address,
+) {
+	faults := make([]ActionHistoryFault, len(result.faults))
+	for i, fault := range result.faults {
+		faults[i] = ActionHistoryFault{
+			Culprit:   fault.culprit.String(),
+			FaultType: fault.faultType.String(),
+		}
+	}
+
+	ah.record(&ActionHistoryEntry{
+		Event:               EventCoordinationResult,
+		WalletPublicKeyHash: actionHistoryWalletPublicKeyHash(result.wallet),
+		Action:              result.proposal.ActionType().String(),
+		CoordinationBlock:   result.window.coordinationBlock,
+		Leader:              result.leader.String(),
+		Leading:             result.leader == operatorAddress,
+		Faults:              faults,
+	})
+}
+
+// recordActionStarted records the start of the given wallet action.
+func (ah *actionHistory) recordActionStarted(action walletAction) {
+	ah.record(&ActionHistoryEntry{
+		Event:               EventActionStarted,
+		WalletPublicKeyHash: actionHistoryWalletPublicKeyHash(action.wallet()),
+		Action:              action.actionType().String(),
+	})
+}
+
+// recordActionFinished records the end of the given wallet action. The
+// actionErr is the error the action terminated with, nil if the action
+// succeeded.
+func (ah *actionHistory) recordActionFinished(
+	action walletAction,
+	actionErr error,
+) {
+	entry := &ActionHistoryEntry{
+		Event:               EventActionFinished,
+		WalletPublicKeyHash: actionHistoryWalletPublicKeyHash(action.wallet()),
+		Action:              action.actionType().String(),
+	}
+
+	if actionErr != nil {
+		entry.Error = actionErr.Error()
+	}
+
+	if transactionAction, ok := action.(walletTransactionAction); ok {
+		if txHash, ok := transactionAction.broadcastedTransaction(); ok {
+			entry.BitcoinTransactionHash = txHash.Hex(bitcoin.ReversedByteOrder)
+		}
+	}
+
+	ah.record(entry)
+}
+
+// recent returns the most recent entries, in the order they were recorded.
+func (ah *actionHistory) recent() []*ActionHistoryEntry {
+	ah.mutex.Lock()
+	defer ah.mutex.Unlock()
+
+	entries := make([]*ActionHistoryEntry, len(ah.recentEntries))
+	copy(entries, ah.recentEntries)
+
+	return entries
+}
+
+// ReadActionHistory reads the full action history persisted using the given
+// tbtc work persistence handle. Entries are returned in the order they were
+// recorded.
+func ReadActionHistory(
+	handle persistence.BasicHandle,
+) ([]*ActionHistoryEntry, error) {
+	descriptors, err := readActionHistoryDescriptors(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ActionHistoryEntry, len(descriptors))
+	for i, descriptor := range descriptors {
+		entry, err := unmarshalActionHistoryEntry(descriptor)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = entry
+	}
+
+	return entries, nil
+}
+
+// readActionHistoryDescriptors returns descriptors of all action history
+// entries persisted using the given handle, sorted by the entry
+// sequence number. Contents of the entries are not read.
+func readActionHistoryDescriptors(
+	handle persistence.BasicHandle,
+) ([]persistence.DataDescriptor, error) {
+	descriptors := make([]persistence.DataDescriptor, 0)
+	var readErrors []error
+
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels. The reason
+	// for using two goroutines at the same time - one for descriptors and
+	// one for errors - is that channels do not have to be buffered, and we
+	// do not know in what order the information is written to channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the action history directory.
+			if descriptor.Directory() != actionHistoryDirectory {
+				continue
+			}
+
+			if _, err := parseActionHistorySequence(
+				descriptor.Name(),
+			); err != nil {
+				logger.Warnf(
+					"ignoring unexpected file [%s] in directory [%s]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			descriptors = append(descriptors, descriptor)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			readErrors = append(readErrors, err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if len(readErrors) > 0 {
+		return nil, fmt.Errorf(
+			"could not read action history: %v",
+			readErrors,
+		)
+	}
+
+	// File names are zero-padded sequence numbers so sorting by name gives
+	// the order in which entries were recorded.
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].Name() < descriptors[j].Name()
+	})
+
+	return descriptors, nil
+}
+
+// unmarshalActionHistoryEntry reads and unmarshals the action history entry
+// described by the given descriptor.
+func unmarshalActionHistoryEntry(
+	descriptor persistence.DataDescriptor,
+) (*ActionHistoryEntry, error) {
+	content, err := descriptor.Content()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read content of file [%s] in directory [%s]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	entry := &ActionHistoryEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal file [%s] in directory [%s]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	return entry, nil
+}
+
+// actionHistoryFileName returns the name of the file holding the action
+// history entry with the given sequence number.
+func actionHistoryFileName(sequence uint64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
+
+// parseActionHistorySequence extracts the entry sequence number from the
+// given action history file name.
+func parseActionHistorySequence(fileName string) (uint64, error) {
+	return strconv.ParseUint(fileName, 10, 64)
+}
+
+// actionHistoryWalletPublicKeyHash returns the 0x-prefixed hex representation
+// of the given wallet's public key hash.
+func actionHistoryWalletPublicKeyHash(wallet wallet) string {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+	return fmt.Sprintf("0x%x", walletPublicKeyHash)
+}
@@ -0,0 +1,230 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestActionHistory(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	actionHistory, err := newActionHistory(persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := fmt.Sprintf(
+		"0x%x",
+		bitcoin.PublicKeyHash(wallet.publicKey),
+	)
+
+	operatorAddress := chain.Address("957ECF59507a6A74b8d98747f07a74De270D3CC3")
+	culpritAddress := chain.Address("5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04")
+
+	actionHistory.recordCoordinationResult(
+		&coordinationResult{
+			wallet:   wallet,
+			window:   newCoordinationWindow(900),
+			leader:   operatorAddress,
+			proposal: &HeartbeatProposal{},
+			faults: []*coordinationFault{
+				{
+					culprit:   culpritAddress,
+					faultType: FaultLeaderImpersonation,
+				},
+			},
+		},
+		operatorAddress,
+	)
+
+	txHash := bitcoin.Hash{0x01, 0x02}
+	action := &mockWalletTransactionAction{
+		mockWalletAction: mockWalletAction{actionWallet: wallet},
+		txHash:           &txHash,
+	}
+
+	actionHistory.recordActionStarted(action)
+	actionHistory.recordActionFinished(action, fmt.Errorf("proof failed"))
+
+	// Re-create the history to make sure entries are loaded from the
+	// persistence and the sequence continues from the last persisted entry.
+	actionHistory, err = newActionHistory(persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actionHistory.recordActionStarted(&mockWalletAction{actionWallet: wallet})
+
+	expectedEntries := []*ActionHistoryEntry{
+		{
+			Sequence:            0,
+			Event:               EventCoordinationResult,
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Action:              ActionHeartbeat.String(),
+			CoordinationBlock:   900,
+			Leader:              operatorAddress.String(),
+			Leading:             true,
+			Faults: []ActionHistoryFault{
+				{
+					Culprit:   culpritAddress.String(),
+					FaultType: FaultLeaderImpersonation.String(),
+				},
+			},
+		},
+		{
+			Sequence:            1,
+			Event:               EventActionStarted,
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Action:              ActionNoop.String(),
+		},
+		{
+			Sequence:               2,
+			Event:                  EventActionFinished,
+			WalletPublicKeyHash:    walletPublicKeyHash,
+			Action:                 ActionNoop.String(),
+			Error:                  "proof failed",
+			BitcoinTransactionHash: txHash.Hex(bitcoin.ReversedByteOrder),
+		},
+		{
+			Sequence:            3,
+			Event:               EventActionStarted,
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Action:              ActionNoop.String(),
+		},
+	}
+
+	assertEntries := func(
+		t *testing.T,
+		expected []*ActionHistoryEntry,
+		actual []*ActionHistoryEntry,
+	) {
+		testutils.AssertIntsEqual(
+			t,
+			"entries count",
+			len(expected),
+			len(actual),
+		)
+
+		for i := range actual {
+			if actual[i].Timestamp.IsZero() {
+				t.Errorf("timestamp of entry [%v] is not set", i)
+			}
+
+			// Timestamps are not deterministic so compare entries
+			// without them.
+			entry := *actual[i]
+			entry.Timestamp = expected[i].Timestamp
+
+			if !reflect.DeepEqual(expected[i], &entry) {
+				t.Errorf(
+					"unexpected entry [%v]\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					i,
+					expected[i],
+					&entry,
+				)
+			}
+		}
+	}
+
+	t.Run("recent entries", func(t *testing.T) {
+		assertEntries(t, expectedEntries, actionHistory.recent())
+	})
+
+	t.Run("persisted entries", func(t *testing.T) {
+		entries, err := ReadActionHistory(persistenceHandle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertEntries(t, expectedEntries, entries)
+	})
+}
+
+func TestActionHistory_RecentEntriesLimit(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	actionHistory, err := newActionHistory(persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := &mockWalletAction{actionWallet: generateWallet(big.NewInt(100))}
+
+	entriesCount := actionHistoryRecentEntriesLimit + 10
+	for i := 0; i < entriesCount; i++ {
+		actionHistory.recordActionStarted(action)
+	}
+
+	assertRecentEntries := func(t *testing.T, recentEntries []*ActionHistoryEntry) {
+		testutils.AssertIntsEqual(
+			t,
+			"recent entries count",
+			actionHistoryRecentEntriesLimit,
+			len(recentEntries),
+		)
+		testutils.AssertUintsEqual(
+			t,
+			"first recent entry sequence",
+			10,
+			recentEntries[0].Sequence,
+		)
+		testutils.AssertUintsEqual(
+			t,
+			"last recent entry sequence",
+			uint64(entriesCount-1),
+			recentEntries[len(recentEntries)-1].Sequence,
+		)
+	}
+
+	t.Run("in-memory history", func(t *testing.T) {
+		assertRecentEntries(t, actionHistory.recent())
+	})
+
+	t.Run("loaded history", func(t *testing.T) {
+		loadedHistory, err := newActionHistory(persistenceHandle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertRecentEntries(t, loadedHistory.recent())
+	})
+
+	t.Run("persisted history", func(t *testing.T) {
+		entries, err := ReadActionHistory(persistenceHandle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertIntsEqual(
+			t,
+			"persisted entries count",
+			entriesCount,
+			len(entries),
+		)
+	})
+}
+
+type mockWalletTransactionAction struct {
+	mockWalletAction
+	txHash *bitcoin.Hash
+}
+
+func (mwta *mockWalletTransactionAction) broadcastedTransaction() (
+	bitcoin.Hash,
+	bool,
+) {
+	if mwta.txHash == nil {
+		return bitcoin.Hash{}, false
+	}
+
+	return *mwta.txHash, true
+}
@@ -14,6 +14,10 @@ type localBitcoinChain struct {
 
 	mempoolMutex sync.Mutex
 	mempool      []*bitcoin.Transaction
+
+	parametersMutex   sync.Mutex
+	latestBlockHeight uint
+	satPerVByteFee    int64
 }
 
 func newLocalBitcoinChain() *localBitcoinChain {
@@ -48,9 +52,27 @@ func (lbc *localBitcoinChain) GetTransactionConfirmations(
 		}
 	}
 
+	lbc.mempoolMutex.Lock()
+	defer lbc.mempoolMutex.Unlock()
+
+	for _, transaction := range lbc.mempool {
+		if transaction.Hash() == transactionHash {
+			return 0, nil
+		}
+	}
+
 	return 0, fmt.Errorf("transaction not found")
 }
 
+func (lbc *localBitcoinChain) addMempoolTransaction(
+	transaction *bitcoin.Transaction,
+) {
+	lbc.mempoolMutex.Lock()
+	defer lbc.mempoolMutex.Unlock()
+
+	lbc.mempool = append(lbc.mempool, transaction)
+}
+
 func (lbc *localBitcoinChain) BroadcastTransaction(
 	transaction *bitcoin.Transaction,
 ) error {
@@ -71,7 +93,17 @@ func (lbc *localBitcoinChain) BroadcastTransaction(
 }
 
 func (lbc *localBitcoinChain) GetLatestBlockHeight() (uint, error) {
-	panic("not implemented")
+	lbc.parametersMutex.Lock()
+	defer lbc.parametersMutex.Unlock()
+
+	return lbc.latestBlockHeight, nil
+}
+
+func (lbc *localBitcoinChain) setLatestBlockHeight(latestBlockHeight uint) {
+	lbc.parametersMutex.Lock()
+	defer lbc.parametersMutex.Unlock()
+
+	lbc.latestBlockHeight = latestBlockHeight
 }
 
 func (lbc *localBitcoinChain) GetBlockHeader(
@@ -227,10 +259,26 @@ func (lbc *localBitcoinChain) GetMempoolUtxosForPublicKeyHash(
 	return nil, nil
 }
 
+func (lbc *localBitcoinChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	return nil, false, nil
+}
+
 func (lbc *localBitcoinChain) EstimateSatPerVByteFee(
 	blocks uint32,
 ) (int64, error) {
-	panic("unsupported")
+	lbc.parametersMutex.Lock()
+	defer lbc.parametersMutex.Unlock()
+
+	return lbc.satPerVByteFee, nil
+}
+
+func (lbc *localBitcoinChain) setSatPerVByteFee(satPerVByteFee int64) {
+	lbc.parametersMutex.Lock()
+	defer lbc.parametersMutex.Unlock()
+
+	lbc.satPerVByteFee = satPerVByteFee
 }
 
 func (lbc *localBitcoinChain) GetCoinbaseTxHash(blockHeight uint) (
@@ -181,6 +181,68 @@ type DKGParameters struct {
 	ApprovePrecedencePeriodBlocks uint64
 }
 
+// InactivityClaimChain defines the subset of the TBTC chain interface that
+// pertains to claiming inactivity of wallet signing group members.
+type InactivityClaimChain interface {
+	// GetInactivityClaimNonce returns the current inactivity claim nonce of
+	// the given wallet. The nonce is incremented each time an inactivity
+	// claim is accepted for the wallet so it protects against replaying
+	// signatures of previous claims.
+	GetInactivityClaimNonce(walletID [32]byte) (*big.Int, error)
+
+	// CalculateInactivityClaimHash calculates a 32-byte hash that is signed
+	// by wallet signing group members supporting the given inactivity claim
+	// of the wallet with the given public key. The nonce must be the current
+	// inactivity claim nonce of the wallet.
+	CalculateInactivityClaimHash(
+		walletPublicKey *ecdsa.PublicKey,
+		claim *InactivityClaim,
+		nonce *big.Int,
+	) (InactivityClaimHash, error)
+
+	// SubmitInactivityClaim submits the given inactivity claim along with
+	// signatures of signing group members supporting it. The signatures are
+	// expected to be produced over the hash returned by
+	// CalculateInactivityClaimHash for the same nonce. The groupMembers
+	// parameter holds the operator IDs of all wallet signing group members,
+	// in the order of the signing group member indexes.
+	SubmitInactivityClaim(
+		claim *InactivityClaim,
+		nonce *big.Int,
+		signatures map[group.MemberIndex][]byte,
+		groupMembers chain.OperatorIDs,
+	) error
+}
+
+// WalletRegistryChain defines the subset of the TBTC chain interface that
+// pertains to the signing group membership of wallets registered on-chain.
+type WalletRegistryChain interface {
+	// IsWalletMember checks whether the given operator is a member of the
+	// signing group of the wallet with the given ID and occupies the seat
+	// with the given member index. The walletMembersIDs parameter holds the
+	// operator IDs of all wallet signing group members, in the order of the
+	// signing group member indexes. An error is returned if walletMembersIDs
+	// does not match the signing group registered on-chain.
+	IsWalletMember(
+		walletID [32]byte,
+		walletMembersIDs chain.OperatorIDs,
+		operator chain.Address,
+		walletMemberIndex group.MemberIndex,
+	) (bool, error)
+}
+
+// InactivityClaimHash represents a hash of the InactivityClaim signed by
+// wallet signing group members. The algorithm used is specific to the chain.
+type InactivityClaimHash [32]byte
+
+// InactivityClaim represents a claim about signing group members of the given
+// wallet that were inactive during a protocol execution.
+type InactivityClaim struct {
+	WalletID               [32]byte
+	InactiveMembersIndexes []group.MemberIndex
+	HeartbeatFailed        bool
+}
+
 // BridgeChain defines the subset of the TBTC chain interface that pertains
 // specifically to the tBTC Bridge operations.
 type BridgeChain interface {
@@ -221,6 +283,29 @@ type BridgeChain interface {
 		fundingTxHash bitcoin.Hash,
 		fundingOutputIndex uint32,
 	) (*DepositChainRequest, bool, error)
+
+	// GetDepositParameters gets the current value of parameters relevant
+	// for the depositing process.
+	GetDepositParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		revealAheadPeriod uint32,
+		err error,
+	)
+
+	// GetRedemptionParameters gets the current value of parameters relevant
+	// for the redemption process.
+	GetRedemptionParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		txMaxTotalFee uint64,
+		timeout uint32,
+		timeoutSlashingAmount *big.Int,
+		timeoutNotifierRewardMultiplier uint32,
+		err error,
+	)
 }
 
 // NewWalletRegisteredEvent represents a new wallet registered event.
@@ -283,6 +368,10 @@ func (dre *DepositRevealedEvent) GetWalletPublicKeyHash() [20]byte {
 	return dre.WalletPublicKeyHash
 }
 
+func (dre *DepositRevealedEvent) GetBlockNumber() uint64 {
+	return dre.BlockNumber
+}
+
 // DepositRevealedEventFilter is a component allowing to filter DepositRevealedEvent.
 type DepositRevealedEventFilter struct {
 	StartBlock          uint64
@@ -305,6 +394,14 @@ type DepositChainRequest struct {
 	SweptAt     time.Time
 }
 
+// FraudChallenge represents a fraud challenge stored on-chain.
+type FraudChallenge struct {
+	Challenger    chain.Address
+	DepositAmount *big.Int
+	ReportedAt    time.Time
+	Resolved      bool
+}
+
 // WalletChainData represents wallet data stored on-chain.
 type WalletChainData struct {
 	EcdsaWalletID                          [32]byte
@@ -349,6 +446,24 @@ type WalletProposalValidatorChain interface {
 		walletPublicKeyHash [20]byte,
 		proposal *HeartbeatProposal,
 	) error
+
+	// ValidateMovingFundsProposal validates the given moving funds proposal
+	// against the chain. The wallet main UTXO must be the one currently
+	// registered in the Bridge. Returns an error if the proposal is not
+	// valid or nil otherwise.
+	ValidateMovingFundsProposal(
+		walletPublicKeyHash [20]byte,
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *MovingFundsProposal,
+	) error
+
+	// ValidateMovedFundsSweepProposal validates the given moved funds sweep
+	// proposal against the chain. Returns an error if the proposal is not
+	// valid or nil otherwise.
+	ValidateMovedFundsSweepProposal(
+		walletPublicKeyHash [20]byte,
+		proposal *MovedFundsSweepProposal,
+	) error
 }
 
 // RedemptionRequestedEvent represents a redemption requested event.
@@ -366,6 +481,10 @@ func (rre *RedemptionRequestedEvent) GetWalletPublicKeyHash() [20]byte {
 	return rre.WalletPublicKeyHash
 }
 
+func (rre *RedemptionRequestedEvent) GetBlockNumber() uint64 {
+	return rre.BlockNumber
+}
+
 // RedemptionRequestedEventFilter is a component allowing to filter RedemptionRequestedEvent.
 type RedemptionRequestedEventFilter struct {
 	StartBlock          uint64
@@ -374,6 +493,54 @@ type RedemptionRequestedEventFilter struct {
 	Redeemer            []chain.Address
 }
 
+// MovingFundsCommitmentSubmittedEvent represents a moving funds commitment
+// submitted event.
+type MovingFundsCommitmentSubmittedEvent struct {
+	WalletPublicKeyHash [20]byte
+	TargetWallets       [][20]byte
+	Submitter           chain.Address
+	BlockNumber         uint64
+}
+
+func (mfcse *MovingFundsCommitmentSubmittedEvent) GetWalletPublicKeyHash() [20]byte {
+	return mfcse.WalletPublicKeyHash
+}
+
+func (mfcse *MovingFundsCommitmentSubmittedEvent) GetBlockNumber() uint64 {
+	return mfcse.BlockNumber
+}
+
+// MovingFundsCommitmentSubmittedEventFilter is a component allowing to
+// filter MovingFundsCommitmentSubmittedEvent.
+type MovingFundsCommitmentSubmittedEventFilter struct {
+	StartBlock          uint64
+	EndBlock            *uint64
+	WalletPublicKeyHash [][20]byte
+}
+
+// MovingFundsCompletedEvent represents a moving funds completed event.
+type MovingFundsCompletedEvent struct {
+	WalletPublicKeyHash [20]byte
+	MovingFundsTxHash   bitcoin.Hash
+	BlockNumber         uint64
+}
+
+func (mfce *MovingFundsCompletedEvent) GetWalletPublicKeyHash() [20]byte {
+	return mfce.WalletPublicKeyHash
+}
+
+func (mfce *MovingFundsCompletedEvent) GetBlockNumber() uint64 {
+	return mfce.BlockNumber
+}
+
+// MovingFundsCompletedEventFilter is a component allowing to filter
+// MovingFundsCompletedEvent.
+type MovingFundsCompletedEventFilter struct {
+	StartBlock          uint64
+	EndBlock            *uint64
+	WalletPublicKeyHash [][20]byte
+}
+
 // Chain represents the interface that the TBTC module expects to interact
 // with the anchoring blockchain on.
 type Chain interface {
@@ -395,6 +562,8 @@ type Chain interface {
 	sortition.Chain
 	GroupSelectionChain
 	DistributedKeyGenerationChain
+	InactivityClaimChain
+	WalletRegistryChain
 	BridgeChain
 	WalletProposalValidatorChain
 }
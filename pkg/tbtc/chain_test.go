@@ -68,6 +68,23 @@ type localChain struct {
 	heartbeatProposalValidationsMutex sync.Mutex
 	heartbeatProposalValidations      map[[16]byte]bool
 
+	movingFundsProposalValidationsMutex sync.Mutex
+	movingFundsProposalValidations      map[[32]byte]bool
+
+	movedFundsSweepProposalValidationsMutex sync.Mutex
+	movedFundsSweepProposalValidations      map[[32]byte]bool
+
+	inactivityClaimsMutex     sync.Mutex
+	inactivityClaimNonces     map[[32]byte]*big.Int
+	submittedInactivityClaims []*submittedInactivityClaim
+
+	walletMembersMutex sync.Mutex
+	walletMembers      map[[32]byte]chain.OperatorIDs
+
+	txMaxFeesMutex          sync.Mutex
+	depositTxMaxFee         uint64
+	redemptionTxMaxTotalFee uint64
+
 	blockCounter       chain.BlockCounter
 	operatorPrivateKey *operator.PrivateKey
 }
@@ -498,6 +515,90 @@ func (lc *localChain) DKGParameters() (*DKGParameters, error) {
 	}, nil
 }
 
+type submittedInactivityClaim struct {
+	claim        *InactivityClaim
+	nonce        *big.Int
+	signatures   map[group.MemberIndex][]byte
+	groupMembers chain.OperatorIDs
+}
+
+func (lc *localChain) GetInactivityClaimNonce(
+	walletID [32]byte,
+) (*big.Int, error) {
+	lc.inactivityClaimsMutex.Lock()
+	defer lc.inactivityClaimsMutex.Unlock()
+
+	nonce, ok := lc.inactivityClaimNonces[walletID]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+
+	return nonce, nil
+}
+
+func (lc *localChain) CalculateInactivityClaimHash(
+	walletPublicKey *ecdsa.PublicKey,
+	claim *InactivityClaim,
+	nonce *big.Int,
+) (InactivityClaimHash, error) {
+	if walletPublicKey == nil {
+		return InactivityClaimHash{}, fmt.Errorf("wallet public key is nil")
+	}
+
+	encoded := fmt.Sprint(
+		walletPublicKey,
+		claim.WalletID,
+		claim.InactiveMembersIndexes,
+		claim.HeartbeatFailed,
+		nonce,
+	)
+
+	return sha3.Sum256([]byte(encoded)), nil
+}
+
+func (lc *localChain) SubmitInactivityClaim(
+	claim *InactivityClaim,
+	nonce *big.Int,
+	signatures map[group.MemberIndex][]byte,
+	groupMembers chain.OperatorIDs,
+) error {
+	lc.inactivityClaimsMutex.Lock()
+	defer lc.inactivityClaimsMutex.Unlock()
+
+	currentNonce, ok := lc.inactivityClaimNonces[claim.WalletID]
+	if !ok {
+		currentNonce = big.NewInt(0)
+	}
+
+	if currentNonce.Cmp(nonce) != 0 {
+		return fmt.Errorf("invalid nonce")
+	}
+
+	lc.inactivityClaimNonces[claim.WalletID] = new(big.Int).Add(
+		currentNonce,
+		big.NewInt(1),
+	)
+
+	lc.submittedInactivityClaims = append(
+		lc.submittedInactivityClaims,
+		&submittedInactivityClaim{
+			claim:        claim,
+			nonce:        nonce,
+			signatures:   signatures,
+			groupMembers: groupMembers,
+		},
+	)
+
+	return nil
+}
+
+func (lc *localChain) getSubmittedInactivityClaims() []*submittedInactivityClaim {
+	lc.inactivityClaimsMutex.Lock()
+	defer lc.inactivityClaimsMutex.Unlock()
+
+	return lc.submittedInactivityClaims
+}
+
 func (lc *localChain) PastDepositRevealedEvents(
 	filter *DepositRevealedEventFilter,
 ) ([]*DepositRevealedEvent, error) {
@@ -589,6 +690,49 @@ func (lc *localChain) GetDepositRequest(
 	panic("not supported")
 }
 
+func (lc *localChain) GetDepositParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	revealAheadPeriod uint32,
+	err error,
+) {
+	lc.txMaxFeesMutex.Lock()
+	defer lc.txMaxFeesMutex.Unlock()
+
+	return 0, 0, lc.depositTxMaxFee, 0, nil
+}
+
+func (lc *localChain) setDepositTxMaxFee(txMaxFee uint64) {
+	lc.txMaxFeesMutex.Lock()
+	defer lc.txMaxFeesMutex.Unlock()
+
+	lc.depositTxMaxFee = txMaxFee
+}
+
+func (lc *localChain) GetRedemptionParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	txMaxTotalFee uint64,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	lc.txMaxFeesMutex.Lock()
+	defer lc.txMaxFeesMutex.Unlock()
+
+	return 0, 0, 0, lc.redemptionTxMaxTotalFee, 0, nil, 0, nil
+}
+
+func (lc *localChain) setRedemptionTxMaxTotalFee(txMaxTotalFee uint64) {
+	lc.txMaxFeesMutex.Lock()
+	defer lc.txMaxFeesMutex.Unlock()
+
+	lc.redemptionTxMaxTotalFee = txMaxTotalFee
+}
+
 func (lc *localChain) setPendingRedemptionRequest(
 	walletPublicKeyHash [20]byte,
 	request *RedemptionRequest,
@@ -611,6 +755,51 @@ func buildRedemptionRequestKey(
 	return sha256.Sum256(append(walletPublicKeyHash[:], redeemerOutputScript...))
 }
 
+func (lc *localChain) IsWalletMember(
+	walletID [32]byte,
+	walletMembersIDs chain.OperatorIDs,
+	operator chain.Address,
+	walletMemberIndex group.MemberIndex,
+) (bool, error) {
+	lc.walletMembersMutex.Lock()
+	defer lc.walletMembersMutex.Unlock()
+
+	registeredMembersIDs, ok := lc.walletMembers[walletID]
+	if !ok {
+		return false, fmt.Errorf("wallet not registered")
+	}
+
+	if !reflect.DeepEqual(registeredMembersIDs, walletMembersIDs) {
+		return false, fmt.Errorf("invalid wallet members identifiers")
+	}
+
+	if walletMemberIndex < 1 || int(walletMemberIndex) > len(walletMembersIDs) {
+		return false, fmt.Errorf("wallet member index is out of range")
+	}
+
+	thisOperatorAddress, err := lc.operatorAddress()
+	if err != nil {
+		return false, err
+	}
+
+	// Local chain knows the ID of its own operator only.
+	if thisOperatorAddress != operator {
+		return false, nil
+	}
+
+	return walletMembersIDs[walletMemberIndex-1] == localChainOperatorID, nil
+}
+
+func (lc *localChain) setWalletMembers(
+	walletID [32]byte,
+	walletMembersIDs chain.OperatorIDs,
+) {
+	lc.walletMembersMutex.Lock()
+	defer lc.walletMembersMutex.Unlock()
+
+	lc.walletMembers[walletID] = walletMembersIDs
+}
+
 func (lc *localChain) GetWallet(walletPublicKeyHash [20]byte) (
 	*WalletChainData,
 	error,
@@ -855,6 +1044,137 @@ func (lc *localChain) setHeartbeatProposalValidationResult(
 	lc.heartbeatProposalValidations[proposal.Message] = result
 }
 
+func (lc *localChain) ValidateMovingFundsProposal(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+) error {
+	lc.movingFundsProposalValidationsMutex.Lock()
+	defer lc.movingFundsProposalValidationsMutex.Unlock()
+
+	key := buildMovingFundsProposalValidationKey(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+	)
+
+	result, ok := lc.movingFundsProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *localChain) setMovingFundsProposalValidationResult(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+	result bool,
+) {
+	lc.movingFundsProposalValidationsMutex.Lock()
+	defer lc.movingFundsProposalValidationsMutex.Unlock()
+
+	key := buildMovingFundsProposalValidationKey(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+	)
+
+	lc.movingFundsProposalValidations[key] = result
+}
+
+func buildMovingFundsProposalValidationKey(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	buffer.Write(walletPublicKeyHash[:])
+
+	buffer.Write(walletMainUtxo.Outpoint.TransactionHash[:])
+
+	outputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndex, walletMainUtxo.Outpoint.OutputIndex)
+	buffer.Write(outputIndex)
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(walletMainUtxo.Value))
+	buffer.Write(value)
+
+	for _, targetWallet := range proposal.TargetWallets {
+		buffer.Write(targetWallet[:])
+	}
+
+	buffer.Write(proposal.MovingFundsTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+func (lc *localChain) ValidateMovedFundsSweepProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *MovedFundsSweepProposal,
+) error {
+	lc.movedFundsSweepProposalValidationsMutex.Lock()
+	defer lc.movedFundsSweepProposalValidationsMutex.Unlock()
+
+	key := buildMovedFundsSweepProposalValidationKey(
+		walletPublicKeyHash,
+		proposal,
+	)
+
+	result, ok := lc.movedFundsSweepProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *localChain) setMovedFundsSweepProposalValidationResult(
+	walletPublicKeyHash [20]byte,
+	proposal *MovedFundsSweepProposal,
+	result bool,
+) {
+	lc.movedFundsSweepProposalValidationsMutex.Lock()
+	defer lc.movedFundsSweepProposalValidationsMutex.Unlock()
+
+	key := buildMovedFundsSweepProposalValidationKey(
+		walletPublicKeyHash,
+		proposal,
+	)
+
+	lc.movedFundsSweepProposalValidations[key] = result
+}
+
+func buildMovedFundsSweepProposalValidationKey(
+	walletPublicKeyHash [20]byte,
+	proposal *MovedFundsSweepProposal,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	buffer.Write(walletPublicKeyHash[:])
+
+	buffer.Write(proposal.MovingFundsTxHash[:])
+
+	outputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndex, proposal.MovingFundsTxOutputIndex)
+	buffer.Write(outputIndex)
+
+	buffer.Write(proposal.SweepTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
 // Connect sets up the local chain.
 func Connect(blockTime ...time.Duration) *localChain {
 	operatorPrivateKey, _, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
@@ -883,16 +1203,20 @@ func ConnectWithKey(
 		dkgResultChallengeHandlers: make(
 			map[int]func(submission *DKGResultChallengedEvent),
 		),
-		wallets:                         make(map[[20]byte]*WalletChainData),
-		blocksByTimestamp:               make(map[uint64]uint64),
-		blocksHashesByNumber:            make(map[uint64][32]byte),
-		pastDepositRevealedEvents:       make(map[[32]byte][]*DepositRevealedEvent),
-		depositSweepProposalValidations: make(map[[32]byte]bool),
-		pendingRedemptionRequests:       make(map[[32]byte]*RedemptionRequest),
-		redemptionProposalValidations:   make(map[[32]byte]bool),
-		heartbeatProposalValidations:    make(map[[16]byte]bool),
-		blockCounter:                    blockCounter,
-		operatorPrivateKey:              operatorPrivateKey,
+		wallets:                            make(map[[20]byte]*WalletChainData),
+		blocksByTimestamp:                  make(map[uint64]uint64),
+		blocksHashesByNumber:               make(map[uint64][32]byte),
+		pastDepositRevealedEvents:          make(map[[32]byte][]*DepositRevealedEvent),
+		depositSweepProposalValidations:    make(map[[32]byte]bool),
+		pendingRedemptionRequests:          make(map[[32]byte]*RedemptionRequest),
+		redemptionProposalValidations:      make(map[[32]byte]bool),
+		heartbeatProposalValidations:       make(map[[16]byte]bool),
+		movingFundsProposalValidations:     make(map[[32]byte]bool),
+		movedFundsSweepProposalValidations: make(map[[32]byte]bool),
+		inactivityClaimNonces:              make(map[[32]byte]*big.Int),
+		walletMembers:                      make(map[[32]byte]chain.OperatorIDs),
+		blockCounter:                       blockCounter,
+		operatorPrivateKey:                 operatorPrivateKey,
 	}
 
 	return localChain
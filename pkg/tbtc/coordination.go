@@ -371,7 +371,13 @@ func (ce *coordinationExecutor) coordinate(
 
 	execLogger.Infof("coordination leader is: [%s]", leader)
 
-	actionsChecklist := ce.getActionsChecklist(window.index(), seed)
+	// Fee bumps of stuck wallet transactions are not subject of the regular
+	// schedule and are checked in every coordination window. Only the
+	// feeBumpProposalGenerator handles the fee bump action.
+	actionsChecklist := append(
+		ce.getActionsChecklist(window.index(), seed),
+		ActionFeeBump,
+	)
 
 	execLogger.Infof("actions checklist is: [%v]", actionsChecklist)
 
@@ -463,9 +469,7 @@ func (ce *coordinationExecutor) coordinate(
 func (ce *coordinationExecutor) getSeed(
 	coordinationBlock uint64,
 ) ([32]byte, error) {
-	walletPublicKeyHash := ce.walletPublicKeyHash()
-
-	safeBlockNumber := coordinationBlock - coordinationSafeBlockShift
+	safeBlockNumber := CoordinationSafeBlock(coordinationBlock)
 	safeBlockHash, err := ce.chain.GetBlockHashByNumber(safeBlockNumber)
 	if err != nil {
 		return [32]byte{}, fmt.Errorf(
@@ -474,19 +478,55 @@ func (ce *coordinationExecutor) getSeed(
 		)
 	}
 
+	return CoordinationSeed(ce.walletPublicKeyHash(), safeBlockHash), nil
+}
+
+// getLeader returns the address of the coordination leader for the given
+// coordination seed.
+func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
+	return CoordinationLeader(seed, ce.coordinatedWallet.signingGroupOperators)
+}
+
+// getActionsChecklist returns a list of wallet actions that should be checked
+// for the given coordination window. Returns nil for incorrect coordination
+// windows whose index is 0.
+func (ce *coordinationExecutor) getActionsChecklist(
+	windowIndex uint64,
+	seed [32]byte,
+) []WalletActionType {
+	return CoordinationActionsChecklist(windowIndex, seed)
+}
+
+// CoordinationSafeBlock returns the number of the safe block whose hash is
+// an ingredient of the coordination seed computed for the coordination window
+// starting at the given coordination block.
+func CoordinationSafeBlock(coordinationBlock uint64) uint64 {
+	return coordinationBlock - coordinationSafeBlockShift
+}
+
+// CoordinationSeed computes the coordination seed of the given wallet using
+// the hash of the safe block of the coordination window.
+func CoordinationSeed(
+	walletPublicKeyHash [20]byte,
+	safeBlockHash [32]byte,
+) [32]byte {
 	return sha256.Sum256(
 		append(
 			walletPublicKeyHash[:],
 			safeBlockHash[:]...,
 		),
-	), nil
+	)
 }
 
-// getLeader returns the address of the coordination leader for the given
-// coordination seed.
-func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
+// CoordinationLeader returns the address of the coordination leader for the
+// given coordination seed, chosen among the given operators backing the
+// wallet.
+func CoordinationLeader(
+	seed [32]byte,
+	signingGroupOperators []chain.Address,
+) chain.Address {
 	// First, take all operators backing the wallet.
-	allOperators := chain.Addresses(ce.coordinatedWallet.signingGroupOperators)
+	allOperators := chain.Addresses(signingGroupOperators)
 
 	// Determine a list of unique operators.
 	uniqueOperators := make([]chain.Address, 0)
@@ -520,13 +560,36 @@ func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
 	return uniqueOperators[0]
 }
 
-// getActionsChecklist returns a list of wallet actions that should be checked
-// for the given coordination window. Returns nil for incorrect coordination
-// windows whose index is 0.
-func (ce *coordinationExecutor) getActionsChecklist(
+// CoordinationActionsChecklist returns a list of wallet actions that should
+// be checked for the coordination window with the given index and seed.
+// Returns nil for incorrect coordination windows whose index is 0.
+func CoordinationActionsChecklist(
 	windowIndex uint64,
 	seed [32]byte,
 ) []WalletActionType {
+	actions := coordinationScheduledActions(windowIndex)
+
+	// Return nil checklist for incorrect coordination windows.
+	if actions == nil {
+		return nil
+	}
+
+	// #nosec G404 (insecure random number source (rand))
+	// Drawing a decision about heartbeat does not require secure randomness.
+	// Use first 8 bytes of the seed to initialize the RNG.
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:8]))))
+	if rng.Float64() < coordinationHeartbeatProbability {
+		actions = append(actions, ActionHeartbeat)
+	}
+
+	return actions
+}
+
+// coordinationScheduledActions returns the part of the actions checklist
+// that depends only on the coordination window index, i.e. all actions but
+// the heartbeat that is drawn using the coordination seed. Returns nil for
+// incorrect coordination windows whose index is 0.
+func coordinationScheduledActions(windowIndex uint64) []WalletActionType {
 	// Return nil checklist for incorrect coordination windows.
 	if windowIndex == 0 {
 		return nil
@@ -556,14 +619,6 @@ func (ce *coordinationExecutor) getActionsChecklist(
 		actions = append(actions, ActionMovingFunds)
 	}
 
-	// #nosec G404 (insecure random number source (rand))
-	// Drawing a decision about heartbeat does not require secure randomness.
-	// Use first 8 bytes of the seed to initialize the RNG.
-	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:8]))))
-	if rng.Float64() < coordinationHeartbeatProbability {
-		actions = append(actions, ActionHeartbeat)
-	}
-
 	return actions
 }
 
@@ -0,0 +1,138 @@
+package tbtc
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// CoordinationScheduleChain represents the interface the coordination
+// schedule expects to interact with the anchoring blockchain on.
+type CoordinationScheduleChain interface {
+	// BlockCounter returns the chain's block counter.
+	BlockCounter() (chain.BlockCounter, error)
+	// GetBlockHashByNumber gets the block hash for the given block number.
+	GetBlockHashByNumber(blockNumber uint64) ([32]byte, error)
+	// GetWalletOperators returns the addresses of the operators forming the
+	// signing group of the given wallet. An operator may appear multiple
+	// times if they control multiple members of the signing group.
+	GetWalletOperators(walletPublicKeyHash [20]byte) ([]chain.Address, error)
+}
+
+// CoordinationScheduleEntry describes a single coordination window of
+// a wallet.
+type CoordinationScheduleEntry struct {
+	// Index is the index of the coordination window.
+	Index uint64
+	// CoordinationBlock is the first block of the coordination window.
+	CoordinationBlock uint64
+	// ActivePhaseEndBlock is the block at which the active phase of the
+	// coordination window ends.
+	ActivePhaseEndBlock uint64
+	// EndBlock is the block at which the coordination window ends.
+	EndBlock uint64
+	// SafeBlock is the block whose hash is used to compute the coordination
+	// seed of the window.
+	SafeBlock uint64
+	// SeedAvailable is true if the safe block is already mined so the
+	// coordination seed is known. If false, the leader is unknown and the
+	// actions checklist contains only the actions that do not depend on
+	// the seed, i.e. it does not say whether the heartbeat is included.
+	SeedAvailable bool
+	// Leader is the coordination leader of the window. Empty if the
+	// coordination seed is not available yet.
+	Leader chain.Address
+	// ActionsChecklist is the list of wallet actions checked in the window,
+	// in the order of precedence.
+	ActionsChecklist []WalletActionType
+}
+
+// CoordinationSchedule returns the given number of coordination windows of the
+// wallet, starting from the window that is currently in progress or the next
+// one if no window is in progress at the moment. The schedule is computed
+// the same way the coordination executor of the node does, using data read
+// from the chain.
+func CoordinationSchedule(
+	scheduleChain CoordinationScheduleChain,
+	walletPublicKeyHash [20]byte,
+	windowsCount uint64,
+) ([]*CoordinationScheduleEntry, error) {
+	blockCounter, err := scheduleChain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	operators, err := scheduleChain.GetWalletOperators(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet operators: [%v]", err)
+	}
+
+	if len(operators) == 0 {
+		return nil, fmt.Errorf("wallet has no operators")
+	}
+
+	schedule := make([]*CoordinationScheduleEntry, 0, windowsCount)
+
+	window := firstScheduledCoordinationWindow(currentBlock)
+	for uint64(len(schedule)) < windowsCount {
+		entry := &CoordinationScheduleEntry{
+			Index:               window.index(),
+			CoordinationBlock:   window.coordinationBlock,
+			ActivePhaseEndBlock: window.activePhaseEndBlock(),
+			EndBlock:            window.endBlock(),
+			SafeBlock:           CoordinationSafeBlock(window.coordinationBlock),
+		}
+
+		if entry.SafeBlock <= currentBlock {
+			safeBlockHash, err := scheduleChain.GetBlockHashByNumber(
+				entry.SafeBlock,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot get hash of safe block [%v]: [%v]",
+					entry.SafeBlock,
+					err,
+				)
+			}
+
+			seed := CoordinationSeed(walletPublicKeyHash, safeBlockHash)
+
+			entry.SeedAvailable = true
+			entry.Leader = CoordinationLeader(seed, operators)
+			entry.ActionsChecklist = CoordinationActionsChecklist(
+				entry.Index,
+				seed,
+			)
+		} else {
+			entry.ActionsChecklist = coordinationScheduledActions(entry.Index)
+		}
+
+		schedule = append(schedule, entry)
+
+		window = newCoordinationWindow(
+			window.coordinationBlock + coordinationFrequencyBlocks,
+		)
+	}
+
+	return schedule, nil
+}
+
+// firstScheduledCoordinationWindow returns the coordination window that is
+// in progress at the given block or the next one if no window is in progress.
+func firstScheduledCoordinationWindow(block uint64) *coordinationWindow {
+	coordinationBlock := block - block%coordinationFrequencyBlocks
+
+	// Windows with index 0 are not valid so the first valid window starts
+	// at the coordination frequency block.
+	if coordinationBlock == 0 ||
+		block >= newCoordinationWindow(coordinationBlock).endBlock() {
+		coordinationBlock += coordinationFrequencyBlocks
+	}
+
+	return newCoordinationWindow(coordinationBlock)
+}
@@ -0,0 +1,203 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+type scheduleChain struct {
+	currentBlock uint64
+	operators    []chain.Address
+}
+
+func (sc *scheduleChain) BlockCounter() (chain.BlockCounter, error) {
+	return &scheduleBlockCounter{currentBlock: sc.currentBlock}, nil
+}
+
+func (sc *scheduleChain) GetBlockHashByNumber(
+	blockNumber uint64,
+) ([32]byte, error) {
+	if blockNumber > sc.currentBlock {
+		return [32]byte{}, fmt.Errorf("block [%v] not mined yet", blockNumber)
+	}
+
+	blockNumberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(blockNumberBytes, blockNumber)
+
+	return sha256.Sum256(blockNumberBytes), nil
+}
+
+func (sc *scheduleChain) GetWalletOperators(
+	walletPublicKeyHash [20]byte,
+) ([]chain.Address, error) {
+	return sc.operators, nil
+}
+
+type scheduleBlockCounter struct {
+	currentBlock uint64
+}
+
+func (sbc *scheduleBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	panic("unsupported")
+}
+
+func (sbc *scheduleBlockCounter) BlockHeightWaiter(blockNumber uint64) (
+	<-chan uint64,
+	error,
+) {
+	panic("unsupported")
+}
+
+func (sbc *scheduleBlockCounter) CurrentBlock() (uint64, error) {
+	return sbc.currentBlock, nil
+}
+
+func (sbc *scheduleBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	panic("unsupported")
+}
+
+func TestCoordinationSchedule(t *testing.T) {
+	walletPublicKeyHash := [20]byte{0x01, 0x02, 0x03}
+
+	operators := []chain.Address{
+		"957ECF59507a6A74b8d98747f07a74De270D3CC3",
+		"5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04",
+		"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c",
+		"5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04",
+	}
+
+	scheduleChain := &scheduleChain{
+		// The window starting at block 13500 is in progress.
+		currentBlock: 13550,
+		operators:    operators,
+	}
+
+	schedule, err := CoordinationSchedule(
+		scheduleChain,
+		walletPublicKeyHash,
+		3,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "schedule length", 3, len(schedule))
+
+	// The first window's safe block is mined so the leader and the full
+	// checklist must be the same as computed by the coordination executor.
+	safeBlockHash, err := scheduleChain.GetBlockHashByNumber(13468)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := CoordinationSeed(walletPublicKeyHash, safeBlockHash)
+
+	executor := &coordinationExecutor{
+		// Set only relevant fields.
+		coordinatedWallet: wallet{
+			signingGroupOperators: operators,
+		},
+	}
+
+	expectedSchedule := []*CoordinationScheduleEntry{
+		{
+			Index:               15,
+			CoordinationBlock:   13500,
+			ActivePhaseEndBlock: 13580,
+			EndBlock:            13600,
+			SafeBlock:           13468,
+			SeedAvailable:       true,
+			Leader:              executor.getLeader(seed),
+			ActionsChecklist:    executor.getActionsChecklist(15, seed),
+		},
+		{
+			Index:               16,
+			CoordinationBlock:   14400,
+			ActivePhaseEndBlock: 14480,
+			EndBlock:            14500,
+			SafeBlock:           14368,
+			SeedAvailable:       false,
+			ActionsChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+				ActionMovingFunds,
+			},
+		},
+		{
+			Index:               17,
+			CoordinationBlock:   15300,
+			ActivePhaseEndBlock: 15380,
+			EndBlock:            15400,
+			SafeBlock:           15268,
+			SeedAvailable:       false,
+			ActionsChecklist:    []WalletActionType{ActionRedemption},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedSchedule, schedule) {
+		t.Errorf(
+			"unexpected schedule\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedSchedule,
+			schedule,
+		)
+	}
+
+	if len(schedule[0].Leader) == 0 {
+		t.Errorf("leader of the first window is not set")
+	}
+}
+
+func TestFirstScheduledCoordinationWindow(t *testing.T) {
+	tests := map[string]struct {
+		block                     uint64
+		expectedCoordinationBlock uint64
+	}{
+		"genesis block": {
+			block:                     0,
+			expectedCoordinationBlock: 900,
+		},
+		"before first window": {
+			block:                     899,
+			expectedCoordinationBlock: 900,
+		},
+		"first block of window": {
+			block:                     1800,
+			expectedCoordinationBlock: 1800,
+		},
+		"last block of window": {
+			block:                     1899,
+			expectedCoordinationBlock: 1800,
+		},
+		"end block of window": {
+			block:                     1900,
+			expectedCoordinationBlock: 2700,
+		},
+		"between windows": {
+			block:                     2500,
+			expectedCoordinationBlock: 2700,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			window := firstScheduledCoordinationWindow(test.block)
+
+			testutils.AssertUintsEqual(
+				t,
+				"coordination block",
+				test.expectedCoordinationBlock,
+				window.coordinationBlock,
+			)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package tbtc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// FindRevealedDeposit looks for the deposit revealed to the Bridge that is
+// represented by the given funding transaction output. Returns an error if
+// the deposit was not revealed or was already swept by the wallet.
+func FindRevealedDeposit(
+	bridgeChain BridgeChain,
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+) (*Deposit, error) {
+	depositRequest, found, err := bridgeChain.GetDepositRequest(
+		fundingTxHash,
+		fundingOutputIndex,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get deposit request: [%v]", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("deposit was not revealed to the Bridge")
+	}
+
+	if !depositRequest.SweptAt.IsZero() {
+		return nil, fmt.Errorf(
+			"deposit was already swept at [%v]",
+			depositRequest.SweptAt,
+		)
+	}
+
+	// The deposit request does not hold all the data necessary to
+	// reconstruct the deposit script so, look for the reveal event.
+	events, err := bridgeChain.PastDepositRevealedEvents(
+		&DepositRevealedEventFilter{
+			Depositor: []chain.Address{depositRequest.Depositor},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past deposit revealed events: [%v]",
+			err,
+		)
+	}
+
+	for _, event := range events {
+		if event.FundingTxHash == fundingTxHash &&
+			event.FundingOutputIndex == fundingOutputIndex {
+			return event.unpack(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("deposit revealed event not found")
+}
+
+// DepositRefundTransactionBuilder assembles a Bitcoin transaction that
+// refunds a deposit to its depositor. Such a transaction unlocks the deposit
+// UTXO using the refund branch of the deposit script so, it can be mined
+// only once the deposit refund locktime passes and must be signed with the
+// private key corresponding to the deposit refund public key hash. The
+// signature is not produced by the builder but must be supplied externally,
+// by the depositor. The builder IS NOT SAFE for concurrent use.
+type DepositRefundTransactionBuilder struct {
+	deposit *Deposit
+	builder *bitcoin.TransactionBuilder
+	sigHash *big.Int
+}
+
+// NewDepositRefundTransactionBuilder constructs a new builder of the refund
+// transaction for the given deposit. The refund transaction has a single
+// input pointing to the deposit UTXO and a single output locked using the
+// given recipient output script. The output value is equal to the deposit
+// value reduced by the given fee. The deposit UTXO must be locked using
+// a P2SH or P2WSH script built from the deposit's script.
+func NewDepositRefundTransactionBuilder(
+	bitcoinChain bitcoin.Chain,
+	deposit *Deposit,
+	recipientOutputScript bitcoin.Script,
+	fee int64,
+) (*DepositRefundTransactionBuilder, error) {
+	if len(recipientOutputScript) == 0 {
+		return nil, fmt.Errorf("recipient output script is empty")
+	}
+
+	if fee <= 0 {
+		return nil, fmt.Errorf("fee must be greater than zero")
+	}
+
+	if fee >= deposit.Utxo.Value {
+		return nil, fmt.Errorf(
+			"fee [%v] must be lower than the deposit value [%v]",
+			fee,
+			deposit.Utxo.Value,
+		)
+	}
+
+	depositScript, err := deposit.Script()
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute deposit script: [%v]", err)
+	}
+
+	err = ensureDepositScriptMatchesUtxo(bitcoinChain, deposit, depositScript)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+
+	builder.SetLocktime(binary.LittleEndian.Uint32(deposit.RefundLocktime[:]))
+
+	if err := builder.AddScriptHashInput(deposit.Utxo, depositScript); err != nil {
+		return nil, fmt.Errorf("cannot add deposit input: [%v]", err)
+	}
+
+	builder.AddOutput(&bitcoin.TransactionOutput{
+		Value:           deposit.Utxo.Value - fee,
+		PublicKeyScript: recipientOutputScript,
+	})
+
+	sigHashes, err := builder.ComputeSignatureHashes()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot compute signature hash of the refund transaction: [%v]",
+			err,
+		)
+	}
+
+	return &DepositRefundTransactionBuilder{
+		deposit: deposit,
+		builder: builder,
+		sigHash: sigHashes[0],
+	}, nil
+}
+
+// ensureDepositScriptMatchesUtxo makes sure the deposit UTXO is locked using
+// the given deposit script. This protects against building a refund
+// transaction using inaccurate deposit data that could never be signed
+// properly.
+func ensureDepositScriptMatchesUtxo(
+	bitcoinChain bitcoin.Chain,
+	deposit *Deposit,
+	depositScript bitcoin.Script,
+) error {
+	transactionHash := deposit.Utxo.Outpoint.TransactionHash
+	outputIndex := deposit.Utxo.Outpoint.OutputIndex
+
+	transaction, err := bitcoinChain.GetTransaction(transactionHash)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get deposit funding transaction [%s]: [%v]",
+			transactionHash.Hex(bitcoin.ReversedByteOrder),
+			err,
+		)
+	}
+
+	if int(outputIndex) >= len(transaction.Outputs) {
+		return fmt.Errorf(
+			"deposit funding transaction does not have output [%v]",
+			outputIndex,
+		)
+	}
+
+	p2shScript, err := bitcoin.PayToScriptHash(
+		bitcoin.ScriptHash(depositScript),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot compute P2SH deposit script: [%v]", err)
+	}
+
+	p2wshScript, err := bitcoin.PayToWitnessScriptHash(
+		bitcoin.WitnessScriptHash(depositScript),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot compute P2WSH deposit script: [%v]", err)
+	}
+
+	utxoScript := transaction.Outputs[outputIndex].PublicKeyScript
+	if !bytes.Equal(utxoScript, p2shScript) &&
+		!bytes.Equal(utxoScript, p2wshScript) {
+		return fmt.Errorf(
+			"deposit UTXO is not locked using the deposit script",
+		)
+	}
+
+	return nil
+}
+
+// SignatureHash returns the hash that must be signed by the depositor using
+// the private key corresponding to the deposit refund public key hash.
+func (drtb *DepositRefundTransactionBuilder) SignatureHash() *big.Int {
+	return drtb.sigHash
+}
+
+// UnsignedTransaction returns the refund transaction without signature data.
+func (drtb *DepositRefundTransactionBuilder) UnsignedTransaction() *bitcoin.Transaction {
+	return drtb.builder.UnsignedTransaction()
+}
+
+// AddSignature applies the given externally produced signature to the
+// refund transaction and returns the signed transaction that is ready to
+// be broadcast once the deposit refund locktime passes. The signature must
+// be valid for the signature hash and must contain the public key whose
+// hash is equal to the deposit refund public key hash.
+func (drtb *DepositRefundTransactionBuilder) AddSignature(
+	signature *bitcoin.SignatureContainer,
+) (*bitcoin.Transaction, error) {
+	if signature.PublicKey == nil {
+		return nil, fmt.Errorf("signature public key is not set")
+	}
+
+	publicKeyHash := bitcoin.PublicKeyHash(signature.PublicKey)
+	if publicKeyHash != drtb.deposit.RefundPublicKeyHash {
+		return nil, fmt.Errorf(
+			"signature public key does not match the deposit refund " +
+				"public key hash",
+		)
+	}
+
+	transaction, err := drtb.builder.AddSignatures(
+		[]*bitcoin.SignatureContainer{signature},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot add signature to the refund transaction: [%v]",
+			err,
+		)
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,386 @@
+package tbtc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestDepositRefundTransactionBuilder(t *testing.T) {
+	var tests = map[string]struct {
+		witness bool
+	}{
+		"P2SH deposit": {
+			witness: false,
+		},
+		"P2WSH deposit": {
+			witness: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			refundPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bitcoinChain := newLocalBitcoinChain()
+
+			deposit := newRefundableDeposit(
+				t,
+				bitcoinChain,
+				&refundPrivateKey.PublicKey,
+				test.witness,
+			)
+
+			recipientOutputScript, err := bitcoin.PayToWitnessPublicKeyHash(
+				deposit.RefundPublicKeyHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fee := int64(1000)
+
+			builder, err := NewDepositRefundTransactionBuilder(
+				bitcoinChain,
+				deposit,
+				recipientOutputScript,
+				fee,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			unsignedTransaction := builder.UnsignedTransaction()
+
+			testutils.AssertIntsEqual(
+				t,
+				"inputs count",
+				1,
+				len(unsignedTransaction.Inputs),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"input signature script length",
+				0,
+				len(unsignedTransaction.Inputs[0].SignatureScript),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"input witness length",
+				0,
+				len(unsignedTransaction.Inputs[0].Witness),
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"input sequence",
+				uint64(wire.MaxTxInSequenceNum-1),
+				uint64(unsignedTransaction.Inputs[0].Sequence),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"outputs count",
+				1,
+				len(unsignedTransaction.Outputs),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"output value",
+				int(deposit.Utxo.Value-fee),
+				int(unsignedTransaction.Outputs[0].Value),
+			)
+			testutils.AssertBytesEqual(
+				t,
+				recipientOutputScript,
+				unsignedTransaction.Outputs[0].PublicKeyScript,
+			)
+			testutils.AssertBytesEqual(
+				t,
+				deposit.RefundLocktime[:],
+				func() []byte {
+					locktime := unsignedTransaction.SerializeLocktime()
+					return locktime[:]
+				}(),
+			)
+
+			// Signing is done by the depositor so, just simulate it here.
+			signature, err := refundPrivateKey.Sign(
+				padSigHash(builder.SignatureHash()),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			transaction, err := builder.AddSignature(
+				&bitcoin.SignatureContainer{
+					R:         signature.R,
+					S:         signature.S,
+					PublicKey: &refundPrivateKey.PublicKey,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertDepositRefundScriptExecution(t, bitcoinChain, deposit, transaction)
+		})
+	}
+}
+
+func TestDepositRefundTransactionBuilder_WrongRefundKey(t *testing.T) {
+	refundPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bitcoinChain := newLocalBitcoinChain()
+
+	deposit := newRefundableDeposit(
+		t,
+		bitcoinChain,
+		&refundPrivateKey.PublicKey,
+		true,
+	)
+
+	builder, err := NewDepositRefundTransactionBuilder(
+		bitcoinChain,
+		deposit,
+		[]byte{0x00, 0x14},
+		1000,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := otherPrivateKey.Sign(padSigHash(builder.SignatureHash()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = builder.AddSignature(
+		&bitcoin.SignatureContainer{
+			R:         signature.R,
+			S:         signature.S,
+			PublicKey: &otherPrivateKey.PublicKey,
+		},
+	)
+
+	expectedErr := "signature public key does not match the deposit " +
+		"refund public key hash"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestNewDepositRefundTransactionBuilder_Errors(t *testing.T) {
+	refundPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		modifyDeposit func(deposit *Deposit)
+		fee           int64
+		expectedErr   string
+	}{
+		"zero fee": {
+			fee:         0,
+			expectedErr: "fee must be greater than zero",
+		},
+		"fee exceeding deposit value": {
+			fee:         100000,
+			expectedErr: "fee [100000] must be lower than the deposit value [100000]",
+		},
+		"deposit data not matching the UTXO": {
+			modifyDeposit: func(deposit *Deposit) {
+				deposit.BlindingFactor[0]++
+			},
+			fee:         1000,
+			expectedErr: "deposit UTXO is not locked using the deposit script",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+
+			deposit := newRefundableDeposit(
+				t,
+				bitcoinChain,
+				&refundPrivateKey.PublicKey,
+				true,
+			)
+
+			if test.modifyDeposit != nil {
+				test.modifyDeposit(deposit)
+			}
+
+			_, err := NewDepositRefundTransactionBuilder(
+				bitcoinChain,
+				deposit,
+				[]byte{0x00, 0x14},
+				test.fee,
+			)
+			if err == nil || err.Error() != test.expectedErr {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+		})
+	}
+}
+
+// newRefundableDeposit creates a deposit refundable with the given public
+// key and records its funding transaction in the given local chain.
+func newRefundableDeposit(
+	t *testing.T,
+	bitcoinChain *localBitcoinChain,
+	refundPublicKey *ecdsa.PublicKey,
+	witness bool,
+) *Deposit {
+	deposit := &Deposit{
+		Depositor:           "934b98637ca318a4d6e7ca6ffd1690b8e77df637",
+		RefundPublicKeyHash: bitcoin.PublicKeyHash(refundPublicKey),
+	}
+
+	blindingFactor, err := hex.DecodeString("f9f0c90d00039523")
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(deposit.BlindingFactor[:], blindingFactor)
+
+	walletPublicKeyHash, err := hex.DecodeString(
+		"8db50eb52063ea9d98b3eac91489a90f738986f6",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(deposit.WalletPublicKeyHash[:], walletPublicKeyHash)
+
+	refundLocktime, err := hex.DecodeString("60bcea61")
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(deposit.RefundLocktime[:], refundLocktime)
+
+	depositScript, err := deposit.Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var depositOutputScript bitcoin.Script
+	if witness {
+		depositOutputScript, err = bitcoin.PayToWitnessScriptHash(
+			bitcoin.WitnessScriptHash(depositScript),
+		)
+	} else {
+		depositOutputScript, err = bitcoin.PayToScriptHash(
+			bitcoin.ScriptHash(depositScript),
+		)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					OutputIndex: 0,
+				},
+				Sequence: wire.MaxTxInSequenceNum,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           100000,
+				PublicKeyScript: depositOutputScript,
+			},
+		},
+	}
+
+	bitcoinChain.transactions = append(
+		bitcoinChain.transactions,
+		fundingTransaction,
+	)
+
+	deposit.Utxo = &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: fundingTransaction.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 100000,
+	}
+
+	return deposit
+}
+
+// assertDepositRefundScriptExecution executes the deposit script against
+// the given refund transaction and makes sure the deposit UTXO is unlocked.
+func assertDepositRefundScriptExecution(
+	t *testing.T,
+	bitcoinChain *localBitcoinChain,
+	deposit *Deposit,
+	transaction *bitcoin.Transaction,
+) {
+	fundingTransaction, err := bitcoinChain.GetTransaction(
+		deposit.Utxo.Outpoint.TransactionHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	if err := msgTx.Deserialize(
+		bytes.NewReader(transaction.Serialize()),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := txscript.NewEngine(
+		fundingTransaction.Outputs[deposit.Utxo.Outpoint.OutputIndex].PublicKeyScript,
+		msgTx,
+		0,
+		txscript.StandardVerifyFlags,
+		nil,
+		nil,
+		deposit.Utxo.Value,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := engine.Execute(); err != nil {
+		t.Errorf("refund transaction does not unlock the deposit: [%v]", err)
+	}
+}
+
+// padSigHash converts the given signature hash to a 32-byte slice.
+func padSigHash(sigHash *big.Int) []byte {
+	result := make([]byte, 32)
+	sigHash.FillBytes(result)
+	return result
+}
@@ -78,6 +78,7 @@ type depositSweepAction struct {
 
 	sweepingWallet      wallet
 	transactionExecutor *walletTransactionExecutor
+	feeBumpWatcher      *feeBumpWatcher
 
 	proposal                     *DepositSweepProposal
 	proposalProcessingStartBlock uint64
@@ -95,6 +96,7 @@ func newDepositSweepAction(
 	btcChain bitcoin.Chain,
 	sweepingWallet wallet,
 	signingExecutor walletSigningExecutor,
+	feeBumpWatcher *feeBumpWatcher,
 	proposal *DepositSweepProposal,
 	proposalProcessingStartBlock uint64,
 	proposalExpiryBlock uint64,
@@ -113,6 +115,7 @@ func newDepositSweepAction(
 		btcChain:                         btcChain,
 		sweepingWallet:                   sweepingWallet,
 		transactionExecutor:              transactionExecutor,
+		feeBumpWatcher:                   feeBumpWatcher,
 		proposal:                         proposal,
 		proposalProcessingStartBlock:     proposalProcessingStartBlock,
 		proposalExpiryBlock:              proposalExpiryBlock,
@@ -216,9 +219,51 @@ func (dsa *depositSweepAction) execute() error {
 		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
 	}
 
+	dsa.watchTransaction(
+		walletPublicKeyHash,
+		sweepTx,
+		walletMainUtxo,
+		validatedDeposits,
+	)
+
 	return nil
 }
 
+// watchTransaction starts watching the broadcast deposit sweep transaction
+// in order to bump its fee if the confirmation lags. The maximum fee of the
+// transaction is determined by the Bridge deposit tx max fee limit.
+func (dsa *depositSweepAction) watchTransaction(
+	walletPublicKeyHash [20]byte,
+	sweepTx *bitcoin.Transaction,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	deposits []*Deposit,
+) {
+	_, _, perDepositMaxFee, _, err := dsa.chain.GetDepositParameters()
+	if err != nil {
+		dsa.logger.Warnf(
+			"cannot watch sweep transaction; cannot get deposit tx "+
+				"max fee: [%v]",
+			err,
+		)
+		return
+	}
+
+	err = dsa.feeBumpWatcher.watch(
+		walletPublicKeyHash,
+		&watchedTransaction{
+			actionType:     ActionDepositSweep,
+			transaction:    sweepTx,
+			fee:            dsa.proposal.SweepTxFee.Int64(),
+			maxFee:         int64(perDepositMaxFee) * int64(len(deposits)),
+			walletMainUtxo: walletMainUtxo,
+			deposits:       deposits,
+		},
+	)
+	if err != nil {
+		dsa.logger.Warnf("cannot watch sweep transaction: [%v]", err)
+	}
+}
+
 // ValidateDepositSweepProposal checks the deposit sweep proposal with on-chain
 // validation rules and verifies transactions on the Bitcoin chain.
 func ValidateDepositSweepProposal(
@@ -392,6 +437,10 @@ func (dsa *depositSweepAction) actionType() WalletActionType {
 	return ActionDepositSweep
 }
 
+func (dsa *depositSweepAction) broadcastedTransaction() (bitcoin.Hash, bool) {
+	return dsa.transactionExecutor.broadcastedTransaction()
+}
+
 // assembleDepositSweepTransaction constructs an unsigned deposit sweep Bitcoin
 // transaction.
 //
@@ -416,6 +465,9 @@ func assembleDepositSweepTransaction(
 	}
 
 	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+	// Wallet transactions signal replaceability so their fees can be
+	// bumped by the feeBumpWatcher if they get stuck.
+	builder.SignalReplaceability()
 
 	if walletMainUtxo != nil {
 		err := builder.AddPublicKeyHashInput(walletMainUtxo)
@@ -187,6 +187,7 @@ func TestDepositSweepAction_Execute(t *testing.T) {
 				bitcoinChain,
 				wallet,
 				signingExecutor,
+				newTestFeeBumpWatcher(t, bitcoinChain),
 				proposal,
 				proposalProcessingStartBlock,
 				proposalExpiryBlock,
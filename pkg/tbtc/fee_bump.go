@@ -0,0 +1,807 @@
+package tbtc
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// feeBumpWatcherDirectory is the name of the work persistence directory
+	// holding the transactions watched by the feeBumpWatcher. Each file
+	// holds the watched transaction of the wallet whose public key hash,
+	// in the hex format, is the file name.
+	feeBumpWatcherDirectory = "fee_bump_watcher"
+	// feeBumpProposalValidityBlocks determines the fee bump proposal
+	// validity time expressed in blocks. In other words, this is the worst-case
+	// time for a fee bump during which the wallet is busy and cannot take
+	// another actions. The value of 600 blocks is roughly 2 hours, assuming
+	// 12 seconds per block.
+	feeBumpProposalValidityBlocks = 600
+	// feeBumpSigningTimeoutSafetyMarginBlocks determines the duration of the
+	// safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire fee bump action. This safety margin
+	// prevents against the case where signing completes late and there is
+	// not enough time to broadcast the replacement transaction properly.
+	// The value of 300 blocks is roughly 1 hour, assuming 12 seconds per block.
+	feeBumpSigningTimeoutSafetyMarginBlocks = 300
+	// feeBumpBroadcastTimeout determines the time window for the replacement
+	// transaction broadcast.
+	feeBumpBroadcastTimeout = 15 * time.Minute
+	// feeBumpBroadcastCheckDelay determines the delay that must be preserved
+	// between the replacement transaction broadcast and the check that
+	// ensures the transaction is known on the Bitcoin chain.
+	feeBumpBroadcastCheckDelay = 1 * time.Minute
+	// feeBumpConfirmationLagBlocks determines the number of Bitcoin blocks
+	// that must be mined since the broadcast of a wallet transaction for
+	// the transaction to be considered stuck if it is still unconfirmed.
+	// The value of 6 blocks is roughly 1 hour, assuming 10 minutes per block.
+	feeBumpConfirmationLagBlocks = 6
+	// feeBumpMinimumIncreasePercent determines the minimum increase of the
+	// fee paid by the replacement transaction, expressed as percentage of the
+	// fee paid by the replaced transaction. The replacement transaction
+	// must also pay for its own relay, according to the BIP-125 rules.
+	feeBumpMinimumIncreasePercent = 50
+	// feeBumpIncrementalRelayFeeRate determines the incremental relay fee
+	// rate (in satoshi per virtual byte) the replacement transaction must pay
+	// on top of the fee of the replaced transaction, according to the
+	// BIP-125 rules. The value corresponds to the default Bitcoin Core policy.
+	feeBumpIncrementalRelayFeeRate = 1
+)
+
+// FeeBumpProposal represents a proposal to replace a stuck wallet transaction
+// with a transaction paying a higher fee (replace-by-fee).
+type FeeBumpProposal struct {
+	// TransactionHash is the hash of the replaced wallet transaction.
+	TransactionHash bitcoin.Hash
+	// Fee is the total fee paid by the replacement transaction.
+	Fee *big.Int
+}
+
+func (fbp *FeeBumpProposal) ActionType() WalletActionType {
+	return ActionFeeBump
+}
+
+func (fbp *FeeBumpProposal) ValidityBlocks() uint64 {
+	return feeBumpProposalValidityBlocks
+}
+
+// watchedTransaction represents a wallet transaction broadcast by this node
+// whose confirmation is watched in order to bump its fee if needed.
+type watchedTransaction struct {
+	// actionType is the type of the wallet action that produced the
+	// transaction.
+	actionType WalletActionType
+	// transaction is the broadcast wallet transaction.
+	transaction *bitcoin.Transaction
+	// fee is the total fee paid by the transaction.
+	fee int64
+	// maxFee is the maximum total fee the transaction can pay according
+	// to the Bridge limits.
+	maxFee int64
+	// broadcastHeight is the height of the Bitcoin chain at the moment
+	// the transaction was broadcast.
+	broadcastHeight uint
+	// walletMainUtxo is the wallet main UTXO spent by the transaction,
+	// nil if the transaction does not spend the main UTXO.
+	walletMainUtxo *bitcoin.UnspentTransactionOutput
+	// deposits are the deposits swept by the transaction. Set only for
+	// deposit sweep transactions.
+	deposits []*Deposit
+	// redemptionRequests are the requests handled by the transaction. Set
+	// only for redemption transactions.
+	redemptionRequests []*RedemptionRequest
+	// redemptionTransactionShape is the shape of the transaction. Set only
+	// for redemption transactions.
+	redemptionTransactionShape RedemptionTransactionShape
+}
+
+// assemble assembles an unsigned replacement of the transaction that
+// spends the same inputs and pays the given total fee.
+func (wt *watchedTransaction) assemble(
+	btcChain bitcoin.Chain,
+	walletPublicKey *ecdsa.PublicKey,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	switch wt.actionType {
+	case ActionDepositSweep:
+		return assembleDepositSweepTransaction(
+			btcChain,
+			walletPublicKey,
+			wt.walletMainUtxo,
+			wt.deposits,
+			fee,
+		)
+	case ActionRedemption:
+		return assembleRedemptionTransaction(
+			btcChain,
+			walletPublicKey,
+			wt.walletMainUtxo,
+			wt.redemptionRequests,
+			withRedemptionTotalFee(fee),
+			wt.redemptionTransactionShape,
+		)
+	default:
+		return nil, fmt.Errorf(
+			"cannot replace transaction of action [%v]",
+			wt.actionType,
+		)
+	}
+}
+
+// replacedWith returns a copy of the watched transaction that represents
+// the given replacement transaction paying the given total fee.
+func (wt *watchedTransaction) replacedWith(
+	replacementTx *bitcoin.Transaction,
+	fee int64,
+) *watchedTransaction {
+	replacement := *wt
+	replacement.transaction = replacementTx
+	replacement.fee = fee
+
+	return &replacement
+}
+
+// watchedTransactionEntry is the persisted form of the watchedTransaction.
+type watchedTransactionEntry struct {
+	ActionType WalletActionType `json:"actionType"`
+	// Transaction is the hex representation of the transaction serialized
+	// in the witness format.
+	Transaction                string                            `json:"transaction"`
+	Fee                        int64                             `json:"fee"`
+	MaxFee                     int64                             `json:"maxFee"`
+	BroadcastHeight            uint                              `json:"broadcastHeight"`
+	WalletMainUtxo             *bitcoin.UnspentTransactionOutput `json:"walletMainUtxo,omitempty"`
+	Deposits                   []*Deposit                        `json:"deposits,omitempty"`
+	RedemptionRequests         []*RedemptionRequest              `json:"redemptionRequests,omitempty"`
+	RedemptionTransactionShape RedemptionTransactionShape        `json:"redemptionTransactionShape"`
+}
+
+func (wt *watchedTransaction) toEntry() *watchedTransactionEntry {
+	return &watchedTransactionEntry{
+		ActionType:                 wt.actionType,
+		Transaction:                hex.EncodeToString(wt.transaction.Serialize()),
+		Fee:                        wt.fee,
+		MaxFee:                     wt.maxFee,
+		BroadcastHeight:            wt.broadcastHeight,
+		WalletMainUtxo:             wt.walletMainUtxo,
+		Deposits:                   wt.deposits,
+		RedemptionRequests:         wt.redemptionRequests,
+		RedemptionTransactionShape: wt.redemptionTransactionShape,
+	}
+}
+
+func (wte *watchedTransactionEntry) toWatchedTransaction() (
+	*watchedTransaction,
+	error,
+) {
+	transactionBytes, err := hex.DecodeString(wte.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode transaction: [%v]", err)
+	}
+
+	transaction := new(bitcoin.Transaction)
+	if err := transaction.Deserialize(transactionBytes); err != nil {
+		return nil, fmt.Errorf("cannot deserialize transaction: [%v]", err)
+	}
+
+	return &watchedTransaction{
+		actionType:                 wte.ActionType,
+		transaction:                transaction,
+		fee:                        wte.Fee,
+		maxFee:                     wte.MaxFee,
+		broadcastHeight:            wte.BroadcastHeight,
+		walletMainUtxo:             wte.WalletMainUtxo,
+		deposits:                   wte.Deposits,
+		redemptionRequests:         wte.RedemptionRequests,
+		redemptionTransactionShape: wte.RedemptionTransactionShape,
+	}, nil
+}
+
+// minimumReplacementFee returns the minimum total fee that must be paid
+// by a replacement of the watched transaction.
+func (wt *watchedTransaction) minimumReplacementFee() int64 {
+	minimumIncrease := wt.fee * feeBumpMinimumIncreasePercent / 100
+
+	relayIncrease := wt.transaction.VirtualSize() * feeBumpIncrementalRelayFeeRate
+	if relayIncrease > minimumIncrease {
+		minimumIncrease = relayIncrease
+	}
+
+	return wt.fee + minimumIncrease
+}
+
+// feeBumpWatcher is a component watching wallet transactions broadcast by
+// this node. It detects transactions whose confirmation lags and determines
+// fees of their replacements. Watched transactions are persisted using the
+// work persistence so they survive the client restart.
+type feeBumpWatcher struct {
+	btcChain    bitcoin.Chain
+	persistence persistence.BasicHandle
+
+	transactionsMutex sync.Mutex
+	// transactions holds the latest watched transaction of the given
+	// wallet. The key is the 20-byte wallet public key hash. Only one
+	// transaction per wallet is watched as wallet actions are executed
+	// sequentially and each wallet transaction spends the output of the
+	// previous one.
+	transactions map[[20]byte]*watchedTransaction
+}
+
+// newFeeBumpWatcher creates a new instance of the feeBumpWatcher and loads
+// the watched transactions persisted using the given persistence handle.
+func newFeeBumpWatcher(
+	btcChain bitcoin.Chain,
+	persistence persistence.BasicHandle,
+) (*feeBumpWatcher, error) {
+	fbw := &feeBumpWatcher{
+		btcChain:     btcChain,
+		persistence:  persistence,
+		transactions: make(map[[20]byte]*watchedTransaction),
+	}
+
+	if err := fbw.load(); err != nil {
+		return nil, err
+	}
+
+	return fbw, nil
+}
+
+// load loads the watched transactions persisted using the work persistence.
+// Entries that cannot be loaded are logged and skipped.
+func (fbw *feeBumpWatcher) load() error {
+	descriptors := make([]persistence.DataDescriptor, 0)
+	var readErrors []error
+
+	descriptorsChan, errorsChan := fbw.persistence.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels. The reason
+	// for using two goroutines at the same time - one for descriptors and
+	// one for errors - is that channels do not have to be buffered, and we
+	// do not know in what order the information is written to channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the fee bump watcher directory.
+			if descriptor.Directory() == feeBumpWatcherDirectory {
+				descriptors = append(descriptors, descriptor)
+			}
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			readErrors = append(readErrors, err)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if len(readErrors) > 0 {
+		return fmt.Errorf(
+			"could not read watched transactions: %v",
+			readErrors,
+		)
+	}
+
+	fbw.transactionsMutex.Lock()
+	defer fbw.transactionsMutex.Unlock()
+
+	for _, descriptor := range descriptors {
+		walletPublicKeyHash, transaction, err := unmarshalWatchedTransaction(
+			descriptor,
+		)
+		if err != nil {
+			logger.Errorf("could not load watched transaction: [%v]", err)
+			continue
+		}
+
+		fbw.transactions[walletPublicKeyHash] = transaction
+	}
+
+	return nil
+}
+
+// unmarshalWatchedTransaction reads and unmarshals the watched transaction
+// described by the given descriptor. It returns the public key hash of the
+// wallet the transaction belongs to.
+func unmarshalWatchedTransaction(
+	descriptor persistence.DataDescriptor,
+) ([20]byte, *watchedTransaction, error) {
+	walletPublicKeyHashBytes, err := hex.DecodeString(descriptor.Name())
+	if err != nil || len(walletPublicKeyHashBytes) != 20 {
+		return [20]byte{}, nil, fmt.Errorf(
+			"unexpected file [%s] in directory [%s]",
+			descriptor.Name(),
+			descriptor.Directory(),
+		)
+	}
+
+	var walletPublicKeyHash [20]byte
+	copy(walletPublicKeyHash[:], walletPublicKeyHashBytes)
+
+	content, err := descriptor.Content()
+	if err != nil {
+		return [20]byte{}, nil, fmt.Errorf(
+			"could not read content of file [%s] in directory [%s]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	entry := &watchedTransactionEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return [20]byte{}, nil, fmt.Errorf(
+			"could not unmarshal file [%s] in directory [%s]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	transaction, err := entry.toWatchedTransaction()
+	if err != nil {
+		return [20]byte{}, nil, fmt.Errorf(
+			"invalid file [%s] in directory [%s]: [%v]",
+			descriptor.Name(),
+			descriptor.Directory(),
+			err,
+		)
+	}
+
+	return walletPublicKeyHash, transaction, nil
+}
+
+// watch starts watching the given transaction of the given wallet. Any
+// transaction of the wallet watched so far is no longer watched. A persistence
+// failure is logged and does not prevent the transaction from being watched.
+func (fbw *feeBumpWatcher) watch(
+	walletPublicKeyHash [20]byte,
+	transaction *watchedTransaction,
+) error {
+	broadcastHeight, err := fbw.btcChain.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("cannot get latest Bitcoin block height: [%v]", err)
+	}
+
+	transaction.broadcastHeight = broadcastHeight
+
+	fbw.transactionsMutex.Lock()
+	defer fbw.transactionsMutex.Unlock()
+
+	fbw.transactions[walletPublicKeyHash] = transaction
+
+	entryBytes, err := json.Marshal(transaction.toEntry())
+	if err != nil {
+		logger.Errorf(
+			"could not marshal watched transaction of wallet [0x%x]: [%v]",
+			walletPublicKeyHash,
+			err,
+		)
+	} else if err := fbw.persistence.Save(
+		entryBytes,
+		feeBumpWatcherDirectory,
+		hex.EncodeToString(walletPublicKeyHash[:]),
+	); err != nil {
+		logger.Errorf(
+			"could not persist watched transaction of wallet [0x%x]: [%v]",
+			walletPublicKeyHash,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// get returns the transaction of the given wallet that is currently watched.
+// The second return value is false if no transaction is watched.
+func (fbw *feeBumpWatcher) get(
+	walletPublicKeyHash [20]byte,
+) (*watchedTransaction, bool) {
+	fbw.transactionsMutex.Lock()
+	defer fbw.transactionsMutex.Unlock()
+
+	transaction, ok := fbw.transactions[walletPublicKeyHash]
+	return transaction, ok
+}
+
+// unwatch stops watching the given transaction of the given wallet.
+func (fbw *feeBumpWatcher) unwatch(
+	walletPublicKeyHash [20]byte,
+	transactionHash bitcoin.Hash,
+) {
+	fbw.transactionsMutex.Lock()
+	defer fbw.transactionsMutex.Unlock()
+
+	transaction, ok := fbw.transactions[walletPublicKeyHash]
+	if !ok || transaction.transaction.Hash() != transactionHash {
+		return
+	}
+
+	delete(fbw.transactions, walletPublicKeyHash)
+
+	if err := fbw.persistence.Delete(
+		feeBumpWatcherDirectory,
+		hex.EncodeToString(walletPublicKeyHash[:]),
+	); err != nil {
+		logger.Errorf(
+			"could not delete watched transaction of wallet [0x%x]: [%v]",
+			walletPublicKeyHash,
+			err,
+		)
+	}
+}
+
+// isStuck determines whether the given watched transaction of the given
+// wallet is stuck, i.e. it is still unconfirmed after
+// feeBumpConfirmationLagBlocks since its broadcast. Confirmed transactions
+// are no longer watched.
+func (fbw *feeBumpWatcher) isStuck(
+	walletPublicKeyHash [20]byte,
+	transaction *watchedTransaction,
+) (bool, error) {
+	transactionHash := transaction.transaction.Hash()
+
+	confirmations, err := fbw.btcChain.GetTransactionConfirmations(
+		transactionHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get confirmations of transaction [%s]: [%v]",
+			transactionHash.Hex(bitcoin.ReversedByteOrder),
+			err,
+		)
+	}
+
+	if confirmations > 0 {
+		fbw.unwatch(walletPublicKeyHash, transactionHash)
+		return false, nil
+	}
+
+	latestHeight, err := fbw.btcChain.GetLatestBlockHeight()
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get latest Bitcoin block height: [%v]",
+			err,
+		)
+	}
+
+	return latestHeight >= transaction.broadcastHeight+feeBumpConfirmationLagBlocks, nil
+}
+
+// proposeFeeBump returns a fee bump proposal for the stuck transaction of
+// the given wallet. The proposed fee is the fee estimated for the next block
+// but not less than the minimum replacement fee. The proposed fee is capped
+// by the Bridge limit. The second return value is false if the wallet has no
+// stuck transaction.
+func (fbw *feeBumpWatcher) proposeFeeBump(
+	walletPublicKeyHash [20]byte,
+) (*FeeBumpProposal, bool, error) {
+	transaction, ok := fbw.get(walletPublicKeyHash)
+	if !ok {
+		return nil, false, nil
+	}
+
+	stuck, err := fbw.isStuck(walletPublicKeyHash, transaction)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !stuck {
+		return nil, false, nil
+	}
+
+	minimumFee := transaction.minimumReplacementFee()
+
+	estimatedFee, err := bitcoin.NewTransactionFeeEstimator(fbw.btcChain).EstimateFee(
+		transaction.transaction.VirtualSize(),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot estimate fee: [%v]", err)
+	}
+
+	fee := minimumFee
+	if estimatedFee > fee {
+		fee = estimatedFee
+	}
+
+	if fee > transaction.maxFee {
+		fee = transaction.maxFee
+	}
+
+	if fee < minimumFee {
+		return nil, false, fmt.Errorf(
+			"minimum replacement fee [%v] of transaction [%s] "+
+				"exceeds the maximum fee [%v]",
+			minimumFee,
+			transaction.transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			transaction.maxFee,
+		)
+	}
+
+	return &FeeBumpProposal{
+		TransactionHash: transaction.transaction.Hash(),
+		Fee:             big.NewInt(fee),
+	}, true, nil
+}
+
+// feeBumpProposalGenerator is a CoordinationProposalGenerator that proposes
+// fee bumps of stuck wallet transactions. It is the only generator handling
+// the fee bump action. Generation of all other actions from the checklist is
+// delegated to the wrapped generator if the wallet has no stuck transactions.
+type feeBumpProposalGenerator struct {
+	watcher *feeBumpWatcher
+	next    CoordinationProposalGenerator
+}
+
+func newFeeBumpProposalGenerator(
+	watcher *feeBumpWatcher,
+	next CoordinationProposalGenerator,
+) *feeBumpProposalGenerator {
+	return &feeBumpProposalGenerator{
+		watcher: watcher,
+		next:    next,
+	}
+}
+
+func (fbpg *feeBumpProposalGenerator) Generate(
+	request *CoordinationProposalRequest,
+) (CoordinationProposal, error) {
+	feeBumpChecked := false
+	actionsChecklist := make([]WalletActionType, 0, len(request.ActionsChecklist))
+	for _, action := range request.ActionsChecklist {
+		if action == ActionFeeBump {
+			feeBumpChecked = true
+			continue
+		}
+
+		actionsChecklist = append(actionsChecklist, action)
+	}
+
+	if feeBumpChecked {
+		proposal, ok, err := fbpg.watcher.proposeFeeBump(
+			request.WalletPublicKeyHash,
+		)
+		if err != nil {
+			// The fee bump is a best-effort procedure and must not prevent
+			// the wallet from generating other proposals.
+			logger.Warnf(
+				"cannot determine fee bump proposal for wallet [0x%x]: [%v]",
+				request.WalletPublicKeyHash,
+				err,
+			)
+		} else if ok {
+			return proposal, nil
+		}
+	}
+
+	nextRequest := *request
+	nextRequest.ActionsChecklist = actionsChecklist
+
+	return fbpg.next.Generate(&nextRequest)
+}
+
+// feeBumpAction is a fee bump walletAction.
+type feeBumpAction struct {
+	logger   *zap.SugaredLogger
+	btcChain bitcoin.Chain
+
+	executingWallet     wallet
+	transactionExecutor *walletTransactionExecutor
+	feeBumpWatcher      *feeBumpWatcher
+
+	proposal                     *FeeBumpProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiryBlock          uint64
+
+	signingTimeoutSafetyMarginBlocks uint64
+	broadcastTimeout                 time.Duration
+	broadcastCheckDelay              time.Duration
+}
+
+func newFeeBumpAction(
+	logger *zap.SugaredLogger,
+	btcChain bitcoin.Chain,
+	executingWallet wallet,
+	signingExecutor walletSigningExecutor,
+	feeBumpWatcher *feeBumpWatcher,
+	proposal *FeeBumpProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiryBlock uint64,
+	waitForBlockFn waitForBlockFn,
+) *feeBumpAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		executingWallet,
+		signingExecutor,
+		waitForBlockFn,
+	)
+
+	return &feeBumpAction{
+		logger:                           logger,
+		btcChain:                         btcChain,
+		executingWallet:                  executingWallet,
+		transactionExecutor:              transactionExecutor,
+		feeBumpWatcher:                   feeBumpWatcher,
+		proposal:                         proposal,
+		proposalProcessingStartBlock:     proposalProcessingStartBlock,
+		proposalExpiryBlock:              proposalExpiryBlock,
+		signingTimeoutSafetyMarginBlocks: feeBumpSigningTimeoutSafetyMarginBlocks,
+		broadcastTimeout:                 feeBumpBroadcastTimeout,
+		broadcastCheckDelay:              feeBumpBroadcastCheckDelay,
+	}
+}
+
+func (fba *feeBumpAction) execute() error {
+	validateProposalLogger := fba.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(fba.wallet().publicKey)
+
+	replacedTransaction, err := fba.validateProposal(
+		validateProposalLogger,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	fee := fba.proposal.Fee.Int64()
+
+	unsignedReplacementTx, err := replacedTransaction.assemble(
+		fba.btcChain,
+		fba.wallet().publicKey,
+		fee,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while assembling replacement transaction: [%v]",
+			err,
+		)
+	}
+
+	signTxLogger := fba.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	// Just in case. This should never happen.
+	if fba.proposalExpiryBlock < fba.signingTimeoutSafetyMarginBlocks {
+		return fmt.Errorf("invalid proposal expiry block")
+	}
+
+	replacementTx, err := fba.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedReplacementTx,
+		fba.proposalProcessingStartBlock,
+		fba.proposalExpiryBlock-fba.signingTimeoutSafetyMarginBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	broadcastTxLogger := fba.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String(
+			"replacementTxHash",
+			replacementTx.Hash().Hex(bitcoin.ReversedByteOrder),
+		),
+	)
+
+	err = fba.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		replacementTx,
+		fba.broadcastTimeout,
+		fba.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	err = fba.feeBumpWatcher.watch(
+		walletPublicKeyHash,
+		replacedTransaction.replacedWith(replacementTx, fee),
+	)
+	if err != nil {
+		// The replacement transaction is already broadcast so do not
+		// fail the action.
+		fba.logger.Warnf(
+			"cannot watch replacement transaction: [%v]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// validateProposal checks the fee bump proposal against the transaction
+// watched by this node for the given wallet. It returns the watched
+// transaction being replaced.
+func (fba *feeBumpAction) validateProposal(
+	validateProposalLogger *zap.SugaredLogger,
+	walletPublicKeyHash [20]byte,
+) (*watchedTransaction, error) {
+	validateProposalLogger.Infof("looking for the replaced transaction")
+
+	replacedTransaction, ok := fba.feeBumpWatcher.get(walletPublicKeyHash)
+	if !ok ||
+		replacedTransaction.transaction.Hash() != fba.proposal.TransactionHash {
+		return nil, fmt.Errorf(
+			"transaction [%s] is not watched by this node",
+			fba.proposal.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	validateProposalLogger.Infof("checking confirmations of the replaced transaction")
+
+	confirmations, err := fba.btcChain.GetTransactionConfirmations(
+		fba.proposal.TransactionHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get confirmations of the replaced transaction: [%v]",
+			err,
+		)
+	}
+
+	if confirmations > 0 {
+		fba.feeBumpWatcher.unwatch(
+			walletPublicKeyHash,
+			fba.proposal.TransactionHash,
+		)
+
+		return nil, fmt.Errorf("replaced transaction is already confirmed")
+	}
+
+	validateProposalLogger.Infof("checking the replacement fee")
+
+	fee := fba.proposal.Fee.Int64()
+
+	if minimumFee := replacedTransaction.minimumReplacementFee(); fee < minimumFee {
+		return nil, fmt.Errorf(
+			"proposed fee [%v] is lower than the minimum replacement fee [%v]",
+			fee,
+			minimumFee,
+		)
+	}
+
+	if fee > replacedTransaction.maxFee {
+		return nil, fmt.Errorf(
+			"proposed fee [%v] exceeds the maximum fee [%v]",
+			fee,
+			replacedTransaction.maxFee,
+		)
+	}
+
+	validateProposalLogger.Infof("fee bump proposal is valid")
+
+	return replacedTransaction, nil
+}
+
+func (fba *feeBumpAction) wallet() wallet {
+	return fba.executingWallet
+}
+
+func (fba *feeBumpAction) actionType() WalletActionType {
+	return ActionFeeBump
+}
+
+func (fba *feeBumpAction) broadcastedTransaction() (bitcoin.Hash, bool) {
+	return fba.transactionExecutor.broadcastedTransaction()
+}
@@ -0,0 +1,583 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestFeeBumpWatcher_ProposeFeeBump(t *testing.T) {
+	transaction := newFeeBumpTestTransaction(0)
+	virtualSize := transaction.VirtualSize()
+
+	var tests = map[string]struct {
+		watched           bool
+		confirmed         bool
+		blocksSinceWatch  uint
+		satPerVByteFee    int64
+		maxFee            int64
+		expectedProposal  *FeeBumpProposal
+		expectedOk        bool
+		expectedErr       error
+		expectedUnwatched bool
+	}{
+		"transaction not watched": {
+			watched:    false,
+			expectedOk: false,
+		},
+		"transaction confirmed": {
+			watched:           true,
+			confirmed:         true,
+			blocksSinceWatch:  feeBumpConfirmationLagBlocks,
+			expectedOk:        false,
+			expectedUnwatched: true,
+		},
+		"transaction confirmation does not lag": {
+			watched:          true,
+			blocksSinceWatch: feeBumpConfirmationLagBlocks - 1,
+			expectedOk:       false,
+		},
+		"transaction stuck - minimum replacement fee": {
+			watched:          true,
+			blocksSinceWatch: feeBumpConfirmationLagBlocks,
+			satPerVByteFee:   1,
+			maxFee:           1000000,
+			expectedProposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(15000),
+			},
+			expectedOk: true,
+		},
+		"transaction stuck - estimated fee": {
+			watched:          true,
+			blocksSinceWatch: feeBumpConfirmationLagBlocks,
+			satPerVByteFee:   1000,
+			maxFee:           1000000,
+			expectedProposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(1000 * virtualSize),
+			},
+			expectedOk: true,
+		},
+		"transaction stuck - fee capped by the maximum fee": {
+			watched:          true,
+			blocksSinceWatch: feeBumpConfirmationLagBlocks,
+			satPerVByteFee:   1000,
+			maxFee:           20000,
+			expectedProposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(20000),
+			},
+			expectedOk: true,
+		},
+		"transaction stuck - minimum replacement fee exceeds the maximum fee": {
+			watched:          true,
+			blocksSinceWatch: feeBumpConfirmationLagBlocks,
+			satPerVByteFee:   1,
+			maxFee:           12000,
+			expectedOk:       false,
+			expectedErr: fmt.Errorf(
+				"minimum replacement fee [15000] of transaction [%s] "+
+					"exceeds the maximum fee [12000]",
+				transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			),
+		},
+	}
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+			bitcoinChain.setLatestBlockHeight(100)
+			bitcoinChain.setSatPerVByteFee(test.satPerVByteFee)
+
+			if test.confirmed {
+				err := bitcoinChain.BroadcastTransaction(transaction)
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				bitcoinChain.addMempoolTransaction(transaction)
+			}
+
+			watcher := newTestFeeBumpWatcher(t, bitcoinChain)
+
+			if test.watched {
+				err := watcher.watch(
+					walletPublicKeyHash,
+					&watchedTransaction{
+						actionType:  ActionDepositSweep,
+						transaction: transaction,
+						fee:         10000,
+						maxFee:      test.maxFee,
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			bitcoinChain.setLatestBlockHeight(100 + test.blocksSinceWatch)
+
+			proposal, ok, err := watcher.proposeFeeBump(walletPublicKeyHash)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			testutils.AssertBoolsEqual(t, "ok", test.expectedOk, ok)
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+
+			if test.watched {
+				_, watched := watcher.get(walletPublicKeyHash)
+				testutils.AssertBoolsEqual(
+					t,
+					"watched",
+					!test.expectedUnwatched,
+					watched,
+				)
+			}
+		})
+	}
+}
+
+func TestFeeBumpWatcher_Watch_ReplacesPreviousTransaction(t *testing.T) {
+	bitcoinChain := newLocalBitcoinChain()
+	bitcoinChain.setLatestBlockHeight(100)
+
+	watcher := newTestFeeBumpWatcher(t, bitcoinChain)
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	previousTransaction := newFeeBumpTestTransaction(0)
+	err := watcher.watch(
+		walletPublicKeyHash,
+		&watchedTransaction{transaction: previousTransaction},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bitcoinChain.setLatestBlockHeight(105)
+
+	currentTransaction := newFeeBumpTestTransaction(1)
+	err = watcher.watch(
+		walletPublicKeyHash,
+		&watchedTransaction{transaction: currentTransaction},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unwatching the previous transaction must not affect the current one.
+	watcher.unwatch(walletPublicKeyHash, previousTransaction.Hash())
+
+	watched, ok := watcher.get(walletPublicKeyHash)
+	if !ok {
+		t.Fatal("transaction is supposed to be watched")
+	}
+
+	if currentTransaction.Hash() != watched.transaction.Hash() {
+		t.Errorf("unexpected watched transaction")
+	}
+	testutils.AssertUintsEqual(
+		t,
+		"broadcast height",
+		105,
+		uint64(watched.broadcastHeight),
+	)
+}
+
+func TestFeeBumpWatcher_Persistence(t *testing.T) {
+	bitcoinChain := newLocalBitcoinChain()
+	bitcoinChain.setLatestBlockHeight(100)
+
+	persistenceHandle := &mockPersistenceHandle{}
+
+	watcher, err := newFeeBumpWatcher(bitcoinChain, persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := [20]byte{0x01}
+	transaction := newFeeBumpTestTransaction(0)
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0xbb},
+			OutputIndex:     1,
+		},
+		Value: 50000,
+	}
+	deposits := []*Deposit{
+		{
+			Utxo: &bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xaa},
+					OutputIndex:     0,
+				},
+				Value: 100000,
+			},
+			Depositor:           "0x1234",
+			BlindingFactor:      [8]byte{0x02},
+			WalletPublicKeyHash: walletPublicKeyHash,
+			RefundPublicKeyHash: [20]byte{0x03},
+			RefundLocktime:      [4]byte{0x04},
+		},
+	}
+
+	err = watcher.watch(
+		walletPublicKeyHash,
+		&watchedTransaction{
+			actionType:     ActionDepositSweep,
+			transaction:    transaction,
+			fee:            10000,
+			maxFee:         20000,
+			walletMainUtxo: walletMainUtxo,
+			deposits:       deposits,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new watcher using the same persistence simulates the client restart.
+	restartedWatcher, err := newFeeBumpWatcher(bitcoinChain, persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watched, ok := restartedWatcher.get(walletPublicKeyHash)
+	if !ok {
+		t.Fatal("transaction is supposed to be watched")
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"action type",
+		ActionDepositSweep.String(),
+		watched.actionType.String(),
+	)
+	if transaction.Hash() != watched.transaction.Hash() {
+		t.Errorf("unexpected watched transaction")
+	}
+	testutils.AssertIntsEqual(t, "fee", 10000, int(watched.fee))
+	testutils.AssertIntsEqual(t, "max fee", 20000, int(watched.maxFee))
+	testutils.AssertUintsEqual(
+		t,
+		"broadcast height",
+		100,
+		uint64(watched.broadcastHeight),
+	)
+	if !reflect.DeepEqual(walletMainUtxo, watched.walletMainUtxo) {
+		t.Errorf("unexpected wallet main UTXO")
+	}
+	if !reflect.DeepEqual(deposits, watched.deposits) {
+		t.Errorf("unexpected deposits")
+	}
+
+	restartedWatcher.unwatch(walletPublicKeyHash, transaction.Hash())
+
+	testutils.AssertIntsEqual(
+		t,
+		"persisted transactions",
+		0,
+		len(persistenceHandle.saved),
+	)
+}
+
+func TestFeeBumpProposalGenerator_Generate(t *testing.T) {
+	transaction := newFeeBumpTestTransaction(0)
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	nextProposal := &NoopProposal{}
+
+	var tests = map[string]struct {
+		blocksSinceWatch      uint
+		maxFee                int64
+		actionsChecklist      []WalletActionType
+		expectedProposal      CoordinationProposal
+		expectedNextChecklist []WalletActionType
+		expectedNextNotCalled bool
+	}{
+		"no stuck transaction": {
+			blocksSinceWatch:      0,
+			maxFee:                1000000,
+			actionsChecklist:      []WalletActionType{ActionRedemption, ActionFeeBump},
+			expectedProposal:      nextProposal,
+			expectedNextChecklist: []WalletActionType{ActionRedemption},
+		},
+		"stuck transaction": {
+			blocksSinceWatch: feeBumpConfirmationLagBlocks,
+			maxFee:           1000000,
+			actionsChecklist: []WalletActionType{ActionRedemption, ActionFeeBump},
+			expectedProposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(15000),
+			},
+			expectedNextNotCalled: true,
+		},
+		"stuck transaction that cannot be bumped": {
+			blocksSinceWatch:      feeBumpConfirmationLagBlocks,
+			maxFee:                12000,
+			actionsChecklist:      []WalletActionType{ActionRedemption, ActionFeeBump},
+			expectedProposal:      nextProposal,
+			expectedNextChecklist: []WalletActionType{ActionRedemption},
+		},
+		"stuck transaction but fee bump not in the checklist": {
+			blocksSinceWatch:      feeBumpConfirmationLagBlocks,
+			maxFee:                1000000,
+			actionsChecklist:      []WalletActionType{ActionRedemption},
+			expectedProposal:      nextProposal,
+			expectedNextChecklist: []WalletActionType{ActionRedemption},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+			bitcoinChain.setLatestBlockHeight(100)
+			bitcoinChain.setSatPerVByteFee(1)
+			bitcoinChain.addMempoolTransaction(transaction)
+
+			watcher := newTestFeeBumpWatcher(t, bitcoinChain)
+
+			err := watcher.watch(
+				walletPublicKeyHash,
+				&watchedTransaction{
+					actionType:  ActionRedemption,
+					transaction: transaction,
+					fee:         10000,
+					maxFee:      test.maxFee,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bitcoinChain.setLatestBlockHeight(100 + test.blocksSinceWatch)
+
+			var nextChecklist []WalletActionType
+			nextCalled := false
+			next := newMockCoordinationProposalGenerator(
+				func(
+					_ [20]byte,
+					actionsChecklist []WalletActionType,
+				) (CoordinationProposal, error) {
+					nextCalled = true
+					nextChecklist = actionsChecklist
+					return nextProposal, nil
+				},
+			)
+
+			generator := newFeeBumpProposalGenerator(watcher, next)
+
+			proposal, err := generator.Generate(
+				&CoordinationProposalRequest{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					ActionsChecklist:    test.actionsChecklist,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"next generator called",
+				!test.expectedNextNotCalled,
+				nextCalled,
+			)
+
+			if nextCalled &&
+				!reflect.DeepEqual(test.expectedNextChecklist, nextChecklist) {
+				t.Errorf(
+					"unexpected next generator checklist\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedNextChecklist,
+					nextChecklist,
+				)
+			}
+		})
+	}
+}
+
+func TestFeeBumpAction_ValidateProposal(t *testing.T) {
+	transaction := newFeeBumpTestTransaction(0)
+
+	var tests = map[string]struct {
+		watched     bool
+		confirmed   bool
+		proposal    *FeeBumpProposal
+		expectedErr error
+	}{
+		"valid proposal": {
+			watched: true,
+			proposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(15000),
+			},
+		},
+		"transaction not watched": {
+			watched: false,
+			proposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(15000),
+			},
+			expectedErr: fmt.Errorf(
+				"transaction [%s] is not watched by this node",
+				transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			),
+		},
+		"transaction confirmed": {
+			watched:   true,
+			confirmed: true,
+			proposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(15000),
+			},
+			expectedErr: fmt.Errorf("replaced transaction is already confirmed"),
+		},
+		"fee lower than the minimum replacement fee": {
+			watched: true,
+			proposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(14999),
+			},
+			expectedErr: fmt.Errorf(
+				"proposed fee [14999] is lower than the minimum " +
+					"replacement fee [15000]",
+			),
+		},
+		"fee exceeding the maximum fee": {
+			watched: true,
+			proposal: &FeeBumpProposal{
+				TransactionHash: transaction.Hash(),
+				Fee:             big.NewInt(20001),
+			},
+			expectedErr: fmt.Errorf(
+				"proposed fee [20001] exceeds the maximum fee [20000]",
+			),
+		},
+	}
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+
+			if test.confirmed {
+				err := bitcoinChain.BroadcastTransaction(transaction)
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				bitcoinChain.addMempoolTransaction(transaction)
+			}
+
+			watcher := newTestFeeBumpWatcher(t, bitcoinChain)
+
+			if test.watched {
+				err := watcher.watch(
+					walletPublicKeyHash,
+					&watchedTransaction{
+						actionType:  ActionDepositSweep,
+						transaction: transaction,
+						fee:         10000,
+						maxFee:      20000,
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			action := newFeeBumpAction(
+				logger.With(),
+				bitcoinChain,
+				wallet{},
+				newMockWalletSigningExecutor(),
+				watcher,
+				test.proposal,
+				0,
+				feeBumpProposalValidityBlocks,
+				nil,
+			)
+
+			_, err := action.validateProposal(
+				logger.With(),
+				walletPublicKeyHash,
+			)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func newTestFeeBumpWatcher(
+	t *testing.T,
+	bitcoinChain bitcoin.Chain,
+) *feeBumpWatcher {
+	watcher, err := newFeeBumpWatcher(bitcoinChain, &mockPersistenceHandle{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return watcher
+}
+
+// newFeeBumpTestTransaction returns a simple transaction whose virtual size
+// is low enough for the BIP-125 relay fee not to exceed the minimum fee
+// increase in the test scenarios. The output index determines the spent
+// outpoint so different indexes produce different transactions.
+func newFeeBumpTestTransaction(outputIndex uint32) *bitcoin.Transaction {
+	return &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xaa},
+					OutputIndex:     outputIndex,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           90000,
+				PublicKeyScript: []byte{0x00, 0x14, 0x01},
+			},
+		},
+	}
+}
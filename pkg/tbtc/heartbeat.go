@@ -2,11 +2,15 @@ package tbtc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
+
 	"github.com/ipfs/go-log/v2"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
-	"math/big"
 )
 
 const (
@@ -23,6 +27,11 @@ const (
 	// another action has been already requested by the coordinator.
 	// The value of 25 blocks is roughly 5 minutes, assuming 12 seconds per block.
 	heartbeatRequestTimeoutSafetyMarginBlocks = 25
+	// heartbeatConsecutiveFailuresThreshold determines the number of
+	// consecutive heartbeat failures of the given wallet after which the
+	// wallet's signing group members claim inactivity of the members who
+	// did not participate in the last failed heartbeat.
+	heartbeatConsecutiveFailuresThreshold = 3
 )
 
 type HeartbeatProposal struct {
@@ -47,14 +56,72 @@ type heartbeatSigningExecutor interface {
 	) (*tecdsa.Signature, uint64, error)
 }
 
+// heartbeatInactivityClaimExecutor is an interface meant to decouple the
+// specific implementation of the inactivity claim executor from the heartbeat
+// action.
+type heartbeatInactivityClaimExecutor interface {
+	claimInactivity(
+		ctx context.Context,
+		inactiveMembersIndexes []group.MemberIndex,
+		heartbeatFailed bool,
+		startBlock uint64,
+		timeoutBlock uint64,
+	) error
+}
+
+// heartbeatFailureCounter holds the number of consecutive heartbeat failures
+// of wallets controlled by this node. The key is the 20-byte wallet public
+// key hash.
+type heartbeatFailureCounter struct {
+	mutex    sync.Mutex
+	counters map[[20]byte]uint
+}
+
+func newHeartbeatFailureCounter() *heartbeatFailureCounter {
+	return &heartbeatFailureCounter{
+		counters: make(map[[20]byte]uint),
+	}
+}
+
+// increment increments the consecutive failures count of the given wallet
+// and returns the new value.
+func (hfc *heartbeatFailureCounter) increment(
+	walletPublicKeyHash [20]byte,
+) uint {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	hfc.counters[walletPublicKeyHash]++
+
+	return hfc.counters[walletPublicKeyHash]
+}
+
+// reset resets the consecutive failures count of the given wallet.
+func (hfc *heartbeatFailureCounter) reset(walletPublicKeyHash [20]byte) {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	delete(hfc.counters, walletPublicKeyHash)
+}
+
+// get returns the consecutive failures count of the given wallet.
+func (hfc *heartbeatFailureCounter) get(walletPublicKeyHash [20]byte) uint {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	return hfc.counters[walletPublicKeyHash]
+}
+
 // heartbeatAction is a walletAction implementation handling heartbeat requests
 // from the wallet coordinator.
 type heartbeatAction struct {
 	logger log.StandardLogger
 	chain  Chain
 
-	executingWallet wallet
-	signingExecutor heartbeatSigningExecutor
+	executingWallet         wallet
+	signingExecutor         heartbeatSigningExecutor
+	inactivityClaimExecutor heartbeatInactivityClaimExecutor
+	failureCounter          *heartbeatFailureCounter
 
 	proposal    *HeartbeatProposal
 	startBlock  uint64
@@ -68,20 +135,24 @@ func newHeartbeatAction(
 	chain Chain,
 	executingWallet wallet,
 	signingExecutor heartbeatSigningExecutor,
+	inactivityClaimExecutor heartbeatInactivityClaimExecutor,
+	failureCounter *heartbeatFailureCounter,
 	proposal *HeartbeatProposal,
 	startBlock uint64,
 	expiryBlock uint64,
 	waitForBlockFn waitForBlockFn,
 ) *heartbeatAction {
 	return &heartbeatAction{
-		logger:          logger,
-		chain:           chain,
-		executingWallet: executingWallet,
-		signingExecutor: signingExecutor,
-		proposal:        proposal,
-		startBlock:      startBlock,
-		expiryBlock:     expiryBlock,
-		waitForBlockFn:  waitForBlockFn,
+		logger:                  logger,
+		chain:                   chain,
+		executingWallet:         executingWallet,
+		signingExecutor:         signingExecutor,
+		inactivityClaimExecutor: inactivityClaimExecutor,
+		failureCounter:          failureCounter,
+		proposal:                proposal,
+		startBlock:              startBlock,
+		expiryBlock:             expiryBlock,
+		waitForBlockFn:          waitForBlockFn,
 	}
 }
 
@@ -113,9 +184,29 @@ func (ha *heartbeatAction) execute() error {
 
 	signature, _, err := ha.signingExecutor.sign(heartbeatCtx, messageToSign, ha.startBlock)
 	if err != nil {
+		failuresCount := ha.failureCounter.increment(walletPublicKeyHash)
+
+		ha.logger.Warnf(
+			"heartbeat failed [%v] consecutive time(s)",
+			failuresCount,
+		)
+
+		if failuresCount >= heartbeatConsecutiveFailuresThreshold {
+			if claimErr := ha.claimInactivity(err); claimErr != nil {
+				ha.logger.Errorf(
+					"cannot claim inactivity of signing group members: [%v]",
+					claimErr,
+				)
+			} else {
+				ha.failureCounter.reset(walletPublicKeyHash)
+			}
+		}
+
 		return fmt.Errorf("cannot sign heartbeat message: [%v]", err)
 	}
 
+	ha.failureCounter.reset(walletPublicKeyHash)
+
 	logger.Infof(
 		"generated signature [%s] for heartbeat message [0x%x]",
 		signature,
@@ -125,6 +216,51 @@ func (ha *heartbeatAction) execute() error {
 	return nil
 }
 
+// claimInactivity claims inactivity of signing group members who did not
+// participate in the failed heartbeat signing. The claim is executed right
+// after the signing timeout and must complete before the heartbeat action
+// expires.
+func (ha *heartbeatAction) claimInactivity(signingErr error) error {
+	var signingFailedErr *signingFailedError
+	if !errors.As(signingErr, &signingFailedErr) {
+		return fmt.Errorf(
+			"signing error does not determine inactive members: [%v]",
+			signingErr,
+		)
+	}
+
+	if len(signingFailedErr.inactiveMembersIndexes) == 0 {
+		return fmt.Errorf("all members were active during the signing")
+	}
+
+	startBlock := signingFailedErr.timeoutBlock + inactivityClaimDelayBlocks
+	timeoutBlock := startBlock + inactivityClaimMaximumBlocks
+
+	if timeoutBlock > ha.expiryBlock-heartbeatRequestTimeoutSafetyMarginBlocks {
+		return fmt.Errorf(
+			"inactivity claim timeout block [%v] exceeds the heartbeat "+
+				"action expiry block [%v] minus the safety margin",
+			timeoutBlock,
+			ha.expiryBlock,
+		)
+	}
+
+	ha.logger.Infof(
+		"claiming inactivity of members [%v] between blocks [%v] and [%v]",
+		signingFailedErr.inactiveMembersIndexes,
+		startBlock,
+		timeoutBlock,
+	)
+
+	return ha.inactivityClaimExecutor.claimInactivity(
+		context.Background(),
+		signingFailedErr.inactiveMembersIndexes,
+		true,
+		startBlock,
+		timeoutBlock,
+	)
+}
+
 func (ha *heartbeatAction) wallet() wallet {
 	return ha.executingWallet
 }
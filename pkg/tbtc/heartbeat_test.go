@@ -5,8 +5,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"math/big"
+	"reflect"
 	"testing"
 )
 
@@ -46,6 +49,8 @@ func TestHeartbeatAction_HappyPath(t *testing.T) {
 			publicKey: unmarshalPublicKey(walletPublicKeyHex),
 		},
 		mockExecutor,
+		&mockHeartbeatInactivityClaimExecutor{},
+		newHeartbeatFailureCounter(),
 		proposal,
 		startBlock,
 		expiryBlock,
@@ -105,6 +110,8 @@ func TestHeartbeatAction_SigningError(t *testing.T) {
 			publicKey: unmarshalPublicKey(walletPublicKeyHex),
 		},
 		mockExecutor,
+		&mockHeartbeatInactivityClaimExecutor{},
+		newHeartbeatFailureCounter(),
 		proposal,
 		startBlock,
 		expiryBlock,
@@ -125,8 +132,195 @@ func TestHeartbeatAction_SigningError(t *testing.T) {
 	)
 }
 
+func TestHeartbeatAction_ConsecutiveFailures(t *testing.T) {
+	walletPublicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executingWallet := wallet{
+		publicKey: unmarshalPublicKey(walletPublicKeyHex),
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	startBlock := uint64(10)
+	expiryBlock := startBlock + heartbeatProposalValidityBlocks
+	signingTimeoutBlock := startBlock + 205
+
+	proposal := &HeartbeatProposal{
+		Message: [16]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		},
+	}
+
+	hostChain := Connect()
+	hostChain.setHeartbeatProposalValidationResult(proposal, true)
+
+	signingExecutor := &mockHeartbeatSigningExecutor{}
+	signingExecutor.shouldFail = true
+	signingExecutor.signingErr = &signingFailedError{
+		inactiveMembersIndexes: []group.MemberIndex{2, 5},
+		timeoutBlock:           signingTimeoutBlock,
+	}
+
+	inactivityClaimExecutor := &mockHeartbeatInactivityClaimExecutor{}
+	failureCounter := newHeartbeatFailureCounter()
+
+	executeAction := func() error {
+		return newHeartbeatAction(
+			logger,
+			hostChain,
+			executingWallet,
+			signingExecutor,
+			inactivityClaimExecutor,
+			failureCounter,
+			proposal,
+			startBlock,
+			expiryBlock,
+			func(ctx context.Context, blockHeight uint64) error {
+				return nil
+			},
+		).execute()
+	}
+
+	for i := 1; i < heartbeatConsecutiveFailuresThreshold; i++ {
+		if err := executeAction(); err == nil {
+			t.Fatal("expected error to be returned")
+		}
+
+		testutils.AssertIntsEqual(
+			t,
+			"inactivity claims count",
+			0,
+			inactivityClaimExecutor.claimsCount,
+		)
+		testutils.AssertUintsEqual(
+			t,
+			"consecutive failures count",
+			uint64(i),
+			uint64(failureCounter.get(walletPublicKeyHash)),
+		)
+	}
+
+	if err := executeAction(); err == nil {
+		t.Fatal("expected error to be returned")
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"inactivity claims count",
+		1,
+		inactivityClaimExecutor.claimsCount,
+	)
+
+	expectedInactiveMembersIndexes := []group.MemberIndex{2, 5}
+	if !reflect.DeepEqual(
+		expectedInactiveMembersIndexes,
+		inactivityClaimExecutor.requestedInactiveMembersIndexes,
+	) {
+		t.Errorf(
+			"unexpected inactive members indexes\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedInactiveMembersIndexes,
+			inactivityClaimExecutor.requestedInactiveMembersIndexes,
+		)
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"heartbeat failed",
+		true,
+		inactivityClaimExecutor.requestedHeartbeatFailed,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"claim start block",
+		signingTimeoutBlock+inactivityClaimDelayBlocks,
+		inactivityClaimExecutor.requestedStartBlock,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"claim timeout block",
+		signingTimeoutBlock+inactivityClaimDelayBlocks+
+			inactivityClaimMaximumBlocks,
+		inactivityClaimExecutor.requestedTimeoutBlock,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		0,
+		uint64(failureCounter.get(walletPublicKeyHash)),
+	)
+}
+
+func TestHeartbeatAction_SuccessResetsFailures(t *testing.T) {
+	walletPublicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executingWallet := wallet{
+		publicKey: unmarshalPublicKey(walletPublicKeyHex),
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	startBlock := uint64(10)
+	expiryBlock := startBlock + heartbeatProposalValidityBlocks
+
+	proposal := &HeartbeatProposal{
+		Message: [16]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		},
+	}
+
+	hostChain := Connect()
+	hostChain.setHeartbeatProposalValidationResult(proposal, true)
+
+	failureCounter := newHeartbeatFailureCounter()
+	for i := 1; i < heartbeatConsecutiveFailuresThreshold; i++ {
+		failureCounter.increment(walletPublicKeyHash)
+	}
+
+	action := newHeartbeatAction(
+		logger,
+		hostChain,
+		executingWallet,
+		&mockHeartbeatSigningExecutor{},
+		&mockHeartbeatInactivityClaimExecutor{},
+		failureCounter,
+		proposal,
+		startBlock,
+		expiryBlock,
+		func(ctx context.Context, blockHeight uint64) error {
+			return nil
+		},
+	)
+
+	err = action.execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		0,
+		uint64(failureCounter.get(walletPublicKeyHash)),
+	)
+}
+
 type mockHeartbeatSigningExecutor struct {
 	shouldFail bool
+	signingErr error
 
 	requestedMessage    *big.Int
 	requestedStartBlock uint64
@@ -141,8 +335,37 @@ func (mhse *mockHeartbeatSigningExecutor) sign(
 	mhse.requestedStartBlock = startBlock
 
 	if mhse.shouldFail {
+		if mhse.signingErr != nil {
+			return nil, 0, mhse.signingErr
+		}
+
 		return nil, 0, fmt.Errorf("oofta")
 	}
 
 	return &tecdsa.Signature{}, startBlock + 1, nil
 }
+
+type mockHeartbeatInactivityClaimExecutor struct {
+	claimsCount int
+
+	requestedInactiveMembersIndexes []group.MemberIndex
+	requestedHeartbeatFailed        bool
+	requestedStartBlock             uint64
+	requestedTimeoutBlock           uint64
+}
+
+func (mhice *mockHeartbeatInactivityClaimExecutor) claimInactivity(
+	ctx context.Context,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+	startBlock uint64,
+	timeoutBlock uint64,
+) error {
+	mhice.claimsCount++
+	mhice.requestedInactiveMembersIndexes = inactiveMembersIndexes
+	mhice.requestedHeartbeatFailed = heartbeatFailed
+	mhice.requestedStartBlock = startBlock
+	mhice.requestedTimeoutBlock = timeoutBlock
+
+	return nil
+}
@@ -0,0 +1,406 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-log/v2"
+	"golang.org/x/exp/slices"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+const (
+	// inactivityClaimDelayBlocks determines the delay that is preserved
+	// between the end of the failed protocol and the start of the inactivity
+	// claim signatures exchange. The delay gives all signing group members
+	// a chance to realize the protocol has failed.
+	inactivityClaimDelayBlocks = 2
+	// inactivityClaimMaximumBlocks determines the maximum block duration of
+	// the inactivity claim signatures exchange and submission.
+	inactivityClaimMaximumBlocks = 30
+	// inactivityClaimReceiveBuffer is a buffer for messages received from
+	// the broadcast channel needed when the inactivity claim executor is
+	// temporarily too slow to handle them.
+	inactivityClaimReceiveBuffer = 512
+)
+
+// inactivityClaimSignatureMessage is a message used to exchange signatures
+// supporting an inactivity claim across signing group members.
+type inactivityClaimSignatureMessage struct {
+	senderID  group.MemberIndex
+	claimHash InactivityClaimHash
+	signature []byte
+}
+
+func (icsm *inactivityClaimSignatureMessage) Type() string {
+	return "tbtc/inactivity_claim_signature_message"
+}
+
+// inactivityClaimExecutor is a component responsible for claiming
+// inactivity of signing group members of a specific wallet whose part is
+// controlled by this node. The claim is supported by signatures of the
+// signing group members controlled by honest operators and lets the chain
+// evict operators who stopped participating in the wallet's protocols.
+type inactivityClaimExecutor struct {
+	logger log.StandardLogger
+	chain  Chain
+
+	signers             []*signer
+	broadcastChannel    net.BroadcastChannel
+	membershipValidator *group.MembershipValidator
+	groupParameters     *GroupParameters
+
+	waitForBlockFn waitForBlockFn
+}
+
+func newInactivityClaimExecutor(
+	logger log.StandardLogger,
+	chain Chain,
+	signers []*signer,
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	groupParameters *GroupParameters,
+	waitForBlockFn waitForBlockFn,
+) *inactivityClaimExecutor {
+	return &inactivityClaimExecutor{
+		logger:              logger,
+		chain:               chain,
+		signers:             signers,
+		broadcastChannel:    broadcastChannel,
+		membershipValidator: membershipValidator,
+		groupParameters:     groupParameters,
+		waitForBlockFn:      waitForBlockFn,
+	}
+}
+
+// claimInactivity produces an inactivity claim naming the given inactive
+// members, exchanges signatures supporting the claim with other signing
+// group members and submits the claim to the chain once enough signatures
+// are gathered. The signatures exchange starts at the given start block
+// and must complete before the given timeout block. Only the active member
+// with the lowest index submits the claim. This function returns an error
+// if the claim could not be supported by the honest majority of the signing
+// group on time.
+func (ice *inactivityClaimExecutor) claimInactivity(
+	ctx context.Context,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+	startBlock uint64,
+	timeoutBlock uint64,
+) error {
+	wallet := ice.wallet()
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	if len(inactiveMembersIndexes) == 0 {
+		return fmt.Errorf("no inactive members to claim")
+	}
+
+	walletChainData, err := ice.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf("cannot get wallet's chain data: [%v]", err)
+	}
+
+	nonce, err := ice.chain.GetInactivityClaimNonce(
+		walletChainData.EcdsaWalletID,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get inactivity claim nonce: [%v]", err)
+	}
+
+	sortedInactiveMembersIndexes := make(
+		[]group.MemberIndex,
+		len(inactiveMembersIndexes),
+	)
+	copy(sortedInactiveMembersIndexes, inactiveMembersIndexes)
+	sort.Slice(sortedInactiveMembersIndexes, func(i, j int) bool {
+		return sortedInactiveMembersIndexes[i] < sortedInactiveMembersIndexes[j]
+	})
+
+	claim := &InactivityClaim{
+		WalletID:               walletChainData.EcdsaWalletID,
+		InactiveMembersIndexes: sortedInactiveMembersIndexes,
+		HeartbeatFailed:        heartbeatFailed,
+	}
+
+	claimHash, err := ice.chain.CalculateInactivityClaimHash(
+		wallet.publicKey,
+		claim,
+		nonce,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot calculate inactivity claim hash: [%v]", err)
+	}
+
+	// All signers controlled by this node share the same operator key so
+	// a single signature is enough.
+	signature, err := ice.chain.Signing().Sign(claimHash[:])
+	if err != nil {
+		return fmt.Errorf("cannot sign inactivity claim hash: [%v]", err)
+	}
+
+	// This context is not canceled earlier, even if enough signatures were
+	// gathered. This is needed to ensure all signing group members have
+	// a chance to receive signatures sent by the members controlled by this
+	// node.
+	claimCtx, _ := withCancelOnBlock(ctx, timeoutBlock, ice.waitForBlockFn)
+
+	signatures, err := ice.exchangeSignatures(
+		claimCtx,
+		claimHash,
+		signature,
+		startBlock,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot exchange inactivity claim signatures: [%v]",
+			err,
+		)
+	}
+
+	submitterMemberIndex, ok := inactivityClaimSubmitter(
+		len(wallet.signingGroupOperators),
+		claim.InactiveMembersIndexes,
+	)
+	if !ok {
+		return fmt.Errorf("cannot determine inactivity claim submitter")
+	}
+
+	isSubmitterLocal := false
+	for _, signer := range ice.signers {
+		if signer.signingGroupMemberIndex == submitterMemberIndex {
+			isSubmitterLocal = true
+			break
+		}
+	}
+
+	if !isSubmitterLocal {
+		ice.logger.Infof(
+			"inactivity claim supported by [%v] members; "+
+				"member [%v] is responsible for the submission",
+			len(signatures),
+			submitterMemberIndex,
+		)
+		return nil
+	}
+
+	// Make sure the claim was not submitted in the meantime. The nonce is
+	// incremented upon each accepted claim.
+	currentNonce, err := ice.chain.GetInactivityClaimNonce(
+		walletChainData.EcdsaWalletID,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get inactivity claim nonce: [%v]", err)
+	}
+
+	if currentNonce.Cmp(nonce) != 0 {
+		ice.logger.Infof(
+			"inactivity claim nonce changed from [%v] to [%v]; "+
+				"skipping the submission",
+			nonce,
+			currentNonce,
+		)
+		return nil
+	}
+
+	groupMembers, err := ice.groupMembers()
+	if err != nil {
+		return fmt.Errorf("cannot get group members: [%v]", err)
+	}
+
+	ice.logger.Infof(
+		"[member:%v] submitting inactivity claim for members [%v] "+
+			"supported by [%v] members",
+		submitterMemberIndex,
+		claim.InactiveMembersIndexes,
+		len(signatures),
+	)
+
+	err = ice.chain.SubmitInactivityClaim(
+		claim,
+		nonce,
+		signatures,
+		groupMembers,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot submit inactivity claim: [%v]", err)
+	}
+
+	return nil
+}
+
+// exchangeSignatures broadcasts the given signature of the given claim hash
+// on behalf of all signers controlled by this node and gathers signatures
+// of other signing group members supporting the same claim hash. The
+// exchange starts at the given start block. This function returns once
+// signatures of the honest majority of the signing group are gathered or
+// returns an error if the context is done earlier.
+func (ice *inactivityClaimExecutor) exchangeSignatures(
+	ctx context.Context,
+	claimHash InactivityClaimHash,
+	signature []byte,
+	startBlock uint64,
+) (map[group.MemberIndex][]byte, error) {
+	// Use a separate context for the message receiver as the receiver and
+	// the consuming loop are closed once this function returns.
+	receiveCtx, cancelReceiveCtx := context.WithCancel(ctx)
+	defer cancelReceiveCtx()
+
+	messagesChan := make(chan net.Message, inactivityClaimReceiveBuffer)
+	ice.broadcastChannel.Recv(receiveCtx, func(message net.Message) {
+		messagesChan <- message
+	})
+
+	if err := ice.waitForBlockFn(ctx, startBlock); err != nil {
+		return nil, fmt.Errorf(
+			"failed waiting for start block [%v]: [%v]",
+			startBlock,
+			err,
+		)
+	}
+
+	signatures := make(map[group.MemberIndex][]byte)
+
+	for _, signer := range ice.signers {
+		signatures[signer.signingGroupMemberIndex] = signature
+
+		err := ice.broadcastChannel.Send(
+			ctx,
+			&inactivityClaimSignatureMessage{
+				senderID:  signer.signingGroupMemberIndex,
+				claimHash: claimHash,
+				signature: signature,
+			},
+			net.BackoffRetransmissionStrategy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"[member:%v] cannot send inactivity claim signature: [%v]",
+				signer.signingGroupMemberIndex,
+				err,
+			)
+		}
+	}
+
+	for len(signatures) < ice.groupParameters.HonestThreshold {
+		select {
+		case netMessage := <-messagesChan:
+			signatureMessage, ok :=
+				netMessage.Payload().(*inactivityClaimSignatureMessage)
+			if !ok {
+				continue
+			}
+
+			if _, exists := signatures[signatureMessage.senderID]; exists {
+				continue
+			}
+
+			if !ice.isValidSignatureMessage(
+				signatureMessage,
+				netMessage.SenderPublicKey(),
+				claimHash,
+			) {
+				continue
+			}
+
+			signatures[signatureMessage.senderID] = signatureMessage.signature
+
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"gathered only [%v] signatures while [%v] are required",
+				len(signatures),
+				ice.groupParameters.HonestThreshold,
+			)
+		}
+	}
+
+	return signatures, nil
+}
+
+// isValidSignatureMessage validates the given inactivityClaimSignatureMessage
+// against the given claim hash.
+func (ice *inactivityClaimExecutor) isValidSignatureMessage(
+	signatureMessage *inactivityClaimSignatureMessage,
+	senderPublicKey []byte,
+	claimHash InactivityClaimHash,
+) bool {
+	if !ice.membershipValidator.IsValidMembership(
+		signatureMessage.senderID,
+		senderPublicKey,
+	) {
+		return false
+	}
+
+	// Members with a different view of inactive members produce a different
+	// claim hash. Their signatures cannot support this claim.
+	if signatureMessage.claimHash != claimHash {
+		return false
+	}
+
+	isValid, err := ice.chain.Signing().VerifyWithPublicKey(
+		claimHash[:],
+		signatureMessage.signature,
+		senderPublicKey,
+	)
+	if err != nil || !isValid {
+		return false
+	}
+
+	return true
+}
+
+// groupMembers returns the operator IDs of all signing group members, in
+// the order of the signing group member indexes.
+func (ice *inactivityClaimExecutor) groupMembers() (chain.OperatorIDs, error) {
+	operatorsIDs := make(map[chain.Address]chain.OperatorID)
+	groupMembers := make(chain.OperatorIDs, 0)
+
+	for _, operatorAddress := range ice.wallet().signingGroupOperators {
+		operatorID, ok := operatorsIDs[operatorAddress]
+		if !ok {
+			var err error
+			operatorID, err = ice.chain.GetOperatorID(operatorAddress)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot get ID of operator [%v]: [%v]",
+					operatorAddress,
+					err,
+				)
+			}
+
+			operatorsIDs[operatorAddress] = operatorID
+		}
+
+		groupMembers = append(groupMembers, operatorID)
+	}
+
+	return groupMembers, nil
+}
+
+func (ice *inactivityClaimExecutor) wallet() wallet {
+	// All signers belong to one wallet. Take that wallet from the
+	// first signer.
+	return ice.signers[0].wallet
+}
+
+// inactivityClaimSubmitter returns the index of the signing group member
+// responsible for the submission of an inactivity claim naming the given
+// inactive members. This is the active member with the lowest index.
+// The second return value is false if all members are inactive.
+func inactivityClaimSubmitter(
+	groupSize int,
+	inactiveMembersIndexes []group.MemberIndex,
+) (group.MemberIndex, bool) {
+	for i := 0; i < groupSize; i++ {
+		memberIndex := group.MemberIndex(i + 1)
+		if !slices.Contains(inactiveMembersIndexes, memberIndex) {
+			return memberIndex, true
+		}
+	}
+
+	return 0, false
+}
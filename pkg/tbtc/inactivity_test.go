@@ -0,0 +1,262 @@
+package tbtc
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestInactivityClaimExecutor_ClaimInactivity(t *testing.T) {
+	executor, localChain := setupInactivityClaimExecutor(t)
+
+	walletID := [32]byte{0x01, 0x02}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executor.wallet().publicKey)
+	localChain.setWallet(
+		walletPublicKeyHash,
+		&WalletChainData{EcdsaWalletID: walletID},
+	)
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = executor.claimInactivity(
+		context.Background(),
+		[]group.MemberIndex{5, 4},
+		true,
+		startBlock,
+		startBlock+inactivityClaimMaximumBlocks,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submittedClaims := localChain.getSubmittedInactivityClaims()
+	testutils.AssertIntsEqual(
+		t,
+		"submitted claims count",
+		1,
+		len(submittedClaims),
+	)
+
+	submittedClaim := submittedClaims[0]
+
+	expectedClaim := &InactivityClaim{
+		WalletID:               walletID,
+		InactiveMembersIndexes: []group.MemberIndex{4, 5},
+		HeartbeatFailed:        true,
+	}
+	if !reflect.DeepEqual(expectedClaim, submittedClaim.claim) {
+		t.Errorf(
+			"unexpected claim\nexpected: [%+v]\nactual:   [%+v]",
+			expectedClaim,
+			submittedClaim.claim,
+		)
+	}
+
+	testutils.AssertBigIntsEqual(
+		t,
+		"nonce",
+		big.NewInt(0),
+		submittedClaim.nonce,
+	)
+
+	if len(submittedClaim.signatures) < executor.groupParameters.HonestThreshold {
+		t.Errorf(
+			"unexpected signatures count\n"+
+				"expected: at least [%v]\n"+
+				"actual:   [%v]",
+			executor.groupParameters.HonestThreshold,
+			len(submittedClaim.signatures),
+		)
+	}
+
+	expectedGroupMembers := make(
+		chain.OperatorIDs,
+		executor.groupParameters.GroupSize,
+	)
+	for i := range expectedGroupMembers {
+		expectedGroupMembers[i] = localChainOperatorID
+	}
+	if !reflect.DeepEqual(expectedGroupMembers, submittedClaim.groupMembers) {
+		t.Errorf(
+			"unexpected group members\nexpected: [%v]\nactual:   [%v]",
+			expectedGroupMembers,
+			submittedClaim.groupMembers,
+		)
+	}
+}
+
+func TestInactivityClaimExecutor_ClaimInactivity_NoInactiveMembers(t *testing.T) {
+	executor, _ := setupInactivityClaimExecutor(t)
+
+	err := executor.claimInactivity(
+		context.Background(),
+		[]group.MemberIndex{},
+		true,
+		0,
+		inactivityClaimMaximumBlocks,
+	)
+
+	expectedError := "no inactive members to claim"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestInactivityClaimSubmitter(t *testing.T) {
+	var tests = map[string]struct {
+		inactiveMembersIndexes []group.MemberIndex
+		expectedSubmitter      group.MemberIndex
+		expectedOk             bool
+	}{
+		"first member active": {
+			inactiveMembersIndexes: []group.MemberIndex{2, 3},
+			expectedSubmitter:      1,
+			expectedOk:             true,
+		},
+		"first members inactive": {
+			inactiveMembersIndexes: []group.MemberIndex{1, 2, 4},
+			expectedSubmitter:      3,
+			expectedOk:             true,
+		},
+		"all members inactive": {
+			inactiveMembersIndexes: []group.MemberIndex{1, 2, 3, 4, 5},
+			expectedOk:             false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			submitter, ok := inactivityClaimSubmitter(
+				5,
+				test.inactiveMembersIndexes,
+			)
+
+			testutils.AssertBoolsEqual(t, "ok", test.expectedOk, ok)
+			testutils.AssertUintsEqual(
+				t,
+				"submitter",
+				uint64(test.expectedSubmitter),
+				uint64(submitter),
+			)
+		})
+	}
+}
+
+// setupInactivityClaimExecutor sets up an instance of the inactivity claim
+// executor controlling all members of the signing group, along with the
+// local chain used by the executor.
+func setupInactivityClaimExecutor(
+	t *testing.T,
+) (*inactivityClaimExecutor, *localChain) {
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := ConnectWithKey(operatorPrivateKey)
+
+	localProvider := local.ConnectWithKey(operatorPublicKey)
+
+	operatorAddress, err := localChain.Signing().PublicKeyToAddress(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var operators []chain.Address
+	for i := 0; i < groupParameters.GroupSize; i++ {
+		operators = append(operators, operatorAddress)
+	}
+
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(
+		groupParameters.GroupSize,
+	)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	signers := make([]*signer, len(testData))
+	for i := range testData {
+		privateKeyShare := tecdsa.NewPrivateKeyShare(testData[i])
+
+		signers[i] = &signer{
+			wallet: wallet{
+				publicKey:             privateKeyShare.PublicKey(),
+				signingGroupOperators: operators,
+			},
+			signingGroupMemberIndex: group.MemberIndex(i + 1),
+			privateKeyShare:         privateKeyShare,
+		}
+	}
+
+	keyStorePersistence := createMockKeyStorePersistence(t, signers...)
+
+	node, err := newNode(
+		groupParameters,
+		localChain,
+		newLocalBitcoinChain(),
+		localProvider,
+		keyStorePersistence,
+		&mockPersistenceHandle{},
+		newTestScheduler(t),
+		&mockCoordinationProposalGenerator{},
+		Config{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingExecutor, ok, err := node.getSigningExecutor(
+		signers[0].wallet.publicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("node is supposed to control wallet signers")
+	}
+
+	executor := newInactivityClaimExecutor(
+		logger,
+		localChain,
+		signingExecutor.signers,
+		signingExecutor.broadcastChannel,
+		signingExecutor.membershipValidator,
+		groupParameters,
+		node.waitForBlockHeight,
+	)
+
+	return executor, localChain
+}
@@ -0,0 +1,438 @@
+package tbtc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+const (
+	// keystoreArchiveVersion is the version of the keystore archive format
+	// produced by ExportKeystoreArchive.
+	keystoreArchiveVersion = 2
+
+	// keystoreArchiveSaltLength is the length of the random salt used to
+	// derive the archive encryption key from the password.
+	keystoreArchiveSaltLength = 32
+	// keystoreArchiveScryptN is the scrypt CPU/memory cost parameter used
+	// for archives produced by ExportKeystoreArchive.
+	keystoreArchiveScryptN = 1 << 18
+	// keystoreArchiveScryptR is the scrypt block size parameter used for
+	// archives produced by ExportKeystoreArchive.
+	keystoreArchiveScryptR = 8
+	// keystoreArchiveScryptP is the scrypt parallelization parameter used
+	// for archives produced by ExportKeystoreArchive.
+	keystoreArchiveScryptP = 1
+	// keystoreArchiveMaxScryptMemory is the maximum memory, in bytes, scrypt
+	// parameters read from an archive may require. It protects the import
+	// against archives crafted to exhaust the memory.
+	keystoreArchiveMaxScryptMemory = 1 << 30
+)
+
+// keystoreArchive is the envelope of the keystore archive. The signers are
+// encrypted with a key derived from the archive password using the key
+// derivation parameters stored in the envelope. The checksum is computed over
+// the ciphertext and allows detecting a corrupted archive before attempting
+// to decrypt it.
+type keystoreArchive struct {
+	Version    uint32              `json:"version"`
+	KDF        *keystoreArchiveKDF `json:"kdf"`
+	Checksum   string              `json:"checksum"`
+	Ciphertext []byte              `json:"ciphertext"`
+}
+
+// keystoreArchiveKDF holds the scrypt parameters used to derive the archive
+// encryption key from the archive password.
+type keystoreArchiveKDF struct {
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// keystoreArchiveContent is the plaintext content of the keystore archive.
+// Each element of Signers is a signer marshaled in the same way as in the
+// keystore.
+type keystoreArchiveContent struct {
+	Signers [][]byte `json:"signers"`
+}
+
+// KeystoreArchiveSigner describes a signer exported to or imported from
+// the keystore archive.
+type KeystoreArchiveSigner struct {
+	// WalletPublicKeyHash is the public key hash of the signer's wallet.
+	WalletPublicKeyHash [20]byte
+	// SigningGroupMemberIndex is the signer's position in the wallet's
+	// signing group.
+	SigningGroupMemberIndex group.MemberIndex
+	// AlreadyPresent is set on import if the signer was already present in
+	// the keystore and was not written again.
+	AlreadyPresent bool
+}
+
+// ExportKeystoreArchive builds an encrypted archive of all signers stored in
+// the given keystore persistence that belong to the wallets with the given
+// public key hashes. If no wallet public key hashes are given, signers of all
+// wallets are exported. Each signer's private key share is verified before
+// it is exported. The archive is encrypted with a key derived from the given
+// password.
+func ExportKeystoreArchive(
+	keyStorePersistence persistence.ProtectedHandle,
+	walletPublicKeyHashes [][20]byte,
+	password string,
+) ([]byte, []*KeystoreArchiveSigner, error) {
+	if len(password) == 0 {
+		return nil, nil, fmt.Errorf("archive password must not be empty")
+	}
+
+	requestedWallets := make(map[[20]byte]bool)
+	for _, walletPublicKeyHash := range walletPublicKeyHashes {
+		requestedWallets[walletPublicKeyHash] = false
+	}
+
+	signers := make([]*signer, 0)
+	for _, walletSigners := range newWalletStorage(
+		keyStorePersistence,
+	).loadSigners() {
+		for _, signer := range walletSigners {
+			walletPublicKeyHash := bitcoin.PublicKeyHash(
+				signer.wallet.publicKey,
+			)
+
+			if len(requestedWallets) > 0 {
+				if _, ok := requestedWallets[walletPublicKeyHash]; !ok {
+					continue
+				}
+				requestedWallets[walletPublicKeyHash] = true
+			}
+
+			signers = append(signers, signer)
+		}
+	}
+
+	for walletPublicKeyHash, found := range requestedWallets {
+		if !found {
+			return nil, nil, fmt.Errorf(
+				"no signers found for wallet [0x%x]",
+				walletPublicKeyHash,
+			)
+		}
+	}
+
+	if len(signers) == 0 {
+		return nil, nil, fmt.Errorf("no signers found in the keystore")
+	}
+
+	// Make the archive content deterministic with regard to the order of
+	// signers. This is not strictly needed but makes the archive summary
+	// easier to read.
+	sort.SliceStable(signers, func(i, j int) bool {
+		iKey := getWalletStorageKey(signers[i].wallet.publicKey)
+		jKey := getWalletStorageKey(signers[j].wallet.publicKey)
+
+		if iKey != jKey {
+			return iKey < jKey
+		}
+
+		return signers[i].signingGroupMemberIndex <
+			signers[j].signingGroupMemberIndex
+	})
+
+	content := &keystoreArchiveContent{
+		Signers: make([][]byte, len(signers)),
+	}
+	summary := make([]*KeystoreArchiveSigner, len(signers))
+
+	for i, signer := range signers {
+		if err := verifySigner(signer); err != nil {
+			return nil, nil, fmt.Errorf(
+				"signer [%v] of wallet [0x%x] is invalid: [%w]",
+				signer.signingGroupMemberIndex,
+				bitcoin.PublicKeyHash(signer.wallet.publicKey),
+				err,
+			)
+		}
+
+		signerBytes, err := signer.Marshal()
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot marshal signer: [%w]", err)
+		}
+
+		content.Signers[i] = signerBytes
+		summary[i] = &KeystoreArchiveSigner{
+			WalletPublicKeyHash:     bitcoin.PublicKeyHash(signer.wallet.publicKey),
+			SigningGroupMemberIndex: signer.signingGroupMemberIndex,
+		}
+	}
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot marshal archive content: [%w]",
+			err,
+		)
+	}
+
+	salt := make([]byte, keystoreArchiveSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("cannot generate archive salt: [%w]", err)
+	}
+
+	kdf := &keystoreArchiveKDF{
+		Salt: salt,
+		N:    keystoreArchiveScryptN,
+		R:    keystoreArchiveScryptR,
+		P:    keystoreArchiveScryptP,
+	}
+
+	box, err := keystoreArchiveBox(password, kdf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ciphertext, err := box.Encrypt(plaintext)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot encrypt archive content: [%w]",
+			err,
+		)
+	}
+
+	checksum := sha256.Sum256(ciphertext)
+
+	archive, err := json.Marshal(&keystoreArchive{
+		Version:    keystoreArchiveVersion,
+		KDF:        kdf,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot marshal archive: [%w]", err)
+	}
+
+	return archive, summary, nil
+}
+
+// ImportKeystoreArchive restores signers from the given archive produced by
+// ExportKeystoreArchive into the given keystore persistence. The archive is
+// decrypted with a key derived from the given password. All signers are
+// verified before any of them is written; a signer is valid if its private
+// key share reconstructs the wallet's public key. Signers that are already
+// present in the keystore are not written again.
+func ImportKeystoreArchive(
+	keyStorePersistence persistence.ProtectedHandle,
+	archiveBytes []byte,
+	password string,
+) ([]*KeystoreArchiveSigner, error) {
+	archive := &keystoreArchive{}
+	if err := json.Unmarshal(archiveBytes, archive); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal archive: [%w]", err)
+	}
+
+	if archive.Version != keystoreArchiveVersion {
+		return nil, fmt.Errorf(
+			"unsupported archive version [%v]; expected [%v]",
+			archive.Version,
+			keystoreArchiveVersion,
+		)
+	}
+
+	checksum := sha256.Sum256(archive.Ciphertext)
+	if hex.EncodeToString(checksum[:]) != archive.Checksum {
+		return nil, fmt.Errorf("archive checksum mismatch; archive is corrupted")
+	}
+
+	if err := validateKeystoreArchiveKDF(archive.KDF); err != nil {
+		return nil, fmt.Errorf("invalid archive key derivation: [%w]", err)
+	}
+
+	box, err := keystoreArchiveBox(password, archive.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := box.Decrypt(archive.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot decrypt archive content; wrong password?: [%w]",
+			err,
+		)
+	}
+
+	content := &keystoreArchiveContent{}
+	if err := json.Unmarshal(plaintext, content); err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal archive content: [%w]",
+			err,
+		)
+	}
+
+	signers := make([]*signer, len(content.Signers))
+	for i, signerBytes := range content.Signers {
+		signer := &signer{}
+		if err := signer.Unmarshal(signerBytes); err != nil {
+			return nil, fmt.Errorf(
+				"cannot unmarshal signer [%v] from archive: [%w]",
+				i,
+				err,
+			)
+		}
+
+		if err := verifySigner(signer); err != nil {
+			return nil, fmt.Errorf(
+				"signer [%v] of wallet [0x%x] is invalid: [%w]",
+				signer.signingGroupMemberIndex,
+				bitcoin.PublicKeyHash(signer.wallet.publicKey),
+				err,
+			)
+		}
+
+		signers[i] = signer
+	}
+
+	walletStorage := newWalletStorage(keyStorePersistence)
+
+	existingSigners := make(map[string]map[group.MemberIndex]bool)
+	for walletStorageKey, walletSigners := range walletStorage.loadSigners() {
+		existingSigners[walletStorageKey] = make(map[group.MemberIndex]bool)
+		for _, signer := range walletSigners {
+			existingSigners[walletStorageKey][signer.signingGroupMemberIndex] = true
+		}
+	}
+
+	summary := make([]*KeystoreArchiveSigner, len(signers))
+	for i, signer := range signers {
+		walletStorageKey := getWalletStorageKey(signer.wallet.publicKey)
+		alreadyPresent :=
+			existingSigners[walletStorageKey][signer.signingGroupMemberIndex]
+
+		summary[i] = &KeystoreArchiveSigner{
+			WalletPublicKeyHash:     bitcoin.PublicKeyHash(signer.wallet.publicKey),
+			SigningGroupMemberIndex: signer.signingGroupMemberIndex,
+			AlreadyPresent:          alreadyPresent,
+		}
+
+		if summary[i].AlreadyPresent {
+			continue
+		}
+
+		if err := walletStorage.saveSigner(signer); err != nil {
+			return nil, fmt.Errorf(
+				"cannot save signer [%v] of wallet [0x%x]: [%w]",
+				signer.signingGroupMemberIndex,
+				summary[i].WalletPublicKeyHash,
+				err,
+			)
+		}
+	}
+
+	return summary, nil
+}
+
+// verifySigner checks whether the given signer is consistent. The signer's
+// private key share must be valid and must correspond to the signer's wallet
+// public key. The signer's member index must point to a member of the
+// wallet's signing group.
+func verifySigner(signer *signer) error {
+	if err := signer.privateKeyShare.Verify(); err != nil {
+		return fmt.Errorf("invalid private key share: [%w]", err)
+	}
+
+	sharePublicKey := signer.privateKeyShare.PublicKey()
+	if sharePublicKey.X.Cmp(signer.wallet.publicKey.X) != 0 ||
+		sharePublicKey.Y.Cmp(signer.wallet.publicKey.Y) != 0 {
+		return fmt.Errorf(
+			"private key share does not correspond to the wallet public key",
+		)
+	}
+
+	if signer.signingGroupMemberIndex < 1 ||
+		int(signer.signingGroupMemberIndex) >
+			len(signer.wallet.signingGroupOperators) {
+		return fmt.Errorf(
+			"member index [%v] is out of the signing group range [1, %v]",
+			signer.signingGroupMemberIndex,
+			len(signer.wallet.signingGroupOperators),
+		)
+	}
+
+	return nil
+}
+
+// validateKeystoreArchiveKDF checks whether the given key derivation
+// parameters read from an archive are complete and within the supported
+// bounds.
+func validateKeystoreArchiveKDF(kdf *keystoreArchiveKDF) error {
+	if kdf == nil {
+		return fmt.Errorf("key derivation parameters are missing")
+	}
+
+	if len(kdf.Salt) != keystoreArchiveSaltLength {
+		return fmt.Errorf(
+			"salt length [%v] is not [%v]",
+			len(kdf.Salt),
+			keystoreArchiveSaltLength,
+		)
+	}
+
+	if kdf.N <= 1 || kdf.N&(kdf.N-1) != 0 {
+		return fmt.Errorf("scrypt N [%v] is not a power of two", kdf.N)
+	}
+
+	if kdf.R <= 0 || kdf.P <= 0 {
+		return fmt.Errorf(
+			"scrypt r [%v] and p [%v] must be positive",
+			kdf.R,
+			kdf.P,
+		)
+	}
+
+	// Scrypt uses 128*N*r bytes for its memory-hard step and 128*r*p bytes
+	// for its blocks.
+	maxNR := keystoreArchiveMaxScryptMemory / 128
+	if kdf.N > maxNR/kdf.R || kdf.P > maxNR/kdf.R {
+		return fmt.Errorf(
+			"scrypt parameters N [%v], r [%v], p [%v] exceed the memory limit",
+			kdf.N,
+			kdf.R,
+			kdf.P,
+		)
+	}
+
+	return nil
+}
+
+// keystoreArchiveBox returns the encryption box used to encrypt and decrypt
+// the keystore archive content with the key derived from the given password
+// using the given scrypt parameters.
+func keystoreArchiveBox(
+	password string,
+	kdf *keystoreArchiveKDF,
+) (encryption.Box, error) {
+	key, err := scrypt.Key(
+		[]byte(password),
+		kdf.Salt,
+		kdf.N,
+		kdf.R,
+		kdf.P,
+		encryption.KeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive archive key: [%w]", err)
+	}
+
+	var boxKey [encryption.KeyLength]byte
+	copy(boxKey[:], key)
+
+	return encryption.NewBox(boxKey), nil
+}
@@ -0,0 +1,342 @@
+package tbtc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestKeystoreArchive_RoundTrip(t *testing.T) {
+	signers := createMockSigners(t, 2)
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signers[0].wallet.publicKey)
+
+	archive, exported, err := ExportKeystoreArchive(
+		createMockKeyStorePersistence(t, signers...),
+		[][20]byte{walletPublicKeyHash},
+		"archive-password",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "exported signers", 2, len(exported))
+	for i, signer := range exported {
+		testutils.AssertBytesEqual(
+			t,
+			walletPublicKeyHash[:],
+			signer.WalletPublicKeyHash[:],
+		)
+		testutils.AssertIntsEqual(
+			t,
+			"member index",
+			i+1,
+			int(signer.SigningGroupMemberIndex),
+		)
+	}
+
+	// Import into a keystore already holding the first signer.
+	keyStorePersistence := createMockKeyStorePersistence(t, signers[0])
+
+	imported, err := ImportKeystoreArchive(
+		keyStorePersistence,
+		archive,
+		"archive-password",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "imported signers", 2, len(imported))
+	testutils.AssertBoolsEqual(
+		t,
+		"first signer already present",
+		true,
+		imported[0].AlreadyPresent,
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"second signer already present",
+		false,
+		imported[1].AlreadyPresent,
+	)
+
+	// Only the second signer should be written.
+	testutils.AssertIntsEqual(
+		t,
+		"keystore entries",
+		2,
+		len(keyStorePersistence.saved),
+	)
+
+	restoredSigners := newWalletStorage(keyStorePersistence).loadSigners()
+	walletSigners := restoredSigners[getWalletStorageKey(
+		signers[0].wallet.publicKey,
+	)]
+	testutils.AssertIntsEqual(t, "restored signers", 2, len(walletSigners))
+}
+
+func TestExportKeystoreArchive_KeyDerivation(t *testing.T) {
+	keyStorePersistence := createMockKeyStorePersistence(
+		t,
+		createMockSigners(t, 1)...,
+	)
+
+	exportArchive := func() *keystoreArchive {
+		archive, _, err := ExportKeystoreArchive(
+			keyStorePersistence,
+			nil,
+			"archive-password",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		envelope := &keystoreArchive{}
+		if err := json.Unmarshal(archive, envelope); err != nil {
+			t.Fatal(err)
+		}
+
+		return envelope
+	}
+
+	first := exportArchive()
+	second := exportArchive()
+
+	testutils.AssertUintsEqual(
+		t,
+		"version",
+		keystoreArchiveVersion,
+		uint64(first.Version),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"salt length",
+		keystoreArchiveSaltLength,
+		len(first.KDF.Salt),
+	)
+	testutils.AssertIntsEqual(t, "scrypt N", keystoreArchiveScryptN, first.KDF.N)
+	testutils.AssertIntsEqual(t, "scrypt r", keystoreArchiveScryptR, first.KDF.R)
+	testutils.AssertIntsEqual(t, "scrypt p", keystoreArchiveScryptP, first.KDF.P)
+
+	// Each archive must be encrypted with a key derived using a fresh salt.
+	if bytes.Equal(first.KDF.Salt, second.KDF.Salt) {
+		t.Errorf("expected different salts of subsequent archives")
+	}
+	if bytes.Equal(first.Ciphertext, second.Ciphertext) {
+		t.Errorf("expected different ciphertexts of subsequent archives")
+	}
+}
+
+func TestExportKeystoreArchive_Errors(t *testing.T) {
+	signers := createMockSigners(t, 1)
+
+	corruptedSigner := createMockSigners(t, 1)[0]
+	corruptedData := corruptedSigner.privateKeyShare.Data()
+	corruptedData.Xi = new(big.Int).Add(corruptedData.Xi, big.NewInt(1))
+	corruptedSigner.privateKeyShare = tecdsa.NewPrivateKeyShare(corruptedData)
+
+	tests := map[string]struct {
+		signers               []*signer
+		walletPublicKeyHashes [][20]byte
+		password              string
+		expectedError         string
+	}{
+		"empty password": {
+			signers:       signers,
+			expectedError: "archive password must not be empty",
+		},
+		"unknown wallet": {
+			signers:               signers,
+			walletPublicKeyHashes: [][20]byte{{0x01}},
+			password:              "archive-password",
+			expectedError:         "no signers found for wallet",
+		},
+		"empty keystore": {
+			password:      "archive-password",
+			expectedError: "no signers found in the keystore",
+		},
+		"corrupted private key share": {
+			signers:       []*signer{corruptedSigner},
+			password:      "archive-password",
+			expectedError: "secret share does not match its public share",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, _, err := ExportKeystoreArchive(
+				createMockKeyStorePersistence(t, test.signers...),
+				test.walletPublicKeyHashes,
+				test.password,
+			)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v\n",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestImportKeystoreArchive_Errors(t *testing.T) {
+	archive, _, err := ExportKeystoreArchive(
+		createMockKeyStorePersistence(t, createMockSigners(t, 1)...),
+		nil,
+		"archive-password",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modifyArchive := func(modifyFn func(archive *keystoreArchive)) []byte {
+		envelope := &keystoreArchive{}
+		if err := json.Unmarshal(archive, envelope); err != nil {
+			t.Fatal(err)
+		}
+
+		modifyFn(envelope)
+
+		modified, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return modified
+	}
+
+	tests := map[string]struct {
+		archive       []byte
+		password      string
+		expectedError string
+	}{
+		"wrong password": {
+			archive:       archive,
+			password:      "wrong-password",
+			expectedError: "cannot decrypt archive content",
+		},
+		"corrupted ciphertext": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.Ciphertext[len(archive.Ciphertext)-1] ^= 0xff
+			}),
+			password:      "archive-password",
+			expectedError: "archive checksum mismatch",
+		},
+		"unsupported version": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.Version = 1
+			}),
+			password:      "archive-password",
+			expectedError: "unsupported archive version [1]",
+		},
+		"missing key derivation parameters": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.KDF = nil
+			}),
+			password:      "archive-password",
+			expectedError: "key derivation parameters are missing",
+		},
+		"short salt": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.KDF.Salt = archive.KDF.Salt[:16]
+			}),
+			password:      "archive-password",
+			expectedError: "salt length [16] is not [32]",
+		},
+		"scrypt N not a power of two": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.KDF.N = 1000
+			}),
+			password:      "archive-password",
+			expectedError: "scrypt N [1000] is not a power of two",
+		},
+		"scrypt parameters exceeding the memory limit": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.KDF.N = 1 << 24
+			}),
+			password:      "archive-password",
+			expectedError: "exceed the memory limit",
+		},
+		"modified salt": {
+			archive: modifyArchive(func(archive *keystoreArchive) {
+				archive.KDF.Salt[0] ^= 0xff
+			}),
+			password:      "archive-password",
+			expectedError: "cannot decrypt archive content",
+		},
+		"not an archive": {
+			archive:       []byte("not an archive"),
+			password:      "archive-password",
+			expectedError: "cannot unmarshal archive",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			keyStorePersistence := createMockKeyStorePersistence(t)
+
+			_, err := ImportKeystoreArchive(
+				keyStorePersistence,
+				test.archive,
+				test.password,
+			)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v\n",
+					test.expectedError,
+					err,
+				)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"keystore entries",
+				0,
+				len(keyStorePersistence.saved),
+			)
+		})
+	}
+}
+
+// createMockSigners creates the given number of signers of the same wallet,
+// occupying subsequent seats of the wallet's signing group.
+func createMockSigners(t *testing.T, count int) []*signer {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(count)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	signingGroupOperators := []chain.Address{
+		"address-1",
+		"address-2",
+		"address-3",
+		"address-4",
+		"address-5",
+	}
+
+	signers := make([]*signer, count)
+	for i := range signers {
+		privateKeyShare := tecdsa.NewPrivateKeyShare(testData[i])
+
+		signers[i] = &signer{
+			wallet: wallet{
+				publicKey:             privateKeyShare.PublicKey(),
+				signingGroupOperators: signingGroupOperators,
+			},
+			signingGroupMemberIndex: group.MemberIndex(i + 1),
+			privateKeyShare:         privateKeyShare,
+		}
+	}
+
+	return signers
+}
@@ -127,6 +127,43 @@ func (sdm *signingDoneMessage) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the inactivityClaimSignatureMessage to a byte array.
+func (icsm *inactivityClaimSignatureMessage) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.InactivityClaimSignatureMessage{
+		SenderID:  uint32(icsm.senderID),
+		ClaimHash: icsm.claimHash[:],
+		Signature: icsm.signature,
+	})
+}
+
+// Unmarshal converts a byte array back to the inactivityClaimSignatureMessage.
+func (icsm *inactivityClaimSignatureMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.InactivityClaimSignatureMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal InactivityClaimSignatureMessage: [%v]",
+			err,
+		)
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	if len(pbMsg.ClaimHash) != len(icsm.claimHash) {
+		return fmt.Errorf(
+			"invalid claim hash length: [%v]",
+			len(pbMsg.ClaimHash),
+		)
+	}
+
+	icsm.senderID = group.MemberIndex(pbMsg.SenderID)
+	copy(icsm.claimHash[:], pbMsg.ClaimHash)
+	icsm.signature = pbMsg.Signature
+
+	return nil
+}
+
 // Marshal converts the coordinationMessage to a byte array.
 func (cm *coordinationMessage) Marshal() ([]byte, error) {
 	proposalBytes, err := cm.proposal.Marshal()
@@ -171,7 +208,7 @@ func (cm *coordinationMessage) Unmarshal(bytes []byte) error {
 	if pbMsg.Proposal == nil {
 		return fmt.Errorf("missing proposal")
 	}
-	proposal, err := unmarshalCoordinationProposal(
+	proposal, err := UnmarshalCoordinationProposal(
 		pbMsg.Proposal.ActionType,
 		pbMsg.Proposal.Payload,
 	)
@@ -203,9 +240,9 @@ func unmarshalWalletPublicKeyHash(bytes []byte) ([20]byte, error) {
 	return walletPublicKeyHash, nil
 }
 
-// unmarshalCoordinationProposal converts a byte array back to the coordination
+// UnmarshalCoordinationProposal converts a byte array back to the coordination
 // proposal.
-func unmarshalCoordinationProposal(actionType uint32, payload []byte) (
+func UnmarshalCoordinationProposal(actionType uint32, payload []byte) (
 	CoordinationProposal,
 	error,
 ) {
@@ -225,13 +262,13 @@ func unmarshalCoordinationProposal(actionType uint32, payload []byte) (
 	}
 
 	proposal, ok := map[WalletActionType]CoordinationProposal{
-		ActionNoop:         &NoopProposal{},
-		ActionHeartbeat:    &HeartbeatProposal{},
-		ActionDepositSweep: &DepositSweepProposal{},
-		ActionRedemption:   &RedemptionProposal{},
-		// TODO: Uncomment when moving funds support is implemented.
-		// ActionMovingFunds:     &MovingFundsProposal{},
-		// ActionMovedFundsSweep: &MovedFundsSweepProposal{},
+		ActionNoop:            &NoopProposal{},
+		ActionHeartbeat:       &HeartbeatProposal{},
+		ActionDepositSweep:    &DepositSweepProposal{},
+		ActionRedemption:      &RedemptionProposal{},
+		ActionMovingFunds:     &MovingFundsProposal{},
+		ActionMovedFundsSweep: &MovedFundsSweepProposal{},
+		ActionFeeBump:         &FeeBumpProposal{},
 	}[parsedActionType]
 	if !ok {
 		return nil, fmt.Errorf(
@@ -395,6 +432,120 @@ func (rp *RedemptionProposal) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the movingFundsProposal to a byte array.
+func (mfp *MovingFundsProposal) Marshal() ([]byte, error) {
+	targetWallets := make([][]byte, len(mfp.TargetWallets))
+	for i, wallet := range mfp.TargetWallets {
+		targetWallets[i] = append([]byte{}, wallet[:]...)
+	}
+
+	return proto.Marshal(
+		&pb.MovingFundsProposal{
+			TargetWallets:    targetWallets,
+			MovingFundsTxFee: mfp.MovingFundsTxFee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the movingFundsProposal.
+func (mfp *MovingFundsProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.MovingFundsProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf("failed to unmarshal MovingFundsProposal: [%v]", err)
+	}
+
+	targetWallets := make([][20]byte, len(pbMsg.TargetWallets))
+	for i, wallet := range pbMsg.TargetWallets {
+		targetWallet, err := unmarshalWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to unmarshal target wallet: [%v]",
+				err,
+			)
+		}
+
+		targetWallets[i] = targetWallet
+	}
+
+	mfp.TargetWallets = targetWallets
+	mfp.MovingFundsTxFee = new(big.Int).SetBytes(pbMsg.MovingFundsTxFee)
+
+	return nil
+}
+
+// Marshal converts the movedFundsSweepProposal to a byte array.
+func (mfsp *MovedFundsSweepProposal) Marshal() ([]byte, error) {
+	return proto.Marshal(
+		&pb.MovedFundsSweepProposal{
+			MovingFundsTxHash:        mfsp.MovingFundsTxHash[:],
+			MovingFundsTxOutputIndex: mfsp.MovingFundsTxOutputIndex,
+			SweepTxFee:               mfsp.SweepTxFee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the movedFundsSweepProposal.
+func (mfsp *MovedFundsSweepProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.MovedFundsSweepProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal MovedFundsSweepProposal: [%v]",
+			err,
+		)
+	}
+
+	movingFundsTxHash, err := bitcoin.NewHash(
+		pbMsg.MovingFundsTxHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal moving funds tx hash: [%v]",
+			err,
+		)
+	}
+
+	mfsp.MovingFundsTxHash = movingFundsTxHash
+	mfsp.MovingFundsTxOutputIndex = pbMsg.MovingFundsTxOutputIndex
+	mfsp.SweepTxFee = new(big.Int).SetBytes(pbMsg.SweepTxFee)
+
+	return nil
+}
+
+// Marshal converts the feeBumpProposal to a byte array.
+func (fbp *FeeBumpProposal) Marshal() ([]byte, error) {
+	return proto.Marshal(
+		&pb.FeeBumpProposal{
+			TransactionHash: fbp.TransactionHash[:],
+			Fee:             fbp.Fee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the feeBumpProposal.
+func (fbp *FeeBumpProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.FeeBumpProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf("failed to unmarshal FeeBumpProposal: [%v]", err)
+	}
+
+	transactionHash, err := bitcoin.NewHash(
+		pbMsg.TransactionHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal transaction hash: [%v]",
+			err,
+		)
+	}
+
+	fbp.TransactionHash = transactionHash
+	fbp.Fee = new(big.Int).SetBytes(pbMsg.Fee)
+
+	return nil
+}
+
 // marshalPublicKey converts an ECDSA public key to a byte
 // array (uncompressed).
 func marshalPublicKey(publicKey *ecdsa.PublicKey) ([]byte, error) {
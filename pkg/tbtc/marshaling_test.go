@@ -107,6 +107,57 @@ func TestFuzzSigningDoneMessage_Unmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&signingDoneMessage{})
 }
 
+func TestInactivityClaimSignatureMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &inactivityClaimSignatureMessage{
+		senderID:  group.MemberIndex(10),
+		claimHash: InactivityClaimHash{0x01, 0x02, 0x03},
+		signature: []byte{0x04, 0x05, 0x06},
+	}
+	unmarshaled := &inactivityClaimSignatureMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzInactivityClaimSignatureMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID  group.MemberIndex
+			claimHash InactivityClaimHash
+			signature []byte
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&claimHash)
+		f.Fuzz(&signature)
+
+		signatureMessage := &inactivityClaimSignatureMessage{
+			senderID:  senderID,
+			claimHash: claimHash,
+			signature: signature,
+		}
+
+		_ = pbutils.RoundTrip(
+			signatureMessage,
+			&inactivityClaimSignatureMessage{},
+		)
+	}
+}
+
+func TestFuzzInactivityClaimSignatureMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&inactivityClaimSignatureMessage{})
+}
+
 func TestCoordinationMessage_MarshalingRoundtrip(t *testing.T) {
 	parseHash := func(hash string) bitcoin.Hash {
 		parsed, err := bitcoin.NewHashFromString(hash, bitcoin.InternalByteOrder)
@@ -168,13 +219,30 @@ func TestCoordinationMessage_MarshalingRoundtrip(t *testing.T) {
 				RedemptionTxFee: big.NewInt(10000),
 			},
 		},
-		// TODO: Uncomment when moving funds support is implemented.
-		// "with moving funds proposal": {
-		//     proposal: &MovingFundsProposal{},
-		// },
-		// "with moved funds sweep proposal": {
-		//     proposal: &MovedFundsSweepProposal{},
-		// },
+		"with moving funds proposal": {
+			proposal: &MovingFundsProposal{
+				TargetWallets: [][20]byte{
+					{0x8d, 0xb5, 0x0e, 0xb5, 0x20, 0x63, 0xea, 0x9d, 0x98, 0xb3,
+						0xea, 0xc9, 0x14, 0x89, 0xa9, 0x0f, 0x73, 0x89, 0x86, 0xf6},
+					{0xaa, 0x76, 0x84, 0x12, 0xce, 0xed, 0x10, 0xbd, 0x42, 0x3c,
+						0x02, 0x55, 0x42, 0xca, 0x90, 0x07, 0x1f, 0x9f, 0xb6, 0x2d},
+				},
+				MovingFundsTxFee: big.NewInt(10000),
+			},
+		},
+		"with moved funds sweep proposal": {
+			proposal: &MovedFundsSweepProposal{
+				MovingFundsTxHash:        parseHash("2d2a79e1d4bf4a2a3e6b2bc8ea06d0d24a7bbc6ec6ec9e1ef8d0a8d4e1a3b5c7"),
+				MovingFundsTxOutputIndex: 2,
+				SweepTxFee:               big.NewInt(10000),
+			},
+		},
+		"with fee bump proposal": {
+			proposal: &FeeBumpProposal{
+				TransactionHash: parseHash("2d2a79e1d4bf4a2a3e6b2bc8ea06d0d24a7bbc6ec6ec9e1ef8d0a8d4e1a3b5c7"),
+				Fee:             big.NewInt(15000),
+			},
+		},
 	}
 
 	walletPublicKeyHashBytes, err := hex.DecodeString(
@@ -324,9 +392,92 @@ func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithNoopProposal(t *testing
 	}
 }
 
-// TODO: Create two unit tests once moving funds is implemented:
-//       - TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovingFundsProposal
-//       - TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovedFundsSweepProposal
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovingFundsProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            MovingFundsProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
+
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovedFundsSweepProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            MovedFundsSweepProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
+
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithFeeBumpProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            FeeBumpProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
 
 func TestFuzzCoordinationMessage_Unmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&coordinationMessage{})
@@ -0,0 +1,411 @@
+package tbtc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// movedFundsSweepProposalValidityBlocks determines the moved funds sweep
+	// proposal validity time expressed in blocks. In other words, this is the
+	// worst-case time for moved funds sweep during which the wallet is busy
+	// and cannot take another actions. The value of 650 blocks is roughly
+	// 2 hours and 10 minutes, assuming 12 seconds per block.
+	movedFundsSweepProposalValidityBlocks = 650
+	// movedFundsSweepSigningTimeoutSafetyMarginBlocks determines the duration
+	// of the safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire moved funds sweep action. This safety
+	// margin prevents against the case where signing completes late and there
+	// is not enough time to broadcast the sweep transaction properly.
+	// In such a case, wallet signatures may leak and make the wallet subject
+	// of fraud accusations. Usage of the safety margin ensures there is enough
+	// time to perform post-signing steps of the moved funds sweep action.
+	// The value of 300 blocks is roughly 1 hour, assuming 12 seconds per block.
+	movedFundsSweepSigningTimeoutSafetyMarginBlocks = 300
+	// movedFundsSweepBroadcastTimeout determines the time window for moved
+	// funds sweep transaction broadcast. It is guaranteed that at least
+	// movedFundsSweepSigningTimeoutSafetyMarginBlocks is preserved for the
+	// broadcast step. However, the happy path for the broadcast step is
+	// usually quick and few retries are needed to recover from temporary
+	// problems. That said, if the broadcast step does not succeed in a tight
+	// timeframe, there is no point to retry for the entire possible time
+	// window. Hence, the timeout for broadcast step is set as 25% of the
+	// entire time widow determined by
+	// movedFundsSweepSigningTimeoutSafetyMarginBlocks.
+	movedFundsSweepBroadcastTimeout = 15 * time.Minute
+	// movedFundsSweepBroadcastCheckDelay determines the delay that must
+	// be preserved between transaction broadcast and the check that ensures
+	// the transaction is known on the Bitcoin chain. This delay is needed
+	// as spreading the transaction over the Bitcoin network takes time.
+	movedFundsSweepBroadcastCheckDelay = 1 * time.Minute
+)
+
+// MovedFundsSweepRequestState represents the state of a moved funds sweep
+// request.
+type MovedFundsSweepRequestState uint8
+
+const (
+	MovedFundsStateUnknown MovedFundsSweepRequestState = iota
+	MovedFundsStatePending
+	MovedFundsStateProcessed
+	MovedFundsStateTimedOut
+)
+
+func (mfsrs MovedFundsSweepRequestState) String() string {
+	switch mfsrs {
+	case MovedFundsStateUnknown:
+		return "Unknown"
+	case MovedFundsStatePending:
+		return "Pending"
+	case MovedFundsStateProcessed:
+		return "Processed"
+	case MovedFundsStateTimedOut:
+		return "TimedOut"
+	default:
+		panic("unknown moved funds sweep request state")
+	}
+}
+
+// MovedFundsSweepRequest represents a tBTC moved funds sweep request. Such
+// a request is created for each output of a moving funds transaction that
+// transfers funds to a target wallet.
+type MovedFundsSweepRequest struct {
+	// WalletPublicKeyHash is the 20-byte public key hash of the target wallet
+	// that is supposed to sweep the moved funds.
+	WalletPublicKeyHash [20]byte
+	// Value is the value of the moved funds UTXO in satoshi.
+	Value uint64
+	// CreatedAt is the time the request was created at.
+	CreatedAt time.Time
+	// State is the current state of the request.
+	State MovedFundsSweepRequestState
+}
+
+// MovedFundsSweepProposal represents a moved funds sweep proposal issued by a
+// wallet's coordination leader.
+type MovedFundsSweepProposal struct {
+	MovingFundsTxHash        bitcoin.Hash
+	MovingFundsTxOutputIndex uint32
+	SweepTxFee               *big.Int
+}
+
+func (mfsp *MovedFundsSweepProposal) ActionType() WalletActionType {
+	return ActionMovedFundsSweep
+}
+
+func (mfsp *MovedFundsSweepProposal) ValidityBlocks() uint64 {
+	return movedFundsSweepProposalValidityBlocks
+}
+
+// movedFundsSweepAction is a moved funds sweep walletAction.
+type movedFundsSweepAction struct {
+	logger   *zap.SugaredLogger
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	sweepingWallet      wallet
+	transactionExecutor *walletTransactionExecutor
+
+	proposal                     *MovedFundsSweepProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiryBlock          uint64
+
+	signingTimeoutSafetyMarginBlocks uint64
+	broadcastTimeout                 time.Duration
+	broadcastCheckDelay              time.Duration
+}
+
+func newMovedFundsSweepAction(
+	logger *zap.SugaredLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	sweepingWallet wallet,
+	signingExecutor walletSigningExecutor,
+	proposal *MovedFundsSweepProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiryBlock uint64,
+	waitForBlockFn waitForBlockFn,
+) *movedFundsSweepAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		sweepingWallet,
+		signingExecutor,
+		waitForBlockFn,
+	)
+
+	return &movedFundsSweepAction{
+		logger:                           logger,
+		chain:                            chain,
+		btcChain:                         btcChain,
+		sweepingWallet:                   sweepingWallet,
+		transactionExecutor:              transactionExecutor,
+		proposal:                         proposal,
+		proposalProcessingStartBlock:     proposalProcessingStartBlock,
+		proposalExpiryBlock:              proposalExpiryBlock,
+		signingTimeoutSafetyMarginBlocks: movedFundsSweepSigningTimeoutSafetyMarginBlocks,
+		broadcastTimeout:                 movedFundsSweepBroadcastTimeout,
+		broadcastCheckDelay:              movedFundsSweepBroadcastCheckDelay,
+	}
+}
+
+func (mfsa *movedFundsSweepAction) execute() error {
+	validateProposalLogger := mfsa.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(mfsa.wallet().publicKey)
+
+	err := ValidateMovedFundsSweepProposal(
+		validateProposalLogger,
+		walletPublicKeyHash,
+		mfsa.proposal,
+		mfsa.chain,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	walletMainUtxo, err := DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		mfsa.chain,
+		mfsa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while determining wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	err = EnsureWalletSyncedBetweenChains(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		mfsa.chain,
+		mfsa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while ensuring wallet state is synced between "+
+				"BTC and host chain: [%v]",
+			err,
+		)
+	}
+
+	movedFundsUtxo, err := mfsa.determineMovedFundsUtxo(walletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf(
+			"error while determining moved funds UTXO: [%v]",
+			err,
+		)
+	}
+
+	unsignedSweepTx, err := assembleMovedFundsSweepTransaction(
+		mfsa.btcChain,
+		walletPublicKeyHash,
+		walletMainUtxo,
+		movedFundsUtxo,
+		mfsa.proposal.SweepTxFee.Int64(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while assembling moved funds sweep transaction: [%v]",
+			err,
+		)
+	}
+
+	signTxLogger := mfsa.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	// Just in case. This should never happen.
+	if mfsa.proposalExpiryBlock < mfsa.signingTimeoutSafetyMarginBlocks {
+		return fmt.Errorf("invalid proposal expiry block")
+	}
+
+	sweepTx, err := mfsa.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedSweepTx,
+		mfsa.proposalProcessingStartBlock,
+		mfsa.proposalExpiryBlock-mfsa.signingTimeoutSafetyMarginBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	broadcastTxLogger := mfsa.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String("sweepTxHash", sweepTx.Hash().Hex(bitcoin.ReversedByteOrder)),
+	)
+
+	err = mfsa.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		sweepTx,
+		mfsa.broadcastTimeout,
+		mfsa.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	return nil
+}
+
+// determineMovedFundsUtxo builds the moved funds UTXO pointed by the proposal.
+// The moving funds transaction is fetched from the Bitcoin chain to make sure
+// the pointed output exists and actually transfers funds to the wallet.
+func (mfsa *movedFundsSweepAction) determineMovedFundsUtxo(
+	walletPublicKeyHash [20]byte,
+) (*bitcoin.UnspentTransactionOutput, error) {
+	movingFundsTx, err := mfsa.btcChain.GetTransaction(
+		mfsa.proposal.MovingFundsTxHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get moving funds transaction: [%v]", err)
+	}
+
+	outputIndex := mfsa.proposal.MovingFundsTxOutputIndex
+	if int(outputIndex) >= len(movingFundsTx.Outputs) {
+		return nil, fmt.Errorf(
+			"moving funds transaction has no output with index [%v]",
+			outputIndex,
+		)
+	}
+
+	output := movingFundsTx.Outputs[outputIndex]
+
+	walletP2PKH, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct P2PKH for wallet: [%v]", err)
+	}
+	walletP2WPKH, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct P2WPKH for wallet: [%v]", err)
+	}
+
+	if !bytes.Equal(output.PublicKeyScript, walletP2PKH) &&
+		!bytes.Equal(output.PublicKeyScript, walletP2WPKH) {
+		return nil, fmt.Errorf("moved funds output does not target the wallet")
+	}
+
+	return &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: mfsa.proposal.MovingFundsTxHash,
+			OutputIndex:     outputIndex,
+		},
+		Value: output.Value,
+	}, nil
+}
+
+// ValidateMovedFundsSweepProposal checks the moved funds sweep proposal with
+// on-chain validation rules.
+func ValidateMovedFundsSweepProposal(
+	validateProposalLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	proposal *MovedFundsSweepProposal,
+	chain interface {
+		// ValidateMovedFundsSweepProposal validates the given moved funds
+		// sweep proposal against the chain. Returns an error if the proposal
+		// is not valid or nil otherwise.
+		ValidateMovedFundsSweepProposal(
+			walletPublicKeyHash [20]byte,
+			proposal *MovedFundsSweepProposal,
+		) error
+	},
+) error {
+	validateProposalLogger.Infof("calling chain for proposal validation")
+
+	err := chain.ValidateMovedFundsSweepProposal(
+		walletPublicKeyHash,
+		proposal,
+	)
+	if err != nil {
+		return fmt.Errorf("moved funds sweep proposal is invalid: [%v]", err)
+	}
+
+	validateProposalLogger.Infof(
+		"moved funds sweep proposal is valid",
+	)
+
+	return nil
+}
+
+func (mfsa *movedFundsSweepAction) wallet() wallet {
+	return mfsa.sweepingWallet
+}
+
+func (mfsa *movedFundsSweepAction) actionType() WalletActionType {
+	return ActionMovedFundsSweep
+}
+
+func (mfsa *movedFundsSweepAction) broadcastedTransaction() (bitcoin.Hash, bool) {
+	return mfsa.transactionExecutor.broadcastedTransaction()
+}
+
+// assembleMovedFundsSweepTransaction constructs an unsigned moved funds sweep
+// Bitcoin transaction.
+//
+// Regarding input arguments, the walletMainUtxo parameter is optional and
+// can be set as nil if the wallet does not have a main UTXO registered on
+// chain yet. The movedFundsUtxo parameter is required. The fee is not
+// validated in any way so must be chosen with respect to the system
+// limitations.
+//
+// The resulting bitcoin.TransactionBuilder instance holds all the data
+// necessary to sign the transaction and obtain a bitcoin.Transaction instance
+// ready to be spread across the Bitcoin network.
+func assembleMovedFundsSweepTransaction(
+	bitcoinChain bitcoin.Chain,
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	movedFundsUtxo *bitcoin.UnspentTransactionOutput,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	if movedFundsUtxo == nil {
+		return nil, fmt.Errorf("moved funds UTXO is required")
+	}
+
+	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+	builder.SignalReplaceability()
+
+	if walletMainUtxo != nil {
+		err := builder.AddPublicKeyHashInput(walletMainUtxo)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot add input pointing to wallet main UTXO: [%v]",
+				err,
+			)
+		}
+	}
+
+	err := builder.AddPublicKeyHashInput(movedFundsUtxo)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot add input pointing to moved funds UTXO: [%v]",
+			err,
+		)
+	}
+
+	outputScript, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute output script: [%v]", err)
+	}
+
+	outputValue := builder.TotalInputsValue() - fee
+	if outputValue <= 0 {
+		return nil, fmt.Errorf("transaction fee exceeds swept value")
+	}
+
+	builder.AddOutput(&bitcoin.TransactionOutput{
+		Value:           outputValue,
+		PublicKeyScript: outputScript,
+	})
+
+	return builder, nil
+}
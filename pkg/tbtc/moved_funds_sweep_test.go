@@ -0,0 +1,389 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestMovedFundsSweepAction_Execute(t *testing.T) {
+	hostChain := Connect()
+	bitcoinChain := newLocalBitcoinChain()
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wallet := wallet{
+		// Set only relevant fields.
+		publicKey: walletPrivateKey.PubKey().ToECDSA(),
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	// Record the transaction holding the wallet main UTXO in the
+	// Bitcoin local chain.
+	walletMainUtxoTx := newWalletMainUtxoTransaction(t, walletPublicKeyHash, 500000)
+	err = bitcoinChain.BroadcastTransaction(walletMainUtxoTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: walletMainUtxoTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 500000,
+	}
+
+	// Record the wallet main UTXO hash in the local host chain so
+	// the moved funds sweep action can detect it.
+	hostChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		MainUtxoHash: hostChain.ComputeMainUtxoHash(walletMainUtxo),
+	})
+
+	// Record the moving funds transaction transferring funds to the wallet
+	// in the Bitcoin local chain. The wallet is the second target wallet.
+	movingFundsTx := newMovingFundsTransaction(
+		t,
+		[][20]byte{
+			hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e"),
+			walletPublicKeyHash,
+		},
+		300000,
+	)
+	err = bitcoinChain.BroadcastTransaction(movingFundsTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movedFundsUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: movingFundsTx.Hash(),
+			OutputIndex:     1,
+		},
+		Value: 300000,
+	}
+
+	proposal := &MovedFundsSweepProposal{
+		MovingFundsTxHash:        movingFundsTx.Hash(),
+		MovingFundsTxOutputIndex: 1,
+		SweepTxFee:               big.NewInt(3000),
+	}
+
+	// Choose an arbitrary start block and expiration time.
+	proposalProcessingStartBlock := uint64(100)
+	proposalExpiryBlock := proposalProcessingStartBlock +
+		movedFundsSweepProposalValidityBlocks
+
+	// Simulate the on-chain proposal validation passes with success.
+	hostChain.setMovedFundsSweepProposalValidationResult(
+		walletPublicKeyHash,
+		proposal,
+		true,
+	)
+
+	// Compute the signature hashes of the expected moved funds sweep
+	// transaction and sign them using the wallet private key. The signing
+	// executor mock will return those signatures when called with the
+	// expected parameters.
+	expectedUnsignedTx, err := assembleMovedFundsSweepTransaction(
+		bitcoinChain,
+		walletPublicKeyHash,
+		walletMainUtxo,
+		movedFundsUtxo,
+		proposal.SweepTxFee.Int64(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigHashes, err := expectedUnsignedTx.ComputeSignatureHashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := make([]*tecdsa.Signature, len(sigHashes))
+	signatureContainers := make([]*bitcoin.SignatureContainer, len(sigHashes))
+	for i, sigHash := range sigHashes {
+		signature, err := walletPrivateKey.Sign(sigHash.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signatures[i] = &tecdsa.Signature{R: signature.R, S: signature.S}
+		signatureContainers[i] = &bitcoin.SignatureContainer{
+			R:         signature.R,
+			S:         signature.S,
+			PublicKey: wallet.publicKey,
+		}
+	}
+
+	signingExecutor := newMockWalletSigningExecutor()
+	signingExecutor.setSignatures(
+		sigHashes,
+		proposalProcessingStartBlock,
+		signatures,
+	)
+
+	action := newMovedFundsSweepAction(
+		logger.With(),
+		hostChain,
+		bitcoinChain,
+		wallet,
+		signingExecutor,
+		proposal,
+		proposalProcessingStartBlock,
+		proposalExpiryBlock,
+		func(ctx context.Context, blockHeight uint64) error {
+			return nil
+		},
+	)
+
+	// Modify the default parameters of the action to make
+	// it possible to execute in the current test environment.
+	action.broadcastCheckDelay = 1 * time.Second
+
+	err = action.execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Action execution that completes without an error is a sign of
+	// success. However, just in case, make an additional check that
+	// the expected moved funds sweep transaction was actually broadcasted
+	// on the local Bitcoin chain.
+	expectedTx, err := expectedUnsignedTx.AddSignatures(signatureContainers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broadcastedTx, err := bitcoinChain.GetTransaction(expectedTx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		expectedTx.Serialize(),
+		broadcastedTx.Serialize(),
+	)
+}
+
+func TestAssembleMovedFundsSweepTransaction(t *testing.T) {
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		(*ecdsa.PublicKey)(walletPrivateKey.PubKey()),
+	)
+
+	bitcoinChain := newLocalBitcoinChain()
+
+	walletMainUtxoTx := newWalletMainUtxoTransaction(t, walletPublicKeyHash, 100000)
+	err = bitcoinChain.BroadcastTransaction(walletMainUtxoTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: walletMainUtxoTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 100000,
+	}
+
+	movingFundsTx := newMovingFundsTransaction(
+		t,
+		[][20]byte{walletPublicKeyHash},
+		50000,
+	)
+	err = bitcoinChain.BroadcastTransaction(movingFundsTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movedFundsUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: movingFundsTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 50000,
+	}
+
+	var tests = map[string]struct {
+		walletMainUtxo      *bitcoin.UnspentTransactionOutput
+		movedFundsUtxo      *bitcoin.UnspentTransactionOutput
+		fee                 int64
+		expectedInputsCount int
+		expectedOutputValue int64
+		expectedError       string
+	}{
+		"with wallet main UTXO": {
+			walletMainUtxo:      walletMainUtxo,
+			movedFundsUtxo:      movedFundsUtxo,
+			fee:                 2000,
+			expectedInputsCount: 2,
+			expectedOutputValue: 148000,
+		},
+		"without wallet main UTXO": {
+			walletMainUtxo:      nil,
+			movedFundsUtxo:      movedFundsUtxo,
+			fee:                 1000,
+			expectedInputsCount: 1,
+			expectedOutputValue: 49000,
+		},
+		"no moved funds UTXO": {
+			walletMainUtxo: walletMainUtxo,
+			movedFundsUtxo: nil,
+			fee:            1000,
+			expectedError:  "moved funds UTXO is required",
+		},
+		"fee exceeds swept value": {
+			walletMainUtxo: nil,
+			movedFundsUtxo: movedFundsUtxo,
+			fee:            50000,
+			expectedError:  "transaction fee exceeds swept value",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			builder, err := assembleMovedFundsSweepTransaction(
+				bitcoinChain,
+				walletPublicKeyHash,
+				test.walletMainUtxo,
+				test.movedFundsUtxo,
+				test.fee,
+			)
+
+			if test.expectedError != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				testutils.AssertStringsEqual(
+					t,
+					"error",
+					test.expectedError,
+					err.Error(),
+				)
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The builder does not expose its outputs directly. Sign the
+			// transaction to obtain the final shape and check the outputs.
+			sigHashes, err := builder.ComputeSignatureHashes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"inputs count",
+				test.expectedInputsCount,
+				len(sigHashes),
+			)
+
+			signatureContainers := make(
+				[]*bitcoin.SignatureContainer,
+				len(sigHashes),
+			)
+			for i, sigHash := range sigHashes {
+				signature, err := walletPrivateKey.Sign(sigHash.Bytes())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				signatureContainers[i] = &bitcoin.SignatureContainer{
+					R:         signature.R,
+					S:         signature.S,
+					PublicKey: (*ecdsa.PublicKey)(walletPrivateKey.PubKey()),
+				}
+			}
+
+			transaction, err := builder.AddSignatures(signatureContainers)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"outputs count",
+				1,
+				len(transaction.Outputs),
+			)
+
+			expectedScript, err := bitcoin.PayToWitnessPublicKeyHash(
+				walletPublicKeyHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				expectedScript,
+				transaction.Outputs[0].PublicKeyScript,
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"output value",
+				int(test.expectedOutputValue),
+				int(transaction.Outputs[0].Value),
+			)
+		})
+	}
+}
+
+// newMovingFundsTransaction creates a transaction that transfers the given
+// value to the P2WPKH address of each of the given target wallets.
+func newMovingFundsTransaction(
+	t *testing.T,
+	targetWallets [][20]byte,
+	value int64,
+) *bitcoin.Transaction {
+	outputs := make([]*bitcoin.TransactionOutput, len(targetWallets))
+	for i, targetWallet := range targetWallets {
+		script, err := bitcoin.PayToWitnessPublicKeyHash(targetWallet)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputs[i] = &bitcoin.TransactionOutput{
+			Value:           value,
+			PublicKeyScript: script,
+		}
+	}
+
+	return &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0x02},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+		Outputs:  outputs,
+		Locktime: 0,
+	}
+}
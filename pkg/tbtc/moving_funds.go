@@ -0,0 +1,335 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// movingFundsProposalValidityBlocks determines the moving funds proposal
+	// validity time expressed in blocks. In other words, this is the worst-case
+	// time for moving funds during which the wallet is busy and cannot take
+	// another actions. The value of 650 blocks is roughly 2 hours and 10
+	// minutes, assuming 12 seconds per block.
+	movingFundsProposalValidityBlocks = 650
+	// movingFundsSigningTimeoutSafetyMarginBlocks determines the duration of
+	// the safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire moving funds action. This safety
+	// margin prevents against the case where signing completes late and there
+	// is not enough time to broadcast the moving funds transaction properly.
+	// In such a case, wallet signatures may leak and make the wallet subject
+	// of fraud accusations. Usage of the safety margin ensures there is enough
+	// time to perform post-signing steps of the moving funds action.
+	// The value of 300 blocks is roughly 1 hour, assuming 12 seconds per block.
+	movingFundsSigningTimeoutSafetyMarginBlocks = 300
+	// movingFundsBroadcastTimeout determines the time window for moving funds
+	// transaction broadcast. It is guaranteed that at least
+	// movingFundsSigningTimeoutSafetyMarginBlocks is preserved for the broadcast
+	// step. However, the happy path for the broadcast step is usually quick
+	// and few retries are needed to recover from temporary problems. That
+	// said, if the broadcast step does not succeed in a tight timeframe,
+	// there is no point to retry for the entire possible time window.
+	// Hence, the timeout for broadcast step is set as 25% of the entire
+	// time widow determined by movingFundsSigningTimeoutSafetyMarginBlocks.
+	movingFundsBroadcastTimeout = 15 * time.Minute
+	// movingFundsBroadcastCheckDelay determines the delay that must
+	// be preserved between transaction broadcast and the check that ensures
+	// the transaction is known on the Bitcoin chain. This delay is needed
+	// as spreading the transaction over the Bitcoin network takes time.
+	movingFundsBroadcastCheckDelay = 1 * time.Minute
+)
+
+// MovingFundsProposal represents a moving funds proposal issued by a
+// wallet's coordination leader.
+type MovingFundsProposal struct {
+	TargetWallets    [][20]byte
+	MovingFundsTxFee *big.Int
+}
+
+func (mfp *MovingFundsProposal) ActionType() WalletActionType {
+	return ActionMovingFunds
+}
+
+func (mfp *MovingFundsProposal) ValidityBlocks() uint64 {
+	return movingFundsProposalValidityBlocks
+}
+
+// movingFundsAction is a moving funds walletAction.
+type movingFundsAction struct {
+	logger   *zap.SugaredLogger
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	movingFundsWallet   wallet
+	transactionExecutor *walletTransactionExecutor
+
+	proposal                     *MovingFundsProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiryBlock          uint64
+
+	signingTimeoutSafetyMarginBlocks uint64
+	broadcastTimeout                 time.Duration
+	broadcastCheckDelay              time.Duration
+}
+
+func newMovingFundsAction(
+	logger *zap.SugaredLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	movingFundsWallet wallet,
+	signingExecutor walletSigningExecutor,
+	proposal *MovingFundsProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiryBlock uint64,
+	waitForBlockFn waitForBlockFn,
+) *movingFundsAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		movingFundsWallet,
+		signingExecutor,
+		waitForBlockFn,
+	)
+
+	return &movingFundsAction{
+		logger:                           logger,
+		chain:                            chain,
+		btcChain:                         btcChain,
+		movingFundsWallet:                movingFundsWallet,
+		transactionExecutor:              transactionExecutor,
+		proposal:                         proposal,
+		proposalProcessingStartBlock:     proposalProcessingStartBlock,
+		proposalExpiryBlock:              proposalExpiryBlock,
+		signingTimeoutSafetyMarginBlocks: movingFundsSigningTimeoutSafetyMarginBlocks,
+		broadcastTimeout:                 movingFundsBroadcastTimeout,
+		broadcastCheckDelay:              movingFundsBroadcastCheckDelay,
+	}
+}
+
+func (mfa *movingFundsAction) execute() error {
+	validateProposalLogger := mfa.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(mfa.wallet().publicKey)
+
+	walletMainUtxo, err := DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		mfa.chain,
+		mfa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while determining wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// The wallet must have a main UTXO in order to move funds. There is
+	// nothing to move otherwise.
+	if walletMainUtxo == nil {
+		return fmt.Errorf("moving funds wallet has no main UTXO")
+	}
+
+	err = ValidateMovingFundsProposal(
+		validateProposalLogger,
+		walletPublicKeyHash,
+		walletMainUtxo,
+		mfa.proposal,
+		mfa.chain,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	err = EnsureWalletSyncedBetweenChains(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		mfa.chain,
+		mfa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while ensuring wallet state is synced between "+
+				"BTC and host chain: [%v]",
+			err,
+		)
+	}
+
+	unsignedMovingFundsTx, err := assembleMovingFundsTransaction(
+		mfa.btcChain,
+		walletMainUtxo,
+		mfa.proposal.TargetWallets,
+		mfa.proposal.MovingFundsTxFee.Int64(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while assembling moving funds transaction: [%v]",
+			err,
+		)
+	}
+
+	signTxLogger := mfa.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	// Just in case. This should never happen.
+	if mfa.proposalExpiryBlock < mfa.signingTimeoutSafetyMarginBlocks {
+		return fmt.Errorf("invalid proposal expiry block")
+	}
+
+	movingFundsTx, err := mfa.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedMovingFundsTx,
+		mfa.proposalProcessingStartBlock,
+		mfa.proposalExpiryBlock-mfa.signingTimeoutSafetyMarginBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	broadcastTxLogger := mfa.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String("movingFundsTxHash", movingFundsTx.Hash().Hex(bitcoin.ReversedByteOrder)),
+	)
+
+	err = mfa.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		movingFundsTx,
+		mfa.broadcastTimeout,
+		mfa.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	return nil
+}
+
+// ValidateMovingFundsProposal checks the moving funds proposal with on-chain
+// validation rules.
+func ValidateMovingFundsProposal(
+	validateProposalLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+	chain interface {
+		// ValidateMovingFundsProposal validates the given moving funds proposal
+		// against the chain. Returns an error if the proposal is not valid or
+		// nil otherwise.
+		ValidateMovingFundsProposal(
+			walletPublicKeyHash [20]byte,
+			walletMainUtxo *bitcoin.UnspentTransactionOutput,
+			proposal *MovingFundsProposal,
+		) error
+	},
+) error {
+	validateProposalLogger.Infof("calling chain for proposal validation")
+
+	err := chain.ValidateMovingFundsProposal(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+	)
+	if err != nil {
+		return fmt.Errorf("moving funds proposal is invalid: [%v]", err)
+	}
+
+	validateProposalLogger.Infof(
+		"moving funds proposal is valid",
+	)
+
+	return nil
+}
+
+func (mfa *movingFundsAction) wallet() wallet {
+	return mfa.movingFundsWallet
+}
+
+func (mfa *movingFundsAction) actionType() WalletActionType {
+	return ActionMovingFunds
+}
+
+func (mfa *movingFundsAction) broadcastedTransaction() (bitcoin.Hash, bool) {
+	return mfa.transactionExecutor.broadcastedTransaction()
+}
+
+// assembleMovingFundsTransaction constructs an unsigned moving funds Bitcoin
+// transaction.
+//
+// Regarding input arguments, the targetWallets slice must contain at least
+// one element. The fee is subtracted from the wallet main UTXO value and the
+// remaining amount is split evenly among all target wallets. If the amount
+// cannot be divided evenly, the last target wallet receives the remainder.
+// The fee is not validated in any way so must be chosen with respect to the
+// system limitations.
+//
+// The resulting bitcoin.TransactionBuilder instance holds all the data
+// necessary to sign the transaction and obtain a bitcoin.Transaction instance
+// ready to be spread across the Bitcoin network.
+func assembleMovingFundsTransaction(
+	bitcoinChain bitcoin.Chain,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	targetWallets [][20]byte,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	if walletMainUtxo == nil {
+		return nil, fmt.Errorf("wallet main UTXO is required")
+	}
+
+	if len(targetWallets) < 1 {
+		return nil, fmt.Errorf("at least one target wallet is required")
+	}
+
+	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+	builder.SignalReplaceability()
+
+	err := builder.AddPublicKeyHashInput(walletMainUtxo)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot add input pointing to wallet main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// The whole value of the main UTXO, reduced by the transaction fee, is
+	// split between the target wallets.
+	totalOutputsValue := builder.TotalInputsValue() - fee
+	if totalOutputsValue <= 0 {
+		return nil, fmt.Errorf("transaction fee exceeds wallet balance")
+	}
+
+	targetWalletsCount := int64(len(targetWallets))
+	remainder := totalOutputsValue % targetWalletsCount
+	valuePerTargetWallet := (totalOutputsValue - remainder) / targetWalletsCount
+
+	for i, targetWallet := range targetWallets {
+		outputScript, err := bitcoin.PayToWitnessPublicKeyHash(targetWallet)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot compute output script for target wallet [0x%x]: [%v]",
+				targetWallet,
+				err,
+			)
+		}
+
+		outputValue := valuePerTargetWallet
+		if i == len(targetWallets)-1 {
+			outputValue += remainder
+		}
+
+		builder.AddOutput(
+			&bitcoin.TransactionOutput{
+				Value:           outputValue,
+				PublicKeyScript: outputScript,
+			},
+		)
+	}
+
+	return builder, nil
+}
@@ -0,0 +1,372 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestMovingFundsAction_Execute(t *testing.T) {
+	hostChain := Connect()
+	bitcoinChain := newLocalBitcoinChain()
+
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wallet := wallet{
+		// Set only relevant fields.
+		publicKey: walletPrivateKey.PubKey().ToECDSA(),
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	// Record the transaction holding the wallet main UTXO in the
+	// Bitcoin local chain.
+	walletMainUtxoTx := newWalletMainUtxoTransaction(t, walletPublicKeyHash, 1000000)
+	err = bitcoinChain.BroadcastTransaction(walletMainUtxoTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: walletMainUtxoTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 1000000,
+	}
+
+	// Record the wallet main UTXO hash in the local host chain so
+	// the moving funds action can detect it.
+	hostChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		MainUtxoHash: hostChain.ComputeMainUtxoHash(walletMainUtxo),
+	})
+
+	proposal := &MovingFundsProposal{
+		TargetWallets: [][20]byte{
+			hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e"),
+			hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e"),
+		},
+		MovingFundsTxFee: big.NewInt(2000),
+	}
+
+	// Choose an arbitrary start block and expiration time.
+	proposalProcessingStartBlock := uint64(100)
+	proposalExpiryBlock := proposalProcessingStartBlock +
+		movingFundsProposalValidityBlocks
+
+	// Simulate the on-chain proposal validation passes with success.
+	hostChain.setMovingFundsProposalValidationResult(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+		true,
+	)
+
+	// Compute the signature hash of the expected moving funds transaction
+	// and sign it using the wallet private key. The signing executor mock
+	// will return that signature when called with the expected parameters.
+	expectedUnsignedTx, err := assembleMovingFundsTransaction(
+		bitcoinChain,
+		walletMainUtxo,
+		proposal.TargetWallets,
+		proposal.MovingFundsTxFee.Int64(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigHashes, err := expectedUnsignedTx.ComputeSignatureHashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := walletPrivateKey.Sign(sigHashes[0].Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingExecutor := newMockWalletSigningExecutor()
+	signingExecutor.setSignatures(
+		sigHashes,
+		proposalProcessingStartBlock,
+		[]*tecdsa.Signature{{R: signature.R, S: signature.S}},
+	)
+
+	action := newMovingFundsAction(
+		logger.With(),
+		hostChain,
+		bitcoinChain,
+		wallet,
+		signingExecutor,
+		proposal,
+		proposalProcessingStartBlock,
+		proposalExpiryBlock,
+		func(ctx context.Context, blockHeight uint64) error {
+			return nil
+		},
+	)
+
+	// Modify the default parameters of the action to make
+	// it possible to execute in the current test environment.
+	action.broadcastCheckDelay = 1 * time.Second
+
+	err = action.execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Action execution that completes without an error is a sign of
+	// success. However, just in case, make an additional check that
+	// the expected moving funds transaction was actually broadcasted
+	// on the local Bitcoin chain.
+	expectedTx, err := expectedUnsignedTx.AddSignatures(
+		[]*bitcoin.SignatureContainer{
+			{
+				R:         signature.R,
+				S:         signature.S,
+				PublicKey: wallet.publicKey,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broadcastedTx, err := bitcoinChain.GetTransaction(expectedTx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		expectedTx.Serialize(),
+		broadcastedTx.Serialize(),
+	)
+}
+
+func TestAssembleMovingFundsTransaction(t *testing.T) {
+	walletPrivateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		(*ecdsa.PublicKey)(walletPrivateKey.PubKey()),
+	)
+
+	bitcoinChain := newLocalBitcoinChain()
+
+	walletMainUtxoTx := newWalletMainUtxoTransaction(t, walletPublicKeyHash, 100003)
+	err = bitcoinChain.BroadcastTransaction(walletMainUtxoTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: walletMainUtxoTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 100003,
+	}
+
+	targetWallets := [][20]byte{
+		hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e"),
+		hexToByte20(t, "6cf8f75e0a1ab2f2d85d3ab04ee3bfa1e2a9c1e6"),
+		hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e"),
+	}
+
+	var tests = map[string]struct {
+		walletMainUtxo       *bitcoin.UnspentTransactionOutput
+		targetWallets        [][20]byte
+		fee                  int64
+		expectedOutputValues []int64
+		expectedError        string
+	}{
+		"even split": {
+			walletMainUtxo:       walletMainUtxo,
+			targetWallets:        targetWallets,
+			fee:                  1000,
+			expectedOutputValues: []int64{33001, 33001, 33001},
+		},
+		"single target wallet": {
+			walletMainUtxo:       walletMainUtxo,
+			targetWallets:        targetWallets[:1],
+			fee:                  3,
+			expectedOutputValues: []int64{100000},
+		},
+		"uneven split": {
+			walletMainUtxo:       walletMainUtxo,
+			targetWallets:        targetWallets[:2],
+			fee:                  2,
+			expectedOutputValues: []int64{50000, 50001},
+		},
+		"no wallet main UTXO": {
+			walletMainUtxo: nil,
+			targetWallets:  targetWallets,
+			fee:            1000,
+			expectedError:  "wallet main UTXO is required",
+		},
+		"no target wallets": {
+			walletMainUtxo: walletMainUtxo,
+			targetWallets:  [][20]byte{},
+			fee:            1000,
+			expectedError:  "at least one target wallet is required",
+		},
+		"fee exceeds wallet balance": {
+			walletMainUtxo: walletMainUtxo,
+			targetWallets:  targetWallets,
+			fee:            100003,
+			expectedError:  "transaction fee exceeds wallet balance",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			builder, err := assembleMovingFundsTransaction(
+				bitcoinChain,
+				test.walletMainUtxo,
+				test.targetWallets,
+				test.fee,
+			)
+
+			if test.expectedError != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				testutils.AssertStringsEqual(
+					t,
+					"error",
+					test.expectedError,
+					err.Error(),
+				)
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"total inputs value",
+				int(test.walletMainUtxo.Value),
+				int(builder.TotalInputsValue()),
+			)
+
+			// The builder does not expose its outputs directly. Sign the
+			// transaction to obtain the final shape and check the outputs.
+			sigHashes, err := builder.ComputeSignatureHashes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signature, err := walletPrivateKey.Sign(sigHashes[0].Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			transaction, err := builder.AddSignatures(
+				[]*bitcoin.SignatureContainer{
+					{
+						R:         signature.R,
+						S:         signature.S,
+						PublicKey: (*ecdsa.PublicKey)(walletPrivateKey.PubKey()),
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"outputs count",
+				len(test.expectedOutputValues),
+				len(transaction.Outputs),
+			)
+
+			for i, output := range transaction.Outputs {
+				expectedScript, err := bitcoin.PayToWitnessPublicKeyHash(
+					test.targetWallets[i],
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				testutils.AssertBytesEqual(
+					t,
+					expectedScript,
+					output.PublicKeyScript,
+				)
+				testutils.AssertIntsEqual(
+					t,
+					"output value",
+					int(test.expectedOutputValues[i]),
+					int(output.Value),
+				)
+			}
+		})
+	}
+}
+
+// newWalletMainUtxoTransaction creates a transaction that transfers the
+// given value to the P2PKH address of the given wallet. The transaction's
+// first output can be used as the wallet main UTXO.
+func newWalletMainUtxoTransaction(
+	t *testing.T,
+	walletPublicKeyHash [20]byte,
+	value int64,
+) *bitcoin.Transaction {
+	script, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0x01},
+					OutputIndex:     0,
+				},
+				SignatureScript: []byte{},
+				Sequence:        0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           value,
+				PublicKeyScript: script,
+			},
+		},
+		Locktime: 0,
+	}
+}
+
+func hexToByte20(t *testing.T, hexString string) [20]byte {
+	bytes, err := hex.DecodeString(hexString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bytes) != 20 {
+		t.Fatal("incorrect hexstring length")
+	}
+
+	var result [20]byte
+	copy(result[:], bytes[:])
+	return result
+}
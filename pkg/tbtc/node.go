@@ -55,6 +55,10 @@ type node struct {
 	// by appropriate actions dispatched through this component.
 	walletDispatcher *walletDispatcher
 
+	// actionHistory records coordination results and wallet actions executed
+	// by the node. The history is persisted and survives the client restart.
+	actionHistory *actionHistory
+
 	// protocolLatch makes sure no expensive number generator operations are
 	// running when signing or generating a wallet key are executed. The
 	// protocolLatch is used by dkgExecutor and signingExecutor.
@@ -84,9 +88,21 @@ type node struct {
 	// coordinationExecutors MUST NOT be used outside this struct.
 	coordinationExecutors map[string]*coordinationExecutor
 
+	// feeBumpWatcher watches wallet transactions broadcast by this node
+	// and detects the ones whose fee should be bumped.
+	feeBumpWatcher *feeBumpWatcher
+
+	// heartbeatFailureCounter holds the number of consecutive heartbeat
+	// failures of wallets controlled by this node.
+	heartbeatFailureCounter *heartbeatFailureCounter
+
 	// proposalGenerator is the implementation of the coordination proposal
 	// generator used by the node.
 	proposalGenerator CoordinationProposalGenerator
+
+	// signersVerification is the report of the verification of signers
+	// stored in the keystore, performed when the node is set up.
+	signersVerification *signersVerificationReport
 }
 
 func newNode(
@@ -100,22 +116,32 @@ func newNode(
 	proposalGenerator CoordinationProposalGenerator,
 	config Config,
 ) (*node, error) {
-	walletRegistry := newWalletRegistry(keyStorePersistance)
+	actionHistory, err := newActionHistory(workPersistence)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize action history: [%v]", err)
+	}
+
+	feeBumpWatcher, err := newFeeBumpWatcher(btcChain, workPersistence)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize fee bump watcher: [%v]", err)
+	}
 
 	latch := generator.NewProtocolLatch()
 	scheduler.RegisterProtocol(latch)
 
 	node := &node{
-		groupParameters:       groupParameters,
-		chain:                 chain,
-		btcChain:              btcChain,
-		netProvider:           netProvider,
-		walletRegistry:        walletRegistry,
-		walletDispatcher:      newWalletDispatcher(),
-		protocolLatch:         latch,
-		signingExecutors:      make(map[string]*signingExecutor),
-		coordinationExecutors: make(map[string]*coordinationExecutor),
-		proposalGenerator:     proposalGenerator,
+		groupParameters:         groupParameters,
+		chain:                   chain,
+		btcChain:                btcChain,
+		netProvider:             netProvider,
+		walletDispatcher:        newWalletDispatcher(actionHistory),
+		actionHistory:           actionHistory,
+		protocolLatch:           latch,
+		signingExecutors:        make(map[string]*signingExecutor),
+		coordinationExecutors:   make(map[string]*coordinationExecutor),
+		feeBumpWatcher:          feeBumpWatcher,
+		heartbeatFailureCounter: newHeartbeatFailureCounter(),
+		proposalGenerator:       proposalGenerator,
 	}
 
 	// Only the operator address is known at this point and can be pre-fetched.
@@ -126,6 +152,17 @@ func newNode(
 		return nil, fmt.Errorf("cannot get node's operator adress: [%v]", err)
 	}
 
+	// Signers must be verified before they are loaded by the wallet registry
+	// so the invalid ones are quarantined and never used by the node.
+	node.signersVerification = verifySigners(
+		chain,
+		keyStorePersistance,
+		operatorAddress,
+	)
+
+	walletRegistry := newWalletRegistry(keyStorePersistance)
+	node.walletRegistry = walletRegistry
+
 	// TODO: This chicken and egg problem should be solved when
 	// waitForBlockHeight becomes a part of BlockHeightWaiter interface.
 	node.dkgExecutor = newDkgExecutor(
@@ -260,6 +297,9 @@ func (n *node) getSigningExecutor(
 	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &signingDoneMessage{}
 	})
+	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &inactivityClaimSignatureMessage{}
+	})
 
 	membershipValidator := group.NewMembershipValidator(
 		executorLogger,
@@ -388,7 +428,7 @@ func (n *node) getCoordinationExecutor(
 		wallet,
 		membersIndexes,
 		operatorAddress,
-		n.proposalGenerator,
+		newFeeBumpProposalGenerator(n.feeBumpWatcher, n.proposalGenerator),
 		broadcastChannel,
 		membershipValidator,
 		n.protocolLatch,
@@ -452,11 +492,23 @@ func (n *node) handleHeartbeatProposal(
 	)
 	walletActionLogger.Infof("dispatching wallet action")
 
+	inactivityClaimExecutor := newInactivityClaimExecutor(
+		walletActionLogger,
+		n.chain,
+		signingExecutor.signers,
+		signingExecutor.broadcastChannel,
+		signingExecutor.membershipValidator,
+		n.groupParameters,
+		n.waitForBlockHeight,
+	)
+
 	action := newHeartbeatAction(
 		walletActionLogger,
 		n.chain,
 		wallet,
 		signingExecutor,
+		inactivityClaimExecutor,
+		n.heartbeatFailureCounter,
 		proposal,
 		startBlock,
 		expiryBlock,
@@ -525,6 +577,7 @@ func (n *node) handleDepositSweepProposal(
 		n.btcChain,
 		wallet,
 		signingExecutor,
+		n.feeBumpWatcher,
 		proposal,
 		startBlock,
 		expiryBlock,
@@ -588,6 +641,143 @@ func (n *node) handleRedemptionProposal(
 	walletActionLogger.Infof("dispatching wallet action")
 
 	action := newRedemptionAction(
+		walletActionLogger,
+		n.chain,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		n.feeBumpWatcher,
+		proposal,
+		startBlock,
+		expiryBlock,
+		n.waitForBlockHeight,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
+// handleMovingFundsProposal handles an incoming moving funds proposal by
+// orchestrating and dispatching an appropriate wallet action.
+func (n *node) handleMovingFundsProposal(
+	wallet wallet,
+	proposal *MovingFundsProposal,
+	startBlock uint64,
+	expiryBlock uint64,
+) {
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getSigningExecutor may change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet [0x%x]; "+
+				"ignoring the received moving funds proposal",
+			walletPublicKeyBytes,
+		)
+		return
+	}
+
+	logger.Infof(
+		"starting orchestration of the moving funds action for wallet [0x%x]; "+
+			"20-byte public key hash of that wallet is [0x%x]",
+		walletPublicKeyBytes,
+		bitcoin.PublicKeyHash(wallet.publicKey),
+	)
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionMovingFunds.String()),
+		zap.Uint64("startBlock", startBlock),
+		zap.Uint64("expiryBlock", expiryBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newMovingFundsAction(
+		walletActionLogger,
+		n.chain,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		proposal,
+		startBlock,
+		expiryBlock,
+		n.waitForBlockHeight,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
+// handleMovedFundsSweepProposal handles an incoming moved funds sweep
+// proposal by orchestrating and dispatching an appropriate wallet action.
+func (n *node) handleMovedFundsSweepProposal(
+	wallet wallet,
+	proposal *MovedFundsSweepProposal,
+	startBlock uint64,
+	expiryBlock uint64,
+) {
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getSigningExecutor may change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet [0x%x]; "+
+				"ignoring the received moved funds sweep proposal",
+			walletPublicKeyBytes,
+		)
+		return
+	}
+
+	logger.Infof(
+		"starting orchestration of the moved funds sweep action for "+
+			"wallet [0x%x]; 20-byte public key hash of that wallet is [0x%x]",
+		walletPublicKeyBytes,
+		bitcoin.PublicKeyHash(wallet.publicKey),
+	)
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionMovedFundsSweep.String()),
+		zap.Uint64("startBlock", startBlock),
+		zap.Uint64("expiryBlock", expiryBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newMovedFundsSweepAction(
 		walletActionLogger,
 		n.chain,
 		n.btcChain,
@@ -608,6 +798,74 @@ func (n *node) handleRedemptionProposal(
 	walletActionLogger.Infof("wallet action dispatched successfully")
 }
 
+// handleFeeBumpProposal handles an incoming fee bump proposal by
+// orchestrating and dispatching an appropriate wallet action.
+func (n *node) handleFeeBumpProposal(
+	wallet wallet,
+	proposal *FeeBumpProposal,
+	startBlock uint64,
+	expiryBlock uint64,
+) {
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getSigningExecutor may change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet [0x%x]; "+
+				"ignoring the received fee bump proposal",
+			walletPublicKeyBytes,
+		)
+		return
+	}
+
+	logger.Infof(
+		"starting orchestration of the fee bump action for wallet [0x%x]; "+
+			"20-byte public key hash of that wallet is [0x%x]",
+		walletPublicKeyBytes,
+		bitcoin.PublicKeyHash(wallet.publicKey),
+	)
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionFeeBump.String()),
+		zap.Uint64("startBlock", startBlock),
+		zap.Uint64("expiryBlock", expiryBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newFeeBumpAction(
+		walletActionLogger,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		n.feeBumpWatcher,
+		proposal,
+		startBlock,
+		expiryBlock,
+		n.waitForBlockHeight,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
 // coordinationLayerSettings represents settings for the coordination layer.
 type coordinationLayerSettings struct {
 	// executeCoordinationProcedureFn is a function executing the coordination
@@ -752,8 +1010,12 @@ func executeCoordinationProcedure(
 func processCoordinationResult(node *node, result *coordinationResult) {
 	logger.Infof("processing coordination result [%s]", result)
 
-	// TODO: In the future, create coordination faults cache and
-	//       record faults from the processed results there.
+	operatorAddress, err := node.operatorAddress()
+	if err != nil {
+		logger.Errorf("cannot get node's operator address: [%v]", err)
+	}
+
+	node.actionHistory.recordCoordinationResult(result, operatorAddress)
 
 	proposedAction := result.proposal.ActionType()
 
@@ -794,25 +1056,33 @@ func processCoordinationResult(node *node, result *coordinationResult) {
 				expiryBlock,
 			)
 		}
-	// TODO: Uncomment when moving funds support is implemented.
-	// case ActionMovingFunds:
-	//	 if proposal, ok := result.proposal.(*MovingFundsProposal); ok {
-	//	 	 node.handleMovingFundsProposal(
-	//	 	 	 result.wallet,
-	//	 	 	 proposal,
-	//	 	 	 startBlock,
-	//	 	 	 expiryBlock,
-	//	 	 )
-	//	 }
-	// case ActionMovedFundsSweep:
-	//	 if proposal, ok := result.proposal.(*MovedFundsSweepProposal); ok {
-	//	 	 node.handleMovedFundsSweepProposal(
-	//	 	 	 result.wallet,
-	//	 	 	 proposal,
-	//	 	 	 startBlock,
-	//	 	 	 expiryBlock,
-	//	 	 )
-	//	 }
+	case ActionMovingFunds:
+		if proposal, ok := result.proposal.(*MovingFundsProposal); ok {
+			node.handleMovingFundsProposal(
+				result.wallet,
+				proposal,
+				startBlock,
+				expiryBlock,
+			)
+		}
+	case ActionMovedFundsSweep:
+		if proposal, ok := result.proposal.(*MovedFundsSweepProposal); ok {
+			node.handleMovedFundsSweepProposal(
+				result.wallet,
+				proposal,
+				startBlock,
+				expiryBlock,
+			)
+		}
+	case ActionFeeBump:
+		if proposal, ok := result.proposal.(*FeeBumpProposal); ok {
+			node.handleFeeBumpProposal(
+				result.wallet,
+				proposal,
+				startBlock,
+				expiryBlock,
+			)
+		}
 	default:
 		logger.Errorf("no handler for coordination result [%s]", result)
 	}
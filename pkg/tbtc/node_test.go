@@ -42,7 +42,7 @@ func TestNode_GetSigningExecutor(t *testing.T) {
 		localProvider,
 		keyStorePersistence,
 		&mockPersistenceHandle{},
-		generator.StartScheduler(),
+		newTestScheduler(t),
 		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
@@ -160,7 +160,7 @@ func TestNode_GetCoordinationExecutor(t *testing.T) {
 		localProvider,
 		keyStorePersistence,
 		&mockPersistenceHandle{},
-		generator.StartScheduler(),
+		newTestScheduler(t),
 		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
@@ -283,7 +283,7 @@ func TestNode_RunCoordinationLayer(t *testing.T) {
 		localProvider,
 		keyStorePersistence,
 		&mockPersistenceHandle{},
-		generator.StartScheduler(),
+		newTestScheduler(t),
 		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
@@ -422,6 +422,20 @@ func (mcp *mockCoordinationProposal) Unmarshal(bytes []byte) error {
 	panic("unsupported")
 }
 
+// newTestScheduler creates a scheduler for a test node. The pre-parameters
+// generation of the node keeps running once the test is done and starves
+// tests executed later so it is stopped upon the test cleanup by reporting
+// an executing protocol to the scheduler.
+func newTestScheduler(t *testing.T) *generator.Scheduler {
+	scheduler := generator.StartScheduler()
+
+	testDoneLatch := generator.NewProtocolLatch()
+	scheduler.RegisterProtocol(testDoneLatch)
+	t.Cleanup(testDoneLatch.Lock)
+
+	return scheduler
+}
+
 // createMockSigner creates a mock signer instance that can be used for
 // test cases that needs a placeholder signer. The produced signer cannot
 // be used to test actual signing scenarios.
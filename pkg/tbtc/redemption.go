@@ -107,6 +107,7 @@ type redemptionAction struct {
 
 	redeemingWallet     wallet
 	transactionExecutor *walletTransactionExecutor
+	feeBumpWatcher      *feeBumpWatcher
 
 	proposal                     *RedemptionProposal
 	proposalProcessingStartBlock uint64
@@ -126,6 +127,7 @@ func newRedemptionAction(
 	btcChain bitcoin.Chain,
 	redeemingWallet wallet,
 	signingExecutor walletSigningExecutor,
+	feeBumpWatcher *feeBumpWatcher,
 	proposal *RedemptionProposal,
 	proposalProcessingStartBlock uint64,
 	proposalExpiryBlock uint64,
@@ -146,6 +148,7 @@ func newRedemptionAction(
 		btcChain:                         btcChain,
 		redeemingWallet:                  redeemingWallet,
 		transactionExecutor:              transactionExecutor,
+		feeBumpWatcher:                   feeBumpWatcher,
 		proposal:                         proposal,
 		proposalProcessingStartBlock:     proposalProcessingStartBlock,
 		proposalExpiryBlock:              proposalExpiryBlock,
@@ -255,9 +258,63 @@ func (ra *redemptionAction) execute() error {
 		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
 	}
 
+	ra.watchTransaction(
+		walletPublicKeyHash,
+		redemptionTx,
+		walletMainUtxo,
+		validatedRequests,
+	)
+
 	return nil
 }
 
+// watchTransaction starts watching the broadcast redemption transaction
+// in order to bump its fee if the confirmation lags. The maximum fee of the
+// transaction is determined by the Bridge redemption tx max total fee limit
+// and the tx max fee limits of individual redemption requests.
+func (ra *redemptionAction) watchTransaction(
+	walletPublicKeyHash [20]byte,
+	redemptionTx *bitcoin.Transaction,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	requests []*RedemptionRequest,
+) {
+	_, _, _, txMaxTotalFee, _, _, _, err := ra.chain.GetRedemptionParameters()
+	if err != nil {
+		ra.logger.Warnf(
+			"cannot watch redemption transaction; cannot get redemption "+
+				"tx max total fee: [%v]",
+			err,
+		)
+		return
+	}
+
+	// The fee is distributed evenly over all requests so, the per-request
+	// share must not exceed the lowest tx max fee among the requests.
+	maxFee := int64(txMaxTotalFee)
+	for _, request := range requests {
+		requestsMaxFee := int64(request.TxMaxFee) * int64(len(requests))
+		if requestsMaxFee < maxFee {
+			maxFee = requestsMaxFee
+		}
+	}
+
+	err = ra.feeBumpWatcher.watch(
+		walletPublicKeyHash,
+		&watchedTransaction{
+			actionType:                 ActionRedemption,
+			transaction:                redemptionTx,
+			fee:                        ra.proposal.RedemptionTxFee.Int64(),
+			maxFee:                     maxFee,
+			walletMainUtxo:             walletMainUtxo,
+			redemptionRequests:         requests,
+			redemptionTransactionShape: ra.transactionShape,
+		},
+	)
+	if err != nil {
+		ra.logger.Warnf("cannot watch redemption transaction: [%v]", err)
+	}
+}
+
 // ValidateRedemptionProposal checks the redemption proposal with on-chain
 // validation rules.
 func ValidateRedemptionProposal(
@@ -333,6 +390,10 @@ func (ra *redemptionAction) actionType() WalletActionType {
 	return ActionRedemption
 }
 
+func (ra *redemptionAction) broadcastedTransaction() (bitcoin.Hash, bool) {
+	return ra.transactionExecutor.broadcastedTransaction()
+}
+
 // redemptionFeeDistributionFn calculates the redemption transaction fee
 // distribution for the given redemption requests. The resulting list
 // contains the fee shares ordered in the same way as the input requests, i.e.
@@ -397,6 +458,7 @@ func assembleRedemptionTransaction(
 	}
 
 	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+	builder.SignalReplaceability()
 
 	err := builder.AddPublicKeyHashInput(walletMainUtxo)
 	if err != nil {
@@ -128,6 +128,7 @@ func TestRedemptionAction_Execute(t *testing.T) {
 				bitcoinChain,
 				wallet,
 				signingExecutor,
+				newTestFeeBumpWatcher(t, bitcoinChain),
 				proposal,
 				proposalProcessingStartBlock,
 				proposalExpiryBlock,
@@ -2,6 +2,7 @@ package tbtc
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -274,11 +275,21 @@ func (mph *mockPersistenceHandle) Save(
 	directory string,
 	name string,
 ) error {
-	mph.saved = append(mph.saved, &mockDescriptor{
+	descriptor := &mockDescriptor{
 		name:      name,
 		directory: directory,
 		content:   data,
-	})
+	}
+
+	// Overwrite the existing data, just as the disk persistence does.
+	for i, saved := range mph.saved {
+		if saved.Directory() == directory && saved.Name() == name {
+			mph.saved[i] = descriptor
+			return nil
+		}
+	}
+
+	mph.saved = append(mph.saved, descriptor)
 
 	return nil
 }
@@ -313,7 +324,14 @@ func (mph *mockPersistenceHandle) Archive(directory string) error {
 }
 
 func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
-	panic("not implemented")
+	for i, saved := range mph.saved {
+		if saved.Directory() == directory && saved.Name() == name {
+			mph.saved = append(mph.saved[:i], mph.saved[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("data [%s] not found in directory [%s]", name, directory)
 }
 
 type mockDescriptor struct {
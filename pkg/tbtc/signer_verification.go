@@ -0,0 +1,368 @@
+package tbtc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// signerVerificationStatus represents the outcome of a signer verification.
+type signerVerificationStatus string
+
+const (
+	// signerValid means the signer passed all local and on-chain checks.
+	signerValid signerVerificationStatus = "valid"
+	// signerUnverified means the signer passed local checks but its on-chain
+	// membership could not be confirmed, e.g. due to a chain connectivity
+	// problem. The signer is kept in the keystore.
+	signerUnverified signerVerificationStatus = "unverified"
+	// signerInvalid means the signer is invalid but could not be moved to
+	// quarantine. The signer is kept in the keystore.
+	signerInvalid signerVerificationStatus = "invalid"
+	// signerQuarantined means the signer is invalid and its file was moved
+	// out of the keystore to the quarantine directory.
+	signerQuarantined signerVerificationStatus = "quarantined"
+)
+
+// keyStoreQuarantine is implemented by keystore persistence handles that
+// allow to move invalid files out of the keystore.
+type keyStoreQuarantine interface {
+	// Quarantine moves the file with the given name from the given directory
+	// of the keystore to the quarantine directory.
+	Quarantine(directory string, name string) error
+}
+
+// signerVerificationResult holds the outcome of the verification of a single
+// signer file.
+type signerVerificationResult struct {
+	Directory               string                   `json:"directory"`
+	Name                    string                   `json:"name"`
+	WalletPublicKeyHash     string                   `json:"wallet_public_key_hash,omitempty"`
+	SigningGroupMemberIndex group.MemberIndex        `json:"signing_group_member_index,omitempty"`
+	Status                  signerVerificationStatus `json:"status"`
+	Reason                  string                   `json:"reason"`
+}
+
+// signersVerificationReport summarizes the verification of all signers
+// stored in the keystore. Only signers that did not pass all the checks are
+// listed in detail.
+type signersVerificationReport struct {
+	VerifiedAt  time.Time                   `json:"verified_at"`
+	Valid       int                         `json:"valid"`
+	Unverified  int                         `json:"unverified"`
+	Invalid     int                         `json:"invalid"`
+	Quarantined int                         `json:"quarantined"`
+	Signers     []*signerVerificationResult `json:"signers"`
+}
+
+// record adds the given result to the report.
+func (svr *signersVerificationReport) record(
+	result *signerVerificationResult,
+) {
+	switch result.Status {
+	case signerValid:
+		svr.Valid++
+		return
+	case signerUnverified:
+		svr.Unverified++
+	case signerInvalid:
+		svr.Invalid++
+	case signerQuarantined:
+		svr.Quarantined++
+	}
+
+	svr.Signers = append(svr.Signers, result)
+}
+
+// walletMembership holds on-chain data of a wallet needed to verify the
+// membership of the wallet's signers.
+type walletMembership struct {
+	walletID         [32]byte
+	walletMembersIDs chain.OperatorIDs
+	err              error
+}
+
+// signersVerifier verifies signers stored in the keystore before they are
+// loaded by the wallet registry. A signer is verified locally to make sure
+// its private key share corresponds to the wallet public key and that it
+// occupies a seat of the node's operator in the wallet's signing group.
+// Then, the signer's membership is confirmed on-chain. Signers that are
+// found invalid are moved to the quarantine, if the keystore supports it,
+// so they are not loaded by the wallet registry.
+type signersVerifier struct {
+	chain           Chain
+	persistence     persistence.ProtectedHandle
+	operatorAddress chain.Address
+
+	operatorsIDs      map[chain.Address]chain.OperatorID
+	walletMemberships map[[20]byte]*walletMembership
+}
+
+// verifySigners verifies all signers stored in the given keystore
+// persistence and returns the verification report.
+func verifySigners(
+	tbtcChain Chain,
+	keyStorePersistence persistence.ProtectedHandle,
+	operatorAddress chain.Address,
+) *signersVerificationReport {
+	verifier := &signersVerifier{
+		chain:             tbtcChain,
+		persistence:       keyStorePersistence,
+		operatorAddress:   operatorAddress,
+		operatorsIDs:      make(map[chain.Address]chain.OperatorID),
+		walletMemberships: make(map[[20]byte]*walletMembership),
+	}
+
+	report := &signersVerificationReport{
+		VerifiedAt: time.Now(),
+		Signers:    make([]*signerVerificationResult, 0),
+	}
+
+	for _, descriptor := range verifier.readDescriptors() {
+		result := verifier.verify(descriptor)
+
+		switch result.Status {
+		case signerUnverified:
+			logger.Warnf(
+				"could not verify signer from file [%v] in directory [%v]: [%v]",
+				result.Name,
+				result.Directory,
+				result.Reason,
+			)
+		case signerInvalid:
+			logger.Errorf(
+				"signer from file [%v] in directory [%v] is invalid "+
+					"and could not be quarantined: [%v]",
+				result.Name,
+				result.Directory,
+				result.Reason,
+			)
+		case signerQuarantined:
+			logger.Errorf(
+				"signer from file [%v] in directory [%v] is invalid "+
+					"and was quarantined: [%v]",
+				result.Name,
+				result.Directory,
+				result.Reason,
+			)
+		}
+
+		report.record(result)
+	}
+
+	logger.Infof(
+		"verified signers stored in the keystore; valid: [%v], "+
+			"unverified: [%v], invalid: [%v], quarantined: [%v]",
+		report.Valid,
+		report.Unverified,
+		report.Invalid,
+		report.Quarantined,
+	)
+
+	return report
+}
+
+// readDescriptors reads descriptors of all files stored in the keystore.
+// Read errors are logged as the wallet registry would do.
+func (sv *signersVerifier) readDescriptors() []persistence.DataDescriptor {
+	descriptors := make([]persistence.DataDescriptor, 0)
+
+	descriptorsChan, errorsChan := sv.persistence.ReadAll()
+
+	// Channels are not buffered so they must be drained concurrently.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			descriptors = append(descriptors, descriptor)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			logger.Errorf(
+				"could not read signer from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return descriptors
+}
+
+// verify verifies the signer stored in the file described by the given
+// descriptor and quarantines the file if the signer is invalid.
+func (sv *signersVerifier) verify(
+	descriptor persistence.DataDescriptor,
+) *signerVerificationResult {
+	result := &signerVerificationResult{
+		Directory: descriptor.Directory(),
+		Name:      descriptor.Name(),
+	}
+
+	content, err := descriptor.Content()
+	if err != nil {
+		// The content may not be readable due to a transient problem
+		// so do not quarantine the file.
+		result.Status = signerUnverified
+		result.Reason = fmt.Sprintf("cannot read file: [%v]", err)
+		return result
+	}
+
+	signer := &signer{}
+	if err := signer.Unmarshal(content); err != nil {
+		return sv.quarantine(
+			descriptor,
+			result,
+			fmt.Errorf("cannot unmarshal signer: [%v]", err),
+		)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	result.WalletPublicKeyHash = fmt.Sprintf("0x%x", walletPublicKeyHash)
+	result.SigningGroupMemberIndex = signer.signingGroupMemberIndex
+
+	if err := verifySigner(signer); err != nil {
+		return sv.quarantine(descriptor, result, err)
+	}
+
+	seatOperator := signer.wallet.signingGroupOperators[signer.signingGroupMemberIndex-1]
+	if seatOperator != sv.operatorAddress {
+		return sv.quarantine(
+			descriptor,
+			result,
+			fmt.Errorf(
+				"member index [%v] is occupied by operator [%v] "+
+					"instead of this node's operator [%v]",
+				signer.signingGroupMemberIndex,
+				seatOperator,
+				sv.operatorAddress,
+			),
+		)
+	}
+
+	membership := sv.walletMembership(walletPublicKeyHash, signer.wallet)
+	if membership.err != nil {
+		result.Status = signerUnverified
+		result.Reason = membership.err.Error()
+		return result
+	}
+
+	isWalletMember, err := sv.chain.IsWalletMember(
+		membership.walletID,
+		membership.walletMembersIDs,
+		sv.operatorAddress,
+		signer.signingGroupMemberIndex,
+	)
+	if err != nil {
+		result.Status = signerUnverified
+		result.Reason = fmt.Sprintf(
+			"cannot check wallet membership: [%v]",
+			err,
+		)
+		return result
+	}
+
+	if !isWalletMember {
+		return sv.quarantine(
+			descriptor,
+			result,
+			fmt.Errorf(
+				"operator is not a member of the wallet's signing group "+
+					"at member index [%v]",
+				signer.signingGroupMemberIndex,
+			),
+		)
+	}
+
+	result.Status = signerValid
+	return result
+}
+
+// walletMembership returns on-chain membership data of the given wallet.
+// The data are fetched once per wallet.
+func (sv *signersVerifier) walletMembership(
+	walletPublicKeyHash [20]byte,
+	wallet wallet,
+) *walletMembership {
+	if membership, ok := sv.walletMemberships[walletPublicKeyHash]; ok {
+		return membership
+	}
+
+	membership := &walletMembership{}
+	sv.walletMemberships[walletPublicKeyHash] = membership
+
+	walletChainData, err := sv.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		membership.err = fmt.Errorf("cannot get wallet data: [%v]", err)
+		return membership
+	}
+
+	membership.walletID = walletChainData.EcdsaWalletID
+	membership.walletMembersIDs = make(
+		chain.OperatorIDs,
+		len(wallet.signingGroupOperators),
+	)
+
+	for i, operatorAddress := range wallet.signingGroupOperators {
+		operatorID, ok := sv.operatorsIDs[operatorAddress]
+		if !ok {
+			operatorID, err = sv.chain.GetOperatorID(operatorAddress)
+			if err != nil {
+				membership.err = fmt.Errorf(
+					"cannot get ID of operator [%v]: [%v]",
+					operatorAddress,
+					err,
+				)
+				return membership
+			}
+
+			sv.operatorsIDs[operatorAddress] = operatorID
+		}
+
+		membership.walletMembersIDs[i] = operatorID
+	}
+
+	return membership
+}
+
+// quarantine moves the file described by the given descriptor to the
+// quarantine and completes the given result accordingly.
+func (sv *signersVerifier) quarantine(
+	descriptor persistence.DataDescriptor,
+	result *signerVerificationResult,
+	reason error,
+) *signerVerificationResult {
+	result.Reason = reason.Error()
+
+	quarantine, ok := sv.persistence.(keyStoreQuarantine)
+	if !ok {
+		result.Status = signerInvalid
+		result.Reason += "; keystore does not support quarantine"
+		return result
+	}
+
+	err := quarantine.Quarantine(descriptor.Directory(), descriptor.Name())
+	if err != nil {
+		result.Status = signerInvalid
+		result.Reason += fmt.Sprintf("; cannot quarantine file: [%v]", err)
+		return result
+	}
+
+	result.Status = signerQuarantined
+	return result
+}
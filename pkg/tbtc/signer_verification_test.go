@@ -0,0 +1,263 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestVerifySigners(t *testing.T) {
+	walletID := [32]byte{0x01, 0x02}
+
+	tests := map[string]struct {
+		// modifySigner modifies the signer with member index 1.
+		modifySigner         func(signer *signer)
+		walletMembersIDs     chain.OperatorIDs
+		quarantineSupported  bool
+		expectedStatus       signerVerificationStatus
+		expectedReason       string
+		expectedValidSigners int
+	}{
+		"all signers valid": {
+			walletMembersIDs:     chain.OperatorIDs{1, 1, 1, 1, 1},
+			quarantineSupported:  true,
+			expectedStatus:       signerValid,
+			expectedValidSigners: 5,
+		},
+		"corrupted private key share": {
+			modifySigner: func(signer *signer) {
+				data := signer.privateKeyShare.Data()
+				data.Xi = new(big.Int).Add(data.Xi, big.NewInt(1))
+				signer.privateKeyShare = tecdsa.NewPrivateKeyShare(data)
+			},
+			walletMembersIDs:     chain.OperatorIDs{1, 1, 1, 1, 1},
+			quarantineSupported:  true,
+			expectedStatus:       signerQuarantined,
+			expectedReason:       "secret share does not match its public share",
+			expectedValidSigners: 4,
+		},
+		"seat occupied by another operator": {
+			modifySigner: func(signer *signer) {
+				operators := make(
+					[]chain.Address,
+					len(signer.wallet.signingGroupOperators),
+				)
+				copy(operators, signer.wallet.signingGroupOperators)
+				operators[0] = "address-1"
+				signer.wallet.signingGroupOperators = operators
+			},
+			walletMembersIDs:     chain.OperatorIDs{1, 1, 1, 1, 1},
+			quarantineSupported:  true,
+			expectedStatus:       signerQuarantined,
+			expectedReason:       "member index [1] is occupied by operator [address-1]",
+			expectedValidSigners: 4,
+		},
+		"quarantine not supported": {
+			modifySigner: func(signer *signer) {
+				data := signer.privateKeyShare.Data()
+				data.Xi = new(big.Int).Add(data.Xi, big.NewInt(1))
+				signer.privateKeyShare = tecdsa.NewPrivateKeyShare(data)
+			},
+			walletMembersIDs:     chain.OperatorIDs{1, 1, 1, 1, 1},
+			quarantineSupported:  false,
+			expectedStatus:       signerInvalid,
+			expectedReason:       "keystore does not support quarantine",
+			expectedValidSigners: 4,
+		},
+		"wallet members mismatch": {
+			walletMembersIDs:     chain.OperatorIDs{1, 1, 1, 1, 2},
+			quarantineSupported:  true,
+			expectedStatus:       signerUnverified,
+			expectedReason:       "invalid wallet members identifiers",
+			expectedValidSigners: 0,
+		},
+		"wallet not registered": {
+			quarantineSupported:  true,
+			expectedStatus:       signerUnverified,
+			expectedReason:       "wallet not registered",
+			expectedValidSigners: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := Connect()
+
+			operatorAddress, err := localChain.operatorAddress()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signers := createMockSigners(t, 5)
+			for _, signer := range signers {
+				signer.wallet.signingGroupOperators = []chain.Address{
+					operatorAddress,
+					operatorAddress,
+					operatorAddress,
+					operatorAddress,
+					operatorAddress,
+				}
+			}
+
+			if test.modifySigner != nil {
+				test.modifySigner(signers[0])
+			}
+
+			localChain.setWallet(
+				bitcoin.PublicKeyHash(signers[0].wallet.publicKey),
+				&WalletChainData{EcdsaWalletID: walletID},
+			)
+			if test.walletMembersIDs != nil {
+				localChain.setWalletMembers(walletID, test.walletMembersIDs)
+			}
+
+			var keyStorePersistence persistence.ProtectedHandle
+			mockPersistence := createMockKeyStorePersistence(t, signers...)
+			if test.quarantineSupported {
+				keyStorePersistence = &mockQuarantinePersistenceHandle{
+					mockPersistenceHandle: mockPersistence,
+				}
+			} else {
+				keyStorePersistence = mockPersistence
+			}
+
+			report := verifySigners(
+				localChain,
+				keyStorePersistence,
+				operatorAddress,
+			)
+
+			testutils.AssertIntsEqual(
+				t,
+				"valid signers",
+				test.expectedValidSigners,
+				report.Valid,
+			)
+
+			if test.expectedStatus == signerValid {
+				testutils.AssertIntsEqual(
+					t,
+					"reported signers",
+					0,
+					len(report.Signers),
+				)
+				return
+			}
+
+			var result *signerVerificationResult
+			for _, reported := range report.Signers {
+				if reported.SigningGroupMemberIndex == 1 {
+					result = reported
+				}
+			}
+			if result == nil {
+				t.Fatal("signer with member index [1] not reported")
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"status",
+				string(test.expectedStatus),
+				string(result.Status),
+			)
+
+			if !strings.Contains(result.Reason, test.expectedReason) {
+				t.Errorf(
+					"unexpected reason\nexpected: %v\nactual:   %v\n",
+					test.expectedReason,
+					result.Reason,
+				)
+			}
+
+			expectedQuarantined := 0
+			if test.expectedStatus == signerQuarantined {
+				expectedQuarantined = 1
+			}
+			testutils.AssertIntsEqual(
+				t,
+				"quarantined signers",
+				expectedQuarantined,
+				report.Quarantined,
+			)
+
+			// Quarantined signers must not be loaded by the wallet registry.
+			walletRegistry := newWalletRegistry(keyStorePersistence)
+			testutils.AssertIntsEqual(
+				t,
+				"loaded signers",
+				5-expectedQuarantined,
+				len(walletRegistry.getSigners(signers[0].wallet.publicKey)),
+			)
+		})
+	}
+}
+
+func TestVerifySigners_MalformedFile(t *testing.T) {
+	localChain := Connect()
+
+	operatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyStorePersistence := &mockQuarantinePersistenceHandle{
+		mockPersistenceHandle: &mockPersistenceHandle{
+			saved: []persistence.DataDescriptor{
+				&mockDescriptor{
+					name:      "membership_1",
+					directory: "wallet",
+					content:   []byte("malformed"),
+				},
+			},
+		},
+	}
+
+	report := verifySigners(localChain, keyStorePersistence, operatorAddress)
+
+	testutils.AssertIntsEqual(t, "quarantined signers", 1, report.Quarantined)
+	testutils.AssertIntsEqual(
+		t,
+		"keystore entries",
+		0,
+		len(keyStorePersistence.saved),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"quarantined entries",
+		1,
+		len(keyStorePersistence.quarantined),
+	)
+
+	if !strings.Contains(report.Signers[0].Reason, "cannot unmarshal signer") {
+		t.Errorf("unexpected reason: [%v]", report.Signers[0].Reason)
+	}
+}
+
+type mockQuarantinePersistenceHandle struct {
+	*mockPersistenceHandle
+
+	quarantined []persistence.DataDescriptor
+}
+
+func (mqph *mockQuarantinePersistenceHandle) Quarantine(
+	directory string,
+	name string,
+) error {
+	for i, descriptor := range mqph.saved {
+		if descriptor.Directory() == directory && descriptor.Name() == name {
+			mqph.saved = append(mqph.saved[:i], mqph.saved[i+1:]...)
+			mqph.quarantined = append(mqph.quarantined, descriptor)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("file [%v] not found in directory [%v]", name, directory)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 
@@ -32,12 +33,41 @@ const (
 	// completed by the slowest signing group member (the one who sends the
 	// signingDoneMessage as the last one).
 	signingBatchInterludeBlocks = 2
+
+	// signingBatchConcurrencyLimit determines the maximum number of messages
+	// from a signing batch that are signed concurrently. Each message is
+	// signed in a separate signing session with its own retry loop. Once the
+	// limit is reached, the signing of the message at index N starts only
+	// after the signing of the message at index N-signingBatchConcurrencyLimit
+	// completes. The start block of the message at index N is then
+	// established the same way as for subsequent signings, i.e. based on the
+	// end block of the message at index N-signingBatchConcurrencyLimit and
+	// signingBatchInterludeBlocks. The end block is common for all signing
+	// group members so, all of them start the given signing at the same block.
+	// This value must be the same for all signing group members.
+	signingBatchConcurrencyLimit = 4
 )
 
 // errSigningExecutorBusy is an error returned when the signing executor
 // cannot execute the requested signature due to an ongoing signing.
 var errSigningExecutorBusy = fmt.Errorf("signing executor is busy")
 
+// signingFailedError is an error returned when none of the signers controlled
+// by the signing executor managed to produce a signature.
+type signingFailedError struct {
+	// inactiveMembersIndexes holds indexes of signing group members who did
+	// not announce their readiness in any signing attempt, from the
+	// perspective of all signers controlled by the signing executor.
+	inactiveMembersIndexes []group.MemberIndex
+	// timeoutBlock is the block at which the signing timed out. This block
+	// is common for all signing group members.
+	timeoutBlock uint64
+}
+
+func (sfe *signingFailedError) Error() string {
+	return "all signers failed"
+}
+
 // signingExecutor is a component responsible for executing signing related to
 // a specific wallet whose part is controlled by this node.
 type signingExecutor struct {
@@ -84,8 +114,10 @@ func newSigningExecutor(
 }
 
 // signBatch performs the signing process for each message from the given
-// messages batch, one after another. If at least one message cannot be signed,
-// this function returns an error. If all messages were signed successfully,
+// messages batch. Messages are signed concurrently, in separate signing
+// sessions, though no more than signingBatchConcurrencyLimit messages are
+// signed at the same time. If at least one message cannot be signed, this
+// function returns an error. If all messages were signed successfully,
 // a slice of signatures is returned. Order of the returned signatures matches
 // the order of the messages in the batch, i.e. the first signature corresponds
 // to the first message, and so on.
@@ -94,6 +126,11 @@ func (se *signingExecutor) signBatch(
 	messages []*big.Int,
 	startBlock uint64,
 ) ([]*tecdsa.Signature, error) {
+	if lockAcquired := se.lock.TryAcquire(1); !lockAcquired {
+		return nil, errSigningExecutorBusy
+	}
+	defer se.lock.Release(1)
+
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
@@ -129,38 +166,145 @@ func (se *signingExecutor) signBatch(
 		zap.String("signedMessages", strings.Join(messagesDigests, ", ")),
 	)
 
-	signingStartBlock := startBlock // start block for the first signing
-	signatures := make([]*tecdsa.Signature, len(messages))
-	endBlocks := make([]uint64, len(messages))
-
+	// Signing sessions of the same message would share session IDs so,
+	// each distinct message is signed only once and its signature is used
+	// for all its occurrences in the batch.
+	uniqueMessages := make([]*big.Int, 0)
+	uniqueMessagesIndexes := make(map[string]int)
+	messagesIndexes := make([]int, len(messages))
 	for i, message := range messages {
-		signingBatchMessageLogger := signingBatchLogger.With(
-			zap.String("signedMessage", fmt.Sprintf("0x%x", message)),
-			zap.String("index", fmt.Sprintf("%v/%v", i+1, len(messages))),
-		)
+		key := message.Text(16)
+
+		index, ok := uniqueMessagesIndexes[key]
+		if !ok {
+			index = len(uniqueMessages)
+			uniqueMessagesIndexes[key] = index
+			uniqueMessages = append(uniqueMessages, message)
+		}
+
+		messagesIndexes[i] = index
+	}
+
+	// The batch context is canceled once the signing of any message fails
+	// as there is no point to continue signing other messages of the batch.
+	// It is not canceled upon successful completion immediately though.
+	// Signing loops of the signed messages keep broadcasting signing done
+	// checks in the background until their timeouts so the batch context
+	// is canceled only once the latest loop timeout block is reached.
+	batchCtx, cancelBatchCtx := context.WithCancel(ctx)
+
+	signatures := make([]*tecdsa.Signature, len(uniqueMessages))
+	endBlocks := make([]uint64, len(uniqueMessages))
+	loopTimeoutBlocks := make([]uint64, len(uniqueMessages))
+	signingDone := make([]chan struct{}, len(uniqueMessages))
+	for i := range signingDone {
+		signingDone[i] = make(chan struct{})
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(uniqueMessages))
+	errChan := make(chan error, len(uniqueMessages))
+
+	for i, message := range uniqueMessages {
+		go func(i int, message *big.Int) {
+			defer wg.Done()
+			defer close(signingDone[i])
+
+			signingBatchMessageLogger := signingBatchLogger.With(
+				zap.String("signedMessage", fmt.Sprintf("0x%x", message)),
+				zap.String(
+					"index",
+					fmt.Sprintf("%v/%v", i+1, len(uniqueMessages)),
+				),
+			)
+
+			signingStartBlock := startBlock
+			if i >= signingBatchConcurrencyLimit {
+				previous := i - signingBatchConcurrencyLimit
+
+				select {
+				case <-signingDone[previous]:
+				case <-batchCtx.Done():
+					return
+				}
+
+				if signatures[previous] == nil {
+					// The signing of the previous message failed and the
+					// error has already been reported.
+					return
+				}
+
+				signingStartBlock = endBlocks[previous] +
+					signingBatchInterludeBlocks
+			}
+
+			loopTimeoutBlocks[i] = signingStartBlock +
+				uint64(se.signingAttemptsLimit*signingAttemptMaximumBlocks())
+
+			signingBatchMessageLogger.Infof("generating signature for message")
+
+			signature, endBlock, err := se.signMessage(
+				batchCtx,
+				message,
+				signingStartBlock,
+			)
+			if err != nil {
+				errChan <- err
+				cancelBatchCtx()
+				return
+			}
+
+			signingBatchMessageLogger.Infof(
+				"generated signature [%v] for message at block [%v]",
+				signature,
+				endBlock,
+			)
+
+			signatures[i] = signature
+			endBlocks[i] = endBlock
+		}(i, message)
+	}
 
-		signingBatchMessageLogger.Infof("generating signature for message")
+	// Wait until signings of all messages complete, regardless of their
+	// result.
+	wg.Wait()
+
+	// Take the first error as the error of the whole batch. Signings of other
+	// messages may have failed just because the batch context was canceled.
+	select {
+	case err := <-errChan:
+		// The batch context has already been canceled by the failed signing.
+		// Cancel it explicitly anyway to release its resources on all paths.
+		cancelBatchCtx()
+		return nil, err
+	default:
+	}
 
-		if i > 0 {
-			signingStartBlock = endBlocks[i-1] + signingBatchInterludeBlocks
+	var latestLoopTimeoutBlock uint64
+	for _, loopTimeoutBlock := range loopTimeoutBlocks {
+		if loopTimeoutBlock > latestLoopTimeoutBlock {
+			latestLoopTimeoutBlock = loopTimeoutBlock
 		}
+	}
+
+	go func() {
+		defer cancelBatchCtx()
 
-		signature, endBlock, err := se.sign(ctx, message, signingStartBlock)
+		err := se.waitForBlockFn(batchCtx, latestLoopTimeoutBlock)
 		if err != nil {
-			return nil, err
+			signingBatchLogger.Warnf(
+				"failed waiting for signing batch stop signal: [%v]",
+				err,
+			)
 		}
+	}()
 
-		signingBatchMessageLogger.Infof(
-			"generated signature [%v] for message at block [%v]",
-			signature,
-			endBlock,
-		)
-
-		signatures[i] = signature
-		endBlocks[i] = endBlock
+	batchSignatures := make([]*tecdsa.Signature, len(messages))
+	for i, index := range messagesIndexes {
+		batchSignatures[i] = signatures[index]
 	}
 
-	return signatures, nil
+	return batchSignatures, nil
 }
 
 // sign performs the signing process for the given message. The process is
@@ -179,6 +323,18 @@ func (se *signingExecutor) sign(
 	}
 	defer se.lock.Release(1)
 
+	return se.signMessage(ctx, message, startBlock)
+}
+
+// signMessage performs the signing process for the given message, exactly as
+// sign does, but without acquiring the signing executor lock. The caller is
+// responsible for holding the lock. Messages signed concurrently must be
+// distinct as the signing session IDs are derived from the message.
+func (se *signingExecutor) signMessage(
+	ctx context.Context,
+	message *big.Int,
+	startBlock uint64,
+) (*tecdsa.Signature, uint64, error) {
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
@@ -204,6 +360,7 @@ func (se *signingExecutor) sign(
 	wg := sync.WaitGroup{}
 	wg.Add(len(se.signers))
 	signingOutcomeChan := make(chan *signingOutcome, len(se.signers))
+	inactiveMembersChan := make(chan []group.MemberIndex, len(se.signers))
 
 	for _, currentSigner := range se.signers {
 		go func(signer *signer) {
@@ -286,11 +443,7 @@ func (se *signingExecutor) sign(
 						se.waitForBlockFn,
 					)
 
-					sessionID := fmt.Sprintf(
-						"%v-%v",
-						message.Text(16),
-						attempt.number,
-					)
+					sessionID := signing.SessionID(message, attempt.number)
 
 					result, err := signing.Execute(
 						attemptCtx,
@@ -331,6 +484,8 @@ func (se *signingExecutor) sign(
 					err,
 				)
 
+				inactiveMembersChan <- retryLoop.inactiveMembersIndexes()
+
 				return
 			}
 
@@ -385,10 +540,48 @@ func (se *signingExecutor) sign(
 	case outcome := <-signingOutcomeChan:
 		return outcome.signature, outcome.endBlock, nil
 	default:
-		return nil, 0, fmt.Errorf("all signers failed")
+		close(inactiveMembersChan)
+
+		return nil, 0, &signingFailedError{
+			inactiveMembersIndexes: commonInactiveMembersIndexes(
+				inactiveMembersChan,
+			),
+			timeoutBlock: loopTimeoutBlock,
+		}
 	}
 }
 
+// commonInactiveMembersIndexes returns indexes of members that were
+// considered inactive by all signers reporting their view through the given
+// channel. The returned indexes are sorted in ascending order.
+func commonInactiveMembersIndexes(
+	inactiveMembersChan <-chan []group.MemberIndex,
+) []group.MemberIndex {
+	reportsCount := 0
+	inactivityReports := make(map[group.MemberIndex]int)
+
+	for inactiveMembersIndexes := range inactiveMembersChan {
+		reportsCount++
+
+		for _, memberIndex := range inactiveMembersIndexes {
+			inactivityReports[memberIndex]++
+		}
+	}
+
+	commonInactiveMembers := make([]group.MemberIndex, 0)
+	for memberIndex, count := range inactivityReports {
+		if count == reportsCount {
+			commonInactiveMembers = append(commonInactiveMembers, memberIndex)
+		}
+	}
+
+	sort.Slice(commonInactiveMembers, func(i, j int) bool {
+		return commonInactiveMembers[i] < commonInactiveMembers[j]
+	})
+
+	return commonInactiveMembers
+}
+
 func (se *signingExecutor) wallet() wallet {
 	// All signers belong to one wallet. Take that wallet from the
 	// first signer.
@@ -93,6 +93,10 @@ type signingRetryLoop struct {
 	attemptStartBlock uint64
 	attemptSeed       int64
 
+	// activeMembersIndexes holds indexes of members who announced their
+	// readiness in at least one attempt.
+	activeMembersIndexes map[group.MemberIndex]bool
+
 	doneCheck signingDoneCheckStrategy
 }
 
@@ -123,6 +127,7 @@ func newSigningRetryLoop(
 		attemptCounter:          0,
 		attemptStartBlock:       initialStartBlock,
 		attemptSeed:             attemptSeed,
+		activeMembersIndexes:    make(map[group.MemberIndex]bool),
 		doneCheck:               doneCheck,
 	}
 }
@@ -261,6 +266,10 @@ func (srl *signingRetryLoop) start(
 			continue
 		}
 
+		for _, memberIndex := range readyMembersIndexes {
+			srl.activeMembersIndexes[memberIndex] = true
+		}
+
 		// Check the loop stop signal again. The announcement took some time
 		// and the context may be done now.
 		if ctx.Err() != nil {
@@ -534,3 +543,19 @@ func (srl *signingRetryLoop) excludedMembersIndexes(
 
 	return excludedMembersIndexes
 }
+
+// inactiveMembersIndexes returns indexes of signing group members who did
+// not announce their readiness in any of the attempts executed so far.
+// The returned indexes are sorted in ascending order.
+func (srl *signingRetryLoop) inactiveMembersIndexes() []group.MemberIndex {
+	inactiveMembersIndexes := make([]group.MemberIndex, 0)
+
+	for i := range srl.signingGroupOperators {
+		memberIndex := group.MemberIndex(i + 1)
+		if !srl.activeMembersIndexes[memberIndex] {
+			inactiveMembersIndexes = append(inactiveMembersIndexes, memberIndex)
+		}
+	}
+
+	return inactiveMembersIndexes
+}
@@ -4,13 +4,12 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"sync"
 	"testing"
-	"time"
 
 	"github.com/keep-network/keep-core/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
-	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
 	"github.com/keep-network/keep-core/pkg/net/local"
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -57,13 +56,16 @@ func TestSigningExecutor_Sign_Busy(t *testing.T) {
 	message := big.NewInt(100)
 	startBlock := uint64(0)
 
+	signingStarted := notifySigningStarted(executor)
+
 	errChan := make(chan error, 1)
 	go func() {
 		_, _, err := executor.sign(ctx, message, startBlock)
 		errChan <- err
 	}()
 
-	time.Sleep(100 * time.Millisecond)
+	// Wait until the first signing holds the executor lock.
+	<-signingStarted
 
 	_, _, err := executor.sign(ctx, message, startBlock)
 	testutils.AssertErrorsSame(t, errSigningExecutorBusy, err)
@@ -80,10 +82,16 @@ func TestSigningExecutor_SignBatch(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
+	// Use more messages than the concurrency limit to make sure signings
+	// exceeding the limit are started once previous signings are done.
+	// Also, include a duplicated message that should be signed only once.
 	messages := []*big.Int{
 		big.NewInt(1000),
 		big.NewInt(2000),
 		big.NewInt(3000),
+		big.NewInt(4000),
+		big.NewInt(5000),
+		big.NewInt(2000),
 	}
 	startBlock := uint64(0)
 
@@ -92,6 +100,13 @@ func TestSigningExecutor_SignBatch(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	testutils.AssertIntsEqual(
+		t,
+		"signatures count",
+		len(messages),
+		len(signatures),
+	)
+
 	walletPublicKey := executor.wallet().publicKey
 
 	for i, signature := range signatures {
@@ -106,6 +121,55 @@ func TestSigningExecutor_SignBatch(t *testing.T) {
 	}
 }
 
+func TestSigningExecutor_SignBatch_Busy(t *testing.T) {
+	executor := setupSigningExecutor(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	message := big.NewInt(100)
+	startBlock := uint64(0)
+
+	signingStarted := notifySigningStarted(executor)
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, _, err := executor.sign(ctx, message, startBlock)
+		errChan <- err
+	}()
+
+	// Wait until the first signing holds the executor lock.
+	<-signingStarted
+
+	_, err := executor.signBatch(
+		ctx,
+		[]*big.Int{big.NewInt(1000), big.NewInt(2000)},
+		startBlock,
+	)
+	testutils.AssertErrorsSame(t, errSigningExecutorBusy, err)
+
+	err = <-errChan
+	if err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+// notifySigningStarted returns a channel that is closed once the given
+// executor starts waiting for the start block of its first signing. At that
+// point, the executor lock is already acquired.
+func notifySigningStarted(executor *signingExecutor) <-chan struct{} {
+	signingStarted := make(chan struct{})
+	once := sync.Once{}
+
+	waitForBlockFn := executor.waitForBlockFn
+	executor.waitForBlockFn = func(ctx context.Context, block uint64) error {
+		once.Do(func() { close(signingStarted) })
+		return waitForBlockFn(ctx, block)
+	}
+
+	return signingStarted
+}
+
 // setupSigningExecutor sets up an instance of the signing executor ready
 // to perform test signing.
 func setupSigningExecutor(t *testing.T) *signingExecutor {
@@ -168,7 +232,7 @@ func setupSigningExecutor(t *testing.T) *signingExecutor {
 		localProvider,
 		keyStorePersistence,
 		&mockPersistenceHandle{},
-		generator.StartScheduler(),
+		newTestScheduler(t),
 		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
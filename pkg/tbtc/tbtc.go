@@ -65,6 +65,9 @@ type Config struct {
 	PreParamsGenerationConcurrency int
 	// Concurrency level for key-generation for tECDSA.
 	KeyGenerationConcurrency int
+	// Name of the policy used to select deposits for sweeping by the
+	// proposal generator. Available policies are defined in tbtcpg.
+	DepositSelectionPolicy string
 }
 
 // Initialize kicks off the TBTC by initializing internal state, ensuring
@@ -120,6 +123,16 @@ func Initialize(
 				},
 			},
 		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc",
+			func() clientinfo.ApplicationInfo {
+				return clientinfo.ApplicationInfo{
+					"action_history":       node.actionHistory.recent(),
+					"signers_verification": node.signersVerification,
+				}
+			},
+		)
 	}
 
 	err = sortition.MonitorPool(
@@ -30,6 +30,7 @@ const (
 	ActionRedemption
 	ActionMovingFunds
 	ActionMovedFundsSweep
+	ActionFeeBump
 )
 
 // ParseWalletActionType parses the given value into a WalletActionType.
@@ -47,6 +48,8 @@ func ParseWalletActionType(value uint8) (WalletActionType, error) {
 		return ActionMovingFunds, nil
 	case 5:
 		return ActionMovedFundsSweep, nil
+	case 6:
+		return ActionFeeBump, nil
 	default:
 		return 0, fmt.Errorf("unknown wallet action type [%v]", value)
 	}
@@ -66,6 +69,8 @@ func (wat WalletActionType) String() string {
 		return "MovingFunds"
 	case ActionMovedFundsSweep:
 		return "MovedFundsSweep"
+	case ActionFeeBump:
+		return "FeeBump"
 	default:
 		panic("unknown wallet action type")
 	}
@@ -83,6 +88,17 @@ type walletAction interface {
 	actionType() WalletActionType
 }
 
+// walletTransactionAction is a walletAction that produces a Bitcoin
+// transaction.
+type walletTransactionAction interface {
+	walletAction
+
+	// broadcastedTransaction returns the hash of the Bitcoin transaction
+	// broadcasted by the walletTransactionAction. The boolean flag is false
+	// if no transaction has been broadcasted.
+	broadcastedTransaction() (bitcoin.Hash, bool)
+}
+
 // WalletState represents the state of a wallet.
 type WalletState uint8
 
@@ -126,11 +142,14 @@ type walletDispatcher struct {
 	// given wallet. The mapping key is the uncompressed public key
 	// (with 04 prefix) of the wallet.
 	actions map[string]WalletActionType
+	// history records the start and the end of dispatched actions.
+	history *actionHistory
 }
 
-func newWalletDispatcher() *walletDispatcher {
+func newWalletDispatcher(history *actionHistory) *walletDispatcher {
 	return &walletDispatcher{
 		actions: make(map[string]WalletActionType),
+		history: history,
 	}
 }
 
@@ -166,8 +185,10 @@ func (wd *walletDispatcher) dispatch(action walletAction) error {
 		}()
 
 		walletActionLogger.Infof("starting action execution")
+		wd.history.recordActionStarted(action)
 
 		err := action.execute()
+		wd.history.recordActionFinished(action, err)
 		if err != nil {
 			walletActionLogger.Errorf(
 				"action execution terminated with error: [%v]",
@@ -201,6 +222,10 @@ type walletTransactionExecutor struct {
 	signingExecutor walletSigningExecutor
 
 	waitForBlockFn waitForBlockFn
+
+	// broadcastedTxHash is the hash of the last transaction passed to
+	// broadcastTransaction. Nil if no transaction has been broadcasted.
+	broadcastedTxHash *bitcoin.Hash
 }
 
 func newWalletTransactionExecutor(
@@ -291,6 +316,7 @@ func (wte *walletTransactionExecutor) broadcastTransaction(
 	checkDelay time.Duration,
 ) error {
 	txHash := tx.Hash()
+	wte.broadcastedTxHash = &txHash
 
 	broadcastCtx, cancelBroadcastCtx := context.WithTimeout(
 		context.Background(),
@@ -355,6 +381,20 @@ func (wte *walletTransactionExecutor) broadcastTransaction(
 	}
 }
 
+// broadcastedTransaction returns the hash of the last transaction passed to
+// broadcastTransaction. The boolean flag is false if no transaction has been
+// broadcasted.
+func (wte *walletTransactionExecutor) broadcastedTransaction() (
+	bitcoin.Hash,
+	bool,
+) {
+	if wte.broadcastedTxHash == nil {
+		return bitcoin.Hash{}, false
+	}
+
+	return *wte.broadcastedTxHash, true
+}
+
 // wallet represents a tBTC wallet. A wallet is one of the basic building
 // blocks of the system that takes BTC under custody during the deposit
 // process and gives that BTC back during redemptions.
@@ -51,9 +51,13 @@ func TestParseWalletActionType(t *testing.T) {
 			value:          5,
 			expectedAction: ActionMovedFundsSweep,
 		},
+		"fee bump": {
+			value:          6,
+			expectedAction: ActionFeeBump,
+		},
 		"unknown": {
-			value:       6,
-			expectedErr: fmt.Errorf("unknown wallet action type [6]"),
+			value:       7,
+			expectedErr: fmt.Errorf("unknown wallet action type [7]"),
 		},
 	}
 
@@ -81,7 +85,12 @@ func TestParseWalletActionType(t *testing.T) {
 }
 
 func TestWalletDispatcher_Dispatch(t *testing.T) {
-	walletDispatcher := newWalletDispatcher()
+	actionHistory, err := newActionHistory(&mockPersistenceHandle{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletDispatcher := newWalletDispatcher(actionHistory)
 
 	wallet1 := generateWallet(big.NewInt(100))
 	wallet2 := generateWallet(big.NewInt(101))
@@ -123,7 +132,7 @@ func TestWalletDispatcher_Dispatch(t *testing.T) {
 	}
 
 	// Dispatch Action 1 for Wallet 1.
-	err := walletDispatcher.dispatch(wallet1Action1)
+	err = walletDispatcher.dispatch(wallet1Action1)
 	if err != nil {
 		t.Errorf("unexpected error: [%v]", err)
 	}
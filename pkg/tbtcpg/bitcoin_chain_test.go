@@ -1,6 +1,7 @@
 package tbtcpg
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -102,7 +103,32 @@ func (lbc *LocalBitcoinChain) GetTransactionsForPublicKeyHash(
 func (lbc *LocalBitcoinChain) GetTxHashesForPublicKeyHash(
 	publicKeyHash [20]byte,
 ) ([]bitcoin.Hash, error) {
-	panic("unsupported")
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	matchingTxHashes := make([]bitcoin.Hash, 0)
+
+	for transactionHash, transaction := range lbc.transactions {
+		for _, output := range transaction.Outputs {
+			script := output.PublicKeyScript
+			if bytes.Equal(script, p2pkh) || bytes.Equal(script, p2wpkh) {
+				matchingTxHashes = append(matchingTxHashes, transactionHash)
+				break
+			}
+		}
+	}
+
+	return matchingTxHashes, nil
 }
 
 func (lbc *LocalBitcoinChain) GetMempoolForPublicKeyHash(
@@ -123,6 +149,12 @@ func (lbc *LocalBitcoinChain) GetMempoolUtxosForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lbc *LocalBitcoinChain) GetSpendingTransaction(
+	outpoint *bitcoin.TransactionOutpoint,
+) (*bitcoin.Transaction, bool, error) {
+	panic("unsupported")
+}
+
 func (lbc *LocalBitcoinChain) EstimateSatPerVByteFee(
 	blocks uint32,
 ) (int64, error) {
@@ -27,16 +27,6 @@ type Chain interface {
 	// which is a unique identifier for a deposit on-chain.
 	BuildDepositKey(fundingTxHash bitcoin.Hash, fundingOutputIndex uint32) *big.Int
 
-	// GetDepositParameters gets the current value of parameters relevant
-	// for the depositing process.
-	GetDepositParameters() (
-		dustThreshold uint64,
-		treasuryFeeDivisor uint64,
-		txMaxFee uint64,
-		revealAheadPeriod uint32,
-		err error,
-	)
-
 	// PastRedemptionRequestedEvents fetches past redemption requested events according
 	// to the provided filter or unfiltered if the filter is nil. Returned
 	// events are sorted by the block number in the ascending order, i.e. the
@@ -53,19 +43,6 @@ type Chain interface {
 		redeemerOutputScript bitcoin.Script,
 	) (*big.Int, error)
 
-	// GetRedemptionParameters gets the current value of parameters relevant
-	// for the redemption process.
-	GetRedemptionParameters() (
-		dustThreshold uint64,
-		treasuryFeeDivisor uint64,
-		txMaxFee uint64,
-		txMaxTotalFee uint64,
-		timeout uint32,
-		timeoutSlashingAmount *big.Int,
-		timeoutNotifierRewardMultiplier uint32,
-		err error,
-	)
-
 	// GetRedemptionMaxSize gets the maximum number of redemption requests that
 	// can be a part of a redemption sweep proposal.
 	GetRedemptionMaxSize() (uint16, error)
@@ -100,6 +77,11 @@ type Chain interface {
 	// be part of a deposit sweep proposal.
 	GetDepositSweepMaxSize() (uint16, error)
 
+	// GetDepositRefundSafetyMargin gets the period of time, in seconds,
+	// before the deposit refund locktime during which the deposit can no
+	// longer be part of a deposit sweep proposal.
+	GetDepositRefundSafetyMargin() (uint32, error)
+
 	BlockCounter() (chain.BlockCounter, error)
 
 	AverageBlockTime() time.Duration
@@ -111,4 +93,111 @@ type Chain interface {
 		walletPublicKeyHash [20]byte,
 		proposal *tbtc.HeartbeatProposal,
 	) error
+
+	// ValidateMovingFundsProposal validates the given moving funds proposal
+	// against the chain. Returns an error if the proposal is not valid or
+	// nil otherwise.
+	ValidateMovingFundsProposal(
+		walletPublicKeyHash [20]byte,
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *tbtc.MovingFundsProposal,
+	) error
+
+	// PastMovingFundsCommitmentSubmittedEvents fetches past moving funds
+	// commitment submitted events according to the provided filter or
+	// unfiltered if the filter is nil. Returned events are sorted by the block
+	// number in the ascending order, i.e. the latest event is at the end of the
+	// slice.
+	PastMovingFundsCommitmentSubmittedEvents(
+		filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+	) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error)
+
+	// ComputeMovingFundsCommitmentHash computes the hash of the moving funds
+	// commitment, i.e. the given list of target wallets, the same way as the
+	// Bridge does it on-chain.
+	ComputeMovingFundsCommitmentHash(targetWallets [][20]byte) [32]byte
+
+	// SubmitMovingFundsCommitment submits the moving funds target wallets
+	// commitment for the given source wallet. The walletMembersIDs must
+	// contain operator IDs of all the wallet signing group members, in the
+	// order they were selected. The walletMemberIndex is the 1-based position
+	// of the submitter within walletMembersIDs.
+	SubmitMovingFundsCommitment(
+		walletPublicKeyHash [20]byte,
+		walletMainUTXO bitcoin.UnspentTransactionOutput,
+		walletMembersIDs []uint32,
+		walletMemberIndex uint32,
+		targetWallets [][20]byte,
+	) error
+
+	// GetMovingFundsParameters gets the current value of parameters relevant
+	// for the moving funds process.
+	GetMovingFundsParameters() (
+		txMaxTotalFee uint64,
+		dustThreshold uint64,
+		timeoutResetDelay uint32,
+		timeout uint32,
+		timeoutSlashingAmount *big.Int,
+		timeoutNotifierRewardMultiplier uint32,
+		commitmentGasOffset uint16,
+		sweepTxMaxTotalFee uint64,
+		sweepTimeout uint32,
+		sweepTimeoutSlashingAmount *big.Int,
+		sweepTimeoutNotifierRewardMultiplier uint32,
+		err error,
+	)
+
+	// GetWalletParameters gets the current value of parameters relevant
+	// for wallet lifecycle.
+	GetWalletParameters() (
+		creationPeriod uint32,
+		creationMinBtcBalance uint64,
+		creationMaxBtcBalance uint64,
+		closureMinBtcBalance uint64,
+		maxAge uint32,
+		maxBtcTransfer uint64,
+		closingPeriod uint32,
+		err error,
+	)
+
+	// GetLiveWalletsCount gets the current count of live wallets.
+	GetLiveWalletsCount() (uint32, error)
+
+	// GetMovedFundsSweepRequest gets the on-chain moved funds sweep request
+	// for the given moving funds transaction hash and output index. The
+	// returned values represent:
+	// - moved funds sweep request which is non-nil only when the request
+	//   was found,
+	// - boolean value which is true if the request was found, false
+	//   otherwise,
+	// - error which is non-nil only when the function execution failed. It
+	//   will be nil if the request was not found, but the function execution
+	//   succeeded.
+	GetMovedFundsSweepRequest(
+		movingFundsTxHash bitcoin.Hash,
+		movingFundsTxOutputIndex uint32,
+	) (*tbtc.MovedFundsSweepRequest, bool, error)
+
+	// PastMovingFundsCompletedEvents fetches past moving funds completed
+	// events according to the provided filter or unfiltered if the filter
+	// is nil. Returned events are sorted by the block number in the ascending
+	// order, i.e. the latest event is at the end of the slice.
+	PastMovingFundsCompletedEvents(
+		filter *tbtc.MovingFundsCompletedEventFilter,
+	) ([]*tbtc.MovingFundsCompletedEvent, error)
+
+	// ValidateMovedFundsSweepProposal validates the given moved funds sweep
+	// proposal against the chain. Returns an error if the proposal is not
+	// valid or nil otherwise.
+	ValidateMovedFundsSweepProposal(
+		walletPublicKeyHash [20]byte,
+		proposal *tbtc.MovedFundsSweepProposal,
+	) error
+
+	// GetOperatorID returns the ID number of the given operator address. An ID
+	// number of 0 means the operator has not been allocated an ID number yet.
+	GetOperatorID(operatorAddress chain.Address) (chain.OperatorID, error)
+
+	// Signing returns the signing associated with the chain.
+	Signing() chain.Signing
 }
@@ -13,6 +13,8 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 )
 
@@ -33,6 +35,40 @@ type redemptionParameters = struct {
 	timeoutNotifierRewardMultiplier uint32
 }
 
+type movingFundsParameters = struct {
+	txMaxTotalFee                        uint64
+	dustThreshold                        uint64
+	timeoutResetDelay                    uint32
+	timeout                              uint32
+	timeoutSlashingAmount                *big.Int
+	timeoutNotifierRewardMultiplier      uint32
+	commitmentGasOffset                  uint16
+	sweepTxMaxTotalFee                   uint64
+	sweepTimeout                         uint32
+	sweepTimeoutSlashingAmount           *big.Int
+	sweepTimeoutNotifierRewardMultiplier uint32
+}
+
+type walletParameters = struct {
+	creationPeriod        uint32
+	creationMinBtcBalance uint64
+	creationMaxBtcBalance uint64
+	closureMinBtcBalance  uint64
+	maxAge                uint32
+	maxBtcTransfer        uint64
+	closingPeriod         uint32
+}
+
+// MovingFundsCommitmentSubmission represents a moving funds commitment
+// submitted to the local chain.
+type MovingFundsCommitmentSubmission struct {
+	WalletPublicKeyHash [20]byte
+	WalletMainUtxo      *bitcoin.UnspentTransactionOutput
+	WalletMembersIDs    []uint32
+	WalletMemberIndex   uint32
+	TargetWallets       [][20]byte
+}
+
 type LocalChain struct {
 	mutex sync.Mutex
 
@@ -43,12 +79,27 @@ type LocalChain struct {
 	depositSweepProposalValidations map[[32]byte]bool
 	redemptionParameters            redemptionParameters
 	redemptionRequestMinAge         uint32
+	depositRefundSafetyMargin       uint32
 	blockCounter                    chain.BlockCounter
 	pastRedemptionRequestedEvents   map[[32]byte][]*tbtc.RedemptionRequestedEvent
 	averageBlockTime                time.Duration
 	pendingRedemptionRequests       map[[32]byte]*tbtc.RedemptionRequest
 	redemptionProposalValidations   map[[32]byte]bool
 	heartbeatProposalValidations    map[[16]byte]bool
+	wallets                         map[[20]byte]*tbtc.WalletChainData
+	movingFundsParameters           movingFundsParameters
+	walletParameters                walletParameters
+	liveWalletsCount                uint32
+	operatorIDs                     map[chain.Address]uint32
+	operatorPrivateKey              *operator.PrivateKey
+
+	pastMovingFundsCommitmentSubmittedEvents map[[32]byte][]*tbtc.MovingFundsCommitmentSubmittedEvent
+	movingFundsCommitmentSubmissions         []*MovingFundsCommitmentSubmission
+	movingFundsProposalValidations           map[[32]byte]bool
+
+	movedFundsSweepRequests            map[[32]byte]*tbtc.MovedFundsSweepRequest
+	pastMovingFundsCompletedEvents     map[[32]byte][]*tbtc.MovingFundsCompletedEvent
+	movedFundsSweepProposalValidations map[[32]byte]bool
 }
 
 func NewLocalChain() *LocalChain {
@@ -61,6 +112,16 @@ func NewLocalChain() *LocalChain {
 		pendingRedemptionRequests:       make(map[[32]byte]*tbtc.RedemptionRequest),
 		redemptionProposalValidations:   make(map[[32]byte]bool),
 		heartbeatProposalValidations:    make(map[[16]byte]bool),
+		wallets:                         make(map[[20]byte]*tbtc.WalletChainData),
+		operatorIDs:                     make(map[chain.Address]uint32),
+
+		pastMovingFundsCommitmentSubmittedEvents: make(map[[32]byte][]*tbtc.MovingFundsCommitmentSubmittedEvent),
+		movingFundsCommitmentSubmissions:         make([]*MovingFundsCommitmentSubmission, 0),
+		movingFundsProposalValidations:           make(map[[32]byte]bool),
+
+		movedFundsSweepRequests:            make(map[[32]byte]*tbtc.MovedFundsSweepRequest),
+		pastMovingFundsCompletedEvents:     make(map[[32]byte][]*tbtc.MovingFundsCompletedEvent),
+		movedFundsSweepProposalValidations: make(map[[32]byte]bool),
 	}
 }
 
@@ -655,6 +716,22 @@ func (lc *LocalChain) GetDepositSweepMaxSize() (uint16, error) {
 	panic("unsupported")
 }
 
+func (lc *LocalChain) GetDepositRefundSafetyMargin() (uint32, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.depositRefundSafetyMargin, nil
+}
+
+func (lc *LocalChain) SetDepositRefundSafetyMargin(
+	depositRefundSafetyMargin uint32,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.depositRefundSafetyMargin = depositRefundSafetyMargin
+}
+
 func (lc *LocalChain) BlockCounter() (chain.BlockCounter, error) {
 	lc.mutex.Lock()
 	defer lc.mutex.Unlock()
@@ -687,11 +764,540 @@ func (lc *LocalChain) GetWallet(walletPublicKeyHash [20]byte) (
 	*tbtc.WalletChainData,
 	error,
 ) {
-	panic("unsupported")
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	walletChainData, ok := lc.wallets[walletPublicKeyHash]
+	if !ok {
+		return nil, fmt.Errorf("no wallet for given PKH")
+	}
+
+	return walletChainData, nil
+}
+
+func (lc *LocalChain) SetWallet(
+	walletPublicKeyHash [20]byte,
+	walletChainData *tbtc.WalletChainData,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.wallets[walletPublicKeyHash] = walletChainData
 }
 
 func (lc *LocalChain) ComputeMainUtxoHash(mainUtxo *bitcoin.UnspentTransactionOutput) [32]byte {
-	panic("unsupported")
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, mainUtxo.Outpoint.OutputIndex)
+
+	valueBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBytes, uint64(mainUtxo.Value))
+
+	mainUtxoHash := sha256.Sum256(
+		append(
+			append(
+				mainUtxo.Outpoint.TransactionHash[:],
+				outputIndexBytes...,
+			), valueBytes...,
+		),
+	)
+
+	return mainUtxoHash
+}
+
+func (lc *LocalChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCommitmentSubmittedEventsKey(filter)
+
+	events, ok := lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey]
+	if !ok {
+		return nil, fmt.Errorf("no events for given filter")
+	}
+
+	return events, nil
+}
+
+func (lc *LocalChain) AddPastMovingFundsCommitmentSubmittedEvent(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+	event *tbtc.MovingFundsCommitmentSubmittedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCommitmentSubmittedEventsKey(filter)
+
+	lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey] = append(
+		lc.pastMovingFundsCommitmentSubmittedEvents[eventsKey],
+		event,
+	)
+}
+
+func buildPastMovingFundsCommitmentSubmittedEventsKey(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) [32]byte {
+	if filter == nil {
+		return [32]byte{}
+	}
+
+	var buffer bytes.Buffer
+
+	startBlock := make([]byte, 8)
+	binary.BigEndian.PutUint64(startBlock, filter.StartBlock)
+	buffer.Write(startBlock)
+
+	if filter.EndBlock != nil {
+		endBlock := make([]byte, 8)
+		binary.BigEndian.PutUint64(endBlock, *filter.EndBlock)
+		buffer.Write(endBlock)
+	}
+
+	for _, walletPublicKeyHash := range filter.WalletPublicKeyHash {
+		buffer.Write(walletPublicKeyHash[:])
+	}
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+func (lc *LocalChain) ComputeMovingFundsCommitmentHash(
+	targetWallets [][20]byte,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	for _, targetWallet := range targetWallets {
+		buffer.Write(targetWallet[:])
+	}
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+// SubmitMovingFundsCommitment records the submission and immediately
+// updates the commitment hash of the wallet, simulating a confirmed
+// transaction.
+func (lc *LocalChain) SubmitMovingFundsCommitment(
+	walletPublicKeyHash [20]byte,
+	walletMainUTXO bitcoin.UnspentTransactionOutput,
+	walletMembersIDs []uint32,
+	walletMemberIndex uint32,
+	targetWallets [][20]byte,
+) error {
+	commitmentHash := lc.ComputeMovingFundsCommitmentHash(targetWallets)
+
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.movingFundsCommitmentSubmissions = append(
+		lc.movingFundsCommitmentSubmissions,
+		&MovingFundsCommitmentSubmission{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			WalletMainUtxo:      &walletMainUTXO,
+			WalletMembersIDs:    walletMembersIDs,
+			WalletMemberIndex:   walletMemberIndex,
+			TargetWallets:       targetWallets,
+		},
+	)
+
+	wallet, ok := lc.wallets[walletPublicKeyHash]
+	if !ok {
+		return fmt.Errorf("no wallet for given PKH")
+	}
+
+	wallet.MovingFundsTargetWalletsCommitmentHash = commitmentHash
+
+	return nil
+}
+
+func (lc *LocalChain) GetMovingFundsCommitmentSubmissions() []*MovingFundsCommitmentSubmission {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.movingFundsCommitmentSubmissions
+}
+
+func (lc *LocalChain) GetMovingFundsParameters() (
+	txMaxTotalFee uint64,
+	dustThreshold uint64,
+	timeoutResetDelay uint32,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	commitmentGasOffset uint16,
+	sweepTxMaxTotalFee uint64,
+	sweepTimeout uint32,
+	sweepTimeoutSlashingAmount *big.Int,
+	sweepTimeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.movingFundsParameters.txMaxTotalFee,
+		lc.movingFundsParameters.dustThreshold,
+		lc.movingFundsParameters.timeoutResetDelay,
+		lc.movingFundsParameters.timeout,
+		lc.movingFundsParameters.timeoutSlashingAmount,
+		lc.movingFundsParameters.timeoutNotifierRewardMultiplier,
+		lc.movingFundsParameters.commitmentGasOffset,
+		lc.movingFundsParameters.sweepTxMaxTotalFee,
+		lc.movingFundsParameters.sweepTimeout,
+		lc.movingFundsParameters.sweepTimeoutSlashingAmount,
+		lc.movingFundsParameters.sweepTimeoutNotifierRewardMultiplier,
+		nil
+}
+
+func (lc *LocalChain) SetMovingFundsParameters(
+	txMaxTotalFee uint64,
+	dustThreshold uint64,
+	timeoutResetDelay uint32,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	commitmentGasOffset uint16,
+	sweepTxMaxTotalFee uint64,
+	sweepTimeout uint32,
+	sweepTimeoutSlashingAmount *big.Int,
+	sweepTimeoutNotifierRewardMultiplier uint32,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.movingFundsParameters = movingFundsParameters{
+		txMaxTotalFee:                        txMaxTotalFee,
+		dustThreshold:                        dustThreshold,
+		timeoutResetDelay:                    timeoutResetDelay,
+		timeout:                              timeout,
+		timeoutSlashingAmount:                timeoutSlashingAmount,
+		timeoutNotifierRewardMultiplier:      timeoutNotifierRewardMultiplier,
+		commitmentGasOffset:                  commitmentGasOffset,
+		sweepTxMaxTotalFee:                   sweepTxMaxTotalFee,
+		sweepTimeout:                         sweepTimeout,
+		sweepTimeoutSlashingAmount:           sweepTimeoutSlashingAmount,
+		sweepTimeoutNotifierRewardMultiplier: sweepTimeoutNotifierRewardMultiplier,
+	}
+}
+
+func (lc *LocalChain) GetWalletParameters() (
+	creationPeriod uint32,
+	creationMinBtcBalance uint64,
+	creationMaxBtcBalance uint64,
+	closureMinBtcBalance uint64,
+	maxAge uint32,
+	maxBtcTransfer uint64,
+	closingPeriod uint32,
+	err error,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.walletParameters.creationPeriod,
+		lc.walletParameters.creationMinBtcBalance,
+		lc.walletParameters.creationMaxBtcBalance,
+		lc.walletParameters.closureMinBtcBalance,
+		lc.walletParameters.maxAge,
+		lc.walletParameters.maxBtcTransfer,
+		lc.walletParameters.closingPeriod,
+		nil
+}
+
+func (lc *LocalChain) SetWalletParameters(
+	creationPeriod uint32,
+	creationMinBtcBalance uint64,
+	creationMaxBtcBalance uint64,
+	closureMinBtcBalance uint64,
+	maxAge uint32,
+	maxBtcTransfer uint64,
+	closingPeriod uint32,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.walletParameters = walletParameters{
+		creationPeriod:        creationPeriod,
+		creationMinBtcBalance: creationMinBtcBalance,
+		creationMaxBtcBalance: creationMaxBtcBalance,
+		closureMinBtcBalance:  closureMinBtcBalance,
+		maxAge:                maxAge,
+		maxBtcTransfer:        maxBtcTransfer,
+		closingPeriod:         closingPeriod,
+	}
+}
+
+func (lc *LocalChain) GetLiveWalletsCount() (uint32, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.liveWalletsCount, nil
+}
+
+func (lc *LocalChain) SetLiveWalletsCount(liveWalletsCount uint32) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.liveWalletsCount = liveWalletsCount
+}
+
+func (lc *LocalChain) GetOperatorID(
+	operatorAddress chain.Address,
+) (chain.OperatorID, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	operatorID, ok := lc.operatorIDs[operatorAddress]
+	if !ok {
+		return 0, fmt.Errorf("operator not found")
+	}
+
+	return operatorID, nil
+}
+
+func (lc *LocalChain) SetOperatorID(
+	operatorAddress chain.Address,
+	operatorID chain.OperatorID,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.operatorIDs[operatorAddress] = operatorID
+}
+
+func (lc *LocalChain) Signing() chain.Signing {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return local_v1.NewSigner(lc.operatorPrivateKey)
+}
+
+func (lc *LocalChain) SetOperatorPrivateKey(operatorPrivateKey *operator.PrivateKey) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.operatorPrivateKey = operatorPrivateKey
+}
+
+func (lc *LocalChain) ValidateMovingFundsProposal(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	key := buildMovingFundsProposalValidationKey(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+	)
+
+	result, ok := lc.movingFundsProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *LocalChain) SetMovingFundsProposalValidationResult(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+	result bool,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	key := buildMovingFundsProposalValidationKey(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+	)
+
+	lc.movingFundsProposalValidations[key] = result
+}
+
+func buildMovingFundsProposalValidationKey(
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	buffer.Write(walletPublicKeyHash[:])
+
+	buffer.Write(walletMainUtxo.Outpoint.TransactionHash[:])
+
+	outputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndex, walletMainUtxo.Outpoint.OutputIndex)
+	buffer.Write(outputIndex)
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(walletMainUtxo.Value))
+	buffer.Write(value)
+
+	for _, targetWallet := range proposal.TargetWallets {
+		buffer.Write(targetWallet[:])
+	}
+
+	buffer.Write(proposal.MovingFundsTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+func (lc *LocalChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	requestKey := buildDepositRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+
+	request, ok := lc.movedFundsSweepRequests[requestKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *LocalChain) SetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+	request *tbtc.MovedFundsSweepRequest,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	requestKey := buildDepositRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+
+	lc.movedFundsSweepRequests[requestKey] = request
+}
+
+func (lc *LocalChain) PastMovingFundsCompletedEvents(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+) ([]*tbtc.MovingFundsCompletedEvent, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCompletedEventsKey(filter)
+
+	events, ok := lc.pastMovingFundsCompletedEvents[eventsKey]
+	if !ok {
+		return nil, fmt.Errorf("no events for given filter")
+	}
+
+	return events, nil
+}
+
+func (lc *LocalChain) AddPastMovingFundsCompletedEvent(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+	event *tbtc.MovingFundsCompletedEvent,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	eventsKey := buildPastMovingFundsCompletedEventsKey(filter)
+
+	lc.pastMovingFundsCompletedEvents[eventsKey] = append(
+		lc.pastMovingFundsCompletedEvents[eventsKey],
+		event,
+	)
+}
+
+func buildPastMovingFundsCompletedEventsKey(
+	filter *tbtc.MovingFundsCompletedEventFilter,
+) [32]byte {
+	if filter == nil {
+		return [32]byte{}
+	}
+
+	var buffer bytes.Buffer
+
+	startBlock := make([]byte, 8)
+	binary.BigEndian.PutUint64(startBlock, filter.StartBlock)
+	buffer.Write(startBlock)
+
+	if filter.EndBlock != nil {
+		endBlock := make([]byte, 8)
+		binary.BigEndian.PutUint64(endBlock, *filter.EndBlock)
+		buffer.Write(endBlock)
+	}
+
+	for _, walletPublicKeyHash := range filter.WalletPublicKeyHash {
+		buffer.Write(walletPublicKeyHash[:])
+	}
+
+	return sha256.Sum256(buffer.Bytes())
+}
+
+func (lc *LocalChain) ValidateMovedFundsSweepProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.MovedFundsSweepProposal,
+) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	key := buildMovedFundsSweepProposalValidationKey(
+		walletPublicKeyHash,
+		proposal,
+	)
+
+	result, ok := lc.movedFundsSweepProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *LocalChain) SetMovedFundsSweepProposalValidationResult(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.MovedFundsSweepProposal,
+	result bool,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	key := buildMovedFundsSweepProposalValidationKey(
+		walletPublicKeyHash,
+		proposal,
+	)
+
+	lc.movedFundsSweepProposalValidations[key] = result
+}
+
+func buildMovedFundsSweepProposalValidationKey(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.MovedFundsSweepProposal,
+) [32]byte {
+	var buffer bytes.Buffer
+
+	buffer.Write(walletPublicKeyHash[:])
+
+	buffer.Write(proposal.MovingFundsTxHash[:])
+
+	outputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndex, proposal.MovingFundsTxOutputIndex)
+	buffer.Write(outputIndex)
+
+	buffer.Write(proposal.SweepTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes())
 }
 
 type MockBlockCounter struct {
@@ -704,7 +1310,14 @@ func NewMockBlockCounter() *MockBlockCounter {
 }
 
 func (mbc *MockBlockCounter) WaitForBlockHeight(blockNumber uint64) error {
-	panic("unsupported")
+	mbc.mutex.Lock()
+	defer mbc.mutex.Unlock()
+
+	if blockNumber > mbc.currentBlock {
+		mbc.currentBlock = blockNumber
+	}
+
+	return nil
 }
 
 func (mbc *MockBlockCounter) BlockHeightWaiter(blockNumber uint64) (
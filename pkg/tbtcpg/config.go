@@ -0,0 +1,19 @@
+package tbtcpg
+
+import (
+	"time"
+)
+
+// DefaultRemoteTimeout is the default timeout of a single request sent to
+// the remote proposal generator.
+const DefaultRemoteTimeout = 1 * time.Minute
+
+// Config holds the configuration of the proposal generator used by the client.
+type Config struct {
+	// RemoteURL is the base URL of the remote proposal generator service.
+	// If empty, proposals are generated in-process.
+	RemoteURL string
+	// RemoteTimeout is the timeout of a single request sent to the remote
+	// proposal generator service.
+	RemoteTimeout time.Duration
+}
@@ -0,0 +1,176 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// OldestFirstPolicy is the name of the deposit selection policy that
+	// selects deposits in the order they were revealed.
+	OldestFirstPolicy = "oldest-first"
+	// LargestValueFirstPolicy is the name of the deposit selection policy
+	// that selects deposits with the largest value first.
+	LargestValueFirstPolicy = "largest-value-first"
+	// ClosestRefundLocktimeFirstPolicy is the name of the deposit selection
+	// policy that selects deposits whose refund locktime comes first.
+	ClosestRefundLocktimeFirstPolicy = "closest-refund-locktime-first"
+	// GroupedByVaultPolicy is the name of the deposit selection policy that
+	// selects only deposits targeting the same vault.
+	GroupedByVaultPolicy = "grouped-by-vault"
+)
+
+// DefaultDepositSelectionPolicy is the name of the deposit selection policy
+// used by default.
+const DefaultDepositSelectionPolicy = OldestFirstPolicy
+
+// DepositSelectionPolicy determines which of the deposits eligible for
+// sweeping should be included in a deposit sweep proposal.
+type DepositSelectionPolicy interface {
+	// CandidatesLimit returns the number of the oldest unswept deposits that
+	// must be fetched as candidates in order to select at most
+	// maxNumberOfDeposits deposits. Zero means that all unswept deposits of
+	// the wallet must be fetched.
+	CandidatesLimit(maxNumberOfDeposits int) int
+
+	// SelectDeposits returns at most maxNumberOfDeposits deposits chosen from
+	// the given candidates, in the order they should be swept. Candidates are
+	// sorted by the reveal block in the ascending order and are all eligible
+	// for sweeping, including the refund safety margin. The given candidates
+	// slice must not be modified.
+	SelectDeposits(candidates []*Deposit, maxNumberOfDeposits int) []*Deposit
+}
+
+// NewDepositSelectionPolicy returns the deposit selection policy with the
+// given name. An empty name denotes the default policy.
+func NewDepositSelectionPolicy(name string) (DepositSelectionPolicy, error) {
+	switch name {
+	case "", OldestFirstPolicy:
+		return &oldestFirstPolicy{}, nil
+	case LargestValueFirstPolicy:
+		return &largestValueFirstPolicy{}, nil
+	case ClosestRefundLocktimeFirstPolicy:
+		return &closestRefundLocktimeFirstPolicy{}, nil
+	case GroupedByVaultPolicy:
+		return &groupedByVaultPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown deposit selection policy: [%s]", name)
+	}
+}
+
+// oldestFirstPolicy selects deposits in the order they were revealed. It is
+// the only policy that does not need to fetch all unswept deposits of the
+// wallet.
+type oldestFirstPolicy struct{}
+
+func (ofp *oldestFirstPolicy) CandidatesLimit(maxNumberOfDeposits int) int {
+	return maxNumberOfDeposits
+}
+
+func (ofp *oldestFirstPolicy) SelectDeposits(
+	candidates []*Deposit,
+	maxNumberOfDeposits int,
+) []*Deposit {
+	return limitDeposits(candidates, maxNumberOfDeposits)
+}
+
+// largestValueFirstPolicy selects deposits with the largest value first.
+// Deposits with the same value are selected in the order they were revealed.
+type largestValueFirstPolicy struct{}
+
+func (lvfp *largestValueFirstPolicy) CandidatesLimit(int) int {
+	return 0
+}
+
+func (lvfp *largestValueFirstPolicy) SelectDeposits(
+	candidates []*Deposit,
+	maxNumberOfDeposits int,
+) []*Deposit {
+	sorted := copyDeposits(candidates)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AmountBtc > sorted[j].AmountBtc
+	})
+
+	return limitDeposits(sorted, maxNumberOfDeposits)
+}
+
+// closestRefundLocktimeFirstPolicy selects deposits whose refund locktime
+// comes first. Such deposits are the most urgent ones because they can no
+// longer be swept once their refund locktime is close. Deposits with the
+// same refund locktime are selected in the order they were revealed.
+type closestRefundLocktimeFirstPolicy struct{}
+
+func (crlfp *closestRefundLocktimeFirstPolicy) CandidatesLimit(int) int {
+	return 0
+}
+
+func (crlfp *closestRefundLocktimeFirstPolicy) SelectDeposits(
+	candidates []*Deposit,
+	maxNumberOfDeposits int,
+) []*Deposit {
+	sorted := copyDeposits(candidates)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RefundLocktime < sorted[j].RefundLocktime
+	})
+
+	return limitDeposits(sorted, maxNumberOfDeposits)
+}
+
+// groupedByVaultPolicy selects only deposits targeting the same vault as the
+// oldest candidate deposit, in the order they were revealed. Deposits that
+// do not target any vault form a separate group. Candidates contain only
+// deposits eligible for sweeping so starting from the oldest candidate
+// guarantees that all groups are eventually swept and a deposit that can no
+// longer be swept never blocks its group.
+type groupedByVaultPolicy struct{}
+
+func (gbvp *groupedByVaultPolicy) CandidatesLimit(int) int {
+	return 0
+}
+
+func (gbvp *groupedByVaultPolicy) SelectDeposits(
+	candidates []*Deposit,
+	maxNumberOfDeposits int,
+) []*Deposit {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	vault := candidates[0].Vault
+
+	sameVault := func(deposit *Deposit) bool {
+		if vault == nil || deposit.Vault == nil {
+			return vault == deposit.Vault
+		}
+
+		return *vault == *deposit.Vault
+	}
+
+	grouped := make([]*Deposit, 0)
+	for _, candidate := range candidates {
+		if sameVault(candidate) {
+			grouped = append(grouped, candidate)
+		}
+	}
+
+	return limitDeposits(grouped, maxNumberOfDeposits)
+}
+
+// copyDeposits returns a shallow copy of the given deposits slice.
+func copyDeposits(deposits []*Deposit) []*Deposit {
+	result := make([]*Deposit, len(deposits))
+	copy(result, deposits)
+	return result
+}
+
+// limitDeposits returns at most maxNumberOfDeposits first deposits from the
+// given slice. A non-positive maxNumberOfDeposits means no limit.
+func limitDeposits(deposits []*Deposit, maxNumberOfDeposits int) []*Deposit {
+	if maxNumberOfDeposits > 0 && len(deposits) > maxNumberOfDeposits {
+		return deposits[:maxNumberOfDeposits]
+	}
+
+	return deposits
+}
@@ -0,0 +1,195 @@
+package tbtcpg_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
+)
+
+func TestDepositSelectionPolicy_SelectDeposits(t *testing.T) {
+	vaultA := chain.Address("0xAA00000000000000000000000000000000000000")
+	vaultB := chain.Address("0xBB00000000000000000000000000000000000000")
+
+	deposit := func(
+		revealBlock uint64,
+		amountBtc float64,
+		refundLocktime uint32,
+		vault *chain.Address,
+	) *tbtcpg.Deposit {
+		return &tbtcpg.Deposit{
+			DepositReference: tbtcpg.DepositReference{
+				RevealBlock: revealBlock,
+			},
+			AmountBtc:      amountBtc,
+			RefundLocktime: refundLocktime,
+			Vault:          vault,
+		}
+	}
+
+	// Candidates are sorted by the reveal block, as returned by the chain.
+	candidates := []*tbtcpg.Deposit{
+		deposit(10, 0.5, 1700000300, &vaultA),
+		deposit(20, 2.0, 1700000100, nil),
+		deposit(30, 1.0, 1700000200, &vaultB),
+		deposit(40, 2.0, 1700000100, &vaultA),
+		deposit(50, 0.1, 1700000400, &vaultA),
+	}
+
+	var tests = map[string]struct {
+		policy               string
+		candidates           []*tbtcpg.Deposit
+		maxNumberOfDeposits  int
+		expectedRevealBlocks []uint64
+	}{
+		"default policy": {
+			policy:               "",
+			candidates:           candidates,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{10, 20, 30},
+		},
+		"oldest first": {
+			policy:               tbtcpg.OldestFirstPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{10, 20, 30},
+		},
+		"oldest first without limit": {
+			policy:               tbtcpg.OldestFirstPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  0,
+			expectedRevealBlocks: []uint64{10, 20, 30, 40, 50},
+		},
+		"largest value first": {
+			policy:               tbtcpg.LargestValueFirstPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{20, 40, 30},
+		},
+		"closest refund locktime first": {
+			policy:               tbtcpg.ClosestRefundLocktimeFirstPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  4,
+			expectedRevealBlocks: []uint64{20, 40, 30, 10},
+		},
+		"grouped by vault": {
+			policy:               tbtcpg.GroupedByVaultPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  5,
+			expectedRevealBlocks: []uint64{10, 40, 50},
+		},
+		"grouped by vault with limit": {
+			policy:               tbtcpg.GroupedByVaultPolicy,
+			candidates:           candidates,
+			maxNumberOfDeposits:  2,
+			expectedRevealBlocks: []uint64{10, 40},
+		},
+		"grouped by vault without vault": {
+			policy:               tbtcpg.GroupedByVaultPolicy,
+			candidates:           candidates[1:],
+			maxNumberOfDeposits:  5,
+			expectedRevealBlocks: []uint64{20},
+		},
+		"no candidates": {
+			policy:               tbtcpg.GroupedByVaultPolicy,
+			candidates:           []*tbtcpg.Deposit{},
+			maxNumberOfDeposits:  5,
+			expectedRevealBlocks: []uint64{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy, err := tbtcpg.NewDepositSelectionPolicy(test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidatesBefore := make([]*tbtcpg.Deposit, len(test.candidates))
+			copy(candidatesBefore, test.candidates)
+
+			selected := policy.SelectDeposits(
+				test.candidates,
+				test.maxNumberOfDeposits,
+			)
+
+			actualRevealBlocks := make([]uint64, len(selected))
+			for i, deposit := range selected {
+				actualRevealBlocks[i] = deposit.RevealBlock
+			}
+
+			if diff := deep.Equal(
+				test.expectedRevealBlocks,
+				actualRevealBlocks,
+			); diff != nil {
+				t.Errorf("invalid selected deposits: %v", diff)
+			}
+
+			if diff := deep.Equal(candidatesBefore, test.candidates); diff != nil {
+				t.Errorf("candidates were modified: %v", diff)
+			}
+		})
+	}
+}
+
+func TestDepositSelectionPolicy_CandidatesLimit(t *testing.T) {
+	var tests = map[string]struct {
+		policy                  string
+		expectedCandidatesLimit int
+	}{
+		"default policy": {
+			policy:                  "",
+			expectedCandidatesLimit: 5,
+		},
+		"oldest first": {
+			policy:                  tbtcpg.OldestFirstPolicy,
+			expectedCandidatesLimit: 5,
+		},
+		"largest value first": {
+			policy:                  tbtcpg.LargestValueFirstPolicy,
+			expectedCandidatesLimit: 0,
+		},
+		"closest refund locktime first": {
+			policy:                  tbtcpg.ClosestRefundLocktimeFirstPolicy,
+			expectedCandidatesLimit: 0,
+		},
+		"grouped by vault": {
+			policy:                  tbtcpg.GroupedByVaultPolicy,
+			expectedCandidatesLimit: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy, err := tbtcpg.NewDepositSelectionPolicy(test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"candidates limit",
+				test.expectedCandidatesLimit,
+				policy.CandidatesLimit(5),
+			)
+		})
+	}
+}
+
+func TestNewDepositSelectionPolicy_UnknownPolicy(t *testing.T) {
+	_, err := tbtcpg.NewDepositSelectionPolicy("newest-first")
+
+	expectedErr := "unknown deposit selection policy: [newest-first]"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
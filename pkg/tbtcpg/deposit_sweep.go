@@ -1,16 +1,19 @@
 package tbtcpg
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
 	"sort"
+	"time"
 
 	"github.com/ipfs/go-log/v2"
 	"go.uber.org/zap"
 
 	"github.com/keep-network/keep-core/internal/hexutils"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 )
 
@@ -18,17 +21,20 @@ const depositScriptByteSize = 92
 
 // DepositSweepTask is a task that may produce a deposit sweep proposal.
 type DepositSweepTask struct {
-	chain    Chain
-	btcChain bitcoin.Chain
+	chain           Chain
+	btcChain        bitcoin.Chain
+	selectionPolicy DepositSelectionPolicy
 }
 
 func NewDepositSweepTask(
 	chain Chain,
 	btcChain bitcoin.Chain,
+	selectionPolicy DepositSelectionPolicy,
 ) *DepositSweepTask {
 	return &DepositSweepTask{
-		chain:    chain,
-		btcChain: btcChain,
+		chain:           chain,
+		btcChain:        btcChain,
+		selectionPolicy: selectionPolicy,
 	}
 }
 
@@ -106,6 +112,8 @@ type Deposit struct {
 	IsSwept             bool
 	AmountBtc           float64
 	Confirmations       uint
+	RefundLocktime      uint32
+	Vault               *chain.Address
 }
 
 // FindDeposits finds deposits according to the given criteria.
@@ -125,10 +133,13 @@ func FindDeposits(
 		maxNumberOfDeposits,
 		skipSwept,
 		skipUnconfirmed,
+		0,
 	)
 }
 
-// findDeposits finds deposits according to the given criteria.
+// findDeposits finds deposits according to the given criteria. Deposits whose
+// refund locktime is not greater than the non-zero refundLocktimeThreshold
+// are skipped and do not count towards maxNumberOfDeposits.
 func findDeposits(
 	fnLogger log.StandardLogger,
 	chain Chain,
@@ -137,6 +148,7 @@ func findDeposits(
 	maxNumberOfDeposits int,
 	skipSwept bool,
 	skipUnconfirmed bool,
+	refundLocktimeThreshold uint32,
 ) ([]*Deposit, error) {
 	fnLogger.Infof("reading revealed deposits from chain")
 
@@ -176,6 +188,15 @@ func findDeposits(
 		depositKey := chain.BuildDepositKey(event.FundingTxHash, event.FundingOutputIndex)
 		depositKeyStr := depositKey.Text(16)
 
+		refundLocktime := binary.LittleEndian.Uint32(event.RefundLocktime[:])
+		if refundLocktimeThreshold > 0 && refundLocktime <= refundLocktimeThreshold {
+			fnLogger.Debugf(
+				"deposit [%s] is within the refund safety margin",
+				depositKeyStr,
+			)
+			continue
+		}
+
 		fnLogger.Debugf("getting details of deposit [%s]", depositKeyStr)
 
 		depositRequest, found, err := chain.GetDepositRequest(
@@ -229,6 +250,11 @@ func findDeposits(
 				"failed to get deposit transaction data: [%v]",
 				err,
 			)
+		} else if int(event.FundingOutputIndex) >= len(depositTransaction.Outputs) {
+			fnLogger.Errorf(
+				"deposit transaction does not have output [%d]",
+				event.FundingOutputIndex,
+			)
 		} else {
 			publicKeyScript := depositTransaction.Outputs[event.FundingOutputIndex].PublicKeyScript
 			scriptType = bitcoin.GetScriptType(publicKeyScript)
@@ -248,6 +274,8 @@ func findDeposits(
 				IsSwept:             isSwept,
 				AmountBtc:           convertSatToBtc(float64(depositRequest.Amount)),
 				Confirmations:       confirmations,
+				RefundLocktime:      refundLocktime,
+				Vault:               event.Vault,
 			},
 		)
 	}
@@ -257,8 +285,8 @@ func findDeposits(
 
 // FindDepositsToSweep finds deposits that can be swept.
 // maxNumberOfDeposits is used as a ceiling for the number of deposits in the
-// result. If number of discovered deposits meets the maxNumberOfDeposits the
-// function will stop fetching more deposits.
+// result. Deposits are chosen from all unswept deposits of the wallet
+// according to the task's deposit selection policy.
 // This function will return a list of deposits from the wallet that can be swept.
 // Deposits with insufficient number of funding transaction confirmations will
 // not be taken into consideration for sweeping.
@@ -275,20 +303,41 @@ func (dst *DepositSweepTask) FindDepositsToSweep(
 
 	taskLogger.Infof("fetching max [%d] deposits", maxNumberOfDeposits)
 
+	refundSafetyMargin, err := dst.chain.GetDepositRefundSafetyMargin()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get deposit refund safety margin: [%w]",
+			err,
+		)
+	}
+
+	// The selection policy may prefer deposits revealed later and require
+	// all unswept deposits to be fetched.
+	candidatesLimit := dst.selectionPolicy.CandidatesLimit(
+		int(maxNumberOfDeposits),
+	)
+
+	// Deposits within the refund safety margin would make the deposit sweep
+	// proposal invalid. They are filtered out before applying the selection
+	// policy so no policy can choose them.
 	unsweptDeposits, err := findDeposits(
 		taskLogger,
 		dst.chain,
 		dst.btcChain,
 		walletPublicKeyHash,
-		int(maxNumberOfDeposits),
+		candidatesLimit,
 		true,
 		true,
+		uint32(time.Now().Unix())+refundSafetyMargin,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	depositsToSweep := unsweptDeposits
+	depositsToSweep := dst.selectionPolicy.SelectDeposits(
+		unsweptDeposits,
+		int(maxNumberOfDeposits),
+	)
 
 	if len(depositsToSweep) == 0 {
 		return nil, nil
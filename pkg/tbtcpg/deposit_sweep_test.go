@@ -1,18 +1,37 @@
 package tbtcpg_test
 
 import (
+	"encoding/binary"
 	"github.com/keep-network/keep-core/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/tbtcpg"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 	"github.com/keep-network/keep-core/pkg/tbtcpg/internal/test"
 )
 
+// depositSelectionPolicies are all supported deposit selection policies.
+// Deposits of the test scenarios do not differ in value, refund locktime, and
+// vault so every policy is expected to select them in the order they were
+// revealed, just as the default policy does.
+var depositSelectionPolicies = []string{
+	tbtcpg.OldestFirstPolicy,
+	tbtcpg.LargestValueFirstPolicy,
+	tbtcpg.ClosestRefundLocktimeFirstPolicy,
+	tbtcpg.GroupedByVaultPolicy,
+}
+
+// scenarioRefundLocktime is the refund locktime of all deposits of the test
+// scenarios. It is far in the future so the deposits are never within the
+// refund safety margin.
+var scenarioRefundLocktime = [4]byte{0xff, 0xff, 0xff, 0xff}
+
 func TestDepositSweepTask_FindDepositsToSweep(t *testing.T) {
 	err := log.SetLogLevel("*", "DEBUG")
 	if err != nil {
@@ -24,31 +43,172 @@ func TestDepositSweepTask_FindDepositsToSweep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for _, scenario := range scenarios {
-		t.Run(scenario.Title, func(t *testing.T) {
+	for _, policy := range depositSelectionPolicies {
+		for _, scenario := range scenarios {
+			t.Run(policy+"/"+scenario.Title, func(t *testing.T) {
+				tbtcChain := tbtcpg.NewLocalChain()
+				btcChain := tbtcpg.NewLocalBitcoinChain()
+
+				// Chain setup.
+				for _, deposit := range scenario.Deposits {
+					tbtcChain.SetDepositRequest(
+						deposit.FundingTxHash,
+						deposit.FundingOutputIndex,
+						&tbtc.DepositChainRequest{SweptAt: deposit.SweptAt},
+					)
+					btcChain.SetTransaction(deposit.FundingTxHash, deposit.FundingTx)
+					btcChain.SetTransactionConfirmations(
+						deposit.FundingTxHash,
+						deposit.FundingTxConfirmations,
+					)
+
+					err := tbtcChain.AddPastDepositRevealedEvent(
+						&tbtc.DepositRevealedEventFilter{WalletPublicKeyHash: [][20]byte{deposit.WalletPublicKeyHash}},
+						&tbtc.DepositRevealedEvent{
+							BlockNumber:         deposit.RevealBlockNumber,
+							WalletPublicKeyHash: deposit.WalletPublicKeyHash,
+							FundingTxHash:       deposit.FundingTxHash,
+							FundingOutputIndex:  deposit.FundingOutputIndex,
+							RefundLocktime:      scenarioRefundLocktime,
+						},
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				tbtcChain.SetDepositRefundSafetyMargin(
+					uint32((24 * time.Hour).Seconds()),
+				)
+
+				selectionPolicy, err := tbtcpg.NewDepositSelectionPolicy(policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				task := tbtcpg.NewDepositSweepTask(
+					tbtcChain,
+					btcChain,
+					selectionPolicy,
+				)
+
+				// Test execution.
+				actualDeposits, err := task.FindDepositsToSweep(
+					&testutils.MockLogger{},
+					scenario.WalletPublicKeyHash,
+					scenario.MaxNumberOfDeposits,
+				)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := deep.Equal(
+					scenario.ExpectedUnsweptDeposits,
+					actualDeposits,
+				); diff != nil {
+					t.Errorf("invalid deposits: %v", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestDepositSweepTask_FindDepositsToSweep_SelectionPolicies(t *testing.T) {
+	walletPublicKeyHash := [20]byte{0x01}
+	vaultA := chain.Address("0xAA00000000000000000000000000000000000000")
+	vaultB := chain.Address("0xBB00000000000000000000000000000000000000")
+
+	refundSafetyMargin := 24 * time.Hour
+	now := time.Now()
+
+	type deposit struct {
+		revealBlock    uint64
+		amount         uint64
+		refundLocktime time.Time
+		vault          *chain.Address
+	}
+
+	// The oldest deposit is within the refund safety margin and is the only
+	// one targeting vaultA. It must never be selected nor affect the
+	// selection of other deposits.
+	deposits := []deposit{
+		{100, 1000000, now.Add(time.Hour), &vaultA},
+		{200, 3000000, now.Add(30 * 24 * time.Hour), &vaultB},
+		{300, 2000000, now.Add(10 * 24 * time.Hour), nil},
+		{400, 5000000, now.Add(20 * 24 * time.Hour), &vaultB},
+		{500, 500000, now.Add(5 * 24 * time.Hour), &vaultB},
+		{600, 4000000, now.Add(40 * 24 * time.Hour), nil},
+	}
+
+	var tests = map[string]struct {
+		policy               string
+		maxNumberOfDeposits  uint16
+		expectedRevealBlocks []uint64
+	}{
+		"oldest first": {
+			policy:               tbtcpg.OldestFirstPolicy,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{200, 300, 400},
+		},
+		"largest value first": {
+			policy:               tbtcpg.LargestValueFirstPolicy,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{400, 600, 200},
+		},
+		"closest refund locktime first": {
+			policy:               tbtcpg.ClosestRefundLocktimeFirstPolicy,
+			maxNumberOfDeposits:  3,
+			expectedRevealBlocks: []uint64{500, 300, 400},
+		},
+		"grouped by vault": {
+			policy:               tbtcpg.GroupedByVaultPolicy,
+			maxNumberOfDeposits:  5,
+			expectedRevealBlocks: []uint64{200, 400, 500},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
 			tbtcChain := tbtcpg.NewLocalChain()
 			btcChain := tbtcpg.NewLocalBitcoinChain()
 
-			// Chain setup.
-			for _, deposit := range scenario.Deposits {
+			expectedDeposits := make([]*tbtcpg.DepositReference, 0)
+
+			for _, deposit := range deposits {
+				fundingTxHash := bitcoin.Hash{byte(deposit.revealBlock / 100)}
+
+				var refundLocktime [4]byte
+				binary.LittleEndian.PutUint32(
+					refundLocktime[:],
+					uint32(deposit.refundLocktime.Unix()),
+				)
+
 				tbtcChain.SetDepositRequest(
-					deposit.FundingTxHash,
-					deposit.FundingOutputIndex,
-					&tbtc.DepositChainRequest{SweptAt: deposit.SweptAt},
+					fundingTxHash,
+					0,
+					&tbtc.DepositChainRequest{
+						Amount:  deposit.amount,
+						SweptAt: time.Unix(0, 0),
+					},
 				)
-				btcChain.SetTransaction(deposit.FundingTxHash, deposit.FundingTx)
+				btcChain.SetTransaction(fundingTxHash, &bitcoin.Transaction{})
 				btcChain.SetTransactionConfirmations(
-					deposit.FundingTxHash,
-					deposit.FundingTxConfirmations,
+					fundingTxHash,
+					tbtc.DepositSweepRequiredFundingTxConfirmations,
 				)
 
 				err := tbtcChain.AddPastDepositRevealedEvent(
-					&tbtc.DepositRevealedEventFilter{WalletPublicKeyHash: [][20]byte{deposit.WalletPublicKeyHash}},
+					&tbtc.DepositRevealedEventFilter{
+						WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+					},
 					&tbtc.DepositRevealedEvent{
-						BlockNumber:         deposit.RevealBlockNumber,
-						WalletPublicKeyHash: deposit.WalletPublicKeyHash,
-						FundingTxHash:       deposit.FundingTxHash,
-						FundingOutputIndex:  deposit.FundingOutputIndex,
+						BlockNumber:         deposit.revealBlock,
+						WalletPublicKeyHash: walletPublicKeyHash,
+						FundingTxHash:       fundingTxHash,
+						FundingOutputIndex:  0,
+						RefundLocktime:      refundLocktime,
+						Vault:               deposit.vault,
 					},
 				)
 				if err != nil {
@@ -56,23 +216,42 @@ func TestDepositSweepTask_FindDepositsToSweep(t *testing.T) {
 				}
 			}
 
-			task := tbtcpg.NewDepositSweepTask(tbtcChain, btcChain)
+			for _, revealBlock := range test.expectedRevealBlocks {
+				expectedDeposits = append(
+					expectedDeposits,
+					&tbtcpg.DepositReference{
+						FundingTxHash:      bitcoin.Hash{byte(revealBlock / 100)},
+						FundingOutputIndex: 0,
+						RevealBlock:        revealBlock,
+					},
+				)
+			}
+
+			tbtcChain.SetDepositRefundSafetyMargin(
+				uint32(refundSafetyMargin.Seconds()),
+			)
+
+			selectionPolicy, err := tbtcpg.NewDepositSelectionPolicy(test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			task := tbtcpg.NewDepositSweepTask(
+				tbtcChain,
+				btcChain,
+				selectionPolicy,
+			)
 
-			// Test execution.
 			actualDeposits, err := task.FindDepositsToSweep(
 				&testutils.MockLogger{},
-				scenario.WalletPublicKeyHash,
-				scenario.MaxNumberOfDeposits,
+				walletPublicKeyHash,
+				test.maxNumberOfDeposits,
 			)
-
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			if diff := deep.Equal(
-				scenario.ExpectedUnsweptDeposits,
-				actualDeposits,
-			); diff != nil {
+			if diff := deep.Equal(expectedDeposits, actualDeposits); diff != nil {
 				t.Errorf("invalid deposits: %v", diff)
 			}
 		})
@@ -90,85 +269,96 @@ func TestDepositSweepTask_ProposeDepositsSweep(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for _, scenario := range scenarios {
-		t.Run(scenario.Title, func(t *testing.T) {
-			tbtcChain := tbtcpg.NewLocalChain()
-			btcChain := tbtcpg.NewLocalBitcoinChain()
+	for _, policy := range depositSelectionPolicies {
+		for _, scenario := range scenarios {
+			t.Run(policy+"/"+scenario.Title, func(t *testing.T) {
+				tbtcChain := tbtcpg.NewLocalChain()
+				btcChain := tbtcpg.NewLocalBitcoinChain()
 
-			// Chain setup.
-			tbtcChain.SetDepositParameters(0, 0, scenario.DepositTxMaxFee, 0)
+				// Chain setup.
+				tbtcChain.SetDepositParameters(0, 0, scenario.DepositTxMaxFee, 0)
 
-			for _, deposit := range scenario.Deposits {
-				err := tbtcChain.AddPastDepositRevealedEvent(
-					&tbtc.DepositRevealedEventFilter{
-						StartBlock:          deposit.RevealBlock,
-						EndBlock:            &deposit.RevealBlock,
-						WalletPublicKeyHash: [][20]byte{scenario.WalletPublicKeyHash},
-					},
-					&tbtc.DepositRevealedEvent{
-						WalletPublicKeyHash: scenario.WalletPublicKeyHash,
-						FundingTxHash:       deposit.FundingTxHash,
-						FundingOutputIndex:  deposit.FundingOutputIndex,
-					},
-				)
-				if err != nil {
-					t.Fatal(err)
+				for _, deposit := range scenario.Deposits {
+					err := tbtcChain.AddPastDepositRevealedEvent(
+						&tbtc.DepositRevealedEventFilter{
+							StartBlock:          deposit.RevealBlock,
+							EndBlock:            &deposit.RevealBlock,
+							WalletPublicKeyHash: [][20]byte{scenario.WalletPublicKeyHash},
+						},
+						&tbtc.DepositRevealedEvent{
+							WalletPublicKeyHash: scenario.WalletPublicKeyHash,
+							FundingTxHash:       deposit.FundingTxHash,
+							FundingOutputIndex:  deposit.FundingOutputIndex,
+						},
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					btcChain.SetTransaction(deposit.FundingTxHash, &bitcoin.Transaction{})
+					btcChain.SetTransactionConfirmations(deposit.FundingTxHash, tbtc.DepositSweepRequiredFundingTxConfirmations)
 				}
 
-				btcChain.SetTransaction(deposit.FundingTxHash, &bitcoin.Transaction{})
-				btcChain.SetTransactionConfirmations(deposit.FundingTxHash, tbtc.DepositSweepRequiredFundingTxConfirmations)
-			}
+				if scenario.ExpectedDepositSweepProposal != nil {
+					err := tbtcChain.SetDepositSweepProposalValidationResult(
+						scenario.WalletPublicKeyHash,
+						scenario.ExpectedDepositSweepProposal,
+						nil,
+						true,
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
 
-			if scenario.ExpectedDepositSweepProposal != nil {
-				err := tbtcChain.SetDepositSweepProposalValidationResult(
-					scenario.WalletPublicKeyHash,
-					scenario.ExpectedDepositSweepProposal,
-					nil,
-					true,
-				)
+				btcChain.SetEstimateSatPerVByteFee(1, scenario.EstimateSatPerVByteFee)
+
+				selectionPolicy, err := tbtcpg.NewDepositSelectionPolicy(policy)
 				if err != nil {
 					t.Fatal(err)
 				}
-			}
-
-			btcChain.SetEstimateSatPerVByteFee(1, scenario.EstimateSatPerVByteFee)
-
-			task := tbtcpg.NewDepositSweepTask(tbtcChain, btcChain)
 
-			// Test execution.
-			proposal, err := task.ProposeDepositsSweep(
-				&testutils.MockLogger{},
-				scenario.WalletPublicKeyHash,
-				scenario.DepositsReferences(),
-				scenario.SweepTxFee,
-			)
+				task := tbtcpg.NewDepositSweepTask(
+					tbtcChain,
+					btcChain,
+					selectionPolicy,
+				)
 
-			if !reflect.DeepEqual(scenario.ExpectedErr, err) {
-				t.Errorf(
-					"unexpected error\n"+
-						"expected: [%+v]\n"+
-						"actual:   [%+v]",
-					scenario.ExpectedErr,
-					err,
+				// Test execution.
+				proposal, err := task.ProposeDepositsSweep(
+					&testutils.MockLogger{},
+					scenario.WalletPublicKeyHash,
+					scenario.DepositsReferences(),
+					scenario.SweepTxFee,
 				)
-			}
 
-			var actualDepositSweepProposals []*tbtc.DepositSweepProposal
-			if proposal != nil {
-				actualDepositSweepProposals = append(actualDepositSweepProposals, proposal)
-			}
+				if !reflect.DeepEqual(scenario.ExpectedErr, err) {
+					t.Errorf(
+						"unexpected error\n"+
+							"expected: [%+v]\n"+
+							"actual:   [%+v]",
+						scenario.ExpectedErr,
+						err,
+					)
+				}
 
-			var expectedDepositSweepProposals []*tbtc.DepositSweepProposal
-			if p := scenario.ExpectedDepositSweepProposal; p != nil {
-				expectedDepositSweepProposals = append(expectedDepositSweepProposals, p)
-			}
+				var actualDepositSweepProposals []*tbtc.DepositSweepProposal
+				if proposal != nil {
+					actualDepositSweepProposals = append(actualDepositSweepProposals, proposal)
+				}
 
-			if diff := deep.Equal(
-				actualDepositSweepProposals,
-				expectedDepositSweepProposals,
-			); diff != nil {
-				t.Errorf("invalid deposits: %v", diff)
-			}
-		})
+				var expectedDepositSweepProposals []*tbtc.DepositSweepProposal
+				if p := scenario.ExpectedDepositSweepProposal; p != nil {
+					expectedDepositSweepProposals = append(expectedDepositSweepProposals, p)
+				}
+
+				if diff := deep.Equal(
+					actualDepositSweepProposals,
+					expectedDepositSweepProposals,
+				); diff != nil {
+					t.Errorf("invalid deposits: %v", diff)
+				}
+			})
+		}
 	}
 }
@@ -0,0 +1,335 @@
+package tbtcpg
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// MovedFundsSweepTask is a task that may produce a moved funds sweep proposal.
+type MovedFundsSweepTask struct {
+	chain    Chain
+	btcChain bitcoin.Chain
+}
+
+func NewMovedFundsSweepTask(
+	chain Chain,
+	btcChain bitcoin.Chain,
+) *MovedFundsSweepTask {
+	return &MovedFundsSweepTask{
+		chain:    chain,
+		btcChain: btcChain,
+	}
+}
+
+// MovedFundsSweepRequest represents a pending moved funds sweep request
+// along with the moving funds transaction output it points to.
+type MovedFundsSweepRequest struct {
+	*tbtc.MovedFundsSweepRequest
+
+	MovingFundsTxHash        bitcoin.Hash
+	MovingFundsTxOutputIndex uint32
+}
+
+func (mfst *MovedFundsSweepTask) Run(request *tbtc.CoordinationProposalRequest) (
+	tbtc.CoordinationProposal,
+	bool,
+	error,
+) {
+	walletPublicKeyHash := request.WalletPublicKeyHash
+
+	taskLogger := logger.With(
+		zap.String("task", mfst.ActionType().String()),
+		zap.String("walletPKH", fmt.Sprintf("0x%x", walletPublicKeyHash)),
+	)
+
+	walletChainData, err := mfst.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get wallet's chain data: [%w]",
+			err,
+		)
+	}
+
+	if walletChainData.PendingMovedFundsSweepRequestsCount == 0 {
+		taskLogger.Infof("wallet has no pending moved funds sweep requests")
+		return nil, false, nil
+	}
+
+	// The Bridge accepts moved funds sweep proofs only from wallets being
+	// in the Live or MovingFunds state.
+	if walletChainData.State != tbtc.StateLive &&
+		walletChainData.State != tbtc.StateMovingFunds {
+		taskLogger.Infof(
+			"wallet not in Live or MovingFunds state; state is [%s]",
+			walletChainData.State,
+		)
+		return nil, false, nil
+	}
+
+	requests, err := mfst.FindPendingMovedFundsSweepRequests(
+		taskLogger,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot find pending moved funds sweep requests: [%w]",
+			err,
+		)
+	}
+
+	if len(requests) == 0 {
+		taskLogger.Infof("no pending moved funds sweep requests found")
+		return nil, false, nil
+	}
+
+	// Requests are sorted by creation time so take the oldest one. The
+	// remaining ones will be handled in subsequent coordination windows.
+	oldestRequest := requests[0]
+
+	proposal, err := mfst.ProposeMovedFundsSweep(
+		taskLogger,
+		walletPublicKeyHash,
+		oldestRequest.MovingFundsTxHash,
+		oldestRequest.MovingFundsTxOutputIndex,
+		0,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot prepare moved funds sweep proposal: [%w]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
+
+func (mfst *MovedFundsSweepTask) ActionType() tbtc.WalletActionType {
+	return tbtc.ActionMovedFundsSweep
+}
+
+// FindPendingMovedFundsSweepRequests finds pending moved funds sweep requests
+// targeting the given wallet. The requests are determined by looking at
+// moving funds transactions of all source wallets that committed to move
+// funds to the given wallet. The returned requests are sorted by their
+// creation time in the ascending order, i.e. the oldest request is at the
+// beginning of the slice.
+func (mfst *MovedFundsSweepTask) FindPendingMovedFundsSweepRequests(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+) ([]*MovedFundsSweepRequest, error) {
+	commitmentEvents, err := mfst.chain.PastMovingFundsCommitmentSubmittedEvents(
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past moving funds commitment submitted events: [%w]",
+			err,
+		)
+	}
+
+	sourceWallets := make([][20]byte, 0)
+	for _, event := range commitmentEvents {
+		if !slices.Contains(event.TargetWallets, walletPublicKeyHash) ||
+			slices.Contains(sourceWallets, event.WalletPublicKeyHash) {
+			continue
+		}
+
+		sourceWallets = append(sourceWallets, event.WalletPublicKeyHash)
+	}
+
+	taskLogger.Infof(
+		"found [%d] source wallets that committed to move funds to the wallet",
+		len(sourceWallets),
+	)
+
+	if len(sourceWallets) == 0 {
+		return []*MovedFundsSweepRequest{}, nil
+	}
+
+	completedEvents, err := mfst.chain.PastMovingFundsCompletedEvents(
+		&tbtc.MovingFundsCompletedEventFilter{
+			WalletPublicKeyHash: sourceWallets,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past moving funds completed events: [%w]",
+			err,
+		)
+	}
+
+	walletP2PKH, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct P2PKH for wallet: [%v]", err)
+	}
+	walletP2WPKH, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct P2WPKH for wallet: [%v]", err)
+	}
+
+	requests := make([]*MovedFundsSweepRequest, 0)
+	for _, event := range completedEvents {
+		movingFundsTx, err := mfst.btcChain.GetTransaction(
+			event.MovingFundsTxHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get moving funds transaction [%s]: [%w]",
+				event.MovingFundsTxHash.Hex(bitcoin.ReversedByteOrder),
+				err,
+			)
+		}
+
+		for outputIndex, output := range movingFundsTx.Outputs {
+			if !bytes.Equal(output.PublicKeyScript, walletP2PKH) &&
+				!bytes.Equal(output.PublicKeyScript, walletP2WPKH) {
+				continue
+			}
+
+			request, found, err := mfst.chain.GetMovedFundsSweepRequest(
+				event.MovingFundsTxHash,
+				uint32(outputIndex),
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot get moved funds sweep request: [%w]",
+					err,
+				)
+			}
+
+			if !found ||
+				request.WalletPublicKeyHash != walletPublicKeyHash ||
+				request.State != tbtc.MovedFundsStatePending {
+				continue
+			}
+
+			requests = append(requests, &MovedFundsSweepRequest{
+				MovedFundsSweepRequest:   request,
+				MovingFundsTxHash:        event.MovingFundsTxHash,
+				MovingFundsTxOutputIndex: uint32(outputIndex),
+			})
+		}
+	}
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+
+	taskLogger.Infof(
+		"found [%d] pending moved funds sweep requests",
+		len(requests),
+	)
+
+	return requests, nil
+}
+
+// ProposeMovedFundsSweep returns a moved funds sweep proposal for the given
+// moved funds sweep request. If the fee is not positive, it is estimated
+// based on the current network conditions.
+func (mfst *MovedFundsSweepTask) ProposeMovedFundsSweep(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+	fee int64,
+) (*tbtc.MovedFundsSweepProposal, error) {
+	taskLogger.Infof("preparing a moved funds sweep proposal")
+
+	// Estimate fee if it's missing. Do not check the estimated fee against
+	// the maximum total fee allowed by the Bridge. This is done during the
+	// on-chain validation of the proposal so there is no need to do it here.
+	if fee <= 0 {
+		taskLogger.Infof("estimating moved funds sweep transaction fee")
+
+		walletMainUtxo, err := tbtc.DetermineWalletMainUtxo(
+			walletPublicKeyHash,
+			mfst.chain,
+			mfst.btcChain,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get wallet's main UTXO: [%w]",
+				err,
+			)
+		}
+
+		estimatedFee, err := EstimateMovedFundsSweepFee(
+			mfst.btcChain,
+			walletMainUtxo != nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot estimate moved funds sweep transaction fee: [%w]",
+				err,
+			)
+		}
+
+		fee = estimatedFee
+	}
+
+	taskLogger.Infof("moved funds sweep transaction fee: [%d]", fee)
+
+	proposal := &tbtc.MovedFundsSweepProposal{
+		MovingFundsTxHash:        movingFundsTxHash,
+		MovingFundsTxOutputIndex: movingFundsTxOutputIndex,
+		SweepTxFee:               big.NewInt(fee),
+	}
+
+	taskLogger.Infof("validating the moved funds sweep proposal")
+
+	if err := tbtc.ValidateMovedFundsSweepProposal(
+		taskLogger,
+		walletPublicKeyHash,
+		proposal,
+		mfst.chain,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"failed to verify moved funds sweep proposal: [%w]",
+			err,
+		)
+	}
+
+	return proposal, nil
+}
+
+// EstimateMovedFundsSweepFee estimates fee for the moved funds sweep
+// transaction that merges the moved funds UTXO with the wallet main UTXO,
+// if the wallet has one.
+func EstimateMovedFundsSweepFee(
+	btcChain bitcoin.Chain,
+	hasMainUtxo bool,
+) (int64, error) {
+	inputsCount := 1
+	if hasMainUtxo {
+		inputsCount++
+	}
+
+	sizeEstimator := bitcoin.NewTransactionSizeEstimator().
+		// P2WPKH moved funds UTXO input and optional P2WPKH main UTXO input.
+		AddPublicKeyHashInputs(inputsCount, true).
+		// 1 P2WPKH output.
+		AddPublicKeyHashOutputs(1, true)
+
+	transactionSize, err := sizeEstimator.VirtualSize()
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction virtual size: [%v]", err)
+	}
+
+	feeEstimator := bitcoin.NewTransactionFeeEstimator(btcChain)
+
+	totalFee, err := feeEstimator.EstimateFee(transactionSize)
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction fee: [%v]", err)
+	}
+
+	return totalFee, nil
+}
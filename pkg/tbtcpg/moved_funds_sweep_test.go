@@ -0,0 +1,387 @@
+package tbtcpg_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
+)
+
+func TestEstimateMovedFundsSweepFee(t *testing.T) {
+	var tests = map[string]struct {
+		hasMainUtxo bool
+		expectedFee int
+	}{
+		"without main UTXO": {
+			hasMainUtxo: false,
+			expectedFee: 1760, // transactionVirtualSize * satPerVByteFee = 110 * 16 = 1760
+		},
+		"with main UTXO": {
+			hasMainUtxo: true,
+			expectedFee: 2848, // transactionVirtualSize * satPerVByteFee = 178 * 16 = 2848
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := tbtcpg.NewLocalBitcoinChain()
+			btcChain.SetEstimateSatPerVByteFee(1, 16)
+
+			actualFee, err := tbtcpg.EstimateMovedFundsSweepFee(
+				btcChain,
+				test.hasMainUtxo,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(t, "fee", test.expectedFee, int(actualFee))
+		})
+	}
+}
+
+func TestMovedFundsSweepTask_FindPendingMovedFundsSweepRequests(t *testing.T) {
+	walletPublicKeyHash := hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e")
+	otherWallet := hexToByte20(t, "8db50eb52063ea9d98b3eac91489a90f738986f6")
+	sourceWallet1 := hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e")
+	sourceWallet2 := hexToByte20(t, "aa768412ceed10bd423c025542ca90071f9fb62d")
+	unrelatedSourceWallet := hexToByte20(t, "6cf8f75e0a1ab2f2d85d3ab04ee3bfa1e2a9c1e6")
+
+	tbtcChain := tbtcpg.NewLocalChain()
+	btcChain := tbtcpg.NewLocalBitcoinChain()
+
+	tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+		nil,
+		&tbtc.MovingFundsCommitmentSubmittedEvent{
+			WalletPublicKeyHash: sourceWallet1,
+			TargetWallets:       [][20]byte{otherWallet, walletPublicKeyHash},
+			BlockNumber:         100,
+		},
+	)
+	tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+		nil,
+		&tbtc.MovingFundsCommitmentSubmittedEvent{
+			WalletPublicKeyHash: unrelatedSourceWallet,
+			TargetWallets:       [][20]byte{otherWallet},
+			BlockNumber:         200,
+		},
+	)
+	tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+		nil,
+		&tbtc.MovingFundsCommitmentSubmittedEvent{
+			WalletPublicKeyHash: sourceWallet2,
+			TargetWallets:       [][20]byte{walletPublicKeyHash},
+			BlockNumber:         300,
+		},
+	)
+
+	movingFundsTx1 := newMovingFundsTransaction(
+		t,
+		[][20]byte{otherWallet, walletPublicKeyHash},
+		0x01,
+	)
+	btcChain.SetTransaction(movingFundsTx1.Hash(), movingFundsTx1)
+
+	movingFundsTx2 := newMovingFundsTransaction(
+		t,
+		[][20]byte{walletPublicKeyHash},
+		0x02,
+	)
+	btcChain.SetTransaction(movingFundsTx2.Hash(), movingFundsTx2)
+
+	tbtcChain.AddPastMovingFundsCompletedEvent(
+		&tbtc.MovingFundsCompletedEventFilter{
+			WalletPublicKeyHash: [][20]byte{sourceWallet1, sourceWallet2},
+		},
+		&tbtc.MovingFundsCompletedEvent{
+			WalletPublicKeyHash: sourceWallet1,
+			MovingFundsTxHash:   movingFundsTx1.Hash(),
+			BlockNumber:         150,
+		},
+	)
+	tbtcChain.AddPastMovingFundsCompletedEvent(
+		&tbtc.MovingFundsCompletedEventFilter{
+			WalletPublicKeyHash: [][20]byte{sourceWallet1, sourceWallet2},
+		},
+		&tbtc.MovingFundsCompletedEvent{
+			WalletPublicKeyHash: sourceWallet2,
+			MovingFundsTxHash:   movingFundsTx2.Hash(),
+			BlockNumber:         350,
+		},
+	)
+
+	// The request of the other target wallet must be ignored.
+	tbtcChain.SetMovedFundsSweepRequest(
+		movingFundsTx1.Hash(),
+		0,
+		&tbtc.MovedFundsSweepRequest{
+			WalletPublicKeyHash: otherWallet,
+			Value:               100000,
+			CreatedAt:           time.Unix(1000, 0),
+			State:               tbtc.MovedFundsStatePending,
+		},
+	)
+	// The request created later goes first in the chain but must be
+	// returned as the second one.
+	tbtcChain.SetMovedFundsSweepRequest(
+		movingFundsTx1.Hash(),
+		1,
+		&tbtc.MovedFundsSweepRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               100000,
+			CreatedAt:           time.Unix(2000, 0),
+			State:               tbtc.MovedFundsStatePending,
+		},
+	)
+	tbtcChain.SetMovedFundsSweepRequest(
+		movingFundsTx2.Hash(),
+		0,
+		&tbtc.MovedFundsSweepRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               100000,
+			CreatedAt:           time.Unix(1500, 0),
+			State:               tbtc.MovedFundsStatePending,
+		},
+	)
+
+	task := tbtcpg.NewMovedFundsSweepTask(tbtcChain, btcChain)
+
+	requests, err := task.FindPendingMovedFundsSweepRequests(
+		&testutils.MockLogger{},
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "requests count", 2, len(requests))
+
+	testutils.AssertStringsEqual(
+		t,
+		"first request tx hash",
+		movingFundsTx2.Hash().String(),
+		requests[0].MovingFundsTxHash.String(),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"first request output index",
+		0,
+		int(requests[0].MovingFundsTxOutputIndex),
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"second request tx hash",
+		movingFundsTx1.Hash().String(),
+		requests[1].MovingFundsTxHash.String(),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"second request output index",
+		1,
+		int(requests[1].MovingFundsTxOutputIndex),
+	)
+
+	// Once the older request is processed, only the newer one should be
+	// returned.
+	tbtcChain.SetMovedFundsSweepRequest(
+		movingFundsTx2.Hash(),
+		0,
+		&tbtc.MovedFundsSweepRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               100000,
+			CreatedAt:           time.Unix(1500, 0),
+			State:               tbtc.MovedFundsStateProcessed,
+		},
+	)
+
+	requests, err = task.FindPendingMovedFundsSweepRequests(
+		&testutils.MockLogger{},
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "requests count", 1, len(requests))
+	testutils.AssertStringsEqual(
+		t,
+		"request tx hash",
+		movingFundsTx1.Hash().String(),
+		requests[0].MovingFundsTxHash.String(),
+	)
+}
+
+func TestMovedFundsSweepTask_Run(t *testing.T) {
+	walletPublicKeyHash := hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e")
+	sourceWallet := hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e")
+
+	setup := func(
+		t *testing.T,
+		walletState tbtc.WalletState,
+		pendingRequestsCount uint32,
+	) (*tbtcpg.LocalChain, *tbtcpg.LocalBitcoinChain, bitcoin.Hash) {
+		tbtcChain := tbtcpg.NewLocalChain()
+		btcChain := tbtcpg.NewLocalBitcoinChain()
+
+		btcChain.SetEstimateSatPerVByteFee(1, 10)
+
+		tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+			nil,
+			&tbtc.MovingFundsCommitmentSubmittedEvent{
+				WalletPublicKeyHash: sourceWallet,
+				TargetWallets:       [][20]byte{walletPublicKeyHash},
+				BlockNumber:         100,
+			},
+		)
+
+		movingFundsTx := newMovingFundsTransaction(
+			t,
+			[][20]byte{walletPublicKeyHash},
+			0x01,
+		)
+		btcChain.SetTransaction(movingFundsTx.Hash(), movingFundsTx)
+
+		tbtcChain.AddPastMovingFundsCompletedEvent(
+			&tbtc.MovingFundsCompletedEventFilter{
+				WalletPublicKeyHash: [][20]byte{sourceWallet},
+			},
+			&tbtc.MovingFundsCompletedEvent{
+				WalletPublicKeyHash: sourceWallet,
+				MovingFundsTxHash:   movingFundsTx.Hash(),
+				BlockNumber:         150,
+			},
+		)
+
+		tbtcChain.SetMovedFundsSweepRequest(
+			movingFundsTx.Hash(),
+			0,
+			&tbtc.MovedFundsSweepRequest{
+				WalletPublicKeyHash: walletPublicKeyHash,
+				Value:               100000,
+				CreatedAt:           time.Unix(1000, 0),
+				State:               tbtc.MovedFundsStatePending,
+			},
+		)
+
+		// The wallet does not have a main UTXO yet.
+		tbtcChain.SetWallet(walletPublicKeyHash, &tbtc.WalletChainData{
+			State:                               walletState,
+			PendingMovedFundsSweepRequestsCount: pendingRequestsCount,
+		})
+
+		return tbtcChain, btcChain, movingFundsTx.Hash()
+	}
+
+	t.Run("wallet with pending requests", func(t *testing.T) {
+		tbtcChain, btcChain, movingFundsTxHash := setup(t, tbtc.StateLive, 1)
+
+		// 1 P2WPKH input and 1 P2WPKH output give 110 vbytes.
+		expectedProposal := &tbtc.MovedFundsSweepProposal{
+			MovingFundsTxHash:        movingFundsTxHash,
+			MovingFundsTxOutputIndex: 0,
+			SweepTxFee:               big.NewInt(1100),
+		}
+
+		tbtcChain.SetMovedFundsSweepProposalValidationResult(
+			walletPublicKeyHash,
+			expectedProposal,
+			true,
+		)
+
+		task := tbtcpg.NewMovedFundsSweepTask(tbtcChain, btcChain)
+
+		proposal, ok, err := task.Run(&tbtc.CoordinationProposalRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			ActionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionMovedFundsSweep,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "result", true, ok)
+
+		if diff := deep.Equal(proposal, expectedProposal); diff != nil {
+			t.Errorf("invalid proposal: %v", diff)
+		}
+	})
+
+	t.Run("wallet without pending requests", func(t *testing.T) {
+		tbtcChain, btcChain, _ := setup(t, tbtc.StateLive, 0)
+
+		task := tbtcpg.NewMovedFundsSweepTask(tbtcChain, btcChain)
+
+		_, ok, err := task.Run(&tbtc.CoordinationProposalRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			ActionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionMovedFundsSweep,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "result", false, ok)
+	})
+
+	t.Run("wallet in closing state", func(t *testing.T) {
+		tbtcChain, btcChain, _ := setup(t, tbtc.StateClosing, 1)
+
+		task := tbtcpg.NewMovedFundsSweepTask(tbtcChain, btcChain)
+
+		_, ok, err := task.Run(&tbtc.CoordinationProposalRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			ActionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionMovedFundsSweep,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "result", false, ok)
+	})
+}
+
+// newMovingFundsTransaction creates a moving funds transaction transferring
+// 100000 satoshi to the P2WPKH address of each of the given target wallets.
+// The input hash byte makes the transaction hash unique.
+func newMovingFundsTransaction(
+	t *testing.T,
+	targetWallets [][20]byte,
+	inputHashByte byte,
+) *bitcoin.Transaction {
+	outputs := make([]*bitcoin.TransactionOutput, len(targetWallets))
+	for i, targetWallet := range targetWallets {
+		script, err := bitcoin.PayToWitnessPublicKeyHash(targetWallet)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputs[i] = &bitcoin.TransactionOutput{
+			Value:           100000,
+			PublicKeyScript: script,
+		}
+	}
+
+	return &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{inputHashByte},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: outputs,
+	}
+}
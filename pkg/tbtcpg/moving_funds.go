@@ -0,0 +1,515 @@
+package tbtcpg
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// MovingFundsTask is a task that may produce a moving funds proposal.
+type MovingFundsTask struct {
+	chain    Chain
+	btcChain bitcoin.Chain
+}
+
+func NewMovingFundsTask(
+	chain Chain,
+	btcChain bitcoin.Chain,
+) *MovingFundsTask {
+	return &MovingFundsTask{
+		chain:    chain,
+		btcChain: btcChain,
+	}
+}
+
+func (mft *MovingFundsTask) Run(request *tbtc.CoordinationProposalRequest) (
+	tbtc.CoordinationProposal,
+	bool,
+	error,
+) {
+	walletPublicKeyHash := request.WalletPublicKeyHash
+
+	taskLogger := logger.With(
+		zap.String("task", mft.ActionType().String()),
+		zap.String("walletPKH", fmt.Sprintf("0x%x", walletPublicKeyHash)),
+	)
+
+	walletChainData, err := mft.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get source wallet's chain data: [%w]",
+			err,
+		)
+	}
+
+	if walletChainData.State != tbtc.StateMovingFunds {
+		taskLogger.Infof(
+			"source wallet not in MovingFunds state; state is [%s]",
+			walletChainData.State,
+		)
+		return nil, false, nil
+	}
+
+	// The Bridge does not allow moving funds as long as the wallet has
+	// pending redemption requests or pending moved funds sweep requests.
+	// Those must be handled first.
+	if walletChainData.PendingRedemptionsValue > 0 {
+		taskLogger.Infof("source wallet has pending redemption requests")
+		return nil, false, nil
+	}
+
+	if walletChainData.PendingMovedFundsSweepRequestsCount > 0 {
+		taskLogger.Infof("source wallet has pending moved funds sweep requests")
+		return nil, false, nil
+	}
+
+	walletMainUtxo, err := tbtc.DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		mft.chain,
+		mft.btcChain,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get wallet's main UTXO: [%w]",
+			err,
+		)
+	}
+
+	// A wallet without a main UTXO has nothing to move.
+	if walletMainUtxo == nil {
+		taskLogger.Infof("source wallet does not have a main UTXO")
+		return nil, false, nil
+	}
+
+	_, dustThreshold, _, _, _, _, _, _, _, _, _, err :=
+		mft.chain.GetMovingFundsParameters()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get moving funds parameters: [%w]",
+			err,
+		)
+	}
+
+	// Funds below the dust threshold are not moved. Such a wallet should
+	// be closed using the moving funds below dust notification instead.
+	if walletMainUtxo.Value < int64(dustThreshold) {
+		taskLogger.Infof(
+			"source wallet balance [%d] is below the moving funds "+
+				"dust threshold [%d]",
+			walletMainUtxo.Value,
+			dustThreshold,
+		)
+		return nil, false, nil
+	}
+
+	targetWallets, alreadySubmitted, err := mft.FindTargetWallets(
+		taskLogger,
+		walletPublicKeyHash,
+		walletChainData.MovingFundsTargetWalletsCommitmentHash,
+		uint64(walletMainUtxo.Value),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot find target wallets: [%w]",
+			err,
+		)
+	}
+
+	if len(targetWallets) == 0 {
+		taskLogger.Infof("no target wallets available")
+		return nil, false, nil
+	}
+
+	// The moving funds proposal can be validated only against a commitment
+	// that is already confirmed on-chain. Instead of waiting for the
+	// confirmation here, submit the commitment and propose moving funds in
+	// one of the next coordination windows, once the commitment is visible
+	// on-chain.
+	if !alreadySubmitted {
+		err := mft.SubmitMovingFundsCommitment(
+			taskLogger,
+			walletPublicKeyHash,
+			walletMainUtxo,
+			request.WalletOperators,
+			targetWallets,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"cannot submit moving funds commitment: [%w]",
+				err,
+			)
+		}
+
+		taskLogger.Infof(
+			"moving funds commitment submitted; moving funds will be " +
+				"proposed once the commitment is confirmed on-chain",
+		)
+
+		return nil, false, nil
+	}
+
+	proposal, err := mft.ProposeMovingFunds(
+		taskLogger,
+		walletPublicKeyHash,
+		walletMainUtxo,
+		targetWallets,
+		0,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot prepare moving funds proposal: [%w]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
+
+func (mft *MovingFundsTask) ActionType() tbtc.WalletActionType {
+	return tbtc.ActionMovingFunds
+}
+
+// FindTargetWallets returns the list of target wallets for the given source
+// wallet. If the target wallets commitment was already submitted on-chain,
+// the committed target wallets are returned and the second return value is
+// true. Otherwise, a new list of target wallets is determined using the
+// live wallets registered in the Bridge and the second return value is
+// false. The returned target wallets are sorted in the ascending order,
+// as required by the Bridge.
+func (mft *MovingFundsTask) FindTargetWallets(
+	taskLogger log.StandardLogger,
+	sourceWalletPublicKeyHash [20]byte,
+	targetWalletsCommitmentHash [32]byte,
+	walletBalance uint64,
+) ([][20]byte, bool, error) {
+	if targetWalletsCommitmentHash != [32]byte{} {
+		taskLogger.Infof("target wallets commitment already submitted")
+
+		targetWallets, err := mft.findCommittedTargetWallets(
+			sourceWalletPublicKeyHash,
+			targetWalletsCommitmentHash,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return targetWallets, true, nil
+	}
+
+	taskLogger.Infof("looking for new target wallets")
+
+	targetWallets, err := mft.findNewTargetWallets(
+		taskLogger,
+		sourceWalletPublicKeyHash,
+		walletBalance,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return targetWallets, false, nil
+}
+
+func (mft *MovingFundsTask) findCommittedTargetWallets(
+	sourceWalletPublicKeyHash [20]byte,
+	targetWalletsCommitmentHash [32]byte,
+) ([][20]byte, error) {
+	events, err := mft.chain.PastMovingFundsCommitmentSubmittedEvents(
+		&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+			WalletPublicKeyHash: [][20]byte{sourceWalletPublicKeyHash},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past moving funds commitment submitted events: [%w]",
+			err,
+		)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf(
+			"no moving funds commitment submitted events for the wallet",
+		)
+	}
+
+	// The latest event holds the current commitment.
+	targetWallets := events[len(events)-1].TargetWallets
+
+	calculatedHash := mft.chain.ComputeMovingFundsCommitmentHash(targetWallets)
+	if calculatedHash != targetWalletsCommitmentHash {
+		return nil, fmt.Errorf(
+			"target wallets from the event do not match the on-chain " +
+				"commitment hash",
+		)
+	}
+
+	return targetWallets, nil
+}
+
+func (mft *MovingFundsTask) findNewTargetWallets(
+	taskLogger log.StandardLogger,
+	sourceWalletPublicKeyHash [20]byte,
+	walletBalance uint64,
+) ([][20]byte, error) {
+	liveWalletsCount, err := mft.chain.GetLiveWalletsCount()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get live wallets count: [%w]", err)
+	}
+
+	if liveWalletsCount == 0 {
+		taskLogger.Infof("there are no live wallets")
+		return nil, nil
+	}
+
+	_, _, _, _, _, walletMaxBtcTransfer, _, err := mft.chain.GetWalletParameters()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet parameters: [%w]", err)
+	}
+
+	if walletMaxBtcTransfer == 0 {
+		return nil, fmt.Errorf("wallet max BTC transfer must be positive")
+	}
+
+	// The Bridge expects the number of target wallets to be equal to
+	// min(liveWalletsCount, ceil(walletBalance / walletMaxBtcTransfer)).
+	targetWalletsCount := (walletBalance + walletMaxBtcTransfer - 1) /
+		walletMaxBtcTransfer
+	if uint64(liveWalletsCount) < targetWalletsCount {
+		targetWalletsCount = uint64(liveWalletsCount)
+	}
+
+	taskLogger.Infof(
+		"looking for [%d] target wallets among [%d] live wallets",
+		targetWalletsCount,
+		liveWalletsCount,
+	)
+
+	events, err := mft.chain.PastNewWalletRegisteredEvents(nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past new wallet registered events: [%w]",
+			err,
+		)
+	}
+
+	targetWallets := make([][20]byte, 0)
+
+	// Start from the newest wallets as they are most likely to be live.
+	for i := len(events) - 1; i >= 0; i-- {
+		if uint64(len(targetWallets)) == targetWalletsCount {
+			break
+		}
+
+		walletPublicKeyHash := events[i].WalletPublicKeyHash
+		if walletPublicKeyHash == sourceWalletPublicKeyHash {
+			continue
+		}
+
+		wallet, err := mft.chain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get wallet data for wallet [0x%x]: [%w]",
+				walletPublicKeyHash,
+				err,
+			)
+		}
+
+		if wallet.State == tbtc.StateLive {
+			targetWallets = append(targetWallets, walletPublicKeyHash)
+		}
+	}
+
+	if uint64(len(targetWallets)) != targetWalletsCount {
+		return nil, fmt.Errorf(
+			"found [%d] live wallets but [%d] are required",
+			len(targetWallets),
+			targetWalletsCount,
+		)
+	}
+
+	sort.Slice(targetWallets, func(i, j int) bool {
+		return bytes.Compare(targetWallets[i][:], targetWallets[j][:]) < 0
+	})
+
+	return targetWallets, nil
+}
+
+// SubmitMovingFundsCommitment submits the moving funds target wallets
+// commitment for the given source wallet. It does not wait until
+// the commitment is confirmed on-chain.
+func (mft *MovingFundsTask) SubmitMovingFundsCommitment(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	walletOperators []chain.Address,
+	targetWallets [][20]byte,
+) error {
+	walletMemberIDs, walletMemberIndex, err := mft.getWalletMembersInfo(
+		walletOperators,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get wallet members info: [%w]", err)
+	}
+
+	taskLogger.Infof(
+		"submitting moving funds commitment with [%d] target wallets",
+		len(targetWallets),
+	)
+
+	err = mft.chain.SubmitMovingFundsCommitment(
+		walletPublicKeyHash,
+		*walletMainUtxo,
+		walletMemberIDs,
+		walletMemberIndex,
+		targetWallets,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while submitting moving funds commitment: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// getWalletMembersInfo returns the operator IDs of the wallet signing group
+// members and the 1-based index of the operator running this client within
+// the signing group.
+func (mft *MovingFundsTask) getWalletMembersInfo(
+	walletOperators []chain.Address,
+) ([]uint32, uint32, error) {
+	// Cache mapping operator addresses to their wallet member IDs. It helps
+	// to limit the number of calls to the chain as one operator can
+	// control multiple members.
+	operatorIDCache := make(map[chain.Address]uint32)
+
+	walletMemberIDs := make([]uint32, 0, len(walletOperators))
+	for _, operatorAddress := range walletOperators {
+		operatorID, ok := operatorIDCache[operatorAddress]
+		if !ok {
+			id, err := mft.chain.GetOperatorID(operatorAddress)
+			if err != nil {
+				return nil, 0, fmt.Errorf(
+					"cannot get operator ID for operator [%s]: [%w]",
+					operatorAddress,
+					err,
+				)
+			}
+
+			operatorID = id
+			operatorIDCache[operatorAddress] = operatorID
+		}
+
+		walletMemberIDs = append(walletMemberIDs, operatorID)
+	}
+
+	ownAddress := mft.chain.Signing().Address()
+
+	for i, operatorAddress := range walletOperators {
+		if operatorAddress == ownAddress {
+			return walletMemberIDs, uint32(i + 1), nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf(
+		"operator [%s] is not a member of the wallet",
+		ownAddress,
+	)
+}
+
+// ProposeMovingFunds returns a moving funds proposal for the given source
+// wallet, main UTXO and target wallets. If the fee is not positive, it is
+// estimated. The proposal is validated against the chain before returning.
+func (mft *MovingFundsTask) ProposeMovingFunds(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	targetWallets [][20]byte,
+	fee int64,
+) (*tbtc.MovingFundsProposal, error) {
+	if len(targetWallets) == 0 {
+		return nil, fmt.Errorf("target wallets list is empty")
+	}
+
+	taskLogger.Infof("preparing a moving funds proposal")
+
+	// Estimate fee if it's missing. Do not check the estimated fee against
+	// the maximum total fee allowed by the Bridge. This is done during the
+	// on-chain validation of the proposal so there is no need to do it here.
+	if fee <= 0 {
+		taskLogger.Infof("estimating moving funds transaction fee")
+
+		estimatedFee, err := EstimateMovingFundsFee(
+			mft.btcChain,
+			len(targetWallets),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot estimate moving funds transaction fee: [%w]",
+				err,
+			)
+		}
+
+		fee = estimatedFee
+	}
+
+	taskLogger.Infof("moving funds transaction fee: [%d]", fee)
+
+	proposal := &tbtc.MovingFundsProposal{
+		TargetWallets:    targetWallets,
+		MovingFundsTxFee: big.NewInt(fee),
+	}
+
+	taskLogger.Infof("validating the moving funds proposal")
+
+	if err := tbtc.ValidateMovingFundsProposal(
+		taskLogger,
+		walletPublicKeyHash,
+		walletMainUtxo,
+		proposal,
+		mft.chain,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"failed to verify moving funds proposal: [%w]",
+			err,
+		)
+	}
+
+	return proposal, nil
+}
+
+// EstimateMovingFundsFee estimates fee for the moving funds transaction that
+// moves funds from the wallet main UTXO to the given number of target wallets.
+func EstimateMovingFundsFee(
+	btcChain bitcoin.Chain,
+	targetWalletsCount int,
+) (int64, error) {
+	sizeEstimator := bitcoin.NewTransactionSizeEstimator().
+		// 1 P2WPKH main UTXO input.
+		AddPublicKeyHashInputs(1, true).
+		// P2WPKH outputs, one for each target wallet.
+		AddPublicKeyHashOutputs(targetWalletsCount, true)
+
+	transactionSize, err := sizeEstimator.VirtualSize()
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction virtual size: [%v]", err)
+	}
+
+	feeEstimator := bitcoin.NewTransactionFeeEstimator(btcChain)
+
+	totalFee, err := feeEstimator.EstimateFee(transactionSize)
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction fee: [%v]", err)
+	}
+
+	return totalFee, nil
+}
@@ -0,0 +1,495 @@
+package tbtcpg_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
+)
+
+func TestEstimateMovingFundsFee(t *testing.T) {
+	btcChain := tbtcpg.NewLocalBitcoinChain()
+	btcChain.SetEstimateSatPerVByteFee(1, 16)
+
+	actualFee, err := tbtcpg.EstimateMovingFundsFee(btcChain, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFee := 2256 // transactionVirtualSize * satPerVByteFee = 141 * 16 = 2256
+	testutils.AssertIntsEqual(t, "fee", expectedFee, int(actualFee))
+}
+
+func TestMovingFundsTask_FindTargetWallets(t *testing.T) {
+	sourceWallet := hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e")
+	liveWallet1 := hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e")
+	liveWallet2 := hexToByte20(t, "0b1c5b13fb6a6d03fcb63d6b2b3f5c1b2c5e3e7a")
+	liveWallet3 := hexToByte20(t, "8db50eb52063ea9d98b3eac91489a90f738986f6")
+	closedWallet := hexToByte20(t, "aa768412ceed10bd423c025542ca90071f9fb62d")
+
+	var tests = map[string]struct {
+		commitmentSubmitted      bool
+		liveWalletsCount         uint32
+		walletMaxBtcTransfer     uint64
+		walletBalance            uint64
+		expectedTargetWallets    [][20]byte
+		expectedAlreadySubmitted bool
+		expectedErr              string
+	}{
+		"commitment already submitted": {
+			commitmentSubmitted:      true,
+			liveWalletsCount:         3,
+			walletMaxBtcTransfer:     100000,
+			walletBalance:            150000,
+			expectedTargetWallets:    [][20]byte{liveWallet2, liveWallet1},
+			expectedAlreadySubmitted: true,
+		},
+		"target wallets count limited by balance": {
+			liveWalletsCount:      3,
+			walletMaxBtcTransfer:  100000,
+			walletBalance:         150000,
+			expectedTargetWallets: [][20]byte{liveWallet3, liveWallet1},
+		},
+		"target wallets count limited by live wallets": {
+			liveWalletsCount:     3,
+			walletMaxBtcTransfer: 100000,
+			walletBalance:        1000000,
+			expectedTargetWallets: [][20]byte{
+				liveWallet2,
+				liveWallet3,
+				liveWallet1,
+			},
+		},
+		"no live wallets": {
+			liveWalletsCount:      0,
+			walletMaxBtcTransfer:  100000,
+			walletBalance:         150000,
+			expectedTargetWallets: nil,
+		},
+		"not enough live wallets found": {
+			liveWalletsCount:     4,
+			walletMaxBtcTransfer: 100000,
+			walletBalance:        1000000,
+			expectedErr:          "found [3] live wallets but [4] are required",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tbtcChain := tbtcpg.NewLocalChain()
+			btcChain := tbtcpg.NewLocalBitcoinChain()
+
+			tbtcChain.SetLiveWalletsCount(test.liveWalletsCount)
+			tbtcChain.SetWalletParameters(
+				0,
+				0,
+				0,
+				0,
+				0,
+				test.walletMaxBtcTransfer,
+				0,
+			)
+
+			// Wallets are registered in the following order. The source
+			// wallet and the closed wallet must never be chosen as targets.
+			registeredWallets := []struct {
+				walletPublicKeyHash [20]byte
+				state               tbtc.WalletState
+			}{
+				{liveWallet2, tbtc.StateLive},
+				{sourceWallet, tbtc.StateMovingFunds},
+				{liveWallet3, tbtc.StateLive},
+				{closedWallet, tbtc.StateClosed},
+				{liveWallet1, tbtc.StateLive},
+			}
+
+			for i, registeredWallet := range registeredWallets {
+				err := tbtcChain.AddPastNewWalletRegisteredEvent(
+					nil,
+					&tbtc.NewWalletRegisteredEvent{
+						WalletPublicKeyHash: registeredWallet.walletPublicKeyHash,
+						BlockNumber:         uint64(100 * (i + 1)),
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				tbtcChain.SetWallet(
+					registeredWallet.walletPublicKeyHash,
+					&tbtc.WalletChainData{
+						State: registeredWallet.state,
+					},
+				)
+			}
+
+			var commitmentHash [32]byte
+			if test.commitmentSubmitted {
+				committedWallets := [][20]byte{liveWallet2, liveWallet1}
+
+				tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+					&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+						WalletPublicKeyHash: [][20]byte{sourceWallet},
+					},
+					&tbtc.MovingFundsCommitmentSubmittedEvent{
+						WalletPublicKeyHash: sourceWallet,
+						TargetWallets:       committedWallets,
+					},
+				)
+
+				commitmentHash = tbtcChain.ComputeMovingFundsCommitmentHash(
+					committedWallets,
+				)
+			}
+
+			task := tbtcpg.NewMovingFundsTask(tbtcChain, btcChain)
+
+			targetWallets, alreadySubmitted, err := task.FindTargetWallets(
+				&testutils.MockLogger{},
+				sourceWallet,
+				commitmentHash,
+				test.walletBalance,
+			)
+
+			if test.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				testutils.AssertStringsEqual(
+					t,
+					"error",
+					test.expectedErr,
+					err.Error(),
+				)
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"already submitted",
+				test.expectedAlreadySubmitted,
+				alreadySubmitted,
+			)
+
+			if diff := deep.Equal(
+				targetWallets,
+				test.expectedTargetWallets,
+			); diff != nil {
+				t.Errorf("invalid target wallets: %v", diff)
+			}
+		})
+	}
+}
+
+func TestMovingFundsTask_ProposeMovingFunds(t *testing.T) {
+	walletPublicKeyHash := hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e")
+
+	walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0x01},
+			OutputIndex:     1,
+		},
+		Value: 200000,
+	}
+
+	targetWallets := [][20]byte{
+		hexToByte20(t, "8db50eb52063ea9d98b3eac91489a90f738986f6"),
+		hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e"),
+	}
+
+	var tests = map[string]struct {
+		fee              int64
+		expectedProposal *tbtc.MovingFundsProposal
+	}{
+		"fee provided": {
+			fee: 10000,
+			expectedProposal: &tbtc.MovingFundsProposal{
+				TargetWallets:    targetWallets,
+				MovingFundsTxFee: big.NewInt(10000),
+			},
+		},
+		"fee estimated": {
+			fee: 0, // trigger fee estimation
+			expectedProposal: &tbtc.MovingFundsProposal{
+				TargetWallets:    targetWallets,
+				MovingFundsTxFee: big.NewInt(3525),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tbtcChain := tbtcpg.NewLocalChain()
+			btcChain := tbtcpg.NewLocalBitcoinChain()
+
+			btcChain.SetEstimateSatPerVByteFee(1, 25)
+
+			tbtcChain.SetMovingFundsProposalValidationResult(
+				walletPublicKeyHash,
+				walletMainUtxo,
+				test.expectedProposal,
+				true,
+			)
+
+			task := tbtcpg.NewMovingFundsTask(tbtcChain, btcChain)
+
+			proposal, err := task.ProposeMovingFunds(
+				&testutils.MockLogger{},
+				walletPublicKeyHash,
+				walletMainUtxo,
+				targetWallets,
+				test.fee,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(proposal, test.expectedProposal); diff != nil {
+				t.Errorf("invalid proposal: %v", diff)
+			}
+		})
+	}
+}
+
+func TestMovingFundsTask_Run(t *testing.T) {
+	sourceWallet := hexToByte20(t, "3091d288521caec06ea912eacfd733edc5a36d6e")
+	targetWallet := hexToByte20(t, "c7302d75072d78be94eb8d36c4b77583c7abb06e")
+
+	setup := func(
+		t *testing.T,
+		sourceWalletState tbtc.WalletState,
+	) (
+		*tbtcpg.LocalChain,
+		*tbtcpg.LocalBitcoinChain,
+		*bitcoin.UnspentTransactionOutput,
+		[]chain.Address,
+	) {
+		tbtcChain := tbtcpg.NewLocalChain()
+		btcChain := tbtcpg.NewLocalBitcoinChain()
+
+		btcChain.SetEstimateSatPerVByteFee(1, 10)
+
+		operatorPrivateKey, _, err := operator.GenerateKeyPair(
+			local_v1.DefaultCurve,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tbtcChain.SetOperatorPrivateKey(operatorPrivateKey)
+
+		// The operator running the task controls the second and the
+		// fourth member of the wallet.
+		ownAddress := tbtcChain.Signing().Address()
+		walletOperators := []chain.Address{
+			"0x1111111111111111111111111111111111111111",
+			ownAddress,
+			"0x2222222222222222222222222222222222222222",
+			ownAddress,
+		}
+		tbtcChain.SetOperatorID(walletOperators[0], 5)
+		tbtcChain.SetOperatorID(ownAddress, 7)
+		tbtcChain.SetOperatorID(walletOperators[2], 9)
+
+		tbtcChain.SetMovingFundsParameters(
+			0, 20000, 0, 0, nil, 0, 0, 0, 0, nil, 0,
+		)
+		tbtcChain.SetWalletParameters(0, 0, 0, 0, 0, 1000000, 0)
+		tbtcChain.SetLiveWalletsCount(1)
+
+		err = tbtcChain.AddPastNewWalletRegisteredEvent(
+			nil,
+			&tbtc.NewWalletRegisteredEvent{
+				WalletPublicKeyHash: targetWallet,
+				BlockNumber:         100,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tbtcChain.SetWallet(targetWallet, &tbtc.WalletChainData{
+			State: tbtc.StateLive,
+		})
+
+		// Record the transaction holding the source wallet main UTXO.
+		walletScript, err := bitcoin.PayToWitnessPublicKeyHash(sourceWallet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mainUtxoTransaction := &bitcoin.Transaction{
+			Version: 1,
+			Inputs: []*bitcoin.TransactionInput{
+				{
+					Outpoint: &bitcoin.TransactionOutpoint{
+						TransactionHash: bitcoin.Hash{0x01},
+						OutputIndex:     0,
+					},
+					Sequence: 0xffffffff,
+				},
+			},
+			Outputs: []*bitcoin.TransactionOutput{
+				{
+					Value:           500000,
+					PublicKeyScript: walletScript,
+				},
+			},
+		}
+		btcChain.SetTransaction(mainUtxoTransaction.Hash(), mainUtxoTransaction)
+
+		walletMainUtxo := &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: mainUtxoTransaction.Hash(),
+				OutputIndex:     0,
+			},
+			Value: 500000,
+		}
+
+		tbtcChain.SetWallet(sourceWallet, &tbtc.WalletChainData{
+			MainUtxoHash: tbtcChain.ComputeMainUtxoHash(walletMainUtxo),
+			State:        sourceWalletState,
+		})
+
+		return tbtcChain, btcChain, walletMainUtxo, walletOperators
+	}
+
+	t.Run("wallet in moving funds state", func(t *testing.T) {
+		tbtcChain, btcChain, walletMainUtxo, walletOperators := setup(
+			t,
+			tbtc.StateMovingFunds,
+		)
+
+		// 1 P2WPKH input and 1 P2WPKH output give 110 vbytes.
+		expectedProposal := &tbtc.MovingFundsProposal{
+			TargetWallets:    [][20]byte{targetWallet},
+			MovingFundsTxFee: big.NewInt(1100),
+		}
+
+		tbtcChain.SetMovingFundsProposalValidationResult(
+			sourceWallet,
+			walletMainUtxo,
+			expectedProposal,
+			true,
+		)
+
+		task := tbtcpg.NewMovingFundsTask(tbtcChain, btcChain)
+
+		request := &tbtc.CoordinationProposalRequest{
+			WalletPublicKeyHash: sourceWallet,
+			WalletOperators:     walletOperators,
+			ActionsChecklist:    []tbtc.WalletActionType{tbtc.ActionMovingFunds},
+		}
+
+		// The first run submits the commitment and does not propose
+		// anything as the commitment is not confirmed yet.
+		proposal, ok, err := task.Run(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "first run result", false, ok)
+		if proposal != nil {
+			t.Errorf("unexpected proposal in the first run: [%v]", proposal)
+		}
+
+		submissions := tbtcChain.GetMovingFundsCommitmentSubmissions()
+		testutils.AssertIntsEqual(t, "submissions count", 1, len(submissions))
+
+		expectedSubmission := &tbtcpg.MovingFundsCommitmentSubmission{
+			WalletPublicKeyHash: sourceWallet,
+			WalletMainUtxo:      walletMainUtxo,
+			WalletMembersIDs:    []uint32{5, 7, 9, 7},
+			WalletMemberIndex:   2,
+			TargetWallets:       [][20]byte{targetWallet},
+		}
+		if diff := deep.Equal(submissions[0], expectedSubmission); diff != nil {
+			t.Errorf("invalid commitment submission: %v", diff)
+		}
+
+		// The local chain confirms the commitment immediately. Record
+		// the event emitted upon the confirmation so the next run,
+		// corresponding to a later coordination window, proposes moving
+		// funds to the committed target wallets.
+		tbtcChain.AddPastMovingFundsCommitmentSubmittedEvent(
+			&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+				WalletPublicKeyHash: [][20]byte{sourceWallet},
+			},
+			&tbtc.MovingFundsCommitmentSubmittedEvent{
+				WalletPublicKeyHash: sourceWallet,
+				TargetWallets:       [][20]byte{targetWallet},
+				BlockNumber:         1000,
+			},
+		)
+
+		proposal, ok, err = task.Run(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "second run result", true, ok)
+
+		if diff := deep.Equal(proposal, expectedProposal); diff != nil {
+			t.Errorf("invalid proposal: %v", diff)
+		}
+
+		testutils.AssertIntsEqual(
+			t,
+			"submissions count after the second run",
+			1,
+			len(tbtcChain.GetMovingFundsCommitmentSubmissions()),
+		)
+	})
+
+	t.Run("wallet in live state", func(t *testing.T) {
+		tbtcChain, btcChain, _, walletOperators := setup(
+			t,
+			tbtc.StateLive,
+		)
+
+		task := tbtcpg.NewMovingFundsTask(tbtcChain, btcChain)
+
+		_, ok, err := task.Run(&tbtc.CoordinationProposalRequest{
+			WalletPublicKeyHash: sourceWallet,
+			WalletOperators:     walletOperators,
+			ActionsChecklist:    []tbtc.WalletActionType{tbtc.ActionMovingFunds},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertBoolsEqual(t, "result", false, ok)
+		testutils.AssertIntsEqual(
+			t,
+			"submissions count",
+			0,
+			len(tbtcChain.GetMovingFundsCommitmentSubmissions()),
+		)
+	})
+}
+
+func hexToByte20(t *testing.T, hexString string) [20]byte {
+	bytes, err := hex.DecodeString(hexString)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bytes) != 20 {
+		t.Fatal("incorrect hexstring length")
+	}
+
+	var result [20]byte
+	copy(result[:], bytes[:])
+	return result
+}
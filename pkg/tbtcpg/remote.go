@@ -0,0 +1,317 @@
+package tbtcpg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// RemoteGeneratePath is the HTTP path of the remote proposal generator
+// endpoint. The endpoint accepts POST requests with a JSON-encoded
+// RemoteGenerateRequest body and responds with a JSON-encoded
+// RemoteGenerateResponse body.
+const RemoteGeneratePath = "/v1/proposals/generate"
+
+// RemoteGenerateRequest is the body of a request sent to the remote
+// proposal generator. It is the JSON representation of
+// tbtc.CoordinationProposalRequest.
+type RemoteGenerateRequest struct {
+	// WalletPublicKeyHash is the 0x-prefixed hex representation of the
+	// 20-byte wallet public key hash.
+	WalletPublicKeyHash string `json:"walletPublicKeyHash"`
+	// WalletOperators are host chain addresses of the wallet operators.
+	WalletOperators []string `json:"walletOperators"`
+	// ActionsChecklist are numeric values of tbtc.WalletActionType that
+	// should be checked, in the order of precedence.
+	ActionsChecklist []uint8 `json:"actionsChecklist"`
+}
+
+// RemoteGenerateResponse is the body of a response returned by the remote
+// proposal generator. If Error is not empty, the request failed and the
+// remaining fields should be ignored.
+type RemoteGenerateResponse struct {
+	// ActionType is the numeric value of the tbtc.WalletActionType of the
+	// generated proposal.
+	ActionType uint8 `json:"actionType"`
+	// Payload is the generated proposal encoded using its protobuf
+	// representation, as defined in pkg/tbtc/gen/pb/message.proto. The
+	// payload is base64-encoded in the JSON body.
+	Payload []byte `json:"payload"`
+	// Error describes the reason of the failure, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// RemoteProposalGenerator is a tbtc.CoordinationProposalGenerator that asks
+// an external service for coordination proposals. Proposals returned by the
+// external service are validated locally, against the chain, so a faulty
+// or malicious service cannot make the wallet execute an invalid action.
+type RemoteProposalGenerator struct {
+	url        string
+	httpClient *http.Client
+	chain      Chain
+	btcChain   bitcoin.Chain
+}
+
+// NewRemoteProposalGenerator returns a new remote proposal generator.
+func NewRemoteProposalGenerator(
+	config Config,
+	chain Chain,
+	btcChain bitcoin.Chain,
+) *RemoteProposalGenerator {
+	timeout := config.RemoteTimeout
+	if timeout == 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	return &RemoteProposalGenerator{
+		url: strings.TrimSuffix(config.RemoteURL, "/") + RemoteGeneratePath,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		chain:    chain,
+		btcChain: btcChain,
+	}
+}
+
+// Generate asks the remote service for a coordination proposal based on the
+// given checklist of possible wallet actions and validates the returned
+// proposal. An error is returned if the remote service cannot be reached or
+// if the returned proposal is not valid.
+func (rpg *RemoteProposalGenerator) Generate(
+	request *tbtc.CoordinationProposalRequest,
+) (tbtc.CoordinationProposal, error) {
+	walletLogger := logger.With(
+		zap.String(
+			"walletPKH",
+			fmt.Sprintf("0x%x", request.WalletPublicKeyHash),
+		),
+	)
+
+	walletLogger.Infof(
+		"requesting remote proposal generation with tasks checklist [%v]",
+		request.ActionsChecklist,
+	)
+
+	response, err := rpg.requestProposal(request)
+	if err != nil {
+		return nil, fmt.Errorf("remote proposal request failed: [%v]", err)
+	}
+
+	proposal, err := tbtc.UnmarshalCoordinationProposal(
+		uint32(response.ActionType),
+		response.Payload,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal remote proposal: [%v]", err)
+	}
+
+	if err := validateRemoteProposal(
+		walletLogger,
+		request,
+		proposal,
+		rpg.chain,
+		rpg.btcChain,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"remote proposal [%s] is not valid: [%v]",
+			proposal.ActionType(),
+			err,
+		)
+	}
+
+	walletLogger.Infof(
+		"remote proposal [%s] validated successfully",
+		proposal.ActionType(),
+	)
+
+	return proposal, nil
+}
+
+// requestProposal sends the given request to the remote service and returns
+// its response.
+func (rpg *RemoteProposalGenerator) requestProposal(
+	request *tbtc.CoordinationProposalRequest,
+) (*RemoteGenerateResponse, error) {
+	walletOperators := make([]string, len(request.WalletOperators))
+	for i, operator := range request.WalletOperators {
+		walletOperators[i] = operator.String()
+	}
+
+	actionsChecklist := make([]uint8, len(request.ActionsChecklist))
+	for i, action := range request.ActionsChecklist {
+		actionsChecklist[i] = uint8(action)
+	}
+
+	requestBody, err := json.Marshal(&RemoteGenerateRequest{
+		WalletPublicKeyHash: hexutils.Encode(request.WalletPublicKeyHash[:]),
+		WalletOperators:     walletOperators,
+		ActionsChecklist:    actionsChecklist,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal request: [%v]", err)
+	}
+
+	httpResponse, err := rpg.httpClient.Post(
+		rpg.url,
+		"application/json",
+		bytes.NewReader(requestBody),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot send request: [%v]", err)
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response: [%v]", err)
+	}
+
+	response := &RemoteGenerateResponse{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal response with status [%v]: [%v]",
+			httpResponse.StatusCode,
+			err,
+		)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK || len(response.Error) > 0 {
+		return nil, fmt.Errorf(
+			"remote service responded with status [%v] and error [%v]",
+			httpResponse.StatusCode,
+			response.Error,
+		)
+	}
+
+	return response, nil
+}
+
+// validateRemoteProposal validates the given proposal returned by the remote
+// proposal generator. The proposal's action must be part of the request's
+// checklist and must pass the same validation the wallet performs before
+// executing the action.
+func validateRemoteProposal(
+	validateLogger log.StandardLogger,
+	request *tbtc.CoordinationProposalRequest,
+	proposal tbtc.CoordinationProposal,
+	chain Chain,
+	btcChain bitcoin.Chain,
+) error {
+	if proposal.ActionType() == tbtc.ActionNoop {
+		return nil
+	}
+
+	if !slices.Contains(request.ActionsChecklist, proposal.ActionType()) {
+		return fmt.Errorf("action is not part of the checklist")
+	}
+
+	walletPublicKeyHash := request.WalletPublicKeyHash
+
+	switch p := proposal.(type) {
+	case *tbtc.HeartbeatProposal:
+		return chain.ValidateHeartbeatProposal(walletPublicKeyHash, p)
+	case *tbtc.DepositSweepProposal:
+		_, err := tbtc.ValidateDepositSweepProposal(
+			validateLogger,
+			walletPublicKeyHash,
+			p,
+			tbtc.DepositSweepRequiredFundingTxConfirmations,
+			chain,
+			btcChain,
+		)
+		return err
+	case *tbtc.RedemptionProposal:
+		_, err := tbtc.ValidateRedemptionProposal(
+			validateLogger,
+			walletPublicKeyHash,
+			p,
+			chain,
+		)
+		return err
+	case *tbtc.MovingFundsProposal:
+		walletMainUtxo, err := tbtc.DetermineWalletMainUtxo(
+			walletPublicKeyHash,
+			chain,
+			btcChain,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot determine wallet main UTXO: [%v]", err)
+		}
+
+		if walletMainUtxo == nil {
+			return fmt.Errorf("moving funds wallet has no main UTXO")
+		}
+
+		return tbtc.ValidateMovingFundsProposal(
+			validateLogger,
+			walletPublicKeyHash,
+			walletMainUtxo,
+			p,
+			chain,
+		)
+	case *tbtc.MovedFundsSweepProposal:
+		return tbtc.ValidateMovedFundsSweepProposal(
+			validateLogger,
+			walletPublicKeyHash,
+			p,
+			chain,
+		)
+	default:
+		return fmt.Errorf("unsupported action type")
+	}
+}
+
+// toCoordinationProposalRequest converts the given remote request to
+// a tbtc.CoordinationProposalRequest.
+func (rgr *RemoteGenerateRequest) toCoordinationProposalRequest() (
+	*tbtc.CoordinationProposalRequest,
+	error,
+) {
+	walletPublicKeyHashBytes, err := hexutils.Decode(rgr.WalletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode wallet public key hash: [%v]", err)
+	}
+
+	if len(walletPublicKeyHashBytes) != 20 {
+		return nil, fmt.Errorf(
+			"invalid wallet public key hash length: [%v]",
+			len(walletPublicKeyHashBytes),
+		)
+	}
+
+	var walletPublicKeyHash [20]byte
+	copy(walletPublicKeyHash[:], walletPublicKeyHashBytes)
+
+	walletOperators := make([]chain.Address, len(rgr.WalletOperators))
+	for i, operator := range rgr.WalletOperators {
+		walletOperators[i] = chain.Address(operator)
+	}
+
+	actionsChecklist := make([]tbtc.WalletActionType, len(rgr.ActionsChecklist))
+	for i, value := range rgr.ActionsChecklist {
+		action, err := tbtc.ParseWalletActionType(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse action type: [%v]", err)
+		}
+
+		actionsChecklist[i] = action
+	}
+
+	return &tbtc.CoordinationProposalRequest{
+		WalletPublicKeyHash: walletPublicKeyHash,
+		WalletOperators:     walletOperators,
+		ActionsChecklist:    actionsChecklist,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package tbtcpg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// maxRemoteRequestSize is the maximum size of a request body accepted by
+// the ProposalServer.
+const maxRemoteRequestSize = 1 << 20
+
+// ProposalServer is an HTTP server adapter exposing the given proposal
+// generator to clients using the RemoteProposalGenerator. It allows running
+// proposal generation policies, e.g. the ones implemented by the
+// ProposalGenerator tasks, in a service separate from the client.
+type ProposalServer struct {
+	generator tbtc.CoordinationProposalGenerator
+}
+
+// NewProposalServer returns a new proposal server wrapping the given
+// proposal generator.
+func NewProposalServer(
+	generator tbtc.CoordinationProposalGenerator,
+) *ProposalServer {
+	return &ProposalServer{
+		generator: generator,
+	}
+}
+
+// Handler returns an HTTP handler serving the remote proposal generator
+// endpoint.
+func (ps *ProposalServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RemoteGeneratePath, ps.handleGenerate)
+	return mux
+}
+
+func (ps *ProposalServer) handleGenerate(
+	writer http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	if httpRequest.Method != http.MethodPost {
+		writeRemoteResponse(
+			writer,
+			http.StatusMethodNotAllowed,
+			&RemoteGenerateResponse{Error: "method not allowed"},
+		)
+		return
+	}
+
+	requestBody, err := io.ReadAll(
+		io.LimitReader(httpRequest.Body, maxRemoteRequestSize),
+	)
+	if err != nil {
+		writeRemoteResponse(
+			writer,
+			http.StatusBadRequest,
+			&RemoteGenerateResponse{
+				Error: fmt.Sprintf("cannot read request: [%v]", err),
+			},
+		)
+		return
+	}
+
+	remoteRequest := &RemoteGenerateRequest{}
+	if err := json.Unmarshal(requestBody, remoteRequest); err != nil {
+		writeRemoteResponse(
+			writer,
+			http.StatusBadRequest,
+			&RemoteGenerateResponse{
+				Error: fmt.Sprintf("cannot unmarshal request: [%v]", err),
+			},
+		)
+		return
+	}
+
+	request, err := remoteRequest.toCoordinationProposalRequest()
+	if err != nil {
+		writeRemoteResponse(
+			writer,
+			http.StatusBadRequest,
+			&RemoteGenerateResponse{
+				Error: fmt.Sprintf("invalid request: [%v]", err),
+			},
+		)
+		return
+	}
+
+	proposal, err := ps.generator.Generate(request)
+	if err != nil {
+		logger.Errorf(
+			"cannot generate proposal for wallet [0x%x]: [%v]",
+			request.WalletPublicKeyHash,
+			err,
+		)
+
+		writeRemoteResponse(
+			writer,
+			http.StatusInternalServerError,
+			&RemoteGenerateResponse{
+				Error: fmt.Sprintf("cannot generate proposal: [%v]", err),
+			},
+		)
+		return
+	}
+
+	payload, err := proposal.Marshal()
+	if err != nil {
+		writeRemoteResponse(
+			writer,
+			http.StatusInternalServerError,
+			&RemoteGenerateResponse{
+				Error: fmt.Sprintf("cannot marshal proposal: [%v]", err),
+			},
+		)
+		return
+	}
+
+	writeRemoteResponse(
+		writer,
+		http.StatusOK,
+		&RemoteGenerateResponse{
+			ActionType: uint8(proposal.ActionType()),
+			Payload:    payload,
+		},
+	)
+}
+
+func writeRemoteResponse(
+	writer http.ResponseWriter,
+	statusCode int,
+	response *RemoteGenerateResponse,
+) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logger.Errorf("cannot write response: [%v]", err)
+	}
+}
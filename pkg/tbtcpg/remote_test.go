@@ -0,0 +1,167 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+type stubProposalGenerator struct {
+	proposal tbtc.CoordinationProposal
+	err      error
+
+	lastRequest *tbtc.CoordinationProposalRequest
+}
+
+func (spg *stubProposalGenerator) Generate(
+	request *tbtc.CoordinationProposalRequest,
+) (tbtc.CoordinationProposal, error) {
+	spg.lastRequest = request
+	return spg.proposal, spg.err
+}
+
+func TestRemoteProposalGenerator_Generate(t *testing.T) {
+	heartbeatProposal := &tbtc.HeartbeatProposal{
+		Message: [16]byte{0xff, 0xff, 0xff, 0xff, 0x01, 0x02},
+	}
+
+	request := &tbtc.CoordinationProposalRequest{
+		WalletPublicKeyHash: [20]byte{0x01, 0x02, 0x03},
+		WalletOperators: []chain.Address{
+			"0xAA00000000000000000000000000000000000000",
+			"0xBB00000000000000000000000000000000000000",
+		},
+		ActionsChecklist: []tbtc.WalletActionType{
+			tbtc.ActionRedemption,
+			tbtc.ActionHeartbeat,
+		},
+	}
+
+	var tests = map[string]struct {
+		generatedProposal tbtc.CoordinationProposal
+		generatorErr      error
+		validationResult  bool
+		expectedProposal  tbtc.CoordinationProposal
+		expectedErr       string
+	}{
+		"valid proposal": {
+			generatedProposal: heartbeatProposal,
+			validationResult:  true,
+			expectedProposal:  heartbeatProposal,
+		},
+		"noop proposal": {
+			generatedProposal: &tbtc.NoopProposal{},
+			expectedProposal:  &tbtc.NoopProposal{},
+		},
+		"invalid proposal": {
+			generatedProposal: heartbeatProposal,
+			validationResult:  false,
+			expectedErr: "remote proposal [Heartbeat] is not valid: " +
+				"[validation failed]",
+		},
+		"proposal not in checklist": {
+			generatedProposal: &tbtc.MovedFundsSweepProposal{
+				MovingFundsTxOutputIndex: 1,
+				SweepTxFee:               big.NewInt(1000),
+			},
+			expectedErr: "remote proposal [MovedFundsSweep] is not valid: " +
+				"[action is not part of the checklist]",
+		},
+		"generator error": {
+			generatorErr: fmt.Errorf("unexpected error"),
+			expectedErr: "remote proposal request failed: [remote service " +
+				"responded with status [500] and error [cannot generate " +
+				"proposal: [unexpected error]]]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			generator := &stubProposalGenerator{
+				proposal: test.generatedProposal,
+				err:      test.generatorErr,
+			}
+
+			server := httptest.NewServer(NewProposalServer(generator).Handler())
+			defer server.Close()
+
+			localChain := NewLocalChain()
+			localChain.SetHeartbeatProposalValidationResult(
+				heartbeatProposal,
+				test.validationResult,
+			)
+
+			remoteGenerator := NewRemoteProposalGenerator(
+				Config{RemoteURL: server.URL + "/"},
+				localChain,
+				nil,
+			)
+
+			proposal, err := remoteGenerator.Generate(request)
+
+			if !reflect.DeepEqual(request, generator.lastRequest) {
+				t.Errorf(
+					"unexpected request received by the server\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					request,
+					generator.lastRequest,
+				)
+			}
+
+			if len(test.expectedErr) > 0 {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Fatalf(
+						"unexpected error\n"+
+							"expected: [%v]\n"+
+							"actual:   [%v]",
+						test.expectedErr,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+		})
+	}
+}
+
+func TestProposalServer_InvalidRequest(t *testing.T) {
+	server := httptest.NewServer(
+		NewProposalServer(&stubProposalGenerator{}).Handler(),
+	)
+	defer server.Close()
+
+	response, err := server.Client().Post(
+		server.URL+RemoteGeneratePath,
+		"application/json",
+		strings.NewReader(`{"walletPublicKeyHash":"0x0102"}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 400 {
+		t.Errorf("unexpected status code: [%v]", response.StatusCode)
+	}
+}
@@ -35,14 +35,14 @@ type ProposalGenerator struct {
 func NewProposalGenerator(
 	chain Chain,
 	btcChain bitcoin.Chain,
+	depositSelectionPolicy DepositSelectionPolicy,
 ) *ProposalGenerator {
 	tasks := []ProposalTask{
-		NewDepositSweepTask(chain, btcChain),
+		NewDepositSweepTask(chain, btcChain, depositSelectionPolicy),
 		NewRedemptionTask(chain, btcChain),
 		NewHeartbeatTask(chain),
-		// TODO: Uncomment when moving funds support is implemented.
-		// newMovedFundsSweepTask(),
-		// newMovingFundsTask(),
+		NewMovedFundsSweepTask(chain, btcChain),
+		NewMovingFundsTask(chain, btcChain),
 	}
 
 	return &ProposalGenerator{
@@ -0,0 +1,299 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// WalletActionAvailability describes whether the wallet is currently able
+// to run the given action.
+type WalletActionAvailability struct {
+	Action    tbtc.WalletActionType
+	Available bool
+	// Reason explains why the action is not available. Empty if the action
+	// is available.
+	Reason string
+}
+
+// WalletInfo is a report gathering the current state of the wallet on both
+// the host chain and the Bitcoin chain.
+type WalletInfo struct {
+	WalletPublicKeyHash [20]byte
+	ChainData           *tbtc.WalletChainData
+	// MainUtxo is the wallet main UTXO registered in the Bridge. It is nil
+	// if the wallet does not have a main UTXO or it could not be determined.
+	MainUtxo *bitcoin.UnspentTransactionOutput
+	// MainUtxoErr is the reason the main UTXO could not be determined, if any.
+	MainUtxoErr error
+	// SyncErr is the reason the wallet is not synced between the host chain
+	// and the Bitcoin chain. It is nil if the wallet is synced.
+	SyncErr error
+	// PendingRedemptions are the pending redemption requests that are not
+	// timed out yet, sorted from the oldest to the newest.
+	PendingRedemptions []*RedemptionRequest
+	// RedemptionRequestMinAge is the minimum age a pending redemption request
+	// must have to become eligible for processing.
+	RedemptionRequestMinAge time.Duration
+	// UnsweptDeposits are the revealed deposits that were not swept yet,
+	// sorted by the reveal block in the ascending order.
+	UnsweptDeposits []*Deposit
+	// MempoolTransactions are the unconfirmed transactions of the wallet.
+	MempoolTransactions []*bitcoin.Transaction
+	// Actions holds the availability of all wallet actions.
+	Actions []*WalletActionAvailability
+}
+
+// GetWalletInfo builds a report about the wallet with the given public key
+// hash. Data that cannot be determined due to the wallet's state, e.g. the
+// main UTXO, are recorded in the report instead of failing the whole call.
+// An error is returned only if the basic data cannot be fetched from either
+// chain.
+func GetWalletInfo(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	walletPublicKeyHash [20]byte,
+) (*WalletInfo, error) {
+	if walletPublicKeyHash == [20]byte{} {
+		return nil, fmt.Errorf("wallet public key hash is required")
+	}
+
+	chainData, err := chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet's chain data: [%w]", err)
+	}
+
+	info := &WalletInfo{
+		WalletPublicKeyHash: walletPublicKeyHash,
+		ChainData:           chainData,
+	}
+
+	info.MainUtxo, info.MainUtxoErr = tbtc.DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		chain,
+		btcChain,
+	)
+	if info.MainUtxoErr != nil {
+		info.SyncErr = fmt.Errorf(
+			"cannot determine wallet main UTXO: [%v]",
+			info.MainUtxoErr,
+		)
+	} else {
+		info.SyncErr = tbtc.EnsureWalletSyncedBetweenChains(
+			walletPublicKeyHash,
+			info.MainUtxo,
+			chain,
+			btcChain,
+		)
+	}
+
+	info.PendingRedemptions, info.RedemptionRequestMinAge, err =
+		getWalletPendingRedemptions(chain, walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pending redemptions: [%w]", err)
+	}
+
+	info.UnsweptDeposits, err = findDeposits(
+		logger,
+		chain,
+		btcChain,
+		walletPublicKeyHash,
+		0,
+		true,
+		false,
+		0,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get unswept deposits: [%w]", err)
+	}
+
+	info.MempoolTransactions, err = btcChain.GetMempoolForPublicKeyHash(
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get mempool transactions: [%w]", err)
+	}
+
+	info.Actions = determineWalletActionsAvailability(info, time.Now())
+
+	return info, nil
+}
+
+// getWalletPendingRedemptions returns all pending redemption requests of the
+// wallet that are not timed out yet, regardless of their age. It also returns
+// the minimum age a request must have to become eligible for processing.
+func getWalletPendingRedemptions(
+	chain Chain,
+	walletPublicKeyHash [20]byte,
+) ([]*RedemptionRequest, time.Duration, error) {
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get block counter: [%w]", err)
+	}
+
+	currentBlockNumber, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"failed to get current block number: [%w]",
+			err,
+		)
+	}
+
+	requestMinAge, err := chain.GetRedemptionRequestMinAge()
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"failed to get redemption request minimum age: [%w]",
+			err,
+		)
+	}
+
+	_, _, _, _, requestTimeout, _, _, err := chain.GetRedemptionParameters()
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"failed to get redemption parameters: [%w]",
+			err,
+		)
+	}
+
+	pendingRedemptions, err := findPendingRedemptions(
+		logger,
+		chain,
+		walletPublicKeyHash,
+		currentBlockNumber,
+		0,
+		requestTimeout,
+		0,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pendingRedemptions,
+		time.Duration(requestMinAge) * time.Second,
+		nil
+}
+
+// determineWalletActionsAvailability determines whether the wallet described
+// by the given report is able to run each of the wallet actions at the given
+// time. The availability reflects the preconditions checked by the proposal
+// generator tasks; a proposal for an available action may still be rejected
+// during the on-chain validation.
+func determineWalletActionsAvailability(
+	info *WalletInfo,
+	now time.Time,
+) []*WalletActionAvailability {
+	state := info.ChainData.State
+	isLive := state == tbtc.StateLive
+	isLiveOrMovingFunds := isLive || state == tbtc.StateMovingFunds
+
+	confirmedDeposits := 0
+	for _, deposit := range info.UnsweptDeposits {
+		if deposit.Confirmations >= tbtc.DepositSweepRequiredFundingTxConfirmations {
+			confirmedDeposits++
+		}
+	}
+
+	eligibleRedemptions := 0
+	for _, redemption := range info.PendingRedemptions {
+		if !redemption.RequestedAt.After(now.Add(-info.RedemptionRequestMinAge)) {
+			eligibleRedemptions++
+		}
+	}
+
+	stateIs := func(met bool, expected string) actionPrecondition {
+		return actionPrecondition{
+			met:    met,
+			reason: fmt.Sprintf("wallet is in [%s] state; expected %s", state, expected),
+		}
+	}
+
+	synced := actionPrecondition{
+		met:    info.SyncErr == nil,
+		reason: "wallet is not synced between chains",
+	}
+
+	reasons := map[tbtc.WalletActionType]string{
+		tbtc.ActionHeartbeat: firstUnmetPrecondition(
+			stateIs(isLive, "Live"),
+		),
+		tbtc.ActionDepositSweep: firstUnmetPrecondition(
+			stateIs(isLive, "Live"),
+			synced,
+			actionPrecondition{
+				met:    confirmedDeposits > 0,
+				reason: "no unswept deposits with enough confirmations",
+			},
+		),
+		tbtc.ActionRedemption: firstUnmetPrecondition(
+			stateIs(isLiveOrMovingFunds, "Live or MovingFunds"),
+			synced,
+			actionPrecondition{
+				met:    eligibleRedemptions > 0,
+				reason: "no pending redemption requests old enough",
+			},
+		),
+		tbtc.ActionMovingFunds: firstUnmetPrecondition(
+			stateIs(state == tbtc.StateMovingFunds, "MovingFunds"),
+			synced,
+			actionPrecondition{
+				met:    info.ChainData.PendingRedemptionsValue == 0,
+				reason: "wallet has pending redemption requests",
+			},
+			actionPrecondition{
+				met:    info.ChainData.PendingMovedFundsSweepRequestsCount == 0,
+				reason: "wallet has pending moved funds sweep requests",
+			},
+			actionPrecondition{
+				met:    info.MainUtxo != nil,
+				reason: "wallet does not have a main UTXO",
+			},
+		),
+		tbtc.ActionMovedFundsSweep: firstUnmetPrecondition(
+			stateIs(isLiveOrMovingFunds, "Live or MovingFunds"),
+			synced,
+			actionPrecondition{
+				met:    info.ChainData.PendingMovedFundsSweepRequestsCount > 0,
+				reason: "wallet has no pending moved funds sweep requests",
+			},
+		),
+	}
+
+	actions := []tbtc.WalletActionType{
+		tbtc.ActionHeartbeat,
+		tbtc.ActionDepositSweep,
+		tbtc.ActionRedemption,
+		tbtc.ActionMovingFunds,
+		tbtc.ActionMovedFundsSweep,
+	}
+
+	result := make([]*WalletActionAvailability, len(actions))
+	for i, action := range actions {
+		result[i] = &WalletActionAvailability{
+			Action:    action,
+			Available: len(reasons[action]) == 0,
+			Reason:    reasons[action],
+		}
+	}
+
+	return result
+}
+
+// actionPrecondition is a single precondition of a wallet action.
+type actionPrecondition struct {
+	met    bool
+	reason string
+}
+
+// firstUnmetPrecondition returns the reason of the first unmet precondition
+// or an empty string if all preconditions are met.
+func firstUnmetPrecondition(preconditions ...actionPrecondition) string {
+	for _, precondition := range preconditions {
+		if !precondition.met {
+			return precondition.reason
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,166 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestDetermineWalletActionsAvailability(t *testing.T) {
+	now := time.Now()
+
+	mainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: bitcoin.Hash{0x01},
+			OutputIndex:     0,
+		},
+		Value: 100000,
+	}
+
+	confirmedDeposit := &Deposit{
+		Confirmations: tbtc.DepositSweepRequiredFundingTxConfirmations,
+	}
+	unconfirmedDeposit := &Deposit{
+		Confirmations: tbtc.DepositSweepRequiredFundingTxConfirmations - 1,
+	}
+
+	oldRedemption := &RedemptionRequest{RequestedAt: now.Add(-2 * time.Hour)}
+	freshRedemption := &RedemptionRequest{RequestedAt: now.Add(-1 * time.Minute)}
+
+	var tests = map[string]struct {
+		info            *WalletInfo
+		expectedReasons map[tbtc.WalletActionType]string
+	}{
+		"live wallet with work to do": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State:                               tbtc.StateLive,
+					PendingMovedFundsSweepRequestsCount: 1,
+				},
+				MainUtxo:                mainUtxo,
+				PendingRedemptions:      []*RedemptionRequest{oldRedemption},
+				RedemptionRequestMinAge: time.Hour,
+				UnsweptDeposits:         []*Deposit{confirmedDeposit},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "",
+				tbtc.ActionDepositSweep:    "",
+				tbtc.ActionRedemption:      "",
+				tbtc.ActionMovingFunds:     "wallet is in [Live] state; expected MovingFunds",
+				tbtc.ActionMovedFundsSweep: "",
+			},
+		},
+		"live wallet without work to do": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State: tbtc.StateLive,
+				},
+				PendingRedemptions:      []*RedemptionRequest{freshRedemption},
+				RedemptionRequestMinAge: time.Hour,
+				UnsweptDeposits:         []*Deposit{unconfirmedDeposit},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "",
+				tbtc.ActionDepositSweep:    "no unswept deposits with enough confirmations",
+				tbtc.ActionRedemption:      "no pending redemption requests old enough",
+				tbtc.ActionMovingFunds:     "wallet is in [Live] state; expected MovingFunds",
+				tbtc.ActionMovedFundsSweep: "wallet has no pending moved funds sweep requests",
+			},
+		},
+		"live wallet not synced": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State:                               tbtc.StateLive,
+					PendingMovedFundsSweepRequestsCount: 1,
+				},
+				SyncErr:            fmt.Errorf("awaiting SPV proof"),
+				PendingRedemptions: []*RedemptionRequest{oldRedemption},
+				UnsweptDeposits:    []*Deposit{confirmedDeposit},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "",
+				tbtc.ActionDepositSweep:    "wallet is not synced between chains",
+				tbtc.ActionRedemption:      "wallet is not synced between chains",
+				tbtc.ActionMovingFunds:     "wallet is in [Live] state; expected MovingFunds",
+				tbtc.ActionMovedFundsSweep: "wallet is not synced between chains",
+			},
+		},
+		"moving funds wallet": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State: tbtc.StateMovingFunds,
+				},
+				MainUtxo:        mainUtxo,
+				UnsweptDeposits: []*Deposit{confirmedDeposit},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "wallet is in [MovingFunds] state; expected Live",
+				tbtc.ActionDepositSweep:    "wallet is in [MovingFunds] state; expected Live",
+				tbtc.ActionRedemption:      "no pending redemption requests old enough",
+				tbtc.ActionMovingFunds:     "",
+				tbtc.ActionMovedFundsSweep: "wallet has no pending moved funds sweep requests",
+			},
+		},
+		"moving funds wallet with pending redemptions": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State:                   tbtc.StateMovingFunds,
+					PendingRedemptionsValue: 1000,
+				},
+				MainUtxo:           mainUtxo,
+				PendingRedemptions: []*RedemptionRequest{oldRedemption},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "wallet is in [MovingFunds] state; expected Live",
+				tbtc.ActionDepositSweep:    "wallet is in [MovingFunds] state; expected Live",
+				tbtc.ActionRedemption:      "",
+				tbtc.ActionMovingFunds:     "wallet has pending redemption requests",
+				tbtc.ActionMovedFundsSweep: "wallet has no pending moved funds sweep requests",
+			},
+		},
+		"closed wallet": {
+			info: &WalletInfo{
+				ChainData: &tbtc.WalletChainData{
+					State: tbtc.StateClosed,
+				},
+			},
+			expectedReasons: map[tbtc.WalletActionType]string{
+				tbtc.ActionHeartbeat:       "wallet is in [Closed] state; expected Live",
+				tbtc.ActionDepositSweep:    "wallet is in [Closed] state; expected Live",
+				tbtc.ActionRedemption:      "wallet is in [Closed] state; expected Live or MovingFunds",
+				tbtc.ActionMovingFunds:     "wallet is in [Closed] state; expected MovingFunds",
+				tbtc.ActionMovedFundsSweep: "wallet is in [Closed] state; expected Live or MovingFunds",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actions := determineWalletActionsAvailability(test.info, now)
+
+			actualReasons := make(map[tbtc.WalletActionType]string)
+			for _, action := range actions {
+				if action.Available != (len(action.Reason) == 0) {
+					t.Errorf(
+						"inconsistent availability of action [%s]",
+						action.Action,
+					)
+				}
+
+				actualReasons[action.Action] = action.Reason
+			}
+
+			if diff := deep.Equal(
+				test.expectedReasons,
+				actualReasons,
+			); diff != nil {
+				t.Errorf("invalid actions availability: %v", diff)
+			}
+		})
+	}
+}
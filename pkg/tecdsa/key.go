@@ -2,7 +2,10 @@ package tecdsa
 
 import (
 	"crypto/ecdsa"
+	"fmt"
+	"math/big"
 
+	"github.com/bnb-chain/tss-lib/common"
 	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/tss"
 )
@@ -44,3 +47,87 @@ func (pks *PrivateKeyShare) PublicKey() *ecdsa.PublicKey {
 func (pks *PrivateKeyShare) Data() keygen.LocalPartySaveData {
 	return pks.data
 }
+
+// Verify checks the integrity of the private key share. It ensures the
+// secret share corresponds to the public share of the given member and that
+// the public shares of all members reconstruct the ECDSA public key of the
+// private key share. An error is returned if any of those checks fails.
+func (pks *PrivateKeyShare) Verify() error {
+	data := pks.data
+
+	if data.Xi == nil || data.ShareID == nil || data.ECDSAPub == nil {
+		return fmt.Errorf("private key share is incomplete")
+	}
+
+	if len(data.Ks) == 0 || len(data.Ks) != len(data.BigXj) {
+		return fmt.Errorf(
+			"inconsistent number of share IDs [%v] and public shares [%v]",
+			len(data.Ks),
+			len(data.BigXj),
+		)
+	}
+
+	ownIndex := -1
+	for i, k := range data.Ks {
+		if k == nil || data.BigXj[i] == nil {
+			return fmt.Errorf("missing share ID or public share [%v]", i)
+		}
+
+		if k.Cmp(data.ShareID) == 0 {
+			ownIndex = i
+		}
+	}
+	if ownIndex < 0 {
+		return fmt.Errorf("share ID not found among share IDs of the group")
+	}
+
+	ownX, ownY := Curve.ScalarBaseMult(data.Xi.Bytes())
+	if ownX.Cmp(data.BigXj[ownIndex].X()) != 0 ||
+		ownY.Cmp(data.BigXj[ownIndex].Y()) != 0 {
+		return fmt.Errorf("secret share does not match its public share")
+	}
+
+	// Interpolate the public shares at zero to reconstruct the public key.
+	// The Lagrange coefficient of the j-th share is the product of
+	// k_m / (k_m - k_j) for all m different from j.
+	modN := common.ModInt(Curve.Params().N)
+
+	var publicKeyX, publicKeyY *big.Int
+	for j, kj := range data.Ks {
+		coefficient := big.NewInt(1)
+		for m, km := range data.Ks {
+			if m == j {
+				continue
+			}
+
+			if km.Cmp(kj) == 0 {
+				return fmt.Errorf("duplicated share ID [%v]", j)
+			}
+
+			coefficient = modN.Mul(
+				coefficient,
+				modN.Mul(km, modN.ModInverse(modN.Sub(km, kj))),
+			)
+		}
+
+		termX, termY := Curve.ScalarMult(
+			data.BigXj[j].X(),
+			data.BigXj[j].Y(),
+			coefficient.Bytes(),
+		)
+
+		if publicKeyX == nil {
+			publicKeyX, publicKeyY = termX, termY
+			continue
+		}
+
+		publicKeyX, publicKeyY = Curve.Add(publicKeyX, publicKeyY, termX, termY)
+	}
+
+	if publicKeyX.Cmp(data.ECDSAPub.X()) != 0 ||
+		publicKeyY.Cmp(data.ECDSAPub.Y()) != 0 {
+		return fmt.Errorf("public shares do not reconstruct the public key")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package tecdsa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/crypto"
+
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+)
+
+func TestPrivateKeyShareVerify(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(2)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	for i := range testData {
+		if err := NewPrivateKeyShare(testData[i]).Verify(); err != nil {
+			t.Errorf("unexpected error for share [%v]: [%v]", i, err)
+		}
+	}
+}
+
+func TestPrivateKeyShareVerify_Corrupted(t *testing.T) {
+	tests := map[string]struct {
+		corruptFn     func(share *PrivateKeyShare)
+		expectedError string
+	}{
+		"wrong secret share": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.Xi = new(big.Int).Add(share.data.Xi, big.NewInt(1))
+			},
+			expectedError: "secret share does not match its public share",
+		},
+		"wrong public key": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.ECDSAPub = crypto.ScalarBaseMult(Curve, big.NewInt(1))
+			},
+			expectedError: "public shares do not reconstruct the public key",
+		},
+		"unknown share ID": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.ShareID = big.NewInt(1)
+			},
+			expectedError: "share ID not found among share IDs of the group",
+		},
+		"missing public shares": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.BigXj = share.data.BigXj[1:]
+			},
+			expectedError: "inconsistent number of share IDs [5] and " +
+				"public shares [4]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+			if err != nil {
+				t.Fatalf("failed to load test data: [%v]", err)
+			}
+
+			share := NewPrivateKeyShare(testData[0])
+			test.corruptFn(share)
+
+			err = share.Verify()
+			if err == nil || err.Error() != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v\n",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
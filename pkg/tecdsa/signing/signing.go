@@ -76,6 +76,15 @@ func Execute(
 	return finalizationState.result(), nil
 }
 
+// SessionID returns the identifier of the signing session for the given
+// message and attempt number. Members accept protocol messages only from
+// their own session so, signing sessions executed concurrently over the same
+// broadcast channel must use distinct messages. Retries of the same message
+// are separated by the attempt number.
+func SessionID(message *big.Int, attemptNumber uint) string {
+	return fmt.Sprintf("%v-%v", message.Text(16), attemptNumber)
+}
+
 // RegisterUnmarshallers initializes the given broadcast channel to be able to
 // perform signing protocol interactions by registering all the required
 // protocol message unmarshallers.
@@ -0,0 +1,32 @@
+package signing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+func TestSessionID(t *testing.T) {
+	testutils.AssertStringsEqual(
+		t,
+		"session ID",
+		"3e8-1",
+		SessionID(big.NewInt(1000), 1),
+	)
+
+	sessionIDs := map[string]bool{}
+	for _, message := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(16),
+		big.NewInt(17),
+	} {
+		for attemptNumber := uint(1); attemptNumber <= 11; attemptNumber++ {
+			sessionID := SessionID(message, attemptNumber)
+			if sessionIDs[sessionID] {
+				t.Errorf("duplicated session ID [%v]", sessionID)
+			}
+			sessionIDs[sessionID] = true
+		}
+	}
+}